@@ -2,12 +2,33 @@ package api
 
 import (
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/handlers"
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/handlers/docs"
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/handlers/tools"
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/logging"
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/metrics"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
-func Routes(handler *handlers.ReportHandler) *gin.Engine {
+func Routes(handler *handlers.ReportHandler, healthHandler *handlers.HealthHandler) *gin.Engine {
 	baseUrl := "/api/v1"
-	router := gin.Default()
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.Use(logging.AccessLog())
+	router.Use(otelgin.Middleware("pdf-generator-api"))
+	router.Use(metrics.HTTP())
+	router.Use(tools.RequestId())
+	router.Use(tools.Problems())
+
+	router.GET("/metrics", metrics.Handler())
+	router.GET("/healthz", healthHandler.Live)
+	router.GET("/readyz", healthHandler.Ready)
+
+	router.GET(baseUrl+"/openapi.json", docs.OpenAPI)
+	router.GET(baseUrl+"/docs", docs.SwaggerUI)
+	for _, asset := range docs.SwaggerAssets {
+		router.GET(baseUrl+"/docs/"+asset, docs.SwaggerAsset)
+	}
 
 	router.GET(baseUrl+"/accounts/:accountId/tenant/:tenantId/transactions.pdf", handler.SendReport)
 
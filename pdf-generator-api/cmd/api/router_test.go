@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/handlers"
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/handlers/docs"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type document struct {
+	OpenAPI    string                                `json:"openapi"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]json.RawMessage `json:"schemas"`
+	} `json:"components"`
+}
+
+func TestOpenAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// the handler is never called, only the routes are read
+	router := Routes(&handlers.ReportHandler{}, &handlers.HealthHandler{})
+
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	var spec document
+	err := json.NewDecoder(res.Body).Decode(&spec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "3.0.3", spec.OpenAPI)
+	assert.Contains(t, spec.Components.Schemas, "Problem")
+
+	t.Run("Every route is documented", func(t *testing.T) {
+		for _, route := range router.Routes() {
+			item, found := spec.Paths[docs.Path(route.Path)]
+
+			if assert.True(t, found, "missing path %s", route.Path) {
+				assert.Contains(t, item, strings.ToLower(route.Method), "missing %s %s", route.Method, route.Path)
+			}
+		}
+	})
+
+	t.Run("Every documented route exists", func(t *testing.T) {
+		routes := make(map[string]struct{})
+		for _, route := range router.Routes() {
+			routes[route.Method+" "+docs.Path(route.Path)] = struct{}{}
+		}
+
+		for path, item := range spec.Paths {
+			for method := range item {
+				assert.Contains(t, routes, strings.ToUpper(method)+" "+path)
+			}
+		}
+	})
+}
+
+func TestSwaggerUI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := Routes(&handlers.ReportHandler{}, &handlers.HealthHandler{})
+
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/api/v1/docs", nil))
+
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, res.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, res.Body.String(), `url: "openapi.json"`)
+	assert.Contains(t, res.Body.String(), "<title>PDF Generator API</title>")
+	assert.NotContains(t, res.Body.String(), "https://")
+
+	for _, asset := range []string{"swagger-ui.css", "swagger-ui-bundle.js"} {
+		assert.Contains(t, res.Body.String(), `"docs/`+asset+`"`)
+
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/api/v1/docs/"+asset, nil))
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.NotEmpty(t, res.Header().Get("Content-Type"))
+		assert.NotZero(t, res.Body.Len())
+	}
+}
@@ -1,56 +1,168 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/cmd/api"
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/config"
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/genproto"
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/handlers"
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/lifecycle"
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/telemetry"
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/usecases"
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/utils"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// serviceConfig balances the calls between the addresses of the upstream
+// and skips the ones whose grpc.health.v1 status is not SERVING.
+const serviceConfig = `{
+	"loadBalancingPolicy": "round_robin",
+	"healthCheckConfig": {"serviceName": "TransactionInfoService"}
+}`
+
 func main() {
-	GRPC_PORT := config.GetEnv("GRPC_PORT")
-	API_PORT := config.GetEnv("PORT")
-	ENV := config.GetEnv("ENV")
+	printConfig := flag.Bool("print-config", false, "print the configuration, secrets redacted, and exit")
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+
+	if *printConfig {
+		config.Print(os.Stdout, cfg)
+	}
+
+	if err != nil {
+		slog.Error("invalid configuration",
+			slog.String("error", err.Error()),
+		)
+		os.Exit(1)
+	}
+
+	if *printConfig {
+		return
+	}
+
+	logger, err := config.InitLogger(&cfg.Log)
+
+	if err != nil {
+		slog.Error("cannot start logger",
+			slog.String("error", err.Error()),
+		)
+		os.Exit(1)
+	}
+
+	slog.Info("configuration loaded", slog.Any("config", cfg))
 
-	client := initClient(GRPC_PORT, ENV)
-	initApiServer(client, API_PORT)
+	app := lifecycle.New(cfg.ShutdownTimeout)
+
+	shutdownTelemetry := initTelemetry(cfg.Telemetry)
+
+	conn := initClientConn(cfg.GrpcPort, cfg.Env)
+	readinessUsecase := usecases.NewReadinessUsecase(healthpb.NewHealthClient(conn))
+	apiServer := newApiServer(conn, readinessUsecase, cfg.Port)
+
+	app.Go("http", func() error {
+		err := apiServer.ListenAndServe()
+
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	})
+
+	// readiness fails first, so the load balancers stop sending traffic
+	// while the server drains
+	app.OnShutdown("readiness", func(ctx context.Context) error {
+		readinessUsecase.Drain()
+		return nil
+	})
+	app.OnShutdown("http server", apiServer.Shutdown)
+	app.OnShutdown("telemetry", shutdownTelemetry)
+	app.OnShutdown("grpc client", func(ctx context.Context) error {
+		return conn.Close()
+	})
+	// last, so the other steps are logged to the file
+	app.OnShutdown("log file", func(ctx context.Context) error {
+		return logger.Close()
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	err = app.Wait(ctx)
+
+	if err != nil {
+		slog.Error("shutdown",
+			slog.String("error", err.Error()),
+		)
+		os.Exit(1)
+	}
 }
 
-func initClient(PORT string, ENV string) genproto.TransactionInfoServiceClient {
+func initClientConn(port int, env string) *grpc.ClientConn {
 	var host string
 
-	switch ENV {
+	switch env {
 	case "prod":
 		host = "nginx"
 	default:
 		host = "0.0.0.0"
 	}
 
-	serverAddress := fmt.Sprintf("%s:%s", host, PORT)
-	conn, err := grpc.NewClient(serverAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	serverAddress := fmt.Sprintf("dns:///%s:%d", host, port)
+	conn, err := grpc.NewClient(serverAddress,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultServiceConfig(serviceConfig),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(
+			usecases.RequestIdUnaryClientInterceptor(),
+			usecases.MetricsUnaryClientInterceptor(),
+		),
+		grpc.WithChainStreamInterceptor(
+			usecases.RequestIdStreamClientInterceptor(),
+			usecases.MetricsStreamClientInterceptor(),
+		),
+	)
 
 	if err != nil {
 		panic(err)
 	}
 
-	return genproto.NewTransactionInfoServiceClient(conn)
+	return conn
 }
 
-func initApiServer(client genproto.TransactionInfoServiceClient, PORT string) {
-	transactionReport := usecases.NewTransactionReport(client, utils.NewGofpdfGenerator())
+func newApiServer(conn *grpc.ClientConn, readinessUsecase *usecases.ReadinessUsecase, port int) *http.Server {
+	transactionReport := usecases.NewTransactionReport(genproto.NewTransactionInfoServiceClient(conn),
+		utils.NewGofpdfGenerator())
 	reportHandler := handlers.NewReportHandler(transactionReport)
+	healthHandler := handlers.NewHealthHandler(readinessUsecase)
 
-	router := api.Routes(reportHandler)
+	return &http.Server{
+		Addr:    fmt.Sprintf("0.0.0.0:%d", port),
+		Handler: api.Routes(reportHandler, healthHandler),
+	}
+}
 
-	err := router.Run(fmt.Sprintf("0.0.0.0:%s", PORT))
+func initTelemetry(cfg config.Telemetry) func(ctx context.Context) error {
+	shutdown, err := telemetry.Init(context.Background(), telemetry.Config{
+		ServiceName: "pdf-generator-api",
+		Exporter:    cfg.Exporter,
+		Endpoint:    cfg.Endpoint,
+	})
 
 	if err != nil {
 		panic(err)
 	}
+
+	return shutdown
 }
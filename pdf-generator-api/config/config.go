@@ -0,0 +1,76 @@
+package config
+
+import (
+	"flag"
+	"log/slog"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/telemetry"
+)
+
+// Config is the configuration of the pdf generator api, loaded once at
+// startup by Load.
+type Config struct {
+	Env             string        `config:"env"`
+	Port            int           `config:"port"`
+	GrpcPort        int           `config:"grpc_port"`
+	ShutdownTimeout time.Duration `config:"shutdown_timeout"`
+	Telemetry       Telemetry
+	Log             LoggerConfig
+}
+
+type Telemetry struct {
+	Exporter string `config:"otel_traces_exporter"`
+	// Endpoint is the host:port of the OTLP gRPC collector.
+	Endpoint string `config:"otel_exporter_otlp_endpoint"`
+}
+
+var defaults = map[string]string{
+	"env":                  "dev",
+	"port":                 "3031",
+	"grpc_port":            "8080",
+	"shutdown_timeout":     "15s",
+	"otel_traces_exporter": telemetry.ExporterNone,
+	"log_format":           LogFormatJson,
+	"log_output":           LogOutputStdout,
+	"log_file":             "./tmp/logs.log",
+	"log_max_size_mb":      "100",
+	"log_max_backups":      "5",
+}
+
+// Load reads the configuration, adding its flags to fs, and validates it.
+// The error lists every problem found, the returned configuration is never
+// nil so it can still be printed.
+func Load(fs *flag.FlagSet, args []string) (*Config, error) {
+	cfg := &Config{}
+	p := load(cfg, defaults, fs, args)
+	cfg.Log.Env = cfg.Env
+
+	cfg.validate(p)
+
+	return cfg, p.err()
+}
+
+func (c *Config) validate(p *problems) {
+	oneOf(p, "env", c.Env, "dev", "prod")
+	validPort(p, "port", c.Port)
+	validPort(p, "grpc_port", c.GrpcPort)
+
+	if c.ShutdownTimeout <= 0 {
+		p.add("shutdown_timeout", "must be positive, got %s", c.ShutdownTimeout)
+	}
+
+	oneOf(p, "otel_traces_exporter", c.Telemetry.Exporter, telemetry.ExporterNone,
+		telemetry.ExporterStdout, telemetry.ExporterOtlp, telemetry.ExporterMemory)
+
+	if c.Telemetry.Exporter == telemetry.ExporterOtlp {
+		required(p, "otel_exporter_otlp_endpoint", c.Telemetry.Endpoint)
+	}
+
+	c.Log.validate(p)
+}
+
+// LogValue logs the configuration with the secrets redacted.
+func (c *Config) LogValue() slog.Value {
+	return logValue(c)
+}
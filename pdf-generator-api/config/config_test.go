@@ -0,0 +1,69 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	// the loader reads .env from the working directory
+	wd, _ := os.Getwd()
+	os.Chdir(t.TempDir())
+	defer os.Chdir(wd)
+
+	load := func(args ...string) (*Config, error) {
+		return Load(flag.NewFlagSet("test", flag.ContinueOnError), args)
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		cfg, err := load()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "dev", cfg.Env)
+		assert.Equal(t, 3031, cfg.Port)
+		assert.Equal(t, 8080, cfg.GrpcPort)
+		assert.Equal(t, 15*time.Second, cfg.ShutdownTimeout)
+	})
+
+	t.Run("Flags over environment over file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "api.yaml")
+		os.WriteFile(file, []byte("port: 4000\ngrpc_port: 4001\nshutdown_timeout: 5s\n"), 0o600)
+
+		t.Setenv("GRPC_PORT", "5001")
+		t.Setenv("SHUTDOWN_TIMEOUT", "10s")
+
+		cfg, err := load("-config", file, "-shutdown-timeout", "30s")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4000, cfg.Port)
+		assert.Equal(t, 5001, cfg.GrpcPort)
+		assert.Equal(t, 30*time.Second, cfg.ShutdownTimeout)
+	})
+
+	t.Run("Reports every problem", func(t *testing.T) {
+		t.Setenv("PORT", "0")
+		t.Setenv("SHUTDOWN_TIMEOUT", "soon")
+		t.Setenv("OTEL_TRACES_EXPORTER", "jaeger")
+
+		_, err := load()
+
+		assert.EqualError(t, err, "SHUTDOWN_TIMEOUT: invalid duration \"soon\", expected a value like 15s\n"+
+			"PORT: must be between 1 and 65535, got 0\n"+
+			"OTEL_TRACES_EXPORTER: must be one of none, stdout, otlp, memory, got \"jaeger\"")
+	})
+}
+
+func TestPrint(t *testing.T) {
+	var out bytes.Buffer
+	Print(&out, &Config{Env: "prod", Port: 3031, ShutdownTimeout: time.Second})
+
+	assert.Equal(t, "ENV=prod\nPORT=3031\nGRPC_PORT=0\nSHUTDOWN_TIMEOUT=1s\n"+
+		"OTEL_TRACES_EXPORTER=\nOTEL_EXPORTER_OTLP_ENDPOINT=\nLOG_LEVEL=\nLOG_FORMAT=\nLOG_OUTPUT=\nLOG_FILE=\n"+
+		"LOG_MAX_SIZE_MB=0\nLOG_MAX_BACKUPS=0\n", out.String())
+}
@@ -0,0 +1,270 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// redacted replaces the value of the secrets when the configuration is
+// printed or logged.
+const redacted = "******"
+
+// field is a configuration value, tagged `config:"key"`. The key is the
+// name of the flag with dashes, and of the environment variable in upper
+// case. Fields tagged `secret:"true"` are redacted.
+type field struct {
+	key    string
+	secret bool
+	value  reflect.Value
+}
+
+func (f field) env() string {
+	return strings.ToUpper(f.key)
+}
+
+func (f field) flag() string {
+	return strings.ReplaceAll(f.key, "_", "-")
+}
+
+// fields lists the tagged fields of cfg, a pointer to a struct, looking
+// into the untagged nested structs.
+func fields(cfg any) []field {
+	var result []field
+
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		for i := 0; i < v.NumField(); i++ {
+			structField := v.Type().Field(i)
+			key := structField.Tag.Get("config")
+
+			if len(key) == 0 {
+				if structField.Type.Kind() == reflect.Struct {
+					walk(v.Field(i))
+				}
+				continue
+			}
+
+			result = append(result, field{
+				key:    key,
+				secret: structField.Tag.Get("secret") == "true",
+				value:  v.Field(i),
+			})
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem())
+
+	return result
+}
+
+// problems collects everything wrong with the configuration, at most one
+// problem per key, so they are all reported at once.
+type problems struct {
+	keys map[string]bool
+	errs []error
+}
+
+func (p *problems) add(key string, format string, args ...any) {
+	if p.keys == nil {
+		p.keys = make(map[string]bool)
+	}
+
+	if p.keys[key] {
+		return
+	}
+
+	p.keys[key] = true
+	p.errs = append(p.errs, fmt.Errorf("%s: %s", strings.ToUpper(key), fmt.Sprintf(format, args...)))
+}
+
+func (p *problems) err() error {
+	return errors.Join(p.errs...)
+}
+
+// load fills cfg from, in increasing precedence, the defaults, an optional
+// file, the environment and the flags, which are added to fs. A key can
+// also be read from the file named by <KEY>_FILE, for secrets mounted by
+// the orchestrator.
+//
+// The file is the one given by -config or CONFIG_FILE, or .env when it
+// exists in the working directory.
+func load(cfg any, defaults map[string]string, fs *flag.FlagSet, args []string) *problems {
+	p := &problems{}
+	v := viper.New()
+	all := fields(cfg)
+
+	configFile := fs.String("config", "", "configuration file, .env, .yaml or .json")
+	flagKeys := make(map[string]string)
+
+	for _, f := range all {
+		v.SetDefault(f.key, defaults[f.key])
+		fs.String(f.flag(), "", fmt.Sprintf("overrides %s", f.env()))
+		flagKeys[f.flag()] = f.key
+	}
+
+	err := fs.Parse(args)
+
+	if err != nil {
+		p.add("flags", "%s", err.Error())
+		return p
+	}
+
+	v.AutomaticEnv()
+
+	path := *configFile
+
+	if len(path) == 0 {
+		path = os.Getenv("CONFIG_FILE")
+	}
+
+	if len(path) == 0 {
+		if _, err := os.Stat(".env"); err == nil {
+			path = ".env"
+		}
+	}
+
+	if len(path) > 0 {
+		v.SetConfigFile(path)
+
+		if err := v.ReadInConfig(); err != nil {
+			p.add("config_file", "%s", err.Error())
+		}
+	}
+
+	for _, f := range all {
+		secretPath := v.GetString(f.key + "_file")
+
+		if len(secretPath) == 0 {
+			continue
+		}
+
+		content, err := os.ReadFile(secretPath)
+
+		if err != nil {
+			p.add(f.key+"_file", "%s", err.Error())
+			continue
+		}
+
+		v.Set(f.key, strings.TrimRight(string(content), "\r\n"))
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		if key, ok := flagKeys[f.Name]; ok {
+			v.Set(key, f.Value.String())
+		}
+	})
+
+	for _, f := range all {
+		decode(p, f, strings.TrimSpace(v.GetString(f.key)))
+	}
+
+	return p
+}
+
+func decode(p *problems, f field, raw string) {
+	if len(raw) == 0 {
+		return
+	}
+
+	switch {
+	case f.value.Type() == reflect.TypeOf(time.Duration(0)):
+		duration, err := time.ParseDuration(raw)
+
+		if err != nil {
+			p.add(f.key, "invalid duration %q, expected a value like 15s", raw)
+			return
+		}
+
+		f.value.SetInt(int64(duration))
+	case f.value.Kind() == reflect.Int:
+		number, err := strconv.Atoi(raw)
+
+		if err != nil {
+			p.add(f.key, "invalid number %q", raw)
+			return
+		}
+
+		f.value.SetInt(int64(number))
+	case f.value.Kind() == reflect.Bool:
+		boolean, err := strconv.ParseBool(raw)
+
+		if err != nil {
+			p.add(f.key, "invalid boolean %q", raw)
+			return
+		}
+
+		f.value.SetBool(boolean)
+	default:
+		f.value.SetString(raw)
+	}
+}
+
+type entry struct {
+	key   string
+	value string
+}
+
+func entries(cfg any) []entry {
+	var result []entry
+
+	for _, f := range fields(cfg) {
+		value := fmt.Sprint(f.value.Interface())
+
+		if f.secret && len(value) > 0 {
+			value = redacted
+		}
+
+		result = append(result, entry{key: f.env(), value: value})
+	}
+
+	return result
+}
+
+// Print writes the effective configuration as KEY=value lines, with the
+// secrets redacted.
+func Print(w io.Writer, cfg any) {
+	for _, e := range entries(cfg) {
+		fmt.Fprintf(w, "%s=%s\n", e.key, e.value)
+	}
+}
+
+func logValue(cfg any) slog.Value {
+	var attrs []slog.Attr
+
+	for _, e := range entries(cfg) {
+		attrs = append(attrs, slog.String(strings.ToLower(e.key), e.value))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+func validPort(p *problems, key string, port int) {
+	if port < 1 || port > 65535 {
+		p.add(key, "must be between 1 and 65535, got %d", port)
+	}
+}
+
+func oneOf(p *problems, key string, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+
+	p.add(key, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+}
+
+func required(p *problems, key string, value string) {
+	if len(value) == 0 {
+		p.add(key, "is required")
+	}
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitLogger(t *testing.T) {
+	previous := slog.Default()
+	defer slog.SetDefault(previous)
+
+	path := filepath.Join(t.TempDir(), "logs.log")
+
+	logger, err := InitLogger(&LoggerConfig{
+		Env:        "prod",
+		Format:     LogFormatText,
+		Output:     LogOutputFile,
+		LogPath:    path,
+		MaxSizeMB:  1,
+		MaxBackups: 1,
+	})
+	assert.NoError(t, err)
+
+	slog.Debug("hidden")
+	slog.Info("paid", slog.String("card", "4111111111111111"))
+
+	logger.Level.Set(slog.LevelDebug)
+	slog.Debug("shown")
+
+	assert.NoError(t, logger.Close())
+
+	content, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "msg=paid")
+	assert.Contains(t, lines[0], "card=************1111")
+	assert.Contains(t, lines[1], "msg=shown")
+}
+
+func TestLoggerConfigValidate(t *testing.T) {
+	p := &problems{}
+
+	(&LoggerConfig{Level: "verbose", Format: "xml", Output: LogOutputBoth}).validate(p)
+
+	assert.EqualError(t, p.err(), "LOG_LEVEL: must be one of debug, info, warn, error, got \"verbose\"\n"+
+		"LOG_FORMAT: must be one of json, text, got \"xml\"\n"+
+		"LOG_FILE: is required\n"+
+		"LOG_MAX_SIZE_MB: must be at least 1, got 0")
+}
@@ -0,0 +1,44 @@
+package docs
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Spec is the OpenAPI document of the service, a test fails when a route of
+// api.Routes is missing from it.
+//
+//go:embed openapi.json
+var Spec []byte
+
+var swaggerUI = renderSwaggerUI(specTitle())
+
+var pathParam = regexp.MustCompile(`:([A-Za-z]+)`)
+
+// Path converts a gin path to its OpenAPI template, :accountId becomes
+// {accountId}.
+func Path(ginPath string) string {
+	return pathParam.ReplaceAllString(ginPath, "{$1}")
+}
+
+func OpenAPI(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", Spec)
+}
+
+func specTitle() string {
+	var document struct {
+		Info struct {
+			Title string `json:"title"`
+		} `json:"info"`
+	}
+
+	if err := json.Unmarshal(Spec, &document); err != nil {
+		panic(err)
+	}
+
+	return document.Info.Title
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	readinessUsecase *usecases.ReadinessUsecase
+}
+
+func NewHealthHandler(readinessUsecase *usecases.ReadinessUsecase) *HealthHandler {
+	return &HealthHandler{readinessUsecase: readinessUsecase}
+}
+
+// Live only tells the process is serving, a failing upstream must not get
+// it restarted.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, usecases.Report{Status: usecases.StatusUp})
+}
+
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report := h.readinessUsecase.Check(c.Request.Context())
+
+	status := http.StatusOK
+
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, report)
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthHandler(t *testing.T) {
+	sut := NewHealthHandler(usecases.NewReadinessUsecase(healthClient))
+	service := "TransactionInfoService"
+
+	serve := func(handler gin.HandlerFunc) (*httptest.ResponseRecorder, usecases.Report) {
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+		c.Request = httptest.NewRequest("GET", "/", nil)
+
+		handler(c)
+
+		var report usecases.Report
+		json.Unmarshal(res.Body.Bytes(), &report)
+
+		return res, report
+	}
+
+	t.Run("[Live] Up even when the upstream is not serving", func(t *testing.T) {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+		defer healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+
+		res, report := serve(sut.Live)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, usecases.StatusUp, report.Status)
+	})
+
+	t.Run("[Ready] Ready", func(t *testing.T) {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+
+		res, report := serve(sut.Ready)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, usecases.StatusUp, report.Status)
+	})
+
+	t.Run("[Ready] Unavailable while the upstream is not serving", func(t *testing.T) {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+		defer healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+
+		res, report := serve(sut.Ready)
+
+		assert.Equal(t, http.StatusServiceUnavailable, res.Code)
+		assert.Equal(t, usecases.StatusDown, report.Status)
+	})
+}
@@ -1,9 +1,7 @@
 package handlers
 
 import (
-	"net/http"
 	"os"
-	"strconv"
 
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/handlers/tools"
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/shared"
@@ -20,40 +18,35 @@ func NewReportHandler(transactionReport *usecases.TransactionReport) *ReportHand
 }
 
 func (h *ReportHandler) SendReport(c *gin.Context) {
-	tenantId, err := strconv.ParseInt(c.Param("tenantId"), 0, 32)
+	tenantId, valid := tools.ParamId(c, "tenantId")
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tenant id"})
+	if !valid {
 		return
 	}
 
-	accountId, err := strconv.ParseInt(c.Param("accountId"), 0, 32)
+	accountId, valid := tools.ParamId(c, "accountId")
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account id"})
+	if !valid {
 		return
 	}
 
-	path, err := h.transactionReport.GeneratePdfReport(usecases.GenerateInputParams{
-		TenantId:  int32(tenantId),
-		AccountId: int32(accountId),
+	path, err := h.transactionReport.GeneratePdfReport(c.Request.Context(), usecases.GenerateInputParams{
+		TenantId:      tenantId,
+		AccountId:     accountId,
+		Authorization: c.GetHeader("Authorization"),
 	})
 
 	if err != nil {
-		if enfErr, ok := err.(*shared.EntityNotFoundError); ok {
-			c.JSON(http.StatusNotFound, gin.H{"error": enfErr.Message})
-			return
-		}
-
-		tools.LogInternalServerError(c, "report", "SendReport", err)
+		c.Error(err)
 		return
 	}
 
+	// every report has a file of its own, removed once sent
+	defer os.Remove(path)
+
 	_, err = os.Stat(path)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "File not found",
-		})
+		c.Error(&shared.EntityNotFoundError{Message: "File not found"})
 		return
 	}
 
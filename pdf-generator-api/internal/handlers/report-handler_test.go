@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/handlers/tools"
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/mocks"
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/usecases"
 	"github.com/gin-gonic/gin"
@@ -31,14 +34,15 @@ func TestReportHandler(t *testing.T) {
 		}}
 
 		sut.SendReport(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, "Invalid tenant id", responseBody["error"])
+		assert.Contains(t, responseBody.Errors, tools.FieldError{Field: "tenantId", Detail: "must be a valid id"})
 	})
 
 	t.Run("[SendReport] Invalid account id", func(t *testing.T) {
@@ -58,14 +62,15 @@ func TestReportHandler(t *testing.T) {
 			}}
 
 		sut.SendReport(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, "Invalid account id", responseBody["error"])
+		assert.Contains(t, responseBody.Errors, tools.FieldError{Field: "accountId", Detail: "must be a valid id"})
 	})
 
 	t.Run("[SendReport] Transaction not found", func(t *testing.T) {
@@ -85,8 +90,9 @@ func TestReportHandler(t *testing.T) {
 			}}
 
 		sut.SendReport(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
@@ -116,13 +122,44 @@ func TestReportHandler(t *testing.T) {
 			}}
 
 		sut.SendReport(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusNotFound, res.Result().StatusCode)
-		assert.Equal(t, "File not found", responseBody["error"])
+		assert.Equal(t, "File not found", responseBody.Detail)
+	})
+
+	t.Run("[SendReport] Report removed once sent", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "transactions-1.pdf")
+		assert.NoError(t, os.WriteFile(path, []byte("%PDF-1.3"), 0o600))
+
+		mockPdfGenerator.On("Generate").Return(path, nil)
+		defer mockPdfGenerator.On("Generate").Unset()
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("GET", "/account", nil)
+		c.Params = []gin.Param{
+			{
+				Key:   "tenantId",
+				Value: "1",
+			},
+			{
+				Key:   "accountId",
+				Value: "1",
+			}}
+
+		sut.SendReport(c)
+
+		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
+		assert.Equal(t, "%PDF-1.3", res.Body.String())
+
+		_, err := os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
 	})
 }
@@ -0,0 +1,27 @@
+package tools
+
+import (
+	"strconv"
+
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/shared"
+	"github.com/gin-gonic/gin"
+)
+
+// ParamId parses an id path parameter, recording a validation error for
+// the problem middleware when it is not a positive number.
+func ParamId(c *gin.Context, name string) (int32, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 0, 32)
+
+	if err != nil || id <= 0 {
+		valErr := &shared.ValidationError{
+			Errors: make(map[string]string),
+		}
+
+		valErr.AddError(name, "must be a valid id")
+
+		c.Error(valErr)
+		return -1, false
+	}
+
+	return int32(id), true
+}
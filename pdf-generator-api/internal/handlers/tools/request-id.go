@@ -0,0 +1,24 @@
+package tools
+
+import (
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/shared"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestId keeps the X-Request-ID sent by the client, or creates one, in
+// the request context so logs and upstream calls can be correlated. The id
+// is echoed in the response.
+func RequestId() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestId := c.GetHeader(shared.RequestIdHeader)
+
+		if !shared.IsValidRequestId(requestId) {
+			requestId = shared.NewRequestId()
+		}
+
+		c.Request = c.Request.WithContext(shared.ContextWithRequestId(c.Request.Context(), requestId))
+		c.Header(shared.RequestIdHeader, requestId)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcClientHandled = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_handled_total",
+		Help: "gRPC calls to the transactions service, by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcClientDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_client_handling_seconds",
+		Help:    "gRPC call latency seen by the client, by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+// ObserveGrpcClient records a finished call, the method is the full name
+// of a method of the generated client so it is bounded.
+func ObserveGrpcClient(method string, err error, elapsed time.Duration) {
+	grpcClientHandled.WithLabelValues(method, status.Code(err).String()).Inc()
+	grpcClientDuration.WithLabelValues(method).Observe(elapsed.Seconds())
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(HTTP())
+	router.GET("/metrics", Handler())
+	router.GET("/accounts/:accountId/tenant/:tenantId/transactions.pdf", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	serve := func(path string) {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	t.Run("Labels the route template and the tenant", func(t *testing.T) {
+		serve("/accounts/1/tenant/7/transactions.pdf")
+		serve("/accounts/2/tenant/7/transactions.pdf")
+
+		assert.Equal(t, 2.0, testutil.ToFloat64(httpRequests.WithLabelValues("GET",
+			"/accounts/:accountId/tenant/:tenantId/transactions.pdf", "200", "7")))
+	})
+
+	t.Run("Invalid tenants are not labels", func(t *testing.T) {
+		serve("/accounts/1/tenant/abc/transactions.pdf")
+
+		assert.Equal(t, 1.0, testutil.ToFloat64(httpRequests.WithLabelValues("GET",
+			"/accounts/:accountId/tenant/:tenantId/transactions.pdf", "200", "none")))
+	})
+
+	t.Run("Unknown routes share one series", func(t *testing.T) {
+		serve("/random/path/1")
+		serve("/random/path/2")
+
+		assert.Equal(t, 2.0, testutil.ToFloat64(httpRequests.WithLabelValues("GET", "unmatched", "404", "none")))
+	})
+
+	t.Run("Tenants past the limit count as other", func(t *testing.T) {
+		// tenant 7 already took one of the slots
+		for i := 0; i < MaxTenantLabels; i++ {
+			serve(fmt.Sprintf("/accounts/1/tenant/%d/transactions.pdf", 1000+i))
+		}
+
+		assert.Equal(t, 1.0, testutil.ToFloat64(httpRequests.WithLabelValues("GET",
+			"/accounts/:accountId/tenant/:tenantId/transactions.pdf", "200", otherLabel)))
+	})
+
+	t.Run("Serves the metrics", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.True(t, strings.Contains(res.Body.String(), "http_request_duration_seconds_bucket"))
+		assert.True(t, strings.Contains(res.Body.String(), "go_goroutines"))
+	})
+}
+
+func TestPdf(t *testing.T) {
+	PdfGenerated(200*time.Millisecond, 20*1024)
+	PdfFailed(10 * time.Millisecond)
+
+	var duration, size dto.Metric
+	_ = pdfDuration.Write(&duration)
+	_ = pdfSize.Write(&size)
+
+	assert.Equal(t, uint64(2), duration.GetHistogram().GetSampleCount())
+	assert.Equal(t, uint64(1), size.GetHistogram().GetSampleCount())
+	assert.Equal(t, 20480.0, size.GetHistogram().GetSampleSum())
+	assert.Equal(t, 1.0, testutil.ToFloat64(pdfFailures))
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	pdfDuration = factory.NewHistogram(prometheus.HistogramOpts{
+		Name:    "pdf_generation_duration_seconds",
+		Help:    "Time to render a report.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	pdfSize = factory.NewHistogram(prometheus.HistogramOpts{
+		Name:    "pdf_size_bytes",
+		Help:    "Size of the rendered reports.",
+		Buckets: prometheus.ExponentialBuckets(4*1024, 4, 8),
+	})
+
+	pdfFailures = factory.NewCounter(prometheus.CounterOpts{
+		Name: "pdf_generation_failures_total",
+		Help: "Reports that failed to render.",
+	})
+)
+
+func PdfGenerated(elapsed time.Duration, size int64) {
+	pdfDuration.Observe(elapsed.Seconds())
+	pdfSize.Observe(float64(size))
+}
+
+func PdfFailed(elapsed time.Duration) {
+	pdfDuration.Observe(elapsed.Seconds())
+	pdfFailures.Inc()
+}
@@ -10,4 +10,14 @@ type PdfGeneratorInputParams struct {
 	FontSize float64
 	Headers  []string
 	Data     [][]string
+	Branding Branding
+}
+
+// Branding holds the tenant identity printed on the report. Colors are
+// hex strings like "#1A2B3C" and the logo is optional.
+type Branding struct {
+	PrimaryColor    string
+	SecondaryColor  string
+	Logo            []byte
+	LogoContentType string
 }
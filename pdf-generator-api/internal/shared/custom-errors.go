@@ -9,3 +9,35 @@ type EntityNotFoundError struct {
 func (e *EntityNotFoundError) Error() string {
 	return fmt.Sprint(e.Message)
 }
+
+type UnauthorizedError struct {
+	Message string
+}
+
+func (e *UnauthorizedError) Error() string {
+	return e.Message
+}
+
+type ForbiddenError struct {
+	Message string
+}
+
+func (e *ForbiddenError) Error() string {
+	return e.Message
+}
+
+type ValidationError struct {
+	Errors map[string]string
+}
+
+func (e *ValidationError) Error() string {
+	return "Validation error"
+}
+
+func (e *ValidationError) AddError(field string, err string) {
+	e.Errors[field] = err
+}
+
+func (e *ValidationError) HasErrors() bool {
+	return len(e.Errors) > 0
+}
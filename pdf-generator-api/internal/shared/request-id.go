@@ -0,0 +1,67 @@
+package shared
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"regexp"
+)
+
+const (
+	RequestIdHeader = "X-Request-ID"
+	// RequestIdMetadata carries the id in gRPC calls, metadata keys are
+	// lower case.
+	RequestIdMetadata = "x-request-id"
+)
+
+// ids sent by clients end up in every log line, so only short tokens like
+// uuids are accepted
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+type requestIdKey struct{}
+
+func ContextWithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+func RequestIdFromContext(ctx context.Context) (string, bool) {
+	requestId, ok := ctx.Value(requestIdKey{}).(string)
+	return requestId, ok
+}
+
+func IsValidRequestId(requestId string) bool {
+	return requestIdPattern.MatchString(requestId)
+}
+
+func NewRequestId() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// RequestIdHandler adds the request id of the context to every record, the
+// *Context functions of slog must be used for it to be found.
+type RequestIdHandler struct {
+	slog.Handler
+}
+
+func NewRequestIdHandler(next slog.Handler) *RequestIdHandler {
+	return &RequestIdHandler{Handler: next}
+}
+
+func (h *RequestIdHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestId, ok := RequestIdFromContext(ctx); ok {
+		record.AddAttrs(slog.String("request_id", requestId))
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *RequestIdHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return NewRequestIdHandler(h.Handler.WithAttrs(attrs))
+}
+
+func (h *RequestIdHandler) WithGroup(name string) slog.Handler {
+	return NewRequestIdHandler(h.Handler.WithGroup(name))
+}
@@ -0,0 +1,107 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/Lukasveiga/customers-users-transactions/pdf-generator-api"
+
+// Exporters accepted by Init, "none" keeps the propagation but records
+// nothing.
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOtlp   = "otlp"
+	ExporterMemory = "memory"
+)
+
+type Config struct {
+	ServiceName string
+	Exporter    string
+	// Endpoint is the host:port of the OTLP gRPC collector.
+	Endpoint string
+}
+
+// Memory keeps the spans of the memory exporter, used by tests and to
+// inspect traces without a collector.
+var Memory = tracetest.NewInMemoryExporter()
+
+// Init installs the global tracer provider and the W3C trace context
+// propagator. The returned function flushes the pending spans.
+func Init(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter, err := newExporter(ctx, cfg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	provider := NewTracerProvider(cfg.ServiceName, exporter)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer starts the spans of the service itself, the HTTP and gRPC ones are
+// recorded by their instrumentations.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// NewTracerProvider exports the spans in batches, except for the memory
+// exporter which must see them as soon as they end.
+func NewTracerProvider(serviceName string, exporter sdktrace.SpanExporter) *sdktrace.TracerProvider {
+	processor := sdktrace.NewBatchSpanProcessor(exporter)
+
+	if _, ok := exporter.(*tracetest.InMemoryExporter); ok {
+		processor = sdktrace.NewSimpleSpanProcessor(exporter)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(serviceName),
+		)),
+	)
+}
+
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case "", ExporterNone:
+		return nil, nil
+	case ExporterStdout:
+		return stdouttrace.New()
+	case ExporterMemory:
+		return Memory, nil
+	case ExporterOtlp:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithInsecure()}
+
+		if len(cfg.Endpoint) > 0 {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+
+		return otlptracegrpc.New(ctx, opts...)
+	}
+
+	return nil, fmt.Errorf("unknown trace exporter %q", cfg.Exporter)
+}
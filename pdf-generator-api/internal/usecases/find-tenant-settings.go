@@ -0,0 +1,19 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/genproto"
+)
+
+func FindTenantSettings(ctx context.Context, client genproto.TransactionInfoServiceClient,
+	tenantId int32) (*genproto.TenantSettings, error) {
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req := &genproto.GetTenantSettingsRequest{TenantId: uint32(tenantId)}
+
+	return client.GetTenantSettings(ctx, req)
+}
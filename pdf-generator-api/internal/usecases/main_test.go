@@ -8,10 +8,14 @@ import (
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/genproto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 var (
-	client genproto.TransactionInfoServiceClient
+	client       genproto.TransactionInfoServiceClient
+	healthClient healthpb.HealthClient
+	healthServer *health.Server
 )
 
 func TestMain(m *testing.M) {
@@ -22,6 +26,9 @@ func TestMain(m *testing.M) {
 	grpcServer := grpc.NewServer()
 	genproto.RegisterTransactionInfoServiceServer(grpcServer, transInfoServer)
 
+	healthServer = health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
 	listener, err := net.Listen("tcp", ":0")
 
 	if err != nil {
@@ -45,6 +52,7 @@ func TestMain(m *testing.M) {
 	}
 
 	client = genproto.NewTransactionInfoServiceClient(conn)
+	healthClient = healthpb.NewHealthClient(conn)
 
 	os.Exit(m.Run())
 }
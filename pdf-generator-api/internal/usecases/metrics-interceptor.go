@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/metrics"
+	"google.golang.org/grpc"
+)
+
+// MetricsUnaryClientInterceptor counts the calls to the transactions
+// service and their latency.
+func MetricsUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		metrics.ObserveGrpcClient(method, err, time.Since(start))
+		return err
+	}
+}
+
+// MetricsStreamClientInterceptor records the stream when it ends, which is
+// when RecvMsg returns io.EOF or an error.
+func MetricsStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+
+		if err != nil {
+			metrics.ObserveGrpcClient(method, err, time.Since(start))
+			return nil, err
+		}
+
+		return &metricsStream{ClientStream: stream, method: method, start: start}, nil
+	}
+}
+
+type metricsStream struct {
+	grpc.ClientStream
+	method string
+	start  time.Time
+	done   bool
+}
+
+func (s *metricsStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+
+	if err != nil && !s.done {
+		s.done = true
+
+		if err == io.EOF {
+			metrics.ObserveGrpcClient(s.method, nil, time.Since(s.start))
+		} else {
+			metrics.ObserveGrpcClient(s.method, err, time.Since(s.start))
+		}
+	}
+
+	return err
+}
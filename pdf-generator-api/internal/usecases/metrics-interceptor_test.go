@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeClientStream struct {
+	grpc.ClientStream
+	messages int
+}
+
+func (s *fakeClientStream) RecvMsg(m interface{}) error {
+	if s.messages == 0 {
+		return io.EOF
+	}
+
+	s.messages--
+	return nil
+}
+
+func TestMetricsClientInterceptor(t *testing.T) {
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		opts ...grpc.CallOption) error {
+		return status.Error(codes.NotFound, "tenant not found")
+	}
+
+	_ = MetricsUnaryClientInterceptor()(context.Background(), "/TransactionInfoService/GetTenantSettings",
+		nil, nil, nil, invoker)
+
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &fakeClientStream{messages: 2}, nil
+	}
+
+	stream, _ := MetricsStreamClientInterceptor()(context.Background(), &grpc.StreamDesc{}, nil,
+		"/TransactionInfoService/SearchTransactionInfo", streamer)
+
+	for stream.RecvMsg(nil) == nil {
+	}
+
+	// a read past the end is not a second call
+	_ = stream.RecvMsg(nil)
+
+	expected := `
+# HELP grpc_client_handled_total gRPC calls to the transactions service, by method and status code.
+# TYPE grpc_client_handled_total counter
+grpc_client_handled_total{code="NotFound",method="/TransactionInfoService/GetTenantSettings"} 1
+grpc_client_handled_total{code="OK",method="/TransactionInfoService/SearchTransactionInfo"} 1
+`
+
+	err := testutil.GatherAndCompare(metrics.Registry, strings.NewReader(expected), "grpc_client_handled_total")
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/genproto"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+type CheckResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is up only when every check is up.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+func (r *Report) Ready() bool {
+	return r.Status == StatusUp
+}
+
+// ReadinessUsecase asks the grpc.health.v1 service of the users
+// transactions api whether it can answer the report searches, and fails
+// once the service is shutting down.
+type ReadinessUsecase struct {
+	health   healthpb.HealthClient
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+func NewReadinessUsecase(health healthpb.HealthClient) *ReadinessUsecase {
+	return &ReadinessUsecase{
+		health:  health,
+		timeout: 2 * time.Second,
+	}
+}
+
+// Drain fails the next checks, so load balancers stop sending traffic
+// before the server shuts down.
+func (uc *ReadinessUsecase) Drain() {
+	uc.draining.Store(true)
+}
+
+func (uc *ReadinessUsecase) Check(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: map[string]CheckResult{"transactions-api": {Status: StatusUp}},
+	}
+
+	err := uc.checkUpstream(ctx)
+
+	if err != nil {
+		report.Status = StatusDown
+		report.Checks["transactions-api"] = CheckResult{Status: StatusDown, Error: err.Error()}
+	}
+
+	if uc.draining.Load() {
+		report.Status = StatusDown
+		report.Checks["shutdown"] = CheckResult{Status: StatusDown, Error: "shutting down"}
+	}
+
+	return report
+}
+
+func (uc *ReadinessUsecase) checkUpstream(ctx context.Context) error {
+	res, err := uc.health.Check(ctx, &healthpb.HealthCheckRequest{
+		Service: genproto.TransactionInfoService_ServiceDesc.ServiceName,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("upstream is %s", res.GetStatus())
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestReadinessUsecase(t *testing.T) {
+	sut := NewReadinessUsecase(healthClient)
+	service := "TransactionInfoService"
+
+	t.Run("Ready while the upstream is serving", func(t *testing.T) {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+
+		report := sut.Check(context.Background())
+
+		assert.True(t, report.Ready())
+		assert.Equal(t, CheckResult{Status: StatusUp}, report.Checks["transactions-api"])
+	})
+
+	t.Run("Not ready while the upstream is not serving", func(t *testing.T) {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+		defer healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+
+		report := sut.Check(context.Background())
+
+		assert.False(t, report.Ready())
+		assert.Equal(t, CheckResult{Status: StatusDown, Error: "upstream is NOT_SERVING"},
+			report.Checks["transactions-api"])
+	})
+
+	t.Run("Not ready while draining", func(t *testing.T) {
+		sut := NewReadinessUsecase(healthClient)
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+
+		sut.Drain()
+		report := sut.Check(context.Background())
+
+		assert.False(t, report.Ready())
+		assert.Equal(t, CheckResult{Status: StatusUp}, report.Checks["transactions-api"])
+		assert.Equal(t, CheckResult{Status: StatusDown, Error: "shutting down"}, report.Checks["shutdown"])
+	})
+
+	t.Run("Not ready while the upstream is unreachable", func(t *testing.T) {
+		// nothing listens on the port of a closed listener
+		listener, _ := net.Listen("tcp", "127.0.0.1:0")
+		listener.Close()
+
+		conn, _ := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		defer conn.Close()
+
+		report := NewReadinessUsecase(healthpb.NewHealthClient(conn)).Check(context.Background())
+
+		assert.False(t, report.Ready())
+		assert.Contains(t, report.Checks["transactions-api"].Error, "Unavailable")
+	})
+}
@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/shared"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIdUnaryClientInterceptor forwards the request id of the context in
+// the "x-request-id" metadata, so the users transactions api logs the call
+// with the same id.
+func RequestIdUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withRequestIdMetadata(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// RequestIdStreamClientInterceptor applies the same rules of
+// RequestIdUnaryClientInterceptor to streams.
+func RequestIdStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withRequestIdMetadata(ctx), desc, cc, method, opts...)
+	}
+}
+
+func withRequestIdMetadata(ctx context.Context) context.Context {
+	requestId, ok := shared.RequestIdFromContext(ctx)
+
+	if !ok {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, shared.RequestIdMetadata, requestId)
+}
@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRequestIdClientInterceptor(t *testing.T) {
+	t.Parallel()
+
+	sent := func(ctx context.Context) []string {
+		var values []string
+
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+			opts ...grpc.CallOption) error {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			values = md.Get(shared.RequestIdMetadata)
+			return nil
+		}
+
+		_ = RequestIdUnaryClientInterceptor()(ctx, "/TransactionInfoService/GetTenantSettings", nil, nil, nil, invoker)
+
+		return values
+	}
+
+	t.Run("Forwards the request id", func(t *testing.T) {
+		ctx := shared.ContextWithRequestId(context.Background(), "req-1")
+
+		assert.Equal(t, []string{"req-1"}, sent(ctx))
+	})
+
+	t.Run("Without request id", func(t *testing.T) {
+		assert.Empty(t, sent(context.Background()))
+	})
+
+	t.Run("Forwards the request id of streams", func(t *testing.T) {
+		var values []string
+
+		streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+			opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			values = md.Get(shared.RequestIdMetadata)
+			return nil, nil
+		}
+
+		ctx := shared.ContextWithRequestId(context.Background(), "req-2")
+		_, _ = RequestIdStreamClientInterceptor()(ctx, &grpc.StreamDesc{}, nil,
+			"/TransactionInfoService/SearchTransactionInfo", streamer)
+
+		assert.Equal(t, []string{"req-2"}, values)
+	})
+}
@@ -8,10 +8,10 @@ import (
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/genproto"
 )
 
-func SearchTransactionInformation(client genproto.TransactionInfoServiceClient,
+func SearchTransactionInformation(ctx context.Context, client genproto.TransactionInfoServiceClient,
 	filter *genproto.Filter) ([]*genproto.TransactionInfo, error) {
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	req := &genproto.SearchTransactionInfoRequest{Filter: filter}
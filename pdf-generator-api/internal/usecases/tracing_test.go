@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/genproto"
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/telemetry"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := telemetry.NewTracerProvider("test", exporter)
+	propagator := propagation.TraceContext{}
+
+	otel.SetTracerProvider(provider)
+
+	grpcServer := grpc.NewServer(grpc.StatsHandler(otelgrpc.NewServerHandler(
+		otelgrpc.WithTracerProvider(provider),
+		otelgrpc.WithPropagators(propagator),
+	)))
+	genproto.RegisterTransactionInfoServiceServer(grpcServer,
+		NewTransactionInfoServer(NewInMemoryTransactionInfoRepository()))
+
+	listener, err := net.Listen("tcp", ":0")
+	assert.NoError(t, err)
+
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient(listener.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+			otelgrpc.WithTracerProvider(provider),
+			otelgrpc.WithPropagators(propagator),
+		)),
+	)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	mockPdfGenerator := new(mocks.MockPdfGenerator)
+	mockPdfGenerator.On("Generate").Return("/reports/transactions.pdf", nil)
+
+	sut := NewTransactionReport(genproto.NewTransactionInfoServiceClient(conn), mockPdfGenerator)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "GET /transactions.pdf")
+	_, err = sut.GeneratePdfReport(ctx, GenerateInputParams{TenantId: 1, AccountId: 1})
+	parent.End()
+
+	assert.NoError(t, err)
+
+	// the server spans end after the client got the response
+	assert.Eventually(t, func() bool {
+		return len(exporter.GetSpans()) == 6
+	}, time.Second, 10*time.Millisecond)
+
+	spans := make(map[string]tracetest.SpanStub)
+
+	for _, span := range exporter.GetSpans() {
+		assert.Equal(t, parent.SpanContext().TraceID(), span.SpanContext.TraceID(), span.Name)
+		spans[span.Name+" "+span.SpanKind.String()] = span
+	}
+
+	client := spans["TransactionInfoService/SearchTransactionInfo client"]
+	server := spans["TransactionInfoService/SearchTransactionInfo server"]
+	render := spans["pdf.render internal"]
+
+	assert.Equal(t, parent.SpanContext().SpanID(), client.Parent.SpanID())
+	assert.Equal(t, client.SpanContext.SpanID(), server.Parent.SpanID())
+	assert.True(t, server.Parent.IsRemote())
+	assert.Equal(t, parent.SpanContext().SpanID(), render.Parent.SpanID())
+}
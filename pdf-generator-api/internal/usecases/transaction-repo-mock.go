@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/genproto"
 	"github.com/jinzhu/copier"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type TransactionInfoRepository interface {
@@ -25,19 +27,28 @@ func NewInMemoryTransactionInfoRepository() *InMemoryTransactionInfoRepository {
 	return &InMemoryTransactionInfoRepository{
 		data: []*genproto.TransactionInfo{
 			{
-				AccountId: 1,
-				Kind:      "Streaming Z",
-				Value:     50,
+				AccountId:          1,
+				Kind:               "Streaming Z",
+				Value:              50,
+				CreatedAt:          timestamppb.New(time.Date(2024, 10, 19, 15, 0, 0, 0, time.UTC)),
+				FormattedValue:     "R$ 0,50",
+				FormattedCreatedAt: "19/10/2024 12:00",
 			},
 			{
-				AccountId: 1,
-				Kind:      "Streaming X",
-				Value:     60,
+				AccountId:          1,
+				Kind:               "Streaming X",
+				Value:              60,
+				CreatedAt:          timestamppb.New(time.Date(2024, 10, 20, 15, 0, 0, 0, time.UTC)),
+				FormattedValue:     "R$ 0,60",
+				FormattedCreatedAt: "20/10/2024 12:00",
 			},
 			{
-				AccountId: 3,
-				Kind:      "Streaming Z",
-				Value:     50,
+				AccountId:          3,
+				Kind:               "Streaming Z",
+				Value:              50,
+				CreatedAt:          timestamppb.New(time.Date(2024, 10, 19, 15, 0, 0, 0, time.UTC)),
+				FormattedValue:     "R$ 0,50",
+				FormattedCreatedAt: "19/10/2024 12:00",
 			},
 		},
 	}
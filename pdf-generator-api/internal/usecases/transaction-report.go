@@ -1,12 +1,20 @@
 package usecases
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/genproto"
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/metrics"
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/ports"
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/shared"
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -23,54 +31,117 @@ func NewTransactionReport(client genproto.TransactionInfoServiceClient, pdfGener
 }
 
 type GenerateInputParams struct {
-	TenantId  int32
-	AccountId int32
+	TenantId      int32
+	AccountId     int32
+	Authorization string
 }
 
-func (r *TransactionReport) GeneratePdfReport(input GenerateInputParams) (string, error) {
+func (r *TransactionReport) GeneratePdfReport(ctx context.Context, input GenerateInputParams) (string, error) {
 
 	filter := &genproto.Filter{
 		TenantId:  uint32(input.TenantId),
 		AccountId: uint32(input.AccountId),
 	}
 
-	result, err := SearchTransactionInformation(r.client, filter)
+	if len(input.Authorization) > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", input.Authorization)
+	}
+
+	settings, err := FindTenantSettings(ctx, r.client, input.TenantId)
 
 	if err != nil {
-		if status.Code(err) == codes.NotFound {
-			return "", &shared.EntityNotFoundError{
-				Message: err.Error(),
-			}
-		}
-		return "", err
+		return "", handleRpcError(err)
+	}
+
+	result, err := SearchTransactionInformation(ctx, r.client, filter)
+
+	if err != nil {
+		return "", handleRpcError(err)
 	}
 
 	data := convertData(result)
 
 	inputPdf := ports.PdfGeneratorInputParams{
-		Title:    fmt.Sprintf("Account %d Transactions Information", input.AccountId),
+		Title:    reportTitle(settings, input.AccountId),
 		Font:     "Arial",
 		FontSize: 12,
-		Headers:  []string{"Account", "Kind", "Value"},
+		Headers:  []string{"Account", "Date", "Kind", "Value"},
 		Data:     data,
+		Branding: ports.Branding{
+			PrimaryColor:    settings.GetPrimaryColor(),
+			SecondaryColor:  settings.GetSecondaryColor(),
+			Logo:            settings.GetLogo(),
+			LogoContentType: settings.GetLogoContentType(),
+		},
 	}
 
-	path, err := r.pdfGenerator.Generate(inputPdf)
+	return r.render(ctx, inputPdf)
+}
+
+func (r *TransactionReport) render(ctx context.Context, input ports.PdfGeneratorInputParams) (string, error) {
+	_, span := telemetry.Tracer().Start(ctx, "pdf.render")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("pdf.rows", len(input.Data)))
+
+	start := time.Now()
+	path, err := r.pdfGenerator.Generate(input)
 
 	if err != nil {
+		metrics.PdfFailed(time.Since(start))
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
 		return "", err
 	}
 
+	var size int64
+
+	if info, err := os.Stat(path); err == nil {
+		size = info.Size()
+	}
+
+	metrics.PdfGenerated(time.Since(start), size)
+	span.SetAttributes(attribute.Int64("pdf.size", size))
+
 	return path, nil
 }
 
+func handleRpcError(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return &shared.EntityNotFoundError{
+			Message: err.Error(),
+		}
+	case codes.Unauthenticated:
+		return &shared.UnauthorizedError{
+			Message: status.Convert(err).Message(),
+		}
+	case codes.PermissionDenied:
+		return &shared.ForbiddenError{
+			Message: status.Convert(err).Message(),
+		}
+	}
+	return err
+}
+
+func reportTitle(settings *genproto.TenantSettings, accountId int32) string {
+	title := fmt.Sprintf("Account %d Transactions Information", accountId)
+
+	if len(settings.GetDisplayName()) > 0 {
+		return fmt.Sprintf("%s - %s", settings.GetDisplayName(), title)
+	}
+
+	return title
+}
+
+// convertData renders the values as the users transactions api formatted
+// them with the tenant settings.
 func convertData(data []*genproto.TransactionInfo) [][]string {
 	table := make([][]string, 0)
 
 	for _, d := range data {
 		accountId := fmt.Sprintf("%d", d.AccountId)
-		value := fmt.Sprintf("%.2f", d.Value)
-		table = append(table, []string{accountId, d.Kind, value})
+		table = append(table, []string{accountId, d.GetFormattedCreatedAt(), d.Kind, d.GetFormattedValue()})
 	}
 
 	return table
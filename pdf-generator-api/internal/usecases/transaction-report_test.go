@@ -1,12 +1,16 @@
 package usecases
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/genproto"
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/mocks"
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/shared"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestTransactionReport(t *testing.T) {
@@ -21,7 +25,7 @@ func TestTransactionReport(t *testing.T) {
 			AccountId: 99,
 		}
 
-		result, err := sut.GeneratePdfReport(input)
+		result, err := sut.GeneratePdfReport(context.Background(), input)
 
 		_, ok := err.(*shared.EntityNotFoundError)
 
@@ -40,7 +44,7 @@ func TestTransactionReport(t *testing.T) {
 			AccountId: 1,
 		}
 
-		result, err := sut.GeneratePdfReport(input)
+		result, err := sut.GeneratePdfReport(context.Background(), input)
 
 		assert.Empty(t, result)
 		assert.Equal(t, expectedErr, err)
@@ -57,9 +61,28 @@ func TestTransactionReport(t *testing.T) {
 			AccountId: 1,
 		}
 
-		result, err := sut.GeneratePdfReport(input)
+		result, err := sut.GeneratePdfReport(context.Background(), input)
 
 		assert.NoError(t, err)
 		assert.Equal(t, path, result)
 	})
+
+	t.Run("Convert data formatted by the api", func(t *testing.T) {
+		data := []*genproto.TransactionInfo{
+			{
+				AccountId:          1,
+				Kind:               "Streaming Z",
+				Value:              123450,
+				CreatedAt:          timestamppb.New(time.Date(2024, 10, 19, 15, 0, 0, 0, time.UTC)),
+				FormattedValue:     "$ 1,234.50",
+				FormattedCreatedAt: "10/19/2024 11:00",
+			},
+		}
+
+		result := convertData(data)
+
+		assert.Equal(t, [][]string{
+			{"1", "10/19/2024 11:00", "Streaming Z", "$ 1,234.50"},
+		}, result)
+	})
 }
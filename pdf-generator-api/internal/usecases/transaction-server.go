@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"context"
 	"database/sql"
 	"log"
 
@@ -44,3 +45,18 @@ func (server *TransactionInfoServer) SearchTransactionInfo(req *genproto.SearchT
 	}
 	return nil
 }
+
+// GetTenantSettings answers with the default settings for every tenant.
+func (server *TransactionInfoServer) GetTenantSettings(ctx context.Context,
+	req *genproto.GetTenantSettingsRequest) (*genproto.TenantSettings, error) {
+
+	return &genproto.TenantSettings{
+		TenantId:       req.GetTenantId(),
+		Currency:       "BRL",
+		Timezone:       "America/Sao_Paulo",
+		Locale:         "pt-BR",
+		ClosingDay:     1,
+		PrimaryColor:   "#000000",
+		SecondaryColor: "#FFFFFF",
+	}, nil
+}
@@ -1,10 +1,21 @@
 package utils
 
 import (
+	"bytes"
+	"os"
+	"strconv"
+
 	"github.com/Lukasveiga/customers-users-transactions/pdf-generator-api/internal/ports"
 	"github.com/jung-kurt/gofpdf"
 )
 
+const reportsDir = "./internal/report-transactions"
+
+var logoImageTypes = map[string]string{
+	"image/png":  "PNG",
+	"image/jpeg": "JPG",
+}
+
 type GofpdfGenerator struct{}
 
 func NewGofpdfGenerator() *GofpdfGenerator {
@@ -13,64 +24,153 @@ func NewGofpdfGenerator() *GofpdfGenerator {
 
 func (g *GofpdfGenerator) Generate(input ports.PdfGeneratorInputParams) (string, error) {
 	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	primaryR, primaryG, primaryB := hexToRGB(input.Branding.PrimaryColor, 0, 0, 0)
+	secondaryR, secondaryG, secondaryB := hexToRGB(input.Branding.SecondaryColor, 255, 255, 255)
+
+	logoName := registerLogo(pdf, input.Branding)
+
 	pdf.SetHeaderFunc(func() {
+		pageWidth, _ := pdf.GetPageSize()
+
+		pdf.SetFillColor(primaryR, primaryG, primaryB)
+		pdf.Rect(0, 0, pageWidth, 25, "F")
+
+		if len(logoName) > 0 {
+			pdf.ImageOptions(logoName, pageWidth-30, 5, 0, 15, false, gofpdf.ImageOptions{}, 0, "")
+		}
+
+		pdf.SetTextColor(secondaryR, secondaryG, secondaryB)
 		pdf.SetFont(input.Font, "B", input.FontSize)
-		pdf.Cell(0, 10, input.Title)
-		pdf.Ln(20)
+		pdf.SetXY(10, 8)
+		pdf.Cell(0, 10, tr(input.Title))
+		pdf.SetTextColor(0, 0, 0)
+		pdf.Ln(22)
 	})
 	pdf.AddPage()
 
-	columnWidths, marginLeft := tableConfig(pdf, 60)
+	columnWidths, marginLeft := tableConfig(pdf, len(input.Headers))
 
-	drawHeaders(pdf, input.Headers, input.Font, input.FontSize, columnWidths, marginLeft)
+	headerColors := [][3]int{
+		{primaryR, primaryG, primaryB},
+		{secondaryR, secondaryG, secondaryB},
+	}
+
+	drawHeaders(pdf, tr, input.Headers, input.Font, input.FontSize, columnWidths, marginLeft, headerColors)
 
 	// Add table rows
 	pdf.SetFont(input.Font, "", input.FontSize)
 	for _, data := range input.Data {
 		pdf.SetX(marginLeft)
 		for j, d := range data {
-			pdf.CellFormat(columnWidths[j], 10, d, "1", 0, "C", false, 0, "")
+			pdf.CellFormat(columnWidths[j], 10, tr(d), "1", 0, "C", false, 0, "")
 		}
 		pdf.Ln(-1)
 
 		if pdf.GetY() > 260 {
 			pdf.AddPage()
-			drawHeaders(pdf, input.Headers, input.Font, input.FontSize, columnWidths, marginLeft)
+			drawHeaders(pdf, tr, input.Headers, input.Font, input.FontSize, columnWidths, marginLeft, headerColors)
 		}
 	}
 
-	filePath := "./internal/report-transactions/transactions.pdf"
+	return writeReport(pdf)
+}
+
+// writeReport saves the report to a file of its own, concurrent reports of
+// different tenants never share one. The caller removes it once sent.
+func writeReport(pdf *gofpdf.Fpdf) (string, error) {
+	file, err := os.CreateTemp(reportsDir, "transactions-*.pdf")
+	if err != nil {
+		return "", err
+	}
+
+	err = pdf.Output(file)
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
 
-	err := pdf.OutputFileAndClose(filePath)
 	if err != nil {
+		os.Remove(file.Name())
 		return "", err
 	}
 
-	return filePath, nil
+	return file.Name(), nil
 }
 
-func drawHeaders(pdf *gofpdf.Fpdf, headers []string, font string, fontSize float64, columnWidths []float64,
-	marginLeft float64) {
+// registerLogo returns the name used to draw the tenant logo, or an empty
+// string when the tenant has no logo in a supported format.
+func registerLogo(pdf *gofpdf.Fpdf, branding ports.Branding) string {
+	imageType, ok := logoImageTypes[branding.LogoContentType]
+
+	if !ok || len(branding.Logo) == 0 {
+		return ""
+	}
+
+	name := "tenant-logo"
+	info := pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: imageType},
+		bytes.NewReader(branding.Logo))
+
+	// a broken logo must not prevent the report from being generated
+	if info == nil || !pdf.Ok() {
+		pdf.ClearError()
+		return ""
+	}
+
+	return name
+}
+
+func drawHeaders(pdf *gofpdf.Fpdf, tr func(string) string, headers []string, font string, fontSize float64,
+	columnWidths []float64, marginLeft float64, colors [][3]int) {
 	pdf.SetFont(font, "B", fontSize)
+	pdf.SetFillColor(colors[0][0], colors[0][1], colors[0][2])
+	pdf.SetTextColor(colors[1][0], colors[1][1], colors[1][2])
 	pdf.SetX(marginLeft)
 	for i, header := range headers {
-		pdf.CellFormat(columnWidths[i], 10, header, "1", 0, "C", false, 0, "")
+		pdf.CellFormat(columnWidths[i], 10, tr(header), "1", 0, "C", true, 0, "")
 	}
 	pdf.Ln(-1)
+	pdf.SetTextColor(0, 0, 0)
 	pdf.SetFont(font, "", fontSize) // reset font
 }
 
-func tableConfig(pdf *gofpdf.Fpdf, cellSize float64) ([]float64, float64) {
-	columnWidths := []float64{cellSize, cellSize, cellSize}
-	tableWidth := 0.0
+// tableConfig splits the printable width between the columns and returns
+// the left margin that centers the table.
+func tableConfig(pdf *gofpdf.Fpdf, columns int) ([]float64, float64) {
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
 
-	for _, width := range columnWidths {
-		tableWidth += width
+	tableWidth := pageWidth - left - right
+	cellSize := tableWidth
+
+	if columns > 0 {
+		cellSize = tableWidth / float64(columns)
 	}
 
-	pageWidth, _ := pdf.GetPageSize()
+	columnWidths := make([]float64, columns)
+
+	for i := range columnWidths {
+		columnWidths[i] = cellSize
+	}
 
 	marginLeft := (pageWidth - tableWidth) / 2
 
 	return columnWidths, marginLeft
 }
+
+// hexToRGB parses colors like "#1A2B3C", falling back to the given color
+// when the value is malformed.
+func hexToRGB(hex string, r, g, b int) (int, int, int) {
+	if len(hex) != 7 || hex[0] != '#' {
+		return r, g, b
+	}
+
+	value, err := strconv.ParseUint(hex[1:], 16, 32)
+
+	if err != nil {
+		return r, g, b
+	}
+
+	return int(value >> 16 & 0xFF), int(value >> 8 & 0xFF), int(value & 0xFF)
+}
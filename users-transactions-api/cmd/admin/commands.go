@@ -0,0 +1,397 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/cmd/api/factory"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/utils"
+)
+
+// errUsage is returned once the problem with the command line and the
+// usage of the command were printed.
+var errUsage = errors.New("invalid usage")
+
+type admin struct {
+	usecases *factory.AdminUsecases
+	out      *printer
+	// actor identifies the operator in the audit records
+	actor string
+}
+
+type command struct {
+	group   string
+	action  string
+	summary string
+	run     func(ctx context.Context, a *admin, args []string) error
+}
+
+var commands = []command{
+	{"tenant", "create", "create a tenant and invite its admin", tenantCreate},
+	{"tenant", "suspend", "suspend a tenant", tenantSuspend},
+	{"account", "create", "create an account", accountCreate},
+	{"card", "create", "create a card for an account", cardCreate},
+	{"card", "recompute", "recompute card balances from their transactions", cardRecompute},
+	{"card", "transactions", "list the transactions of a card", cardTransactions},
+	{"transaction", "adjust", "post an adjustment transaction to a card", transactionAdjust},
+	{"report", "generate", "download the transactions report of an account", reportGenerate},
+}
+
+func findCommand(group string, action string) (command, bool) {
+	for _, c := range commands {
+		if c.group == group && c.action == action {
+			return c, true
+		}
+	}
+
+	return command{}, false
+}
+
+// parse parses the flags of a command and checks the required ones were
+// given, printing the usage of the command otherwise.
+func parse(fs *flag.FlagSet, args []string, required ...string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	for _, name := range required {
+		if !set[name] {
+			fmt.Fprintf(fs.Output(), "missing -%s\n", name)
+			fs.Usage()
+			return errUsage
+		}
+	}
+
+	if fs.NArg() > 0 {
+		fmt.Fprintf(fs.Output(), "unexpected argument %q\n", fs.Arg(0))
+		fs.Usage()
+		return errUsage
+	}
+
+	return nil
+}
+
+func id(value int32) string {
+	return strconv.Itoa(int(value))
+}
+
+// formatter renders money and times like the api does for the tenant.
+func (a *admin) formatter(ctx context.Context, tenantId int32) *utils.LocaleFormatter {
+	settings, err := a.usecases.FindTenantSettings.FindOne(ctx, tenantId)
+
+	if err != nil {
+		return utils.DefaultLocaleFormatter()
+	}
+
+	return utils.NewLocaleFormatter(settings.Timezone, settings.Locale, settings.Currency)
+}
+
+func tenantCreate(ctx context.Context, a *admin, args []string) error {
+	fs := flag.NewFlagSet("tenant create", flag.ContinueOnError)
+	name := fs.String("name", "", "tenant name")
+	adminEmail := fs.String("admin-email", "", "email invited as the tenant admin")
+
+	if err := parse(fs, args, "name", "admin-email"); err != nil {
+		return err
+	}
+
+	result, err := a.usecases.CreateTenant.Create(ctx, *name, *adminEmail)
+
+	if err != nil {
+		return err
+	}
+
+	response := dto.CreatedTenantToResponse(*result)
+
+	return a.out.print(response, table{
+		headers: []string{"ID", "NAME", "STATUS", "ADMIN"},
+		rows:    [][]string{{id(response.ID), response.Name, response.Status, response.Admin.Email}},
+	})
+}
+
+func tenantSuspend(ctx context.Context, a *admin, args []string) error {
+	fs := flag.NewFlagSet("tenant suspend", flag.ContinueOnError)
+	tenantId := fs.Int("tenant", 0, "tenant id")
+
+	if err := parse(fs, args, "tenant"); err != nil {
+		return err
+	}
+
+	tenant, err := a.usecases.SuspendTenant.Suspend(ctx, int32(*tenantId))
+
+	if err != nil {
+		return err
+	}
+
+	response := dto.TenantToResponse(*tenant)
+
+	return a.out.print(response, table{
+		headers: []string{"ID", "NAME", "STATUS", "SUSPENDED_AT"},
+		rows: [][]string{{id(response.ID), response.Name, response.Status,
+			tenant.SuspendedAt.Time.Format(time.RFC3339)}},
+	})
+}
+
+func accountCreate(ctx context.Context, a *admin, args []string) error {
+	fs := flag.NewFlagSet("account create", flag.ContinueOnError)
+	tenantId := fs.Int("tenant", 0, "tenant id")
+	status := fs.String("status", shared.AccountStatusActive, "initial status, pending or active")
+
+	if err := parse(fs, args, "tenant"); err != nil {
+		return err
+	}
+
+	account, err := a.usecases.CreateAccount.Create(ctx, int32(*tenantId), *status, a.actor)
+
+	if err != nil {
+		return err
+	}
+
+	response := dto.AccountToResponse(*account)
+
+	return a.out.print(response, table{
+		headers: []string{"ID", "TENANT", "STATUS"},
+		rows:    [][]string{{id(response.ID), id(response.TenantID), response.Status}},
+	})
+}
+
+func cardCreate(ctx context.Context, a *admin, args []string) error {
+	fs := flag.NewFlagSet("card create", flag.ContinueOnError)
+	tenantId := fs.Int("tenant", 0, "tenant id")
+	accountId := fs.Int("account", 0, "account id")
+
+	if err := parse(fs, args, "tenant", "account"); err != nil {
+		return err
+	}
+
+	card, err := a.usecases.CreateCard.Create(ctx, int32(*tenantId), int32(*accountId))
+
+	if err != nil {
+		return err
+	}
+
+	response := dto.CardToResponse(*card, a.formatter(ctx, int32(*tenantId)))
+
+	return a.out.print(response, table{
+		headers: []string{"ID", "ACCOUNT", "AMOUNT"},
+		rows:    [][]string{{id(response.ID), id(response.AccountID), response.FormattedAmount}},
+	})
+}
+
+type recomputedCardResponse struct {
+	dto.CardResponse
+	PreviousAmount int64 `json:"previous_amount"`
+}
+
+func cardRecompute(ctx context.Context, a *admin, args []string) error {
+	fs := flag.NewFlagSet("card recompute", flag.ContinueOnError)
+	tenantId := fs.Int("tenant", 0, "tenant id")
+	accountId := fs.Int("account", 0, "account id")
+	cardId := fs.Int("card", 0, "card id, all cards of the account when not given")
+
+	if err := parse(fs, args, "tenant", "account"); err != nil {
+		return err
+	}
+
+	cardIds := []int32{int32(*cardId)}
+
+	if *cardId == 0 {
+		cards, err := a.usecases.FindAllCards.FindAll(ctx, int32(*tenantId), int32(*accountId))
+
+		if err != nil {
+			return err
+		}
+
+		cardIds = cardIds[:0]
+
+		for _, card := range cards {
+			cardIds = append(cardIds, card.ID)
+		}
+	}
+
+	formatter := a.formatter(ctx, int32(*tenantId))
+	response := make([]recomputedCardResponse, 0, len(cardIds))
+	t := table{headers: []string{"ID", "PREVIOUS_AMOUNT", "AMOUNT", "CHANGED"}}
+
+	for _, cardId := range cardIds {
+		card, previous, err := a.usecases.RecomputeCardBalance.Recompute(ctx, int32(*tenantId), int32(*accountId),
+			cardId)
+
+		if err != nil {
+			return err
+		}
+
+		response = append(response, recomputedCardResponse{
+			CardResponse:   dto.CardToResponse(*card, formatter),
+			PreviousAmount: previous,
+		})
+		t.rows = append(t.rows, []string{id(card.ID), formatter.Money(previous), formatter.Money(card.Amount),
+			strconv.FormatBool(previous != card.Amount)})
+	}
+
+	return a.out.print(response, t)
+}
+
+func cardTransactions(ctx context.Context, a *admin, args []string) error {
+	fs := flag.NewFlagSet("card transactions", flag.ContinueOnError)
+	tenantId := fs.Int("tenant", 0, "tenant id")
+	accountId := fs.Int("account", 0, "account id")
+	cardId := fs.Int("card", 0, "card id")
+
+	if err := parse(fs, args, "tenant", "account", "card"); err != nil {
+		return err
+	}
+
+	transactions, err := a.usecases.FindAllTransactions.FindAll(ctx, int32(*tenantId), int32(*accountId),
+		int32(*cardId))
+
+	if err != nil {
+		return err
+	}
+
+	formatter := a.formatter(ctx, int32(*tenantId))
+	response := make([]dto.TransactionResponse, 0, len(transactions))
+	t := table{headers: []string{"ID", "KIND", "VALUE", "CREATED_AT"}}
+
+	for _, transaction := range transactions {
+		r := dto.TransactionToResponse(transaction, formatter)
+		response = append(response, r)
+		t.rows = append(t.rows, []string{id(r.ID), r.Kind, r.FormattedValue, r.CreatedAt.Format(time.RFC3339)})
+	}
+
+	return a.out.print(response, t)
+}
+
+// transactionAdjust posts a signed correction to the card balance, recorded
+// with the operator and the reason.
+func transactionAdjust(ctx context.Context, a *admin, args []string) error {
+	fs := flag.NewFlagSet("transaction adjust", flag.ContinueOnError)
+	tenantId := fs.Int("tenant", 0, "tenant id")
+	accountId := fs.Int("account", 0, "account id")
+	cardId := fs.Int("card", 0, "card id")
+	value := fs.Int64("value", 0, "value added to the card balance, negative to subtract")
+	kind := fs.String("kind", "adjustment", "description of the transaction")
+	reason := fs.String("reason", "", "why the balance is adjusted")
+
+	if err := parse(fs, args, "tenant", "account", "card", "value", "reason"); err != nil {
+		return err
+	}
+
+	transaction, err := a.usecases.AdjustTransaction.Adjust(ctx, int32(*tenantId), int32(*accountId),
+		infra.Transaction{
+			CardID: int32(*cardId),
+			Kind:   *kind,
+			Value:  *value,
+		}, a.actor, *reason)
+
+	if err != nil {
+		return err
+	}
+
+	response := dto.TransactionToResponse(*transaction, a.formatter(ctx, int32(*tenantId)))
+
+	return a.out.print(response, table{
+		headers: []string{"ID", "CARD", "KIND", "DIRECTION", "VALUE"},
+		rows: [][]string{{id(response.ID), id(response.CardId), response.Kind, response.Direction,
+			response.FormattedValue}},
+	})
+}
+
+type reportResponse struct {
+	File  string `json:"file"`
+	Bytes int64  `json:"bytes"`
+}
+
+// reportGenerate asks the pdf generator for the report, authenticated by a
+// short lived api key with the reports:read scope unless one is given.
+func reportGenerate(ctx context.Context, a *admin, args []string) error {
+	fs := flag.NewFlagSet("report generate", flag.ContinueOnError)
+	tenantId := fs.Int("tenant", 0, "tenant id")
+	accountId := fs.Int("account", 0, "account id")
+	out := fs.String("out", "transactions-report.pdf", "file the report is written to")
+	pdfUrl := fs.String("pdf-url", "http://localhost:3031", "base url of the pdf generator api")
+	apiKey := fs.String("api-key", "", "api key with the reports:read scope, a temporary one when not given")
+	timeout := fs.Duration("timeout", time.Minute, "time to wait for the report")
+
+	if err := parse(fs, args, "tenant", "account"); err != nil {
+		return err
+	}
+
+	key := *apiKey
+
+	if len(key) == 0 {
+		created, secret, err := a.usecases.CreateApiKey.Create(ctx, int32(*tenantId), "admin-cli-report",
+			[]string{shared.ScopeReportsRead})
+
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			if err := a.usecases.RevokeApiKey.Revoke(context.Background(), created.TenantID, created.ID); err != nil {
+				fmt.Fprintf(os.Stderr, "revoking temporary api key %s: %s\n", created.Prefix, err)
+			}
+		}()
+
+		key = secret
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	url := fmt.Sprintf("%s/api/v1/accounts/%d/tenant/%d/transactions.pdf", *pdfUrl, *accountId, *tenantId)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Authorization", "Bearer "+key)
+
+	response, err := http.DefaultClient.Do(request)
+
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+		return fmt.Errorf("pdf generator answered %s: %s", response.Status, body)
+	}
+
+	file, err := os.Create(*out)
+
+	if err != nil {
+		return err
+	}
+
+	written, err := io.Copy(file, response.Body)
+
+	if err = errors.Join(err, file.Close()); err != nil {
+		return err
+	}
+
+	return a.out.print(reportResponse{File: *out, Bytes: written}, table{
+		headers: []string{"FILE", "BYTES"},
+		rows:    [][]string{{*out, strconv.FormatInt(written, 10)}},
+	})
+}
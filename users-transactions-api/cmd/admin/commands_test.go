@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/cmd/api/factory"
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	accountUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/account"
+	cardUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/cards"
+	settingsUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	newFlagSet := func() *flag.FlagSet {
+		fs := flag.NewFlagSet("card create", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.Int("tenant", 0, "tenant id")
+		fs.Int("account", 0, "account id")
+		return fs
+	}
+
+	t.Run("Required flags given", func(t *testing.T) {
+		err := parse(newFlagSet(), []string{"-tenant", "1", "-account", "2"}, "tenant", "account")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Missing required flag", func(t *testing.T) {
+		err := parse(newFlagSet(), []string{"-tenant", "1"}, "tenant", "account")
+
+		assert.ErrorIs(t, err, errUsage)
+	})
+
+	t.Run("Unknown flag", func(t *testing.T) {
+		err := parse(newFlagSet(), []string{"-card", "1"}, "tenant")
+
+		assert.ErrorIs(t, err, errUsage)
+	})
+
+	t.Run("Unexpected argument", func(t *testing.T) {
+		err := parse(newFlagSet(), []string{"-tenant", "1", "extra"}, "tenant")
+
+		assert.ErrorIs(t, err, errUsage)
+	})
+
+	t.Run("Help", func(t *testing.T) {
+		err := parse(newFlagSet(), []string{"-h"})
+
+		assert.ErrorIs(t, err, flag.ErrHelp)
+	})
+}
+
+func TestPrinter(t *testing.T) {
+	value := map[string]int{"id": 1}
+	result := table{
+		headers: []string{"ID", "STATUS"},
+		rows:    [][]string{{"1", "active"}, {"10", "pending"}},
+	}
+
+	t.Run("Table", func(t *testing.T) {
+		var out bytes.Buffer
+
+		err := (&printer{format: outputTable, w: &out}).print(value, result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ID  STATUS\n1   active\n10  pending\n", out.String())
+	})
+
+	t.Run("Json", func(t *testing.T) {
+		var out bytes.Buffer
+
+		err := (&printer{format: outputJson, w: &out}).print(value, result)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"id": 1}`, out.String())
+	})
+}
+
+func TestCardRecompute(t *testing.T) {
+	mockRepo := new(mocks.MockRepository)
+
+	findAccountUsecase := accountUsecases.NewFindOneAccountUsecase(mockRepo)
+	findCardUsecase := cardUsecases.NewFindCardUsecase(mockRepo, findAccountUsecase)
+
+	var out bytes.Buffer
+
+	a := &admin{
+		usecases: &factory.AdminUsecases{
+			FindTenantSettings: settingsUsecases.NewFindTenantSettingsUsecase(mockRepo,
+				settingsUsecases.NewSettingsCache(time.Minute)),
+			FindAllCards:         cardUsecases.NewFindAllCards(mockRepo, findAccountUsecase),
+			RecomputeCardBalance: cardUsecases.NewRecomputeCardBalanceUsecase(mockRepo, findCardUsecase),
+		},
+		out: &printer{format: outputJson, w: &out},
+	}
+
+	account := infra.Account{ID: 1, TenantID: 1, Status: "active"}
+	card := infra.Card{ID: 1, AccountID: 1, Amount: 200}
+
+	mockRepo.On("GetAccount").Return(account, nil)
+	mockRepo.On("GetCards").Return([]infra.Card{card}, nil)
+	mockRepo.On("GetCard").Return(card, nil)
+	mockRepo.On("GetTenantSettings").Return(nil, sql.ErrNoRows)
+	mockRepo.On("RecomputeCardAmount").Return(infra.Card{ID: 1, AccountID: 1, Amount: 150}, nil)
+
+	err := cardRecompute(context.Background(), a, []string{"-tenant", "1", "-account", "1"})
+
+	assert.NoError(t, err)
+
+	var response []recomputedCardResponse
+	assert.NoError(t, json.NewDecoder(strings.NewReader(out.String())).Decode(&response))
+	assert.Len(t, response, 1)
+	assert.Equal(t, int64(200), response[0].PreviousAmount)
+	assert.Equal(t, int64(150), response[0].Amount)
+}
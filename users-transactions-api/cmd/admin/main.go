@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"os/user"
+	"syscall"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/cmd/api/factory"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/config"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/logging"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/utils"
+)
+
+const adminUsage = `usage: admin [flags] <group> <action> [action flags]
+
+Operates tenants, accounts and cards through the same usecases of the api.
+Run an action with -h to list its flags.
+
+`
+
+// Admin cli for operators, connected to the database of the api.
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	fs := flag.NewFlagSet("admin", flag.ExitOnError)
+	output := fs.String("output", outputTable, "output format, table or json")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), adminUsage)
+
+		for _, c := range commands {
+			fmt.Fprintf(fs.Output(), "  %-12s %-13s %s\n", c.group, c.action, c.summary)
+		}
+
+		fmt.Fprintln(fs.Output(), "\nflags:")
+		fs.PrintDefaults()
+	}
+
+	cfg, err := config.Load(fs, args)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if *output != outputTable && *output != outputJson {
+		fmt.Fprintf(os.Stderr, "output must be %s or %s, got %q\n", outputTable, outputJson, *output)
+		return 2
+	}
+
+	cmd, found := findCommand(fs.Arg(0), fs.Arg(1))
+
+	if !found {
+		fs.Usage()
+		return 2
+	}
+
+	// stdout carries the results, the usecases only report warnings
+	slog.SetDefault(slog.New(logging.NewRedactHandler(slog.NewTextHandler(os.Stderr,
+		&slog.HandlerOptions{Level: slog.LevelWarn}))))
+
+	dbConnection := config.InitConfig(cfg.Database.ConnString())
+	defer dbConnection.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	a := &admin{
+		usecases: factory.InitAdminUsecases(dbConnection, utils.NewFileMailer("./tmp/mail")),
+		out:      &printer{format: *output, w: os.Stdout},
+		actor:    actor(),
+	}
+
+	err = cmd.run(ctx, a, fs.Args()[2:])
+
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+
+	if errors.Is(err, errUsage) {
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+// actor names the operator in the audit records, like user:<id> does for
+// the api.
+func actor() string {
+	current, err := user.Current()
+
+	if err != nil {
+		return "admin_cli"
+	}
+
+	return "admin_cli:" + current.Username
+}
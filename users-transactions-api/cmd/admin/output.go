@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	outputTable = "table"
+	outputJson  = "json"
+)
+
+// table is the human readable form of a command result.
+type table struct {
+	headers []string
+	rows    [][]string
+}
+
+// printer writes the results either as aligned columns or as the json
+// returned by the api for the same resources.
+type printer struct {
+	format string
+	w      io.Writer
+}
+
+func (p *printer) print(value any, t table) error {
+	if p.format == outputJson {
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.headers, "\t"))
+
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
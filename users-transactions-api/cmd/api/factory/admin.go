@@ -0,0 +1,58 @@
+package factory
+
+import (
+	"database/sql"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/ports"
+	accountUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/account"
+	apiKeyUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/apikey"
+	cardUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/cards"
+	quotaUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/quota"
+	settingsUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/settings"
+	tenantUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/tenant"
+	transactionUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/transactions"
+)
+
+// AdminUsecases are the usecases behind the operators cli, wired like the
+// ones of the handlers so both enforce the same rules and quotas.
+type AdminUsecases struct {
+	FindTenantSettings   *settingsUsecases.FindTenantSettingsUsecase
+	CreateTenant         *tenantUsecases.CreateTenantUsecase
+	SuspendTenant        *tenantUsecases.SuspendTenantUsecase
+	CreateAccount        *accountUsecases.CreateAccountUsecase
+	CreateCard           *cardUsecases.CreateCardUsecase
+	FindCard             *cardUsecases.FindCardUsecase
+	FindAllCards         *cardUsecases.FindAllCards
+	RecomputeCardBalance *cardUsecases.RecomputeCardBalanceUsecase
+	AdjustTransaction    *transactionUsecases.AdjustTransactionUsecase
+	FindAllTransactions  *transactionUsecases.FindAllTransactionsUsecase
+	CreateApiKey         *apiKeyUsecases.CreateApiKeyUsecase
+	RevokeApiKey         *apiKeyUsecases.RevokeApiKeyUsecase
+}
+
+func InitAdminUsecases(dbConnection *sql.DB, mailer ports.Mailer) *AdminUsecases {
+	repository := infra.NewTx(dbConnection)
+
+	findTenantSettingsUsecase := settingsUsecases.NewFindTenantSettingsUsecase(repository,
+		settingsUsecases.NewSettingsCache(time.Minute))
+	quotaUsecase := quotaUsecases.NewQuotaUsecase(findTenantSettingsUsecase)
+	findOneAccountUsecase := accountUsecases.NewFindOneAccountUsecase(repository)
+	findCardUsecase := cardUsecases.NewFindCardUsecase(repository, findOneAccountUsecase)
+
+	return &AdminUsecases{
+		FindTenantSettings:   findTenantSettingsUsecase,
+		CreateTenant:         tenantUsecases.NewCreateTenantUsecase(repository, mailer),
+		SuspendTenant:        tenantUsecases.NewSuspendTenantUsecase(repository),
+		CreateAccount:        accountUsecases.NewCreateAccountUsecase(repository, quotaUsecase),
+		CreateCard:           cardUsecases.NewCreateCardUsecase(repository, quotaUsecase),
+		FindCard:             findCardUsecase,
+		FindAllCards:         cardUsecases.NewFindAllCards(repository, findOneAccountUsecase),
+		RecomputeCardBalance: cardUsecases.NewRecomputeCardBalanceUsecase(repository, findCardUsecase),
+		AdjustTransaction:    transactionUsecases.NewAdjustTransactionUsecase(repository, findCardUsecase),
+		FindAllTransactions:  transactionUsecases.NewFindAllTransactionsUsecase(repository, findCardUsecase),
+		CreateApiKey:         apiKeyUsecases.NewCreateApiKeyUsecase(repository),
+		RevokeApiKey:         apiKeyUsecases.NewRevokeApiKeyUsecase(repository),
+	}
+}
@@ -2,15 +2,26 @@ package factory
 
 import (
 	"database/sql"
-	"fmt"
+	"log/slog"
+	"time"
 
-	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/config"
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers"
 	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/ports"
 	accountUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/account"
+	apiKeyUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/apikey"
+	authUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/auth"
 	cardUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/cards"
+	grpcUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/grpc"
+	healthUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/health"
+	privacyUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/privacy"
+	quotaUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/quota"
+	rateLimitUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/ratelimit"
+	settingsUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/settings"
 	tenantUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/tenant"
 	transactionUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/transactions"
+	userUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/user"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/migrations"
 )
 
 type Handlers struct {
@@ -18,68 +29,155 @@ type Handlers struct {
 	TenantHandler      *handlers.TenantHandler
 	CardHandler        *handlers.CardHandler
 	TransactionHandler *handlers.TransactionHandler
+	ApiKeyHandler      *handlers.ApiKeyHandler
+	AuthHandler        *handlers.AuthHandler
+	UserHandler        *handlers.UserHandler
+	SettingsHandler    *handlers.SettingsHandler
+	RateLimitHandler   *handlers.RateLimitHandler
+	PrivacyHandler     *handlers.PrivacyHandler
+	HealthHandler      *handlers.HealthHandler
+	LogLevelHandler    *handlers.LogLevelHandler
 }
 
-func InitHandlers(dbConnection *sql.DB) *Handlers {
-	// Repository
+// erasedDataRetention is how long pseudonymized financial records are
+// kept, following the five year limit for bookkeeping records.
+const erasedDataRetention = 5 * 365 * 24 * time.Hour
+
+func InitAuthenticateUsecase(repository infra.Querier,
+	lastUsedTracker *apiKeyUsecases.LastUsedTracker) *authUsecases.AuthenticateUsecase {
+	authenticateApiKeyUsecase := apiKeyUsecases.NewAuthenticateApiKeyUsecase(repository, lastUsedTracker)
+	authenticateUserUsecase := userUsecases.NewAuthenticateUserUsecase(repository)
+
+	return authUsecases.NewAuthenticateUsecase(authenticateApiKeyUsecase, authenticateUserUsecase)
+}
+
+// InitTransactionInfo builds the gRPC service, whose tenant checks share
+// the tenant cache of the REST api.
+func InitTransactionInfo(dbConnection *sql.DB, replicaPool *infra.ReplicaPool,
+	tenantCache *tenantUsecases.TenantCache) *grpcUsecases.TransactionInfo {
 	repository := infra.NewTx(dbConnection)
+	findOneTenantUsecase := tenantUsecases.NewFindOneTenantUseCase(repository, tenantCache)
+
+	return grpcUsecases.NewTransactionInfo(repository, infra.NewReadTx(replicaPool), findOneTenantUsecase)
+}
+
+func InitReadinessUsecase(dbConnection *sql.DB) *healthUsecases.ReadinessUsecase {
+	return healthUsecases.NewReadinessUsecase(infra.NewTx(dbConnection), migrations.FS)
+}
+
+func InitHandlers(dbConnection *sql.DB, replicaPool *infra.ReplicaPool,
+	lastUsedTracker *apiKeyUsecases.LastUsedTracker, readinessUsecase *healthUsecases.ReadinessUsecase,
+	tenantCache *tenantUsecases.TenantCache, logLevel *slog.LevelVar, mailer ports.Mailer, rateLimitMode string) *Handlers {
+	// Repositories, the read only usecases go to the replica when there is
+	// one. The tenant and its settings stay on the primary: they are cached
+	// right after a change, a lagging replica would cache the old values.
+	repository := infra.NewTx(dbConnection)
+	readRepository := infra.NewReadTx(replicaPool)
+
+	// Rate limiter, shared between replicas in postgres mode
+	var limiter rateLimitUsecases.Limiter = rateLimitUsecases.NewMemoryLimiter()
+
+	if rateLimitMode == "postgres" {
+		limiter = rateLimitUsecases.NewPostgresLimiter(repository)
+	}
+
+	// Settings usecases
+	settingsCache := settingsUsecases.NewSettingsCache(time.Minute)
+	findTenantSettingsUsecase := settingsUsecases.NewFindTenantSettingsUsecase(repository, settingsCache)
+	updateTenantSettingsUsecase := settingsUsecases.NewUpdateTenantSettingsUsecase(repository, settingsCache)
+	uploadTenantLogoUsecase := settingsUsecases.NewUploadTenantLogoUsecase(repository)
+	findTenantLogoUsecase := settingsUsecases.NewFindTenantLogoUsecase(repository)
+	deleteTenantLogoUsecase := settingsUsecases.NewDeleteTenantLogoUsecase(repository)
+	updateTenantLimitsUsecase := settingsUsecases.NewUpdateTenantLimitsUsecase(repository, settingsCache)
+
+	// Quota and rate limit usecases
+	quotaUsecase := quotaUsecases.NewQuotaUsecase(findTenantSettingsUsecase)
+	rateLimitUsecase := rateLimitUsecases.NewRateLimitUsecase(limiter, findTenantSettingsUsecase)
 
 	// Account usecases
-	createAccountUsecase := accountUsecases.NewCreateAccountUsecase(repository)
-	findOneAccountUsecase := accountUsecases.NewFindOneAccountUsecase(repository)
-	findAllAccountsUsecase := accountUsecases.NewFindAllAccountsUsecase(repository)
-	updateAccountUsecase := accountUsecases.NewActiveAccountUsecase(repository)
-	deleteAaccountUsecase := accountUsecases.NewInactiveAccountUsecase(repository)
+	createAccountUsecase := accountUsecases.NewCreateAccountUsecase(repository, quotaUsecase)
+	findOneAccountUsecase := accountUsecases.NewFindOneAccountUsecase(readRepository)
+	findAllAccountsUsecase := accountUsecases.NewFindAllAccountsUsecase(readRepository)
+	changeAccountStatusUsecase := accountUsecases.NewChangeAccountStatusUsecase(repository)
+	findAccountStatusHistoryUsecase := accountUsecases.NewFindAccountStatusHistoryUsecase(readRepository)
 
 	// Tenant usecases
-	findOneTenantUsecase := tenantUsecases.NewFindOneTenantUseCase(repository)
+	findOneTenantUsecase := tenantUsecases.NewFindOneTenantUseCase(repository, tenantCache)
+	createTenantUsecase := tenantUsecases.NewCreateTenantUsecase(repository, mailer)
+	findAllTenantsUsecase := tenantUsecases.NewFindAllTenantsUsecase(readRepository)
+	renameTenantUsecase := tenantUsecases.NewRenameTenantUsecase(repository)
+	suspendTenantUsecase := tenantUsecases.NewSuspendTenantUsecase(repository)
+	reactivateTenantUsecase := tenantUsecases.NewReactivateTenantUsecase(repository)
 
 	// Card usecases
-	createCardUsecase := cardUsecases.NewCreateCardUsecase(repository, findOneAccountUsecase)
-	findCardUsecase := cardUsecases.NewFindCardUsecase(repository, findOneAccountUsecase)
-	findAllCardsUsecase := cardUsecases.NewFindAllCards(repository, findOneAccountUsecase)
+	createCardUsecase := cardUsecases.NewCreateCardUsecase(repository, quotaUsecase)
+	findCardUsecase := cardUsecases.NewFindCardUsecase(readRepository, findOneAccountUsecase)
+	findAllCardsUsecase := cardUsecases.NewFindAllCards(readRepository, findOneAccountUsecase)
 
 	// Transaction usecases
-	createTransactionUsecase := transactionUsecases.NewCreateTransactionUsecase(repository, findCardUsecase)
-	findTransactionUsecase := transactionUsecases.NewFindTransactionUsecase(repository, findCardUsecase)
-	findTransactionsUsecase := transactionUsecases.NewFindAllTransactionsUsecase(repository, findCardUsecase)
+	createTransactionUsecase := transactionUsecases.NewCreateTransactionUsecase(repository, findCardUsecase,
+		quotaUsecase)
+	findTransactionUsecase := transactionUsecases.NewFindTransactionUsecase(readRepository, findCardUsecase)
+	findTransactionsUsecase := transactionUsecases.NewFindAllTransactionsUsecase(readRepository, findCardUsecase)
+
+	// Privacy usecases
+	exportAccountDataUsecase := privacyUsecases.NewExportAccountDataUsecase(repository)
+	eraseAccountDataUsecase := privacyUsecases.NewEraseAccountDataUsecase(repository, erasedDataRetention)
+	findDataSubjectRequestsUsecase := privacyUsecases.NewFindDataSubjectRequestsUsecase(readRepository)
+
+	// Api key usecases
+	createApiKeyUsecase := apiKeyUsecases.NewCreateApiKeyUsecase(repository)
+	findAllApiKeysUsecase := apiKeyUsecases.NewFindAllApiKeysUsecase(readRepository)
+	rotateApiKeyUsecase := apiKeyUsecases.NewRotateApiKeyUsecase(repository)
+	revokeApiKeyUsecase := apiKeyUsecases.NewRevokeApiKeyUsecase(repository)
+
+	// User usecases
+	authenticateUsecase := InitAuthenticateUsecase(repository, lastUsedTracker)
+	loginUsecase := userUsecases.NewLoginUsecase(repository, findOneTenantUsecase)
+	acceptInvitationUsecase := userUsecases.NewAcceptInvitationUsecase(repository, repository,
+		findOneTenantUsecase)
+	requestPasswordResetUsecase := userUsecases.NewRequestPasswordResetUsecase(repository, mailer)
+	resetPasswordUsecase := userUsecases.NewResetPasswordUsecase(repository, repository)
+	inviteUserUsecase := userUsecases.NewInviteUserUsecase(repository, repository, mailer)
+	findAllUsersUsecase := userUsecases.NewFindAllUsersUsecase(readRepository)
+	updateUserRoleUsecase := userUsecases.NewUpdateUserRoleUsecase(repository)
+	disableUserUsecase := userUsecases.NewDisableUserUsecase(repository)
 
 	// Handlers
-	accountHandler := handlers.NewAccountHandler(createAccountUsecase, findAllAccountsUsecase, findOneAccountUsecase, updateAccountUsecase, deleteAaccountUsecase)
-	tenantHandler := handlers.NewTenantHandler(findOneTenantUsecase)
+	accountHandler := handlers.NewAccountHandler(createAccountUsecase, findAllAccountsUsecase, findOneAccountUsecase,
+		changeAccountStatusUsecase, findAccountStatusHistoryUsecase)
+	tenantHandler := handlers.NewTenantHandler(findOneTenantUsecase, createTenantUsecase, findAllTenantsUsecase,
+		renameTenantUsecase, suspendTenantUsecase, reactivateTenantUsecase)
 	cardHandler := handlers.NewCardHandler(createCardUsecase, findCardUsecase, findAllCardsUsecase)
 	transactionHandler := handlers.NewTransactionHandler(createTransactionUsecase, findTransactionUsecase,
-		findTransactionsUsecase)
+		findTransactionsUsecase, findCardUsecase)
+	apiKeyHandler := handlers.NewApiKeyHandler(createApiKeyUsecase, findAllApiKeysUsecase, rotateApiKeyUsecase,
+		revokeApiKeyUsecase)
+	authHandler := handlers.NewAuthHandler(authenticateUsecase, loginUsecase, acceptInvitationUsecase,
+		requestPasswordResetUsecase, resetPasswordUsecase)
+	userHandler := handlers.NewUserHandler(inviteUserUsecase, findAllUsersUsecase, updateUserRoleUsecase,
+		disableUserUsecase)
+	settingsHandler := handlers.NewSettingsHandler(findTenantSettingsUsecase, updateTenantSettingsUsecase,
+		uploadTenantLogoUsecase, findTenantLogoUsecase, deleteTenantLogoUsecase, updateTenantLimitsUsecase,
+		settingsCache)
+	rateLimitHandler := handlers.NewRateLimitHandler(rateLimitUsecase)
+	privacyHandler := handlers.NewPrivacyHandler(exportAccountDataUsecase, eraseAccountDataUsecase,
+		findDataSubjectRequestsUsecase)
+	healthHandler := handlers.NewHealthHandler(readinessUsecase)
+	logLevelHandler := handlers.NewLogLevelHandler(logLevel)
 
 	return &Handlers{
 		AccountHandler:     accountHandler,
 		TenantHandler:      tenantHandler,
 		CardHandler:        cardHandler,
 		TransactionHandler: transactionHandler,
+		ApiKeyHandler:      apiKeyHandler,
+		AuthHandler:        authHandler,
+		UserHandler:        userHandler,
+		SettingsHandler:    settingsHandler,
+		RateLimitHandler:   rateLimitHandler,
+		PrivacyHandler:     privacyHandler,
+		HealthHandler:      healthHandler,
+		LogLevelHandler:    logLevelHandler,
 	}
 }
-
-func GetDbUrlConn(env string) string {
-	var (
-		host, db_port, user, password, dbname string
-	)
-
-	switch env {
-	case "prod":
-		host = config.GetEnv("DB_HOST")
-		db_port = config.GetEnv("DB_PORT")
-		user = config.GetEnv("DB_USERNAME")
-		password = config.GetEnv("DB_PASSWORD")
-		dbname = config.GetEnv("DB_NAME")
-	default:
-		host = config.GetEnv("DB_HOST_DEV")
-		db_port = config.GetEnv("DB_PORT_DEV")
-		user = config.GetEnv("DB_USERNAME_DEV")
-		password = config.GetEnv("DB_PASSWORD_DEV")
-		dbname = config.GetEnv("DB_NAME_DEV")
-	}
-
-	return fmt.Sprintf("host=%s port=%s user=%s "+
-		"password=%s dbname=%s sslmode=disable",
-		host, db_port, user, password, dbname)
-}
@@ -4,29 +4,117 @@ import (
 	"net/http"
 
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/cmd/api/factory"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/docs"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/logging"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/metrics"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
-func Routes(handlers *factory.Handlers) *gin.Engine {
+func Routes(handlers *factory.Handlers, platformAdminToken string) *gin.Engine {
 	baseUrl := "/api/v1"
-	router := gin.Default()
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.Use(logging.AccessLog())
+	router.Use(otelgin.Middleware("users-transactions-api"))
+	router.Use(metrics.HTTP())
+	router.Use(tools.RequestId())
+	router.Use(tools.ReadYourWrites())
+	router.Use(tools.Problems())
 
 	router.GET(baseUrl+"/ping", func(c *gin.Context) {
 		c.JSON(http.StatusOK, "pong")
 	})
 
+	router.GET("/metrics", metrics.Handler())
+	router.GET("/healthz", handlers.HealthHandler.Live)
+	router.GET("/readyz", handlers.HealthHandler.Ready)
+
+	router.GET(baseUrl+"/openapi.json", docs.OpenAPI)
+	router.GET(baseUrl+"/docs", docs.SwaggerUI)
+	for _, asset := range docs.SwaggerAssets {
+		router.GET(baseUrl+"/docs/"+asset, docs.SwaggerAsset)
+	}
+
+	auth := router.Group(baseUrl)
+	{
+		auth.POST("/auth/login", handlers.AuthHandler.Login)
+		auth.POST("/auth/logout", handlers.AuthHandler.Logout)
+		auth.POST("/auth/invitation/accept", handlers.AuthHandler.AcceptInvitation)
+		auth.POST("/auth/password-reset", handlers.AuthHandler.RequestPasswordReset)
+		auth.POST("/auth/password-reset/confirm", handlers.AuthHandler.ResetPassword)
+	}
+
+	admin := router.Group(baseUrl + "/admin")
+	admin.Use(tools.RequirePlatformAdmin(platformAdminToken))
+	{
+		admin.POST("/tenant", handlers.TenantHandler.Create)
+		admin.GET("/tenant", handlers.TenantHandler.FindAll)
+		admin.PUT("/tenant/:tenantId", handlers.TenantHandler.Rename)
+		admin.POST("/tenant/:tenantId/suspend", handlers.TenantHandler.Suspend)
+		admin.POST("/tenant/:tenantId/reactivate", handlers.TenantHandler.Reactivate)
+		admin.PUT("/tenant/:tenantId/limits", handlers.SettingsHandler.UpdateLimits)
+		admin.GET("/log-level", handlers.LogLevelHandler.Get)
+		admin.PUT("/log-level", handlers.LogLevelHandler.Update)
+	}
+
+	router.Use(handlers.AuthHandler.Authenticate())
 	router.Use(handlers.TenantHandler.FindTenant())
+	router.Use(handlers.RateLimitHandler.Limit())
+	router.Use(handlers.SettingsHandler.LoadSettings())
+
+	settings := router.Group(baseUrl)
+	settings.Use(tools.RequireScopes(shared.ScopeSettingsRead, shared.ScopeSettingsWrite))
+	{
+		settings.GET("/settings", handlers.SettingsHandler.FindOne)
+		settings.PUT("/settings", handlers.SettingsHandler.Update)
+		settings.GET("/settings/logo", handlers.SettingsHandler.FindLogo)
+		settings.PUT("/settings/logo", handlers.SettingsHandler.UploadLogo)
+		settings.DELETE("/settings/logo", handlers.SettingsHandler.DeleteLogo)
+	}
+
+	user := router.Group(baseUrl)
+	user.Use(tools.RequireScopes(shared.ScopeUsersRead, shared.ScopeUsersWrite))
+	{
+		user.POST("/user", handlers.UserHandler.Invite)
+		user.GET("/user", handlers.UserHandler.FindAll)
+		user.PUT("/user/:userId/role", handlers.UserHandler.UpdateRole)
+		user.DELETE("/user/:userId", handlers.UserHandler.Disable)
+	}
+
+	apiKey := router.Group(baseUrl)
+	apiKey.Use(tools.RequireScopes(shared.ScopeApiKeysRead, shared.ScopeApiKeysWrite))
+	{
+		apiKey.POST("/api-key", handlers.ApiKeyHandler.Create)
+		apiKey.GET("/api-key", handlers.ApiKeyHandler.FindAll)
+		apiKey.POST("/api-key/:apiKeyId/rotate", handlers.ApiKeyHandler.Rotate)
+		apiKey.DELETE("/api-key/:apiKeyId", handlers.ApiKeyHandler.Revoke)
+	}
 
 	account := router.Group(baseUrl)
+	account.Use(tools.RequireScopes(shared.ScopeAccountsRead, shared.ScopeAccountsWrite))
 	{
 		account.POST("/account", handlers.AccountHandler.Create)
 		account.GET("/account/:accountId", handlers.AccountHandler.FindOne)
 		account.GET("/account", handlers.AccountHandler.FindAll)
 		account.PUT("/account/:accountId", handlers.AccountHandler.Active)
-		account.DELETE("/account/:accountId", handlers.AccountHandler.Inactive)
+		account.DELETE("/account/:accountId", handlers.AccountHandler.Freeze)
+		account.POST("/account/:accountId/status", handlers.AccountHandler.ChangeStatus)
+		account.GET("/account/:accountId/status", handlers.AccountHandler.StatusHistory)
+	}
+
+	privacy := router.Group(baseUrl)
+	privacy.Use(tools.RequireScopes(shared.ScopePrivacyRead, shared.ScopePrivacyWrite))
+	{
+		privacy.GET("/privacy/account/:accountId/export", handlers.PrivacyHandler.Export)
+		privacy.POST("/privacy/account/:accountId/erasure", handlers.PrivacyHandler.Erase)
+		privacy.GET("/privacy/account/:accountId/requests", handlers.PrivacyHandler.FindRequests)
 	}
 
 	card := router.Group(baseUrl)
+	card.Use(tools.RequireScopes(shared.ScopeCardsRead, shared.ScopeCardsWrite))
 	{
 		card.POST("/card/:accountId", handlers.CardHandler.Create)
 		card.GET("/card/:cardId/account/:accountId", handlers.CardHandler.FindOne)
@@ -34,6 +122,7 @@ func Routes(handlers *factory.Handlers) *gin.Engine {
 	}
 
 	transaction := router.Group(baseUrl)
+	transaction.Use(tools.RequireScopes(shared.ScopeTransactionsRead, shared.ScopeTransactionsWrite))
 	{
 		transaction.POST("/transaction/account/:accountId", handlers.TransactionHandler.Create)
 		transaction.GET("/transaction/:transactionId/account/:accountId/card/:cardId",
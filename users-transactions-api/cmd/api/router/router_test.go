@@ -0,0 +1,116 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/cmd/api/factory"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/docs"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// the handlers are never called, only the routes are read
+	router := Routes(&factory.Handlers{}, "")
+
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	var document docs.Document
+	err := json.NewDecoder(res.Body).Decode(&document)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "3.0.3", document.OpenAPI)
+
+	t.Run("Every route is documented", func(t *testing.T) {
+		for _, route := range router.Routes() {
+			item, found := document.Paths[docs.Path(route.Path)]
+
+			if assert.True(t, found, "missing path %s", route.Path) {
+				assert.Contains(t, item, strings.ToLower(route.Method), "missing %s %s", route.Method, route.Path)
+			}
+		}
+	})
+
+	t.Run("Every documented route exists", func(t *testing.T) {
+		routes := make(map[string]struct{})
+		for _, route := range router.Routes() {
+			routes[route.Method+" "+docs.Path(route.Path)] = struct{}{}
+		}
+
+		for path, item := range document.Paths {
+			for method := range item {
+				assert.Contains(t, routes, strings.ToUpper(method)+" "+path)
+			}
+		}
+	})
+
+	t.Run("Every schema reference resolves", func(t *testing.T) {
+		spec, _ := docs.Spec()
+
+		for _, ref := range strings.Split(string(spec), `"$ref": "#/components/`)[1:] {
+			kind, name, _ := strings.Cut(ref[:strings.Index(ref, `"`)], "/")
+
+			switch kind {
+			case "schemas":
+				assert.Contains(t, document.Components.Schemas, name)
+			case "parameters":
+				assert.Contains(t, document.Components.Parameters, name)
+			case "responses":
+				assert.Contains(t, document.Components.Responses, name)
+			default:
+				t.Errorf("unknown reference %s/%s", kind, name)
+			}
+		}
+	})
+
+	t.Run("Errors are documented as problems", func(t *testing.T) {
+		problem := document.Components.Schemas["Problem"]
+
+		if assert.NotNil(t, problem) {
+			assert.Contains(t, problem.Properties, "type")
+			assert.Contains(t, problem.Properties, "errors")
+		}
+
+		request := document.Components.Schemas["TransactioRequest"]
+
+		if assert.NotNil(t, request) {
+			assert.ElementsMatch(t, []string{"card_id", "kind"}, request.Required)
+			assert.Equal(t, int64(1), *request.Properties["value"].Minimum)
+		}
+	})
+}
+
+func TestSwaggerUI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := Routes(&factory.Handlers{}, "")
+
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/api/v1/docs", nil))
+
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, res.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, res.Body.String(), `url: "openapi.json"`)
+	assert.Contains(t, res.Body.String(), "<title>Users Transactions API</title>")
+	assert.NotContains(t, res.Body.String(), "https://")
+
+	for _, asset := range []string{"swagger-ui.css", "swagger-ui-bundle.js"} {
+		assert.Contains(t, res.Body.String(), `"docs/`+asset+`"`)
+
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/api/v1/docs/"+asset, nil))
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.NotEmpty(t, res.Header().Get("Content-Type"))
+		assert.NotZero(t, res.Body.Len())
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/config"
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/apikey"
+)
+
+// Issues the first api key of a tenant, which can then manage the others
+// through the api.
+func main() {
+	tenantId := flag.Int("tenant", 0, "tenant id")
+	name := flag.String("name", "bootstrap", "api key name")
+	scopes := flag.String("scopes", "api-keys:read,api-keys:write", "comma separated scopes")
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	dbConnection := config.InitConfig(cfg.Database.ConnString())
+	defer dbConnection.Close()
+
+	createApiKeyUsecase := usecases.NewCreateApiKeyUsecase(infra.New(dbConnection))
+
+	apiKey, key, err := createApiKeyUsecase.Create(context.Background(), int32(*tenantId), *name, strings.Split(*scopes, ","))
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("api key %d (%s) created for tenant %d\n%s\n", apiKey.ID, apiKey.Prefix, apiKey.TenantID, key)
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/config"
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/loadtest"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/apikey"
+)
+
+// scopes of the api keys created for the run
+var scopes = []string{shared.ScopeAccountsRead, shared.ScopeCardsRead, shared.ScopeTransactionsRead,
+	shared.ScopeTransactionsWrite, shared.ScopeReportsRead}
+
+// Sends a mixed workload to both apis and reports latency percentiles,
+// error rates and throughput.
+func main() {
+	os.Exit(run())
+}
+
+func run() int {
+	usersUrl := flag.String("users-url", "http://localhost:3030", "base url of the users transactions api")
+	pdfUrl := flag.String("pdf-url", "http://localhost:3031", "base url of the pdf generator api")
+	rate := flag.Float64("rate", 20, "requests per second, 0 sends as fast as the workers allow")
+	concurrency := flag.Int("concurrency", 10, "concurrent workers")
+	duration := flag.Duration("duration", 30*time.Second, "duration of the run")
+	mix := flag.String("mix", "create=30,list=40,accounts=20,report=10", "weight of each operation")
+	spread := flag.String("spread", loadtest.SpreadUniform, "distribution of the load among the tenants, uniform or zipf")
+	tenantIds := flag.String("tenants", "1-5", "tenant ids or ranges, e.g. 1-5,8")
+	keys := flag.String("keys", "", "api keys as tenant=key pairs, created in the database for the run when empty")
+	maxAccounts := flag.Int("max-accounts", 20, "accounts of each tenant used by the run")
+	requestTimeout := flag.Duration("request-timeout", 30*time.Second, "timeout of each request")
+	seed := flag.Int64("seed", 1, "seed of the sequence of operations")
+	jsonReport := flag.String("json", "", "file the report is written to as json, - for stdout")
+	// the database is only used to create the api keys
+	cfg, cfgErr := config.Load(flag.CommandLine, os.Args[1:])
+
+	parsedMix, err := loadtest.ParseMix(*mix)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	ids, err := loadtest.ParseIds(*tenantIds)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	tenants := make([]loadtest.Tenant, len(ids))
+
+	for i, id := range ids {
+		tenants[i] = loadtest.Tenant{ID: id}
+	}
+
+	if len(*keys) > 0 {
+		err = assignKeys(tenants, *keys)
+	} else if cfgErr != nil {
+		err = fmt.Errorf("api keys are created in the database when -keys is empty: %w", cfgErr)
+	} else {
+		var revoke func()
+		revoke, err = createKeys(ctx, cfg, tenants)
+		defer revoke()
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	client := &http.Client{
+		Timeout: *requestTimeout,
+		Transport: &http.Transport{
+			MaxIdleConns:        *concurrency,
+			MaxIdleConnsPerHost: *concurrency,
+		},
+	}
+
+	for i := range tenants {
+		if err := loadtest.Discover(ctx, client, *usersUrl, &tenants[i], *maxAccounts); err != nil {
+			fmt.Fprintf(os.Stderr, "tenant %d: %s\n", tenants[i].ID, err)
+			return 1
+		}
+
+		if len(tenants[i].Accounts) == 0 {
+			fmt.Fprintf(os.Stderr, "tenant %d has no active account with cards, skipped\n", tenants[i].ID)
+		}
+	}
+
+	report, err := loadtest.Run(ctx, client, loadtest.Config{
+		UsersUrl:    *usersUrl,
+		PdfUrl:      *pdfUrl,
+		Rate:        *rate,
+		Concurrency: *concurrency,
+		Duration:    *duration,
+		Mix:         parsedMix,
+		Spread:      *spread,
+		Seed:        *seed,
+		Tenants:     tenants,
+	})
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	// the summary moves to stderr when stdout carries the json
+	summary := os.Stdout
+
+	if *jsonReport == "-" {
+		summary = os.Stderr
+	}
+
+	if err := loadtest.WriteSummary(summary, report); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if len(*jsonReport) > 0 {
+		if err := writeJSON(*jsonReport, report); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func assignKeys(tenants []loadtest.Tenant, pairs string) error {
+	keys := make(map[int32]string)
+
+	for _, pair := range strings.Split(pairs, ",") {
+		tenant, key, found := strings.Cut(strings.TrimSpace(pair), "=")
+		id, err := strconv.ParseInt(tenant, 10, 32)
+
+		if !found || err != nil || len(key) == 0 {
+			return fmt.Errorf("keys entry %q must be tenant=key", pair)
+		}
+
+		keys[int32(id)] = key
+	}
+
+	for i := range tenants {
+		key, found := keys[tenants[i].ID]
+
+		if !found {
+			return fmt.Errorf("no api key for tenant %d", tenants[i].ID)
+		}
+
+		tenants[i].ApiKey = key
+	}
+
+	return nil
+}
+
+// createKeys creates an api key for each tenant and returns the function
+// revoking them.
+func createKeys(ctx context.Context, cfg *config.Config, tenants []loadtest.Tenant) (func(), error) {
+	dbConnection := config.InitConfig(cfg.Database.ConnString())
+	repository := infra.New(dbConnection)
+	createApiKeyUsecase := usecases.NewCreateApiKeyUsecase(repository)
+	revokeApiKeyUsecase := usecases.NewRevokeApiKeyUsecase(repository)
+
+	var created []*infra.ApiKey
+
+	revoke := func() {
+		for _, apiKey := range created {
+			err := revokeApiKeyUsecase.Revoke(context.Background(), apiKey.TenantID, apiKey.ID)
+
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "revoking api key %s: %s\n", apiKey.Prefix, err)
+			}
+		}
+
+		dbConnection.Close()
+	}
+
+	for i := range tenants {
+		apiKey, key, err := createApiKeyUsecase.Create(ctx, tenants[i].ID, "load-test", scopes)
+
+		if err != nil {
+			return revoke, fmt.Errorf("creating the api key of tenant %d: %w", tenants[i].ID, err)
+		}
+
+		created = append(created, apiKey)
+		tenants[i].ApiKey = key
+	}
+
+	return revoke, nil
+}
+
+func writeJSON(path string, report loadtest.Report) error {
+	out := os.Stdout
+
+	if path != "-" {
+		file, err := os.Create(path)
+
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		out = file
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
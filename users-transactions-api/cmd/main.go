@@ -1,65 +1,237 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/cmd/api/factory"
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/cmd/api/router"
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/config"
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/genproto"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/migrate"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/notify"
 	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/lifecycle"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/metrics"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/telemetry"
+	apiKeyUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/apikey"
 	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/grpc"
+	healthUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/health"
+	tenantUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/tenant"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/utils"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/migrations"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func main() {
-	PORT := config.GetEnv("PORT")
-	ENV := config.GetEnv("ENV")
-	GRPC_PORT := config.GetEnv("GRPC_PORT")
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(migrateCommand(os.Args[2:]))
+	}
+
+	printConfig := flag.Bool("print-config", false, "print the configuration, secrets redacted, and exit")
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+
+	if *printConfig {
+		config.Print(os.Stdout, cfg)
+	}
+
+	if err != nil {
+		slog.Error("invalid configuration",
+			slog.String("error", err.Error()),
+		)
+		os.Exit(1)
+	}
+
+	if *printConfig {
+		return
+	}
+
+	logger, err := config.InitLogger(&cfg.Log)
 
-	logConfig := &config.LoggerConfig{
-		Env:     ENV,
-		LogPath: "./tmp/logs.log",
+	if err != nil {
+		slog.Error("cannot start logger",
+			slog.String("error", err.Error()),
+		)
+		os.Exit(1)
 	}
 
-	config.InitLogger(logConfig)
+	slog.Info("configuration loaded", slog.Any("config", cfg))
 
-	psqlInfo := factory.GetDbUrlConn(ENV)
+	app := lifecycle.New(cfg.ShutdownTimeout)
 
-	dbConnection := initDbConnection(psqlInfo)
+	shutdownTelemetry := initTelemetry(cfg.Telemetry)
 
-	go startGrpcServer(GRPC_PORT, dbConnection)
+	dbConnection := initDbConnection(cfg.Database.ConnString())
 
-	startApiServer(PORT, dbConnection)
-}
+	if cfg.AutoMigrate {
+		autoMigrate(dbConnection)
+	}
+
+	metrics.RegisterDB(dbConnection, "users_transactions_db")
+
+	replicaPool, replicaConnection := initReplicaPool(cfg.Database, dbConnection)
+	stopWatchReplica := replicaPool.Watch(cfg.Database.ReplicaCheckInterval)
+
+	lastUsedTracker := apiKeyUsecases.NewLastUsedTracker(infra.New(telemetry.TraceDB(dbConnection)))
+	stopLastUsedTracker := lastUsedTracker.Start(30 * time.Second)
+
+	readinessUsecase := factory.InitReadinessUsecase(dbConnection)
+
+	tenantCache, stopTenantCacheListener := initTenantCache(cfg)
+
+	apiServer := newApiServer(cfg, dbConnection, replicaPool, lastUsedTracker, readinessUsecase, tenantCache,
+		logger.Level)
+	grpcServer, healthServer := newGrpcServer(dbConnection, replicaPool, tenantCache, lastUsedTracker)
+	grpcListener := listen(cfg.GrpcPort)
+
+	stopWatchReadiness := usecases.WatchReadiness(readinessUsecase, healthServer, 5*time.Second)
 
-func startApiServer(PORT string, dbConnection *sql.DB) {
-	handlers := factory.InitHandlers(dbConnection)
+	app.Go("http", func() error {
+		err := apiServer.ListenAndServe()
 
-	router := router.Routes(handlers)
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	})
+	app.Go("grpc", func() error {
+		return grpcServer.Serve(grpcListener)
+	})
 
-	err := router.Run(fmt.Sprintf("0.0.0.0:%s", PORT))
+	// readiness fails first, so the load balancers stop sending traffic
+	// while the servers drain
+	app.OnShutdown("readiness", func(ctx context.Context) error {
+		readinessUsecase.Drain()
+		stopWatchReadiness()
+		return nil
+	})
+	app.OnShutdown("http server", apiServer.Shutdown)
+	app.OnShutdown("grpc server", func(ctx context.Context) error {
+		return lifecycle.GracefulStop(ctx, grpcServer)
+	})
+	app.OnShutdown("last used tracker", func(ctx context.Context) error {
+		stopLastUsedTracker()
+		return nil
+	})
+	app.OnShutdown("tenant cache listener", func(ctx context.Context) error {
+		stopTenantCacheListener()
+		return nil
+	})
+	app.OnShutdown("replica health check", func(ctx context.Context) error {
+		stopWatchReplica()
+		return nil
+	})
+	app.OnShutdown("telemetry", shutdownTelemetry)
+	app.OnShutdown("database", func(ctx context.Context) error {
+		if replicaConnection != nil {
+			return errors.Join(replicaConnection.Close(), dbConnection.Close())
+		}
+		return dbConnection.Close()
+	})
+	// last, so the other steps are logged to the file
+	app.OnShutdown("log file", func(ctx context.Context) error {
+		return logger.Close()
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	err = app.Wait(ctx)
 
 	if err != nil {
-		slog.Error("cannot start server",
+		slog.Error("shutdown",
 			slog.String("error", err.Error()),
 		)
+		os.Exit(1)
+	}
+}
 
-		panic(err)
+func newApiServer(cfg *config.Config, dbConnection *sql.DB, replicaPool *infra.ReplicaPool,
+	lastUsedTracker *apiKeyUsecases.LastUsedTracker, readinessUsecase *healthUsecases.ReadinessUsecase,
+	tenantCache *tenantUsecases.TenantCache, logLevel *slog.LevelVar) *http.Server {
+	handlers := factory.InitHandlers(dbConnection, replicaPool, lastUsedTracker, readinessUsecase, tenantCache, logLevel,
+		utils.NewFileMailer("./tmp/mail"), cfg.RateLimitMode)
+
+	return &http.Server{
+		Addr:    fmt.Sprintf("0.0.0.0:%d", cfg.Port),
+		Handler: router.Routes(handlers, cfg.PlatformAdminToken),
 	}
 }
 
-func startGrpcServer(PORT string, dbConnection *sql.DB) {
-	repo := infra.New(dbConnection)
-	server := usecases.NewTransactionInfo(repo)
+// initReplicaPool opens the replica when configured, the returned
+// connection is nil otherwise.
+func initReplicaPool(cfg config.Database, dbConnection *sql.DB) (*infra.ReplicaPool, *sql.DB) {
+	if len(cfg.ReplicaHost) == 0 {
+		return infra.NewReplicaPool(dbConnection, nil), nil
+	}
 
-	grpcServer := grpc.NewServer()
+	replicaConnection := config.InitConfig(cfg.ReplicaConnString())
+	metrics.RegisterDB(replicaConnection, "users_transactions_db_replica")
+
+	return infra.NewReplicaPool(dbConnection, replicaConnection), replicaConnection
+}
+
+// initTenantCache returns a nil cache when disabled. The cached tenants
+// are invalidated by the notifications of the tenant changes, including
+// the ones made by the other replicas and the admin cli.
+func initTenantCache(cfg *config.Config) (*tenantUsecases.TenantCache, func()) {
+	if cfg.TenantCache.Size == 0 {
+		return nil, func() {}
+	}
+
+	cache := tenantUsecases.NewTenantCache(cfg.TenantCache.Size, cfg.TenantCache.TTL, cfg.TenantCache.NegativeTTL)
+	stop := notify.Listen(cfg.Database.ConnString(), tenantUsecases.TenantChangedChannel, cache.OnTenantChanged,
+		cache.Purge)
+
+	return cache, stop
+}
+
+func newGrpcServer(dbConnection *sql.DB, replicaPool *infra.ReplicaPool, tenantCache *tenantUsecases.TenantCache,
+	lastUsedTracker *apiKeyUsecases.LastUsedTracker) (*grpc.Server, *health.Server) {
+	repo := infra.New(telemetry.TraceDB(dbConnection))
+	// only the search stream reads from the replica, a revoked api key must
+	// be refused right away so the authentication stays on the primary
+	server := factory.InitTransactionInfo(dbConnection, replicaPool, tenantCache)
+	authenticateUsecase := factory.InitAuthenticateUsecase(repo, lastUsedTracker)
+
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainStreamInterceptor(
+			usecases.RequestIdStreamInterceptor(),
+			usecases.ReadYourWritesStreamInterceptor(),
+			usecases.MetricsStreamInterceptor(),
+			usecases.AuthStreamInterceptor(authenticateUsecase),
+		),
+		grpc.ChainUnaryInterceptor(
+			usecases.RequestIdUnaryInterceptor(),
+			usecases.ReadYourWritesUnaryInterceptor(),
+			usecases.MetricsUnaryInterceptor(),
+			usecases.AuthUnaryInterceptor(authenticateUsecase),
+		),
+	)
 	genproto.RegisterTransactionInfoServiceServer(grpcServer, server)
 
-	address := fmt.Sprintf("0.0.0.0:%s", PORT)
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	return grpcServer, healthServer
+}
+
+func listen(port int) net.Listener {
+	address := fmt.Sprintf("0.0.0.0:%d", port)
 	listener, err := net.Listen("tcp", address)
 
 	if err != nil {
@@ -69,14 +241,50 @@ func startGrpcServer(PORT string, dbConnection *sql.DB) {
 		panic(err)
 	}
 
-	err = grpcServer.Serve(listener)
+	return listener
+}
+
+func initTelemetry(cfg config.Telemetry) func(ctx context.Context) error {
+	shutdown, err := telemetry.Init(context.Background(), telemetry.Config{
+		ServiceName: "users-transactions-api",
+		Exporter:    cfg.Exporter,
+		Endpoint:    cfg.Endpoint,
+	})
 
 	if err != nil {
-		slog.Error("cannot start server",
+		slog.Error("cannot start telemetry",
 			slog.String("error", err.Error()),
 		)
 		panic(err)
 	}
+
+	return shutdown
+}
+
+// autoMigrate applies the pending migrations, the advisory lock of the
+// migrator makes the other replicas wait instead of racing.
+func autoMigrate(dbConnection *sql.DB) {
+	migrator, err := migrate.New(dbConnection, migrations.FS)
+
+	if err != nil {
+		slog.Error("cannot read migrations",
+			slog.String("error", err.Error()),
+		)
+		os.Exit(1)
+	}
+
+	applied, err := migrator.Up(context.Background())
+
+	for _, migration := range applied {
+		slog.Info("migration applied", slog.String("migration", migration.Name))
+	}
+
+	if err != nil {
+		slog.Error("cannot apply migrations",
+			slog.String("error", err.Error()),
+		)
+		os.Exit(1)
+	}
 }
 
 func initDbConnection(psqlInfo string) *sql.DB {
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/config"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/migrate"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/migrations"
+)
+
+const migrateUsage = `usage: users-transactions-api migrate [flags] up|down|status|redo
+
+  up      apply the pending migrations
+  down    roll back the last applied migration
+  status  list the migrations and when they were applied
+  redo    roll back and apply again the last migration
+`
+
+// migrateCommand runs the migrations embedded in the binary against the
+// configured database and returns the exit code.
+func migrateCommand(args []string) int {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), migrateUsage)
+		fs.PrintDefaults()
+	}
+
+	cfg, err := config.Load(fs, args)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	dbConnection := config.InitConfig(cfg.Database.ConnString())
+	defer dbConnection.Close()
+
+	migrator, err := migrate.New(dbConnection, migrations.FS)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	ctx := context.Background()
+
+	switch fs.Arg(0) {
+	case "up":
+		var applied []migrate.Migration
+		applied, err = migrator.Up(ctx)
+
+		for _, migration := range applied {
+			fmt.Printf("applied %s\n", migration.Name)
+		}
+
+		if err == nil && len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "down":
+		var migration *migrate.Migration
+		migration, err = migrator.Down(ctx)
+		printMigration("rolled back", migration, err)
+	case "redo":
+		var migration *migrate.Migration
+		migration, err = migrator.Redo(ctx)
+		printMigration("redone", migration, err)
+	case "status":
+		err = printStatus(ctx, migrator)
+	default:
+		fs.Usage()
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+func printMigration(action string, migration *migrate.Migration, err error) {
+	switch {
+	case err != nil:
+	case migration == nil:
+		fmt.Println("no applied migrations")
+	default:
+		fmt.Printf("%s %s\n", action, migration.Name)
+	}
+}
+
+func printStatus(ctx context.Context, migrator *migrate.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "APPLIED AT\tMIGRATION")
+
+	for _, status := range statuses {
+		appliedAt := "pending"
+
+		if status.Applied {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+
+		fmt.Fprintf(w, "%s\t%s\n", appliedAt, status.Name)
+	}
+
+	return w.Flush()
+}
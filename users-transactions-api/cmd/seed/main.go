@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/config"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/seed"
+)
+
+// Writes a synthetic dataset, the same for a given seed and period, for
+// local development, demos and performance tests.
+func main() {
+	seedValue := flag.Int64("seed", 1, "seed of the generated data")
+	tenants := flag.Int("tenants", 5, "number of tenants")
+	accounts := flag.Int("accounts", 20, "accounts per tenant")
+	cards := flag.Int("cards", 2, "cards per account")
+	transactions := flag.Int("transactions", 20, "average purchases per card and month, subscriptions excluded")
+	months := flag.Int("months", 12, "months of transactions")
+	until := flag.String("until", "", "end of the period, YYYY-MM-DD, the start of the current month when empty")
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	end := time.Now().UTC()
+	end = time.Date(end.Year(), end.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	if len(*until) > 0 {
+		end, err = time.Parse(time.DateOnly, *until)
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "until must be a YYYY-MM-DD date: %s\n", err)
+			os.Exit(1)
+		}
+	}
+
+	dbConnection := config.InitConfig(cfg.Database.ConnString())
+	defer dbConnection.Close()
+
+	start := time.Now()
+
+	summary, err := seed.Run(context.Background(), dbConnection, seed.Options{
+		Seed:                 *seedValue,
+		Tenants:              *tenants,
+		AccountsPerTenant:    *accounts,
+		CardsPerAccount:      *cards,
+		TransactionsPerMonth: *transactions,
+		Months:               *months,
+		Until:                end,
+	})
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		dbConnection.Close()
+		os.Exit(1)
+	}
+
+	fmt.Printf("tenants %d to %d: %d accounts, %d cards and %d transactions from %s to %s in %s\n",
+		summary.FirstTenantId, summary.FirstTenantId+int32(summary.Tenants)-1, summary.Accounts, summary.Cards,
+		summary.Transactions, summary.From.Format(time.DateOnly), summary.Until.Format(time.DateOnly),
+		time.Since(start).Round(time.Millisecond))
+}
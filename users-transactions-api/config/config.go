@@ -0,0 +1,154 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/telemetry"
+)
+
+// Config is the configuration of the users transactions api, loaded once
+// at startup by Load.
+type Config struct {
+	Env                string        `config:"env"`
+	Port               int           `config:"port"`
+	GrpcPort           int           `config:"grpc_port"`
+	PlatformAdminToken string        `config:"platform_admin_token" secret:"true"`
+	RateLimitMode      string        `config:"rate_limit_mode"`
+	ShutdownTimeout    time.Duration `config:"shutdown_timeout"`
+	// AutoMigrate applies the pending migrations before serving.
+	AutoMigrate bool `config:"auto_migrate"`
+	Database    Database
+	TenantCache TenantCache
+	Telemetry   Telemetry
+	Log         LoggerConfig
+}
+
+type Database struct {
+	Host     string `config:"db_host"`
+	Port     int    `config:"db_port"`
+	Name     string `config:"db_name"`
+	Username string `config:"db_username"`
+	Password string `config:"db_password" secret:"true"`
+	// ReplicaHost receives the reads of the read only usecases, with the
+	// name and credentials of the primary. Empty sends them to the primary.
+	ReplicaHost          string        `config:"db_replica_host"`
+	ReplicaPort          int           `config:"db_replica_port"`
+	ReplicaCheckInterval time.Duration `config:"db_replica_check_interval"`
+}
+
+func (d Database) ConnString() string {
+	return postgresConnString(d.Host, d.Port, d)
+}
+
+func (d Database) ReplicaConnString() string {
+	return postgresConnString(d.ReplicaHost, d.ReplicaPort, d)
+}
+
+func postgresConnString(host string, port int, d Database) string {
+	return fmt.Sprintf("host=%s port=%d user=%s "+
+		"password=%s dbname=%s sslmode=disable",
+		host, port, d.Username, d.Password, d.Name)
+}
+
+// TenantCache sizes the cache of the tenant looked up on every request, a
+// zero size disables it.
+type TenantCache struct {
+	Size        int           `config:"tenant_cache_size"`
+	TTL         time.Duration `config:"tenant_cache_ttl"`
+	NegativeTTL time.Duration `config:"tenant_cache_negative_ttl"`
+}
+
+type Telemetry struct {
+	Exporter string `config:"otel_traces_exporter"`
+	// Endpoint is the host:port of the OTLP gRPC collector.
+	Endpoint string `config:"otel_exporter_otlp_endpoint"`
+}
+
+var defaults = map[string]string{
+	"env":                       "dev",
+	"port":                      "3030",
+	"grpc_port":                 "8080",
+	"rate_limit_mode":           "memory",
+	"shutdown_timeout":          "15s",
+	"auto_migrate":              "false",
+	"db_host":                   "localhost",
+	"db_port":                   "5432",
+	"db_name":                   "users_transactions_db",
+	"db_replica_port":           "5432",
+	"db_replica_check_interval": "5s",
+	"tenant_cache_size":         "10000",
+	"tenant_cache_ttl":          "5m",
+	"tenant_cache_negative_ttl": "30s",
+	"otel_traces_exporter":      telemetry.ExporterNone,
+	"log_format":                LogFormatJson,
+	"log_output":                LogOutputStdout,
+	"log_file":                  "./tmp/logs.log",
+	"log_max_size_mb":           "100",
+	"log_max_backups":           "5",
+}
+
+// Load reads the configuration, adding its flags to fs, and validates it.
+// The error lists every problem found, the returned configuration is never
+// nil so it can still be printed.
+func Load(fs *flag.FlagSet, args []string) (*Config, error) {
+	cfg := &Config{}
+	p := load(cfg, defaults, fs, args)
+	cfg.Log.Env = cfg.Env
+
+	cfg.validate(p)
+
+	return cfg, p.err()
+}
+
+func (c *Config) validate(p *problems) {
+	oneOf(p, "env", c.Env, "dev", "prod")
+	validPort(p, "port", c.Port)
+	validPort(p, "grpc_port", c.GrpcPort)
+	oneOf(p, "rate_limit_mode", c.RateLimitMode, "memory", "postgres")
+
+	if c.ShutdownTimeout <= 0 {
+		p.add("shutdown_timeout", "must be positive, got %s", c.ShutdownTimeout)
+	}
+
+	required(p, "db_host", c.Database.Host)
+	validPort(p, "db_port", c.Database.Port)
+	required(p, "db_name", c.Database.Name)
+	required(p, "db_username", c.Database.Username)
+
+	if len(c.Database.ReplicaHost) > 0 {
+		validPort(p, "db_replica_port", c.Database.ReplicaPort)
+
+		if c.Database.ReplicaCheckInterval <= 0 {
+			p.add("db_replica_check_interval", "must be positive, got %s", c.Database.ReplicaCheckInterval)
+		}
+	}
+
+	if c.TenantCache.Size < 0 {
+		p.add("tenant_cache_size", "must not be negative, got %d", c.TenantCache.Size)
+	}
+
+	if c.TenantCache.Size > 0 && c.TenantCache.TTL <= 0 {
+		p.add("tenant_cache_ttl", "must be positive, got %s", c.TenantCache.TTL)
+	}
+
+	if c.TenantCache.Size > 0 && c.TenantCache.NegativeTTL <= 0 {
+		p.add("tenant_cache_negative_ttl", "must be positive, got %s", c.TenantCache.NegativeTTL)
+	}
+
+	oneOf(p, "otel_traces_exporter", c.Telemetry.Exporter, telemetry.ExporterNone,
+		telemetry.ExporterStdout, telemetry.ExporterOtlp, telemetry.ExporterMemory)
+
+	if c.Telemetry.Exporter == telemetry.ExporterOtlp {
+		required(p, "otel_exporter_otlp_endpoint", c.Telemetry.Endpoint)
+	}
+
+	c.Log.validate(p)
+}
+
+// LogValue logs the configuration with the secrets redacted.
+func (c *Config) LogValue() slog.Value {
+	return logValue(c)
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	// the loader reads .env from the working directory
+	wd, _ := os.Getwd()
+	os.Chdir(t.TempDir())
+	defer os.Chdir(wd)
+
+	load := func(args ...string) (*Config, error) {
+		return Load(flag.NewFlagSet("test", flag.ContinueOnError), args)
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		t.Setenv("DB_USERNAME", "postgre")
+
+		cfg, err := load()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "dev", cfg.Env)
+		assert.Equal(t, 3030, cfg.Port)
+		assert.Equal(t, 15*time.Second, cfg.ShutdownTimeout)
+		assert.Equal(t, TenantCache{Size: 10000, TTL: 5 * time.Minute, NegativeTTL: 30 * time.Second}, cfg.TenantCache)
+		assert.Equal(t, "host=localhost port=5432 user=postgre password= dbname=users_transactions_db sslmode=disable",
+			cfg.Database.ConnString())
+	})
+
+	t.Run("Flags over environment over file", func(t *testing.T) {
+		dir := t.TempDir()
+		file := filepath.Join(dir, "api.env")
+		os.WriteFile(file, []byte("PORT=4000\nGRPC_PORT=4001\nDB_HOST=file\nDB_USERNAME=postgre\n"), 0o600)
+
+		t.Setenv("CONFIG_FILE", file)
+		t.Setenv("GRPC_PORT", "5001")
+		t.Setenv("DB_HOST", "env")
+
+		cfg, err := load("-db-host", "flag")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4000, cfg.Port)
+		assert.Equal(t, 5001, cfg.GrpcPort)
+		assert.Equal(t, "flag", cfg.Database.Host)
+	})
+
+	t.Run("Reads .env when present", func(t *testing.T) {
+		os.WriteFile(".env", []byte("ENV=prod\nDB_USERNAME=postgre\n"), 0o600)
+		defer os.Remove(".env")
+
+		cfg, err := load()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "prod", cfg.Env)
+	})
+
+	t.Run("Secrets from files", func(t *testing.T) {
+		secret := filepath.Join(t.TempDir(), "db_password")
+		os.WriteFile(secret, []byte("s3cret\n"), 0o600)
+
+		t.Setenv("DB_USERNAME", "postgre")
+		t.Setenv("DB_PASSWORD_FILE", secret)
+
+		cfg, err := load()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "s3cret", cfg.Database.Password)
+	})
+
+	t.Run("Reports every problem", func(t *testing.T) {
+		t.Setenv("PORT", "http")
+		t.Setenv("GRPC_PORT", "70000")
+		t.Setenv("ENV", "staging")
+		t.Setenv("OTEL_TRACES_EXPORTER", "otlp")
+		t.Setenv("DB_PASSWORD_FILE", "/missing/db_password")
+
+		_, err := load()
+
+		assert.EqualError(t, err, "DB_PASSWORD_FILE: open /missing/db_password: no such file or directory\n"+
+			"PORT: invalid number \"http\"\n"+
+			"ENV: must be one of dev, prod, got \"staging\"\n"+
+			"GRPC_PORT: must be between 1 and 65535, got 70000\n"+
+			"DB_USERNAME: is required\n"+
+			"OTEL_EXPORTER_OTLP_ENDPOINT: is required")
+	})
+}
+
+func TestPrint(t *testing.T) {
+	cfg := &Config{
+		Env:                "prod",
+		PlatformAdminToken: "change-me",
+		Database:           Database{Host: "database", Password: "postgre"},
+	}
+
+	var out bytes.Buffer
+	Print(&out, cfg)
+
+	assert.Contains(t, out.String(), "ENV=prod\n")
+	assert.Contains(t, out.String(), "PLATFORM_ADMIN_TOKEN=******\n")
+	assert.Contains(t, out.String(), "DB_HOST=database\n")
+	assert.Contains(t, out.String(), "DB_PASSWORD=******\n")
+	assert.Contains(t, out.String(), "OTEL_EXPORTER_OTLP_ENDPOINT=\n")
+	assert.NotContains(t, out.String(), "postgre\n")
+}
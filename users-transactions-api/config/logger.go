@@ -4,31 +4,123 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"strings"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/logging"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+const (
+	LogOutputStdout = "stdout"
+	LogOutputFile   = "file"
+	LogOutputBoth   = "both"
+
+	LogFormatJson = "json"
+	LogFormatText = "text"
 )
 
 type LoggerConfig struct {
-	Env     string
-	LogPath string
+	// Env sets the level when Level is empty, debug outside of prod.
+	Env        string
+	Level      string `config:"log_level"`
+	Format     string `config:"log_format"`
+	Output     string `config:"log_output"`
+	LogPath    string `config:"log_file"`
+	MaxSizeMB  int    `config:"log_max_size_mb"`
+	MaxBackups int    `config:"log_max_backups"`
+}
+
+func (lc *LoggerConfig) validate(p *problems) {
+	if len(lc.Level) > 0 {
+		var level slog.Level
+
+		if err := level.UnmarshalText([]byte(lc.Level)); err != nil {
+			p.add("log_level", "must be one of debug, info, warn, error, got %q", lc.Level)
+		}
+	}
+
+	oneOf(p, "log_format", lc.Format, LogFormatJson, LogFormatText)
+	oneOf(p, "log_output", lc.Output, LogOutputStdout, LogOutputFile, LogOutputBoth)
+
+	if lc.Output != LogOutputStdout {
+		required(p, "log_file", lc.LogPath)
+
+		if lc.MaxSizeMB < 1 {
+			p.add("log_max_size_mb", "must be at least 1, got %d", lc.MaxSizeMB)
+		}
+
+		if lc.MaxBackups < 0 {
+			p.add("log_max_backups", "must not be negative, got %d", lc.MaxBackups)
+		}
+	}
+}
+
+// Logger is the default slog logger installed by InitLogger. Level can be
+// changed at runtime.
+type Logger struct {
+	Level *slog.LevelVar
+	file  *logging.RotatingFile
 }
 
-func InitLogger(loggerConfig *LoggerConfig) {
-	level := new(slog.LevelVar)
-	var writer io.Writer
+// Close closes the log file, if any. Records logged afterwards to the file
+// are dropped.
+func (l *Logger) Close() error {
+	if l.file == nil {
+		return nil
+	}
+
+	return l.file.Close()
+}
+
+func InitLogger(loggerConfig *LoggerConfig) (*Logger, error) {
+	logger := &Logger{Level: new(slog.LevelVar)}
 
-	switch loggerConfig.Env {
-	case "prod":
-		level.Set(slog.LevelInfo.Level())
-		writer = os.Stdout
+	switch {
+	case len(loggerConfig.Level) > 0:
+		var level slog.Level
+		_ = level.UnmarshalText([]byte(loggerConfig.Level))
+		logger.Level.Set(level)
+	case loggerConfig.Env == "prod":
+		logger.Level.Set(slog.LevelInfo)
 	default:
-		level.Set(slog.LevelDebug.Level())
-		writer = os.Stdout
+		logger.Level.Set(slog.LevelDebug)
+	}
+
+	var writers []io.Writer
+
+	if loggerConfig.Output != LogOutputFile {
+		writers = append(writers, os.Stdout)
+	}
+
+	if loggerConfig.Output == LogOutputFile || loggerConfig.Output == LogOutputBoth {
+		file, err := logging.NewRotatingFile(loggerConfig.LogPath, int64(loggerConfig.MaxSizeMB)<<20,
+			loggerConfig.MaxBackups)
+
+		if err != nil {
+			return nil, err
+		}
+
+		logger.file = file
+		writers = append(writers, file)
 	}
 
 	handlerOpts := &slog.HandlerOptions{
-		Level:     level,
+		Level:     logger.Level,
 		AddSource: true,
 	}
 
-	l := slog.New(slog.NewJSONHandler(writer, handlerOpts))
+	var handler slog.Handler
+
+	if strings.EqualFold(loggerConfig.Format, LogFormatText) {
+		handler = slog.NewTextHandler(io.MultiWriter(writers...), handlerOpts)
+	} else {
+		handler = slog.NewJSONHandler(io.MultiWriter(writers...), handlerOpts)
+	}
+
+	// records logged with a request context carry its request id, and
+	// nothing reaches the sinks before the redaction
+	l := slog.New(shared.NewRequestIdHandler(logging.NewRedactHandler(handler)))
 	slog.SetDefault(l)
+
+	return logger, nil
 }
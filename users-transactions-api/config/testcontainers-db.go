@@ -4,24 +4,23 @@ import (
 	"context"
 	"database/sql"
 	"log/slog"
-	"path/filepath"
-	"runtime"
 	"time"
 
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/migrate"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/migrations"
+
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 var (
-	ctx        context.Context
-	connString string
+	ctx context.Context
+	// connStrings of the databases returned by SetupPgTestcontainers
+	connStrings = make(map[*sql.DB]string)
 )
 
-func setupContainer() {
-	_, b, _, _ := runtime.Caller(0)
-	basepath := filepath.Dir(b)
-
+func setupContainer() string {
 	ctx = context.Background()
 	c, err := postgres.Run(
 		ctx,
@@ -29,7 +28,6 @@ func setupContainer() {
 		postgres.WithDatabase("test"),
 		postgres.WithUsername("postgre"),
 		postgres.WithPassword("postgre"),
-		postgres.WithInitScripts(basepath+"/init_db.sql"),
 		testcontainers.WithWaitStrategy(
 			wait.ForLog("database system is ready to accept connections").
 				WithOccurrence(2).
@@ -42,7 +40,7 @@ func setupContainer() {
 		panic(err)
 	}
 
-	connString, err = c.ConnectionString(ctx, "sslmode=disable", "application_name=test")
+	connString, err := c.ConnectionString(ctx, "sslmode=disable", "application_name=test")
 
 	slog.Debug("testcontainers url connection", "url", connString)
 
@@ -50,9 +48,33 @@ func setupContainer() {
 		slog.Error("testcontainers configuration - ConnectionString", "error", err)
 		panic(err)
 	}
+
+	return connString
 }
 
+// SetupPgTestcontainers starts a postgres container with the migrations
+// embedded in the binary applied. Every call starts a new container.
 func SetupPgTestcontainers() *sql.DB {
-	setupContainer()
-	return InitConfig(connString)
+	connString := setupContainer()
+	db := InitConfig(connString)
+	connStrings[db] = connString
+
+	migrator, err := migrate.New(db, migrations.FS)
+
+	if err == nil {
+		_, err = migrator.Up(ctx)
+	}
+
+	if err != nil {
+		slog.Error("testcontainers configuration - migrations", "error", err)
+		panic(err)
+	}
+
+	return db
+}
+
+// PgTestcontainersConnString is the connection string of the container of
+// db, for the connections opened apart from its pool.
+func PgTestcontainersConnString(db *sql.DB) string {
+	return connStrings[db]
 }
@@ -23,6 +23,7 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type TransactionInfoServiceClient interface {
 	SearchTransactionInfo(ctx context.Context, in *SearchTransactionInfoRequest, opts ...grpc.CallOption) (TransactionInfoService_SearchTransactionInfoClient, error)
+	GetTenantSettings(ctx context.Context, in *GetTenantSettingsRequest, opts ...grpc.CallOption) (*TenantSettings, error)
 }
 
 type transactionInfoServiceClient struct {
@@ -65,11 +66,21 @@ func (x *transactionInfoServiceSearchTransactionInfoClient) Recv() (*SearchTrans
 	return m, nil
 }
 
+func (c *transactionInfoServiceClient) GetTenantSettings(ctx context.Context, in *GetTenantSettingsRequest, opts ...grpc.CallOption) (*TenantSettings, error) {
+	out := new(TenantSettings)
+	err := c.cc.Invoke(ctx, "/TransactionInfoService/GetTenantSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TransactionInfoServiceServer is the server API for TransactionInfoService service.
 // All implementations should embed UnimplementedTransactionInfoServiceServer
 // for forward compatibility
 type TransactionInfoServiceServer interface {
 	SearchTransactionInfo(*SearchTransactionInfoRequest, TransactionInfoService_SearchTransactionInfoServer) error
+	GetTenantSettings(context.Context, *GetTenantSettingsRequest) (*TenantSettings, error)
 }
 
 // UnimplementedTransactionInfoServiceServer should be embedded to have forward compatible implementations.
@@ -79,6 +90,9 @@ type UnimplementedTransactionInfoServiceServer struct {
 func (UnimplementedTransactionInfoServiceServer) SearchTransactionInfo(*SearchTransactionInfoRequest, TransactionInfoService_SearchTransactionInfoServer) error {
 	return status.Errorf(codes.Unimplemented, "method SearchTransactionInfo not implemented")
 }
+func (UnimplementedTransactionInfoServiceServer) GetTenantSettings(context.Context, *GetTenantSettingsRequest) (*TenantSettings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTenantSettings not implemented")
+}
 
 // UnsafeTransactionInfoServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to TransactionInfoServiceServer will
@@ -112,13 +126,36 @@ func (x *transactionInfoServiceSearchTransactionInfoServer) Send(m *SearchTransa
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TransactionInfoService_GetTenantSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTenantSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionInfoServiceServer).GetTenantSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TransactionInfoService/GetTenantSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionInfoServiceServer).GetTenantSettings(ctx, req.(*GetTenantSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TransactionInfoService_ServiceDesc is the grpc.ServiceDesc for TransactionInfoService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var TransactionInfoService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "TransactionInfoService",
 	HandlerType: (*TransactionInfoServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTenantSettings",
+			Handler:    _TransactionInfoService_GetTenantSettings_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "SearchTransactionInfo",
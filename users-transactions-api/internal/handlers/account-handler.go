@@ -1,9 +1,7 @@
 package handlers
 
 import (
-	"fmt"
 	"net/http"
-	"strconv"
 
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
@@ -13,20 +11,23 @@ import (
 )
 
 type AccountHandler struct {
-	createAccountUsecase   *usecases.CreateAccountUsecase
-	findAllAccountsUsecase *usecases.FindAllAccountsUsecase
-	findOneAccountUsecase  *usecases.FindOneAccountUsecase
-	activeAccountUsecase   *usecases.ActiveAccountUsecase
-	inactiveAccountUsecase *usecases.InactiveAccountUsecase
+	createAccountUsecase            *usecases.CreateAccountUsecase
+	findAllAccountsUsecase          *usecases.FindAllAccountsUsecase
+	findOneAccountUsecase           *usecases.FindOneAccountUsecase
+	changeAccountStatusUsecase      *usecases.ChangeAccountStatusUsecase
+	findAccountStatusHistoryUsecase *usecases.FindAccountStatusHistoryUsecase
 }
 
-func NewAccountHandler(createAccountUsecase *usecases.CreateAccountUsecase, findAllAccountsUsecase *usecases.FindAllAccountsUsecase, findOneAccountUsecase *usecases.FindOneAccountUsecase, activeAccountUsecase *usecases.ActiveAccountUsecase, inactiveAccountUsecase *usecases.InactiveAccountUsecase) *AccountHandler {
+func NewAccountHandler(createAccountUsecase *usecases.CreateAccountUsecase,
+	findAllAccountsUsecase *usecases.FindAllAccountsUsecase, findOneAccountUsecase *usecases.FindOneAccountUsecase,
+	changeAccountStatusUsecase *usecases.ChangeAccountStatusUsecase,
+	findAccountStatusHistoryUsecase *usecases.FindAccountStatusHistoryUsecase) *AccountHandler {
 	return &AccountHandler{
-		createAccountUsecase:   createAccountUsecase,
-		findAllAccountsUsecase: findAllAccountsUsecase,
-		findOneAccountUsecase:  findOneAccountUsecase,
-		activeAccountUsecase:   activeAccountUsecase,
-		inactiveAccountUsecase: inactiveAccountUsecase,
+		createAccountUsecase:            createAccountUsecase,
+		findAllAccountsUsecase:          findAllAccountsUsecase,
+		findOneAccountUsecase:           findOneAccountUsecase,
+		changeAccountStatusUsecase:      changeAccountStatusUsecase,
+		findAccountStatusHistoryUsecase: findAccountStatusHistoryUsecase,
 	}
 }
 
@@ -37,10 +38,25 @@ func (ah *AccountHandler) Create(c *gin.Context) {
 		return
 	}
 
-	savedAccount, err := ah.createAccountUsecase.Create(tenantId)
+	principal, valid := tools.GetPrincipal(c)
+
+	if !valid {
+		return
+	}
+
+	// the body is optional, accounts are opened as active by default
+	var request dto.AccountRequest
+
+	if c.Request.ContentLength > 0 {
+		if !tools.BindJSON(c, &request) {
+			return
+		}
+	}
+
+	savedAccount, err := ah.createAccountUsecase.Create(c.Request.Context(), tenantId, request.Status, principal.Actor())
 
 	if err != nil {
-		tools.LogInternalServerError(c, "account handler", "Create", err)
+		c.Error(err)
 		return
 	}
 
@@ -54,22 +70,20 @@ func (ah *AccountHandler) FindOne(c *gin.Context) {
 		return
 	}
 
-	accountId, err := strconv.ParseInt(c.Param("accountId"), 0, 32)
+	accountId, valid := tools.ParamId(c, "accountId")
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account id"})
+	if !valid {
 		return
 	}
 
-	account, err := ah.findOneAccountUsecase.FindOne(int32(tenantId), int32(accountId))
+	account, err := ah.findOneAccountUsecase.FindOne(c.Request.Context(), int32(tenantId), accountId)
 
 	if err != nil {
-		if enf, ok := err.(*shared.EntityNotFoundError); ok {
-			c.JSON(http.StatusNotFound, gin.H{"error": enf.Error()})
-			return
-		}
+		c.Error(err)
+		return
+	}
 
-		tools.LogInternalServerError(c, "account handler", "FindOne", err)
+	if tools.NotModified(c, tools.ETag(account.Version)) {
 		return
 	}
 
@@ -83,10 +97,10 @@ func (ah *AccountHandler) FindAll(c *gin.Context) {
 		return
 	}
 
-	accounts, err := ah.findAllAccountsUsecase.FindAll(int32(tenantId))
+	accounts, err := ah.findAllAccountsUsecase.FindAll(c.Request.Context(), int32(tenantId))
 
 	if err != nil {
-		tools.LogInternalServerError(c, "account handler", "FindAll", err)
+		c.Error(err)
 		return
 	}
 
@@ -98,60 +112,123 @@ func (ah *AccountHandler) FindAll(c *gin.Context) {
 	c.JSON(http.StatusOK, accountsResponse)
 }
 
+func (ah *AccountHandler) ChangeStatus(c *gin.Context) {
+	ah.changeStatus(c, func() (dto.AccountStatusRequest, bool) {
+		var request dto.AccountStatusRequest
+		valid := tools.BindJSON(c, &request)
+		return request, valid
+	})
+}
+
+// Active and Freeze are shortcuts to the most common changes, the reason is
+// optional for them. Freeze stays reversible like the inactivation it
+// replaced, an account is closed only through ChangeStatus.
 func (ah *AccountHandler) Active(c *gin.Context) {
+	ah.changeStatus(c, ah.shortcutRequest(c, shared.AccountStatusActive, "account activated"))
+}
+
+func (ah *AccountHandler) Freeze(c *gin.Context) {
+	ah.changeStatus(c, ah.shortcutRequest(c, shared.AccountStatusFrozen, "account frozen"))
+}
+
+func (ah *AccountHandler) StatusHistory(c *gin.Context) {
 	tenantId, valid := tools.CheckTenantHeader(c)
 
 	if !valid {
 		return
 	}
 
-	accountId, err := strconv.ParseInt(c.Param("accountId"), 0, 32)
+	accountId, valid := tools.ParamId(c, "accountId")
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account id"})
+	if !valid {
 		return
 	}
 
-	err = ah.activeAccountUsecase.Active(tenantId, int32(accountId))
+	history, err := ah.findAccountStatusHistoryUsecase.FindAll(c.Request.Context(), tenantId, accountId)
 
 	if err != nil {
-		if enf, ok := err.(*shared.EntityNotFoundError); ok {
-			c.JSON(http.StatusBadRequest, gin.H{"error": enf.Error()})
-			return
-		}
-
-		tools.LogInternalServerError(c, "account handler", "Active", err)
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Account with id %d was actived successfully", accountId)})
+	historyResponse := make([]dto.AccountStatusHistoryResponse, 0, len(history))
+	for _, h := range history {
+		historyResponse = append(historyResponse, dto.AccountStatusHistoryToResponse(h))
+	}
+
+	c.JSON(http.StatusOK, historyResponse)
+}
+
+func (ah *AccountHandler) shortcutRequest(c *gin.Context, status string,
+	defaultReason string) func() (dto.AccountStatusRequest, bool) {
+	return func() (dto.AccountStatusRequest, bool) {
+		// the body is optional, without a reason it falls back to the default
+		var shortcut dto.AccountStatusShortcutRequest
+
+		if c.Request.ContentLength > 0 {
+			if !tools.BindJSON(c, &shortcut) {
+				return dto.AccountStatusRequest{}, false
+			}
+		}
+
+		request := dto.AccountStatusRequest{
+			Status: status,
+			Reason: shortcut.Reason,
+		}
+
+		if len(request.Reason) == 0 {
+			request.Reason = defaultReason
+		}
+
+		return request, true
+	}
 }
 
-func (ah *AccountHandler) Inactive(c *gin.Context) {
+// changeStatus binds the request only after the tenant and the account id
+// are known to be valid.
+func (ah *AccountHandler) changeStatus(c *gin.Context, bind func() (dto.AccountStatusRequest, bool)) {
 	tenantId, valid := tools.CheckTenantHeader(c)
 
 	if !valid {
 		return
 	}
 
-	accountId, err := strconv.ParseInt(c.Param("accountId"), 0, 32)
+	principal, valid := tools.GetPrincipal(c)
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account id"})
+	if !valid {
 		return
 	}
 
-	err = ah.inactiveAccountUsecase.Inactive(tenantId, int32(accountId))
+	accountId, valid := tools.ParamId(c, "accountId")
 
-	if err != nil {
-		if enf, ok := err.(*shared.EntityNotFoundError); ok {
-			c.JSON(http.StatusBadRequest, gin.H{"error": enf.Error()})
-			return
-		}
+	if !valid {
+		return
+	}
 
-		tools.LogInternalServerError(c, "account handler", "Inactive", err)
+	request, valid := bind()
+
+	if !valid {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Account with id %d was deleted successfully", accountId)})
+	version, valid := tools.IfMatchVersion(c)
+
+	if !valid {
+		return
+	}
+
+	account, err := ah.changeAccountStatusUsecase.Change(c.Request.Context(), tenantId, accountId, usecases.StatusChange{
+		Status:  request.Status,
+		Reason:  request.Reason,
+		Actor:   principal.Actor(),
+		Version: version,
+	})
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("ETag", tools.ETag(account.Version))
+	c.JSON(http.StatusOK, dto.AccountToResponse(*account))
 }
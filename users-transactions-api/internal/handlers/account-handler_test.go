@@ -11,9 +11,13 @@ import (
 	"testing"
 
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
 	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
 	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/account"
+	quotaUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/quota"
+	settingsUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/settings"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
@@ -22,13 +26,21 @@ func TestAccountHandler(t *testing.T) {
 	t.Parallel()
 
 	mockRepo := new(mocks.MockRepository)
-	accountCreateUsecase := usecases.NewCreateAccountUsecase(mockRepo)
+
+	// tenants without settings are not limited by quotas
+	mockRepo.On("GetTenantSettings").Return(nil, sql.ErrNoRows)
+	findTenantSettingsUsecase := settingsUsecases.NewFindTenantSettingsUsecase(mockRepo, settingsUsecases.NewSettingsCache(0))
+	quotaUsecase := quotaUsecases.NewQuotaUsecase(findTenantSettingsUsecase)
+	accountCreateUsecase := usecases.NewCreateAccountUsecase(mockRepo, quotaUsecase)
 	findOneAccountUsecase := usecases.NewFindOneAccountUsecase(mockRepo)
 	findAllAccountsUsecase := usecases.NewFindAllAccountsUsecase(mockRepo)
-	updateAccountUsecase := usecases.NewActiveAccountUsecase(mockRepo)
-	deleteAccountUSecase := usecases.NewInactiveAccountUsecase(mockRepo)
+	changeAccountStatusUsecase := usecases.NewChangeAccountStatusUsecase(mockRepo)
+	findAccountStatusHistoryUsecase := usecases.NewFindAccountStatusHistoryUsecase(mockRepo)
+
+	sut := NewAccountHandler(accountCreateUsecase, findAllAccountsUsecase, findOneAccountUsecase,
+		changeAccountStatusUsecase, findAccountStatusHistoryUsecase)
 
-	sut := NewAccountHandler(accountCreateUsecase, findAllAccountsUsecase, findOneAccountUsecase, updateAccountUsecase, deleteAccountUSecase)
+	principal := &shared.Principal{TenantId: 1, UserId: 1, Role: shared.RoleAdmin}
 
 	account := infra.Account{
 		ID:       1,
@@ -45,14 +57,15 @@ func TestAccountHandler(t *testing.T) {
 		c.Request.Header.Set("tenant-id", "invalid")
 
 		sut.Create(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, "Invalid tenant-id", responseBody["error"])
+		assert.Contains(t, responseBody.Errors, tools.FieldError{Field: "tenant-id", Detail: "must be a valid id"})
 	})
 
 	t.Run("[Create] Internal server error", func(t *testing.T) {
@@ -65,30 +78,72 @@ func TestAccountHandler(t *testing.T) {
 		c.Request = httptest.NewRequest("POST", "/account", nil)
 		c.Request.Header.Set("Content-Type", "application/json")
 		c.Request.Header.Set("tenant-id", "1")
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), principal))
 
 		sut.Create(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusInternalServerError, res.Result().StatusCode)
-		assert.Equal(t, "Internal Server Error", responseBody["error"])
+		assert.Equal(t, "Internal Server Error", responseBody.Title)
+	})
+
+	t.Run("[Create] Missing credentials", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("POST", "/account", nil)
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("tenant-id", "1")
+
+		sut.Create(c)
+		tools.RenderProblem(c)
+
+		assert.Equal(t, http.StatusUnauthorized, res.Result().StatusCode)
+	})
+
+	t.Run("[Create] Invalid initial status", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("POST", "/account", bytes.NewBufferString(`{"status":"closed"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("tenant-id", "1")
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), principal))
+
+		sut.Create(c)
+		tools.RenderProblem(c)
+
+		var responseBody tools.Problem
+		err := json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
+		assert.Contains(t, responseBody.Errors, tools.FieldError{Field: "status", Detail: "must be one of pending or active"})
 	})
 
 	t.Run("[Create] Account created successfully", func(t *testing.T) {
 		mockRepo.On("CreateAccount").Return(account, nil)
 		defer mockRepo.On("CreateAccount").Unset()
 
+		mockRepo.On("CreateAccountStatusHistory").Return(infra.AccountStatusHistory{}, nil)
+		defer mockRepo.On("CreateAccountStatusHistory").Unset()
+
 		res := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(res)
 
 		c.Request = httptest.NewRequest("POST", "/account", nil)
 		c.Request.Header.Set("Content-Type", "application/json")
 		c.Request.Header.Set("tenant-id", "1")
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), principal))
 
 		sut.Create(c)
+		tools.RenderProblem(c)
 
 		var responseBody dto.AccountResponse
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
@@ -108,14 +163,15 @@ func TestAccountHandler(t *testing.T) {
 		c.Request.Header.Set("tenant-id", "invalid")
 
 		sut.FindOne(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, "Invalid tenant-id", responseBody["error"])
+		assert.Contains(t, responseBody.Errors, tools.FieldError{Field: "tenant-id", Detail: "must be a valid id"})
 	})
 
 	t.Run("[FindOne] Invalid account id", func(t *testing.T) {
@@ -131,14 +187,15 @@ func TestAccountHandler(t *testing.T) {
 		}}
 
 		sut.FindOne(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, "Invalid account id", responseBody["error"])
+		assert.Contains(t, responseBody.Errors, tools.FieldError{Field: "accountId", Detail: "must be a valid id"})
 	})
 
 	t.Run("[FindOne] Account not found by id", func(t *testing.T) {
@@ -159,14 +216,15 @@ func TestAccountHandler(t *testing.T) {
 		}}
 
 		sut.FindOne(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusNotFound, res.Result().StatusCode)
-		assert.Equal(t, fmt.Sprintf("account not found with id %s", accountId), responseBody["error"])
+		assert.Equal(t, fmt.Sprintf("account not found with id %s", accountId), responseBody.Detail)
 	})
 
 	t.Run("[FindOne] Internal server error", func(t *testing.T) {
@@ -185,14 +243,15 @@ func TestAccountHandler(t *testing.T) {
 		}}
 
 		sut.FindOne(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusInternalServerError, res.Result().StatusCode)
-		assert.Equal(t, "Internal Server Error", responseBody["error"])
+		assert.Equal(t, "Internal Server Error", responseBody.Title)
 	})
 
 	t.Run("[FindOne] Success", func(t *testing.T) {
@@ -211,6 +270,7 @@ func TestAccountHandler(t *testing.T) {
 		}}
 
 		sut.FindOne(c)
+		tools.RenderProblem(c)
 
 		var responseBody dto.AccountResponse
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
@@ -221,6 +281,57 @@ func TestAccountHandler(t *testing.T) {
 		assert.Equal(t, dto.AccountToResponse(account), responseBody)
 	})
 
+	t.Run("[FindOne] Not modified", func(t *testing.T) {
+		versionedAccount := account
+		versionedAccount.Version = 2
+
+		mockRepo.On("GetAccount").Return(versionedAccount, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("GET", "/account/1", nil)
+		c.Request.Header.Set("tenant-id", "1")
+		c.Request.Header.Set("If-None-Match", `"1", W/"2"`)
+		c.Params = []gin.Param{{
+			Key:   "accountId",
+			Value: "1",
+		}}
+
+		sut.FindOne(c)
+		tools.RenderProblem(c)
+
+		assert.Equal(t, http.StatusNotModified, c.Writer.Status())
+		assert.Equal(t, `"2"`, res.Header().Get("ETag"))
+		assert.Empty(t, res.Body.String())
+	})
+
+	t.Run("[FindOne] Modified since", func(t *testing.T) {
+		versionedAccount := account
+		versionedAccount.Version = 3
+
+		mockRepo.On("GetAccount").Return(versionedAccount, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("GET", "/account/1", nil)
+		c.Request.Header.Set("tenant-id", "1")
+		c.Request.Header.Set("If-None-Match", `"2"`)
+		c.Params = []gin.Param{{
+			Key:   "accountId",
+			Value: "1",
+		}}
+
+		sut.FindOne(c)
+		tools.RenderProblem(c)
+
+		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
+		assert.Equal(t, `"3"`, res.Header().Get("ETag"))
+	})
+
 	t.Run("[FindAll] Invalid tenant id", func(t *testing.T) {
 		res := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(res)
@@ -230,14 +341,15 @@ func TestAccountHandler(t *testing.T) {
 		c.Request.Header.Set("tenant-id", "invalid")
 
 		sut.FindAll(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, "Invalid tenant-id", responseBody["error"])
+		assert.Contains(t, responseBody.Errors, tools.FieldError{Field: "tenant-id", Detail: "must be a valid id"})
 	})
 
 	t.Run("[FindAll] Internal server error", func(t *testing.T) {
@@ -252,14 +364,15 @@ func TestAccountHandler(t *testing.T) {
 		c.Request.Header.Set("tenant-id", "1")
 
 		sut.FindAll(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusInternalServerError, res.Result().StatusCode)
-		assert.Equal(t, "Internal Server Error", responseBody["error"])
+		assert.Equal(t, "Internal Server Error", responseBody.Title)
 	})
 
 	t.Run("[FindAll] Success with empty array", func(t *testing.T) {
@@ -276,6 +389,7 @@ func TestAccountHandler(t *testing.T) {
 		c.Request.Header.Set("tenant-id", "1")
 
 		sut.FindAll(c)
+		tools.RenderProblem(c)
 
 		var responseBody []infra.Account
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
@@ -301,6 +415,7 @@ func TestAccountHandler(t *testing.T) {
 		c.Request.Header.Set("tenant-id", "1")
 
 		sut.FindAll(c)
+		tools.RenderProblem(c)
 
 		var responseBody []dto.AccountResponse
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
@@ -312,257 +427,435 @@ func TestAccountHandler(t *testing.T) {
 		assert.Equal(t, []dto.AccountResponse{dto.AccountToResponse(account)}, responseBody)
 	})
 
-	t.Run("[Active] Invalid tenant id", func(t *testing.T) {
+	t.Run("[ChangeStatus] Invalid tenant id", func(t *testing.T) {
 		res := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(res)
 
-		c.Request = httptest.NewRequest("PUT", "/account", nil)
+		c.Request = httptest.NewRequest("POST", "/account/1/status", bytes.NewBufferString(`{}`))
 		c.Request.Header.Set("Content-Type", "application/json")
 		c.Request.Header.Set("tenant-id", "invalid")
 
-		sut.Active(c)
+		sut.ChangeStatus(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, "Invalid tenant-id", responseBody["error"])
+		assert.Contains(t, responseBody.Errors, tools.FieldError{Field: "tenant-id", Detail: "must be a valid id"})
 	})
 
-	t.Run("[Active] Invalid account Id", func(t *testing.T) {
+	t.Run("[ChangeStatus] Invalid account id", func(t *testing.T) {
 		res := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(res)
 
-		c.Request = httptest.NewRequest("PUT", "/account", bytes.NewBuffer([]byte("invalid json")))
+		c.Request = httptest.NewRequest("POST", "/account/invalid/status", bytes.NewBufferString(`{}`))
 		c.Request.Header.Set("Content-Type", "application/json")
 		c.Request.Header.Set("tenant-id", "1")
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), principal))
 		c.Params = []gin.Param{{
 			Key:   "accountId",
 			Value: "invalid",
 		}}
 
-		sut.Active(c)
+		sut.ChangeStatus(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, "Invalid account id", responseBody["error"])
+		assert.Contains(t, responseBody.Errors, tools.FieldError{Field: "accountId", Detail: "must be a valid id"})
 	})
 
-	t.Run("[Active] Account not found by id", func(t *testing.T) {
-		mockRepo.On("GetAccount").Return(nil, sql.ErrNoRows)
-		defer mockRepo.On("GetAccount").Unset()
-
+	t.Run("[ChangeStatus] Validation error", func(t *testing.T) {
 		res := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(res)
 
-		accountId := "1"
-
-		c.Request = httptest.NewRequest("PUT", "/account", nil)
+		c.Request = httptest.NewRequest("POST", "/account/1/status", bytes.NewBufferString(`{"status":"inactive"}`))
 		c.Request.Header.Set("Content-Type", "application/json")
 		c.Request.Header.Set("tenant-id", "1")
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), principal))
 		c.Params = []gin.Param{{
 			Key:   "accountId",
-			Value: accountId,
+			Value: "1",
 		}}
 
-		sut.Active(c)
+		sut.ChangeStatus(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, fmt.Sprintf("account not found with id %s", accountId),
-			responseBody["error"])
+		assert.Contains(t, responseBody.Errors, tools.FieldError{Field: "reason", Detail: "cannot be empty"})
 	})
 
-	t.Run("[Active] Internal server error", func(t *testing.T) {
-		mockRepo.On("GetAccount").Return(account, errors.New("Internal server error"))
-		defer mockRepo.On("GetAccount").Unset()
+	t.Run("[ChangeStatus] Account not found by id", func(t *testing.T) {
+		mockRepo.On("GetAccountForUpdate").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetAccountForUpdate").Unset()
 
 		res := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(res)
 
-		c.Request = httptest.NewRequest("PUT", "/account", nil)
+		c.Request = httptest.NewRequest("POST", "/account/1/status",
+			bytes.NewBufferString(`{"status":"frozen","reason":"card reported lost"}`))
 		c.Request.Header.Set("Content-Type", "application/json")
 		c.Request.Header.Set("tenant-id", "1")
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), principal))
 		c.Params = []gin.Param{{
 			Key:   "accountId",
 			Value: "1",
 		}}
 
-		sut.Active(c)
+		sut.ChangeStatus(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
-		assert.Equal(t, http.StatusInternalServerError, res.Result().StatusCode)
-		assert.Equal(t, "Internal Server Error", responseBody["error"])
+		assert.Equal(t, http.StatusNotFound, res.Result().StatusCode)
+		assert.Equal(t, "account not found with id 1", responseBody.Detail)
 	})
 
-	t.Run("[Active] Account updated successfully", func(t *testing.T) {
-		mockRepo.On("GetAccount").Return(account, nil)
-		defer mockRepo.On("GetAccount").Unset()
+	t.Run("[ChangeStatus] Transition not allowed", func(t *testing.T) {
+		closedAccount := account
+		closedAccount.Status = shared.AccountStatusClosed
 
-		mockRepo.On("UpdateAccount").Return(account, nil)
-		defer mockRepo.On("UpdateAccount").Unset()
+		mockRepo.On("GetAccountForUpdate").Return(closedAccount, nil)
+		defer mockRepo.On("GetAccountForUpdate").Unset()
 
 		res := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(res)
 
-		c.Request = httptest.NewRequest("PUT", "/account", nil)
+		c.Request = httptest.NewRequest("POST", "/account/1/status",
+			bytes.NewBufferString(`{"status":"frozen","reason":"card reported lost"}`))
 		c.Request.Header.Set("Content-Type", "application/json")
 		c.Request.Header.Set("tenant-id", "1")
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), principal))
 		c.Params = []gin.Param{{
 			Key:   "accountId",
 			Value: "1",
 		}}
 
-		sut.Active(c)
+		sut.ChangeStatus(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
-		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
-		assert.Equal(t, fmt.Sprintf("Account with id %d was actived successfully", account.ID),
-			responseBody["message"])
+		assert.Equal(t, http.StatusConflict, res.Result().StatusCode)
+		assert.Equal(t, "account cannot change from closed to frozen", responseBody.Detail)
 	})
 
-	t.Run("[Inactive] Invalid tenant id", func(t *testing.T) {
+	t.Run("[ChangeStatus] Internal server error", func(t *testing.T) {
+		mockRepo.On("GetAccountForUpdate").Return(nil, errors.New("Internal server error"))
+		defer mockRepo.On("GetAccountForUpdate").Unset()
+
 		res := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(res)
 
-		c.Request = httptest.NewRequest("DELETE", "/account", nil)
+		c.Request = httptest.NewRequest("POST", "/account/1/status",
+			bytes.NewBufferString(`{"status":"frozen","reason":"card reported lost"}`))
 		c.Request.Header.Set("Content-Type", "application/json")
-		c.Request.Header.Set("tenant-id", "invalid")
+		c.Request.Header.Set("tenant-id", "1")
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), principal))
+		c.Params = []gin.Param{{
+			Key:   "accountId",
+			Value: "1",
+		}}
 
-		sut.Inactive(c)
+		sut.ChangeStatus(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, "Invalid tenant-id", responseBody["error"])
+		assert.Equal(t, http.StatusInternalServerError, res.Result().StatusCode)
+		assert.Equal(t, "Internal Server Error", responseBody.Title)
 	})
 
-	t.Run("[Inactive] Invalid account id", func(t *testing.T) {
+	t.Run("[ChangeStatus] Malformed If-Match", func(t *testing.T) {
 		res := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(res)
 
-		c.Request = httptest.NewRequest("DELETE", "/account", bytes.NewBuffer([]byte("")))
+		c.Request = httptest.NewRequest("POST", "/account/1/status",
+			bytes.NewBufferString(`{"status":"frozen","reason":"card reported lost"}`))
 		c.Request.Header.Set("Content-Type", "application/json")
 		c.Request.Header.Set("tenant-id", "1")
+		c.Request.Header.Set("If-Match", `W/"1"`)
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), principal))
 		c.Params = []gin.Param{{
 			Key:   "accountId",
-			Value: "invalid",
+			Value: "1",
 		}}
 
-		sut.Inactive(c)
+		sut.ChangeStatus(c)
+		tools.RenderProblem(c)
+
+		assert.Equal(t, http.StatusPreconditionFailed, res.Result().StatusCode)
+	})
+
+	t.Run("[ChangeStatus] Stale If-Match", func(t *testing.T) {
+		versionedAccount := account
+		versionedAccount.Version = 3
+
+		mockRepo.On("GetAccountForUpdate").Return(versionedAccount, nil)
+		defer mockRepo.On("GetAccountForUpdate").Unset()
 
-		var responseBody map[string]string
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("POST", "/account/1/status",
+			bytes.NewBufferString(`{"status":"frozen","reason":"card reported lost"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("tenant-id", "1")
+		c.Request.Header.Set("If-Match", `"2"`)
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), principal))
+		c.Params = []gin.Param{{
+			Key:   "accountId",
+			Value: "1",
+		}}
+
+		sut.ChangeStatus(c)
+		tools.RenderProblem(c)
+
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, "Invalid account id", responseBody["error"])
+		assert.Equal(t, http.StatusPreconditionFailed, res.Result().StatusCode)
+		assert.Equal(t, `"3"`, res.Header().Get("ETag"))
+		assert.Equal(t, "account with id 1 was modified, current version is 3", responseBody.Detail)
 	})
 
-	t.Run("[Inactive] Account not found by id", func(t *testing.T) {
-		mockRepo.On("GetAccount").Return(nil, sql.ErrNoRows)
-		defer mockRepo.On("GetAccount").Unset()
+	t.Run("[ChangeStatus] Status changed successfully", func(t *testing.T) {
+		frozenAccount := account
+		frozenAccount.Status = shared.AccountStatusFrozen
+
+		frozenAccount.Version = 2
+		versionedAccount := account
+		versionedAccount.Version = 1
+
+		mockRepo.On("GetAccountForUpdate").Return(versionedAccount, nil)
+		defer mockRepo.On("GetAccountForUpdate").Unset()
+
+		mockRepo.On("UpdateAccount").Return(frozenAccount, nil)
+		defer mockRepo.On("UpdateAccount").Unset()
+
+		mockRepo.On("CreateAccountStatusHistory").Return(infra.AccountStatusHistory{}, nil)
+		defer mockRepo.On("CreateAccountStatusHistory").Unset()
 
 		res := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(res)
 
-		accountId := "1"
-
-		c.Request = httptest.NewRequest("DELETE", "/account", bytes.NewBuffer([]byte("")))
+		c.Request = httptest.NewRequest("POST", "/account/1/status",
+			bytes.NewBufferString(`{"status":"frozen","reason":"card reported lost"}`))
 		c.Request.Header.Set("Content-Type", "application/json")
 		c.Request.Header.Set("tenant-id", "1")
+		c.Request.Header.Set("If-Match", `"1"`)
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), principal))
 		c.Params = []gin.Param{{
 			Key:   "accountId",
-			Value: accountId,
+			Value: "1",
 		}}
 
-		sut.Inactive(c)
+		sut.ChangeStatus(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody dto.AccountResponse
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, fmt.Sprintf("account not found with id %s", accountId),
-			responseBody["error"])
+		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
+		assert.Equal(t, `"2"`, res.Header().Get("ETag"))
+		assert.Equal(t, dto.AccountToResponse(frozenAccount), responseBody)
 	})
 
-	t.Run("[Inactive] Internal server error", func(t *testing.T) {
-		mockRepo.On("GetAccount").Return(nil, errors.New("Internal server error"))
-		defer mockRepo.On("GetAccount").Unset()
+	t.Run("[Active] Missing credentials", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("PUT", "/account/1", nil)
+		c.Request.Header.Set("tenant-id", "1")
+		c.Params = []gin.Param{{
+			Key:   "accountId",
+			Value: "1",
+		}}
+
+		sut.Active(c)
+		tools.RenderProblem(c)
+
+		assert.Equal(t, http.StatusUnauthorized, res.Result().StatusCode)
+	})
+
+	t.Run("[Active] Account activated successfully", func(t *testing.T) {
+		frozenAccount := account
+		frozenAccount.Status = shared.AccountStatusFrozen
+
+		mockRepo.On("GetAccountForUpdate").Return(frozenAccount, nil)
+		defer mockRepo.On("GetAccountForUpdate").Unset()
+
+		mockRepo.On("UpdateAccount").Return(account, nil)
+		defer mockRepo.On("UpdateAccount").Unset()
+
+		mockRepo.On("CreateAccountStatusHistory").Return(infra.AccountStatusHistory{}, nil)
+		defer mockRepo.On("CreateAccountStatusHistory").Unset()
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("PUT", "/account/1", nil)
+		c.Request.Header.Set("tenant-id", "1")
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), principal))
+		c.Params = []gin.Param{{
+			Key:   "accountId",
+			Value: "1",
+		}}
+
+		sut.Active(c)
+		tools.RenderProblem(c)
+
+		var responseBody dto.AccountResponse
+		err := json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
+		assert.Equal(t, dto.AccountToResponse(account), responseBody)
+	})
+
+	t.Run("[Freeze] Account frozen successfully", func(t *testing.T) {
+		frozenAccount := account
+		frozenAccount.Status = shared.AccountStatusFrozen
+
+		mockRepo.On("GetAccountForUpdate").Return(account, nil)
+		defer mockRepo.On("GetAccountForUpdate").Unset()
+
+		mockRepo.On("UpdateAccount").Return(frozenAccount, nil)
+		defer mockRepo.On("UpdateAccount").Unset()
+
+		mockRepo.On("CreateAccountStatusHistory").Return(infra.AccountStatusHistory{}, nil)
+		defer mockRepo.On("CreateAccountStatusHistory").Unset()
 
 		res := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(res)
 
-		c.Request = httptest.NewRequest("DELETE", "/account", bytes.NewBuffer([]byte("")))
+		c.Request = httptest.NewRequest("DELETE", "/account/1", bytes.NewBufferString(`{"reason":"customer request"}`))
 		c.Request.Header.Set("Content-Type", "application/json")
 		c.Request.Header.Set("tenant-id", "1")
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), principal))
 		c.Params = []gin.Param{{
 			Key:   "accountId",
 			Value: "1",
 		}}
 
-		sut.Inactive(c)
+		sut.Freeze(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody dto.AccountResponse
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
-		assert.Equal(t, http.StatusInternalServerError, res.Result().StatusCode)
-		assert.Equal(t, "Internal Server Error", responseBody["error"])
+		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
+		assert.Equal(t, dto.AccountToResponse(frozenAccount), responseBody)
 	})
 
-	t.Run("[Inactive] Account deleted successfully", func(t *testing.T) {
+	t.Run("[Freeze] Malformed body", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("DELETE", "/account/1", bytes.NewBufferString(`{"reason":`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("tenant-id", "1")
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), principal))
+		c.Params = []gin.Param{{
+			Key:   "accountId",
+			Value: "1",
+		}}
+
+		sut.Freeze(c)
+		tools.RenderProblem(c)
+
+		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
+	})
+
+	t.Run("[StatusHistory] Account not found by id", func(t *testing.T) {
+		mockRepo.On("GetAccount").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetAccount").Unset()
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("GET", "/account/1/status", nil)
+		c.Request.Header.Set("tenant-id", "1")
+		c.Params = []gin.Param{{
+			Key:   "accountId",
+			Value: "1",
+		}}
+
+		sut.StatusHistory(c)
+		tools.RenderProblem(c)
+
+		assert.Equal(t, http.StatusNotFound, res.Result().StatusCode)
+	})
+
+	t.Run("[StatusHistory] Success", func(t *testing.T) {
+		history := []infra.AccountStatusHistory{
+			{ID: 1, AccountID: 1, ToStatus: shared.AccountStatusActive, Reason: "account opened", Actor: "user:1"},
+			{
+				ID:         2,
+				AccountID:  1,
+				FromStatus: sql.NullString{String: shared.AccountStatusActive, Valid: true},
+				ToStatus:   shared.AccountStatusFrozen,
+				Reason:     "card reported lost",
+				Actor:      "user:1",
+			},
+		}
+
 		mockRepo.On("GetAccount").Return(account, nil)
 		defer mockRepo.On("GetAccount").Unset()
 
-		mockRepo.On("UpdateAccount").Return(account, nil)
-		defer mockRepo.On("UpdateAccount").Unset()
+		mockRepo.On("GetAccountStatusHistory").Return(history, nil)
+		defer mockRepo.On("GetAccountStatusHistory").Unset()
 
 		res := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(res)
 
-		c.Request = httptest.NewRequest("DELETE", "/account", nil)
-		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request = httptest.NewRequest("GET", "/account/1/status", nil)
 		c.Request.Header.Set("tenant-id", "1")
 		c.Params = []gin.Param{{
 			Key:   "accountId",
 			Value: "1",
 		}}
 
-		sut.Inactive(c)
+		sut.StatusHistory(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody []dto.AccountStatusHistoryResponse
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
-		assert.Equal(t, fmt.Sprintf("Account with id %d was deleted successfully", account.ID),
-			responseBody["message"])
+		assert.Len(t, responseBody, 2)
+		assert.Nil(t, responseBody[0].FromStatus)
+		assert.Equal(t, shared.AccountStatusActive, *responseBody[1].FromStatus)
+		assert.Equal(t, shared.AccountStatusFrozen, responseBody[1].ToStatus)
 	})
 }
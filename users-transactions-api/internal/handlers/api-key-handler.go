@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/apikey"
+	"github.com/gin-gonic/gin"
+)
+
+type ApiKeyHandler struct {
+	createApiKeyUsecase   *usecases.CreateApiKeyUsecase
+	findAllApiKeysUsecase *usecases.FindAllApiKeysUsecase
+	rotateApiKeyUsecase   *usecases.RotateApiKeyUsecase
+	revokeApiKeyUsecase   *usecases.RevokeApiKeyUsecase
+}
+
+func NewApiKeyHandler(createApiKeyUsecase *usecases.CreateApiKeyUsecase,
+	findAllApiKeysUsecase *usecases.FindAllApiKeysUsecase,
+	rotateApiKeyUsecase *usecases.RotateApiKeyUsecase,
+	revokeApiKeyUsecase *usecases.RevokeApiKeyUsecase) *ApiKeyHandler {
+	return &ApiKeyHandler{
+		createApiKeyUsecase:   createApiKeyUsecase,
+		findAllApiKeysUsecase: findAllApiKeysUsecase,
+		rotateApiKeyUsecase:   rotateApiKeyUsecase,
+		revokeApiKeyUsecase:   revokeApiKeyUsecase,
+	}
+}
+
+func (ah *ApiKeyHandler) Create(c *gin.Context) {
+	tenantId, valid := tools.CheckTenantHeader(c)
+
+	if !valid {
+		return
+	}
+
+	var request dto.ApiKeyRequest
+
+	if !tools.BindJSON(c, &request) {
+		return
+	}
+
+	savedApiKey, key, err := ah.createApiKeyUsecase.Create(c.Request.Context(), tenantId, request.Name, request.Scopes)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApiKeyToSecretResponse(*savedApiKey, key))
+}
+
+func (ah *ApiKeyHandler) FindAll(c *gin.Context) {
+	tenantId, valid := tools.CheckTenantHeader(c)
+
+	if !valid {
+		return
+	}
+
+	apiKeys, err := ah.findAllApiKeysUsecase.FindAll(c.Request.Context(), tenantId)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	apiKeysResponse := make([]dto.ApiKeyResponse, 0)
+	for _, apiKey := range apiKeys {
+		apiKeysResponse = append(apiKeysResponse, dto.ApiKeyToResponse(apiKey))
+	}
+
+	c.JSON(http.StatusOK, apiKeysResponse)
+}
+
+func (ah *ApiKeyHandler) Rotate(c *gin.Context) {
+	tenantId, valid := tools.CheckTenantHeader(c)
+
+	if !valid {
+		return
+	}
+
+	apiKeyId, valid := tools.ParamId(c, "apiKeyId")
+
+	if !valid {
+		return
+	}
+
+	rotatedApiKey, key, err := ah.rotateApiKeyUsecase.Rotate(c.Request.Context(), tenantId, apiKeyId)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ApiKeyToSecretResponse(*rotatedApiKey, key))
+}
+
+func (ah *ApiKeyHandler) Revoke(c *gin.Context) {
+	tenantId, valid := tools.CheckTenantHeader(c)
+
+	if !valid {
+		return
+	}
+
+	apiKeyId, valid := tools.ParamId(c, "apiKeyId")
+
+	if !valid {
+		return
+	}
+
+	err := ah.revokeApiKeyUsecase.Revoke(c.Request.Context(), tenantId, apiKeyId)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Api key with id %d was revoked successfully", apiKeyId)})
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/apikey"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiKeyHandler(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+	createApiKeyUsecase := usecases.NewCreateApiKeyUsecase(mockRepo)
+	findAllApiKeysUsecase := usecases.NewFindAllApiKeysUsecase(mockRepo)
+	rotateApiKeyUsecase := usecases.NewRotateApiKeyUsecase(mockRepo)
+	revokeApiKeyUsecase := usecases.NewRevokeApiKeyUsecase(mockRepo)
+
+	sut := NewApiKeyHandler(createApiKeyUsecase, findAllApiKeysUsecase, rotateApiKeyUsecase,
+		revokeApiKeyUsecase)
+
+	apiKey := infra.ApiKey{
+		ID:       1,
+		TenantID: 1,
+		Name:     "billing",
+		Prefix:   "utk_0a1b2c3d4e5f",
+		Scopes:   []string{shared.ScopeAccountsRead},
+	}
+
+	t.Run("[Create] Invalid scopes", func(t *testing.T) {
+		body, _ := json.Marshal(dto.ApiKeyRequest{Name: "billing", Scopes: []string{"accounts:delete"}})
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("POST", "/api-key", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("tenant-id", "1")
+
+		sut.Create(c)
+		tools.RenderProblem(c)
+
+		var responseBody tools.Problem
+		err := json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
+		assert.Contains(t, responseBody.Errors, tools.FieldError{Field: "scopes", Detail: "unknown scope accounts:delete"})
+	})
+
+	t.Run("[Create] Api key created successfully", func(t *testing.T) {
+		mockRepo.On("CreateApiKey").Return(apiKey, nil)
+		defer mockRepo.On("CreateApiKey").Unset()
+
+		body, _ := json.Marshal(dto.ApiKeyRequest{Name: apiKey.Name, Scopes: apiKey.Scopes})
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("POST", "/api-key", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("tenant-id", "1")
+
+		sut.Create(c)
+		tools.RenderProblem(c)
+
+		var responseBody dto.ApiKeySecretResponse
+		err := json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, res.Result().StatusCode)
+		assert.Equal(t, apiKey.ID, responseBody.ID)
+		assert.NotEmpty(t, responseBody.Key)
+	})
+
+	t.Run("[Revoke] Api key not found", func(t *testing.T) {
+		mockRepo.On("GetApiKey").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetApiKey").Unset()
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("DELETE", "/api-key/1", nil)
+		c.Request.Header.Set("tenant-id", "1")
+		c.Params = []gin.Param{{
+			Key:   "apiKeyId",
+			Value: "1",
+		}}
+
+		sut.Revoke(c)
+		tools.RenderProblem(c)
+
+		var responseBody tools.Problem
+		err := json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusNotFound, res.Result().StatusCode)
+		assert.Equal(t, "api key not found with id 1", responseBody.Detail)
+	})
+
+	t.Run("[RequireScopes] Missing write scope", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		principal := &shared.Principal{TenantId: 1, Scopes: []string{shared.ScopeAccountsRead}}
+
+		c.Request = httptest.NewRequest("DELETE", "/account/1", nil)
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), principal))
+
+		tools.RequireScopes(shared.ScopeAccountsRead, shared.ScopeAccountsWrite)(c)
+		tools.RenderProblem(c)
+
+		var responseBody tools.Problem
+		err := json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.True(t, c.IsAborted())
+		assert.Equal(t, http.StatusForbidden, res.Result().StatusCode)
+		assert.Equal(t, "missing scope accounts:write", responseBody.Detail)
+	})
+}
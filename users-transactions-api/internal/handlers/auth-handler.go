@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	authUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/auth"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/user"
+	"github.com/gin-gonic/gin"
+)
+
+type AuthHandler struct {
+	authenticateUsecase         *authUsecases.AuthenticateUsecase
+	loginUsecase                *usecases.LoginUsecase
+	acceptInvitationUsecase     *usecases.AcceptInvitationUsecase
+	requestPasswordResetUsecase *usecases.RequestPasswordResetUsecase
+	resetPasswordUsecase        *usecases.ResetPasswordUsecase
+}
+
+func NewAuthHandler(authenticateUsecase *authUsecases.AuthenticateUsecase,
+	loginUsecase *usecases.LoginUsecase,
+	acceptInvitationUsecase *usecases.AcceptInvitationUsecase,
+	requestPasswordResetUsecase *usecases.RequestPasswordResetUsecase,
+	resetPasswordUsecase *usecases.ResetPasswordUsecase) *AuthHandler {
+	return &AuthHandler{
+		authenticateUsecase:         authenticateUsecase,
+		loginUsecase:                loginUsecase,
+		acceptInvitationUsecase:     acceptInvitationUsecase,
+		requestPasswordResetUsecase: requestPasswordResetUsecase,
+		resetPasswordUsecase:        resetPasswordUsecase,
+	}
+}
+
+func bearerToken(c *gin.Context) (string, bool) {
+	token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	return token, found && len(token) > 0
+}
+
+func (ah *AuthHandler) Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		credential, found := bearerToken(c)
+
+		if !found {
+			c.Error(&shared.UnauthorizedError{Message: "missing credentials"})
+			c.Abort()
+			return
+		}
+
+		principal, err := ah.authenticateUsecase.Authenticate(c.Request.Context(), credential)
+
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		tenantId, err := strconv.ParseInt(c.GetHeader("tenant-id"), 0, 32)
+
+		if err == nil && int32(tenantId) != principal.TenantId {
+			c.Error(&shared.ForbiddenError{Message: "credentials do not belong to tenant"})
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), principal))
+
+		c.Next()
+	}
+}
+
+func (ah *AuthHandler) Login(c *gin.Context) {
+	tenantId, valid := tools.CheckTenantHeader(c)
+
+	if !valid {
+		return
+	}
+
+	var request dto.LoginRequest
+
+	if !tools.BindJSON(c, &request) {
+		return
+	}
+
+	token, expiresAt, err := ah.loginUsecase.Login(c.Request.Context(), tenantId, request.Email, request.Password)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.LoginResponse{Token: token, ExpiresAt: expiresAt})
+}
+
+func (ah *AuthHandler) Logout(c *gin.Context) {
+	token, found := bearerToken(c)
+
+	if !found {
+		c.Error(&shared.UnauthorizedError{Message: "missing credentials"})
+		return
+	}
+
+	err := ah.loginUsecase.Logout(c.Request.Context(), token)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (ah *AuthHandler) AcceptInvitation(c *gin.Context) {
+	var request dto.SetPasswordRequest
+
+	if !tools.BindJSON(c, &request) {
+		return
+	}
+
+	err := ah.acceptInvitationUsecase.Accept(c.Request.Context(), request.Token, request.Password)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation accepted successfully"})
+}
+
+func (ah *AuthHandler) RequestPasswordReset(c *gin.Context) {
+	tenantId, valid := tools.CheckTenantHeader(c)
+
+	if !valid {
+		return
+	}
+
+	var request dto.PasswordResetRequest
+
+	if !tools.BindJSON(c, &request) {
+		return
+	}
+
+	err := ah.requestPasswordResetUsecase.Request(c.Request.Context(), tenantId, request.Email)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "If the email exists, a reset token was sent to it"})
+}
+
+func (ah *AuthHandler) ResetPassword(c *gin.Context) {
+	var request dto.SetPasswordRequest
+
+	if !tools.BindJSON(c, &request) {
+		return
+	}
+
+	err := ah.resetPasswordUsecase.Reset(c.Request.Context(), request.Token, request.Password)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+}
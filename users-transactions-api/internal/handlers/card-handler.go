@@ -2,11 +2,9 @@ package handlers
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
-	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
 	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/cards"
 	"github.com/gin-gonic/gin"
 )
@@ -33,31 +31,20 @@ func (ch *CardHandler) Create(c *gin.Context) {
 		return
 	}
 
-	accountId, err := strconv.ParseInt(c.Param("accountId"), 0, 32)
+	accountId, valid := tools.ParamId(c, "accountId")
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account id"})
+	if !valid {
 		return
 	}
 
-	savedCard, err := ch.createCardUsecase.Create(tenantId, int32(accountId))
+	savedCard, err := ch.createCardUsecase.Create(c.Request.Context(), tenantId, accountId)
 
 	if err != nil {
-		if enf, ok := err.(*shared.EntityNotFoundError); ok {
-			c.JSON(http.StatusNotFound, gin.H{"error": enf.Error()})
-			return
-		}
-
-		if ia, ok := err.(*shared.InactiveAccountError); ok {
-			c.JSON(http.StatusBadRequest, gin.H{"error": ia.Error()})
-			return
-		}
-
-		tools.LogInternalServerError(c, "card handler", "Create", err)
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, dto.CardToResponse(*savedCard))
+	c.JSON(http.StatusCreated, dto.CardToResponse(*savedCard, tools.GetLocaleFormatter(c)))
 }
 
 func (ch *CardHandler) FindOne(c *gin.Context) {
@@ -67,33 +54,30 @@ func (ch *CardHandler) FindOne(c *gin.Context) {
 		return
 	}
 
-	accountId, err := strconv.ParseInt(c.Param("accountId"), 0, 32)
+	accountId, valid := tools.ParamId(c, "accountId")
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account id"})
+	if !valid {
 		return
 	}
 
-	cardId, err := strconv.ParseInt(c.Param("cardId"), 0, 32)
+	cardId, valid := tools.ParamId(c, "cardId")
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid card id"})
+	if !valid {
 		return
 	}
 
-	card, err := ch.findCardUsecase.FindOne(tenantId, int32(accountId), int32(cardId))
+	card, err := ch.findCardUsecase.FindOne(c.Request.Context(), tenantId, accountId, cardId)
 
 	if err != nil {
-		if enf, ok := err.(*shared.EntityNotFoundError); ok {
-			c.JSON(http.StatusNotFound, gin.H{"error": enf.Error()})
-			return
-		}
+		c.Error(err)
+		return
+	}
 
-		tools.LogInternalServerError(c, "card handler", "Create", err)
+	if tools.NotModified(c, tools.WeakETag(card.Version)) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.CardToResponse(*card))
+	c.JSON(http.StatusOK, dto.CardToResponse(*card, tools.GetLocaleFormatter(c)))
 }
 
 func (ch *CardHandler) FindAll(c *gin.Context) {
@@ -103,28 +87,24 @@ func (ch *CardHandler) FindAll(c *gin.Context) {
 		return
 	}
 
-	accountId, err := strconv.ParseInt(c.Param("accountId"), 0, 32)
+	accountId, valid := tools.ParamId(c, "accountId")
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account id"})
+	if !valid {
 		return
 	}
 
-	cards, err := ch.findAllCardsUsecase.FindAll(tenantId, int32(accountId))
+	cards, err := ch.findAllCardsUsecase.FindAll(c.Request.Context(), tenantId, accountId)
 
 	if err != nil {
-		if enf, ok := err.(*shared.EntityNotFoundError); ok {
-			c.JSON(http.StatusNotFound, gin.H{"error": enf.Error()})
-			return
-		}
-
-		tools.LogInternalServerError(c, "card handler", "Create", err)
+		c.Error(err)
 		return
 	}
 
+	formatter := tools.GetLocaleFormatter(c)
+
 	cardsResponse := make([]dto.CardResponse, 0)
 	for _, card := range cards {
-		cardsResponse = append(cardsResponse, dto.CardToResponse(card))
+		cardsResponse = append(cardsResponse, dto.CardToResponse(card, formatter))
 	}
 
 	c.JSON(http.StatusOK, cardsResponse)
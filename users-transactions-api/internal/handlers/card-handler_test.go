@@ -9,10 +9,13 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
 	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
 	accountUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/account"
 	cardUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/cards"
+	quotaUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/quota"
+	settingsUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/settings"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
@@ -22,8 +25,13 @@ func TestCardHandler(t *testing.T) {
 
 	mockRepo := new(mocks.MockRepository)
 
+	// tenants without settings are not limited by quotas
+	mockRepo.On("GetTenantSettings").Return(nil, sql.ErrNoRows)
+	findTenantSettingsUsecase := settingsUsecases.NewFindTenantSettingsUsecase(mockRepo, settingsUsecases.NewSettingsCache(0))
+	quotaUsecase := quotaUsecases.NewQuotaUsecase(findTenantSettingsUsecase)
+
 	findOneAccountUsecase := accountUsecases.NewFindOneAccountUsecase(mockRepo)
-	createCardUsecase := cardUsecases.NewCreateCardUsecase(mockRepo, findOneAccountUsecase)
+	createCardUsecase := cardUsecases.NewCreateCardUsecase(mockRepo, quotaUsecase)
 	findCardUsecase := cardUsecases.NewFindCardUsecase(mockRepo, findOneAccountUsecase)
 	findAllCardsUsecase := cardUsecases.NewFindAllCards(mockRepo, findOneAccountUsecase)
 
@@ -50,14 +58,15 @@ func TestCardHandler(t *testing.T) {
 		c.Request.Header.Set("tenant-id", "invalid")
 
 		sut.Create(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, "Invalid tenant-id", responseBody["error"])
+		assert.Contains(t, responseBody.Errors, tools.FieldError{Field: "tenant-id", Detail: "must be a valid id"})
 	})
 
 	t.Run("[Create] invalid account id", func(t *testing.T) {
@@ -73,19 +82,20 @@ func TestCardHandler(t *testing.T) {
 		}}
 
 		sut.Create(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, "Invalid account id", responseBody["error"])
+		assert.Contains(t, responseBody.Errors, tools.FieldError{Field: "accountId", Detail: "must be a valid id"})
 	})
 
 	t.Run("[Create] Internal server error", func(t *testing.T) {
-		mockRepo.On("GetAccount").Return(nil, errors.New("Internal server error"))
-		defer mockRepo.On("GetAccount").Unset()
+		mockRepo.On("GetAccountForShare").Return(nil, errors.New("Internal server error"))
+		defer mockRepo.On("GetAccountForShare").Unset()
 
 		res := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(res)
@@ -99,19 +109,20 @@ func TestCardHandler(t *testing.T) {
 		}}
 
 		sut.Create(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusInternalServerError, res.Result().StatusCode)
-		assert.Equal(t, "Internal Server Error", responseBody["error"])
+		assert.Equal(t, "Internal Server Error", responseBody.Title)
 	})
 
 	t.Run("[Create] Error account not found", func(t *testing.T) {
-		mockRepo.On("GetAccount").Return(nil, sql.ErrNoRows)
-		defer mockRepo.On("GetAccount").Unset()
+		mockRepo.On("GetAccountForShare").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetAccountForShare").Unset()
 
 		accountId := "1"
 
@@ -127,22 +138,23 @@ func TestCardHandler(t *testing.T) {
 		}}
 
 		sut.Create(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusNotFound, res.Result().StatusCode)
 		assert.Equal(t, fmt.Sprintf("account not found with id %s", accountId),
-			responseBody["error"])
+			responseBody.Detail)
 	})
 
-	t.Run("[Create] Error account inactive", func(t *testing.T) {
-		account.Status = "inactive"
+	t.Run("[Create] Error account frozen", func(t *testing.T) {
+		account.Status = "frozen"
 
-		mockRepo.On("GetAccount").Return(account, nil)
-		defer mockRepo.On("GetAccount").Unset()
+		mockRepo.On("GetAccountForShare").Return(account, nil)
+		defer mockRepo.On("GetAccountForShare").Unset()
 
 		res := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(res)
@@ -156,22 +168,23 @@ func TestCardHandler(t *testing.T) {
 		}}
 
 		sut.Create(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, "Card cannot be created to an inactive account",
-			responseBody["error"])
+		assert.Equal(t, http.StatusConflict, res.Result().StatusCode)
+		assert.Equal(t, "card creation is not allowed for a frozen account",
+			responseBody.Detail)
 	})
 
 	t.Run("[Create] Card created successfully", func(t *testing.T) {
 		account.Status = "active"
 
-		mockRepo.On("GetAccount").Return(account, nil)
-		defer mockRepo.On("GetAccount").Unset()
+		mockRepo.On("GetAccountForShare").Return(account, nil)
+		defer mockRepo.On("GetAccountForShare").Unset()
 
 		mockRepo.On("CreateCard").Return(card, nil)
 		defer mockRepo.On("CreateCard").Unset()
@@ -188,6 +201,7 @@ func TestCardHandler(t *testing.T) {
 		}}
 
 		sut.Create(c)
+		tools.RenderProblem(c)
 
 		var responseBody infra.Card
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
@@ -215,14 +229,15 @@ func TestCardHandler(t *testing.T) {
 			}}
 
 		sut.FindOne(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, "Invalid card id", responseBody["error"])
+		assert.Contains(t, responseBody.Errors, tools.FieldError{Field: "cardId", Detail: "must be a valid id"})
 	})
 
 	t.Run("[FindCard] Error account not found", func(t *testing.T) {
@@ -247,15 +262,16 @@ func TestCardHandler(t *testing.T) {
 			}}
 
 		sut.FindOne(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusNotFound, res.Result().StatusCode)
 		assert.Equal(t, fmt.Sprintf("account not found with id %s", accountId),
-			responseBody["error"])
+			responseBody.Detail)
 	})
 
 	t.Run("[FindCard] Success to find card by id", func(t *testing.T) {
@@ -283,6 +299,7 @@ func TestCardHandler(t *testing.T) {
 			}}
 
 		sut.FindOne(c)
+		tools.RenderProblem(c)
 
 		var responseBody infra.Card
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
@@ -293,6 +310,39 @@ func TestCardHandler(t *testing.T) {
 		assert.Equal(t, card, responseBody)
 	})
 
+	t.Run("[FindCard] Not modified", func(t *testing.T) {
+		account.Status = "active"
+		versionedCard := card
+		versionedCard.Version = 4
+
+		mockRepo.On("GetAccount").Return(account, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetCard").Return(versionedCard, nil)
+		defer mockRepo.On("GetCard").Unset()
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("GET", "/card", nil)
+		c.Request.Header.Set("tenant-id", "1")
+		c.Request.Header.Set("If-None-Match", `"4"`)
+		c.Params = []gin.Param{{
+			Key:   "accountId",
+			Value: fmt.Sprint(account.ID),
+		},
+			{
+				Key:   "cardId",
+				Value: fmt.Sprint(card.ID),
+			}}
+
+		sut.FindOne(c)
+		tools.RenderProblem(c)
+
+		assert.Equal(t, http.StatusNotModified, c.Writer.Status())
+		assert.Equal(t, `W/"4"`, res.Header().Get("ETag"))
+	})
+
 	t.Run("[FindAll] Invalid tenant id", func(t *testing.T) {
 		res := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(res)
@@ -302,14 +352,15 @@ func TestCardHandler(t *testing.T) {
 		c.Request.Header.Set("tenant-id", "invalid")
 
 		sut.FindAll(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, "Invalid tenant-id", responseBody["error"])
+		assert.Contains(t, responseBody.Errors, tools.FieldError{Field: "tenant-id", Detail: "must be a valid id"})
 	})
 
 	t.Run("[FindAll] Invalid account id", func(t *testing.T) {
@@ -325,14 +376,15 @@ func TestCardHandler(t *testing.T) {
 		}}
 
 		sut.FindAll(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
-		assert.Equal(t, "Invalid account id", responseBody["error"])
+		assert.Contains(t, responseBody.Errors, tools.FieldError{Field: "accountId", Detail: "must be a valid id"})
 	})
 
 	t.Run("[FindAll] Internal server error", func(t *testing.T) {
@@ -351,14 +403,15 @@ func TestCardHandler(t *testing.T) {
 		}}
 
 		sut.FindAll(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusInternalServerError, res.Result().StatusCode)
-		assert.Equal(t, "Internal Server Error", responseBody["error"])
+		assert.Equal(t, "Internal Server Error", responseBody.Title)
 	})
 
 	t.Run("[FindAll] Account not found error", func(t *testing.T) {
@@ -377,15 +430,16 @@ func TestCardHandler(t *testing.T) {
 		}}
 
 		sut.FindAll(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusNotFound, res.Result().StatusCode)
 		assert.Equal(t, fmt.Sprintf("account not found with id %d", account.ID),
-			responseBody["error"])
+			responseBody.Detail)
 	})
 
 	t.Run("[FindAll] Success", func(t *testing.T) {
@@ -411,6 +465,7 @@ func TestCardHandler(t *testing.T) {
 		}}
 
 		sut.FindAll(c)
+		tools.RenderProblem(c)
 
 		var responseBody []infra.Card
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
@@ -0,0 +1,37 @@
+package docs
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+var swaggerUI = renderSwaggerUI(info.Title)
+
+var (
+	spec     []byte
+	specErr  error
+	specOnce sync.Once
+)
+
+// Spec is the OpenAPI document of the service, built once from the
+// operations and the dto types.
+func Spec() ([]byte, error) {
+	specOnce.Do(func() {
+		spec, specErr = build(info, tags, operations)
+	})
+
+	return spec, specErr
+}
+
+func OpenAPI(c *gin.Context) {
+	document, err := Spec()
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", document)
+}
@@ -0,0 +1,187 @@
+package docs
+
+import (
+	"net/http"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+const baseUrl = "/api/v1"
+
+var info = Info{
+	Title: "Users Transactions API",
+	Description: "Multi-tenant accounts, cards and transactions. Errors are answered as " +
+		"application/problem+json, see the Problem schema.",
+	Version: "1.0.0",
+}
+
+var tags = []Tag{
+	{Name: "docs", Description: "This document and its viewer."},
+	{Name: "health", Description: "Service checks and metrics."},
+	{Name: "auth", Description: "Sessions, invitations and password resets."},
+	{Name: "admin", Description: "Tenant management and operations, only for the platform admin."},
+	{Name: "settings", Description: "Tenant settings and logo."},
+	{Name: "users", Description: "Users of the tenant."},
+	{Name: "api-keys", Description: "Api keys of the tenant."},
+	{Name: "accounts", Description: "Accounts and their status."},
+	{Name: "privacy", Description: "LGPD data subject requests."},
+	{Name: "cards", Description: "Cards of an account."},
+	{Name: "transactions", Description: "Transactions of a card."},
+}
+
+var logo = content{
+	"image/png":  binary,
+	"image/jpeg": binary,
+}
+
+// operations must list every route of router.Routes, a test fails when one
+// is missing.
+var operations = []operation{
+	{method: http.MethodGet, path: baseUrl + "/openapi.json", tag: "docs", summary: "This document",
+		status: http.StatusOK, response: content{"application/json": &Schema{Type: "object"}}},
+	{method: http.MethodGet, path: baseUrl + "/docs", tag: "docs", summary: "Swagger UI",
+		status: http.StatusOK, response: content{"text/html": &Schema{Type: "string"}}},
+	{method: http.MethodGet, path: baseUrl + "/docs/swagger-ui.css", tag: "docs", summary: "Swagger UI stylesheet",
+		status: http.StatusOK, response: content{"text/css": &Schema{Type: "string"}}},
+	{method: http.MethodGet, path: baseUrl + "/docs/swagger-ui-bundle.js", tag: "docs", summary: "Swagger UI script",
+		status: http.StatusOK, response: content{"text/javascript": &Schema{Type: "string"}}},
+	{method: http.MethodGet, path: baseUrl + "/ping", tag: "health", summary: "Liveness check",
+		status: http.StatusOK, response: content{"application/json": &Schema{Type: "string"}}},
+	{method: http.MethodGet, path: "/metrics", tag: "health", summary: "Prometheus metrics",
+		status: http.StatusOK, response: content{"text/plain": &Schema{Type: "string"}}},
+	{method: http.MethodGet, path: "/healthz", tag: "health", summary: "Liveness probe",
+		status: http.StatusOK, response: dto.HealthResponse{}},
+	{method: http.MethodGet, path: "/readyz", tag: "health",
+		summary: "Readiness probe, answered with 503 while the database or its migrations are not ready",
+		status:  http.StatusOK, response: dto.HealthResponse{}},
+
+	{method: http.MethodPost, path: baseUrl + "/auth/login", tag: "auth", summary: "Log in with email and password",
+		tenantHeader: true, request: dto.LoginRequest{}, status: http.StatusOK, response: dto.LoginResponse{}},
+	{method: http.MethodPost, path: baseUrl + "/auth/logout", tag: "auth", summary: "Revoke the session token",
+		access: session, status: http.StatusNoContent},
+	{method: http.MethodPost, path: baseUrl + "/auth/invitation/accept", tag: "auth",
+		summary: "Set the password of an invited user", request: dto.SetPasswordRequest{},
+		status: http.StatusOK, response: Message{}},
+	{method: http.MethodPost, path: baseUrl + "/auth/password-reset", tag: "auth",
+		summary: "Send a password reset token", tenantHeader: true, request: dto.PasswordResetRequest{},
+		status: http.StatusAccepted, response: Message{}},
+	{method: http.MethodPost, path: baseUrl + "/auth/password-reset/confirm", tag: "auth",
+		summary: "Set a new password with a reset token", request: dto.SetPasswordRequest{},
+		status: http.StatusOK, response: Message{}},
+
+	{method: http.MethodPost, path: baseUrl + "/admin/tenant", tag: "admin", summary: "Create a tenant and its owner",
+		access: platformAdmin, request: dto.TenantRequest{}, status: http.StatusCreated,
+		response: dto.CreatedTenantResponse{}},
+	{method: http.MethodGet, path: baseUrl + "/admin/tenant", tag: "admin", summary: "List the tenants",
+		access: platformAdmin, status: http.StatusOK, response: []dto.TenantResponse{}},
+	{method: http.MethodPut, path: baseUrl + "/admin/tenant/:tenantId", tag: "admin", summary: "Rename a tenant",
+		access: platformAdmin, request: dto.RenameTenantRequest{}, status: http.StatusOK,
+		response: dto.TenantResponse{}},
+	{method: http.MethodPost, path: baseUrl + "/admin/tenant/:tenantId/suspend", tag: "admin",
+		summary: "Suspend a tenant", access: platformAdmin, status: http.StatusOK, response: dto.TenantResponse{}},
+	{method: http.MethodPost, path: baseUrl + "/admin/tenant/:tenantId/reactivate", tag: "admin",
+		summary: "Reactivate a tenant", access: platformAdmin, status: http.StatusOK, response: dto.TenantResponse{}},
+	{method: http.MethodPut, path: baseUrl + "/admin/tenant/:tenantId/limits", tag: "admin",
+		summary: "Update the rate limit and quotas of a tenant", access: platformAdmin,
+		request: dto.TenantLimitsRequest{}, status: http.StatusOK, response: dto.TenantSettingsResponse{}},
+	{method: http.MethodGet, path: baseUrl + "/admin/log-level", tag: "admin", summary: "Find the log level",
+		access: platformAdmin, status: http.StatusOK, response: dto.LogLevelResponse{}},
+	{method: http.MethodPut, path: baseUrl + "/admin/log-level", tag: "admin",
+		summary: "Change the log level until the next restart", access: platformAdmin,
+		request: dto.LogLevelRequest{}, status: http.StatusOK, response: dto.LogLevelResponse{}},
+
+	{method: http.MethodGet, path: baseUrl + "/settings", tag: "settings", summary: "Find the settings",
+		access: tenant, scope: shared.ScopeSettingsRead, status: http.StatusOK,
+		response: dto.TenantSettingsResponse{}},
+	{method: http.MethodPut, path: baseUrl + "/settings", tag: "settings", summary: "Update the settings",
+		access: tenant, scope: shared.ScopeSettingsWrite, request: dto.TenantSettingsRequest{},
+		status: http.StatusOK, response: dto.TenantSettingsResponse{}},
+	{method: http.MethodGet, path: baseUrl + "/settings/logo", tag: "settings", summary: "Download the logo",
+		access: tenant, scope: shared.ScopeSettingsRead, status: http.StatusOK, response: logo},
+	{method: http.MethodPut, path: baseUrl + "/settings/logo", tag: "settings",
+		summary: "Upload a PNG or JPEG logo", access: tenant, scope: shared.ScopeSettingsWrite,
+		request: logo, status: http.StatusNoContent},
+	{method: http.MethodDelete, path: baseUrl + "/settings/logo", tag: "settings", summary: "Delete the logo",
+		access: tenant, scope: shared.ScopeSettingsWrite, status: http.StatusNoContent},
+
+	{method: http.MethodPost, path: baseUrl + "/user", tag: "users", summary: "Invite a user",
+		access: tenant, scope: shared.ScopeUsersWrite, request: dto.InviteUserRequest{},
+		status: http.StatusCreated, response: dto.UserResponse{}},
+	{method: http.MethodGet, path: baseUrl + "/user", tag: "users", summary: "List the users",
+		access: tenant, scope: shared.ScopeUsersRead, status: http.StatusOK, response: []dto.UserResponse{}},
+	{method: http.MethodPut, path: baseUrl + "/user/:userId/role", tag: "users", summary: "Change the role of a user",
+		access: tenant, scope: shared.ScopeUsersWrite, request: dto.UpdateUserRoleRequest{},
+		status: http.StatusOK, response: dto.UserResponse{}},
+	{method: http.MethodDelete, path: baseUrl + "/user/:userId", tag: "users", summary: "Disable a user",
+		access: tenant, scope: shared.ScopeUsersWrite, status: http.StatusOK, response: Message{}},
+
+	{method: http.MethodPost, path: baseUrl + "/api-key", tag: "api-keys",
+		summary: "Create an api key, the key is only shown once", access: tenant, scope: shared.ScopeApiKeysWrite,
+		request: dto.ApiKeyRequest{}, status: http.StatusCreated, response: dto.ApiKeySecretResponse{}},
+	{method: http.MethodGet, path: baseUrl + "/api-key", tag: "api-keys", summary: "List the api keys",
+		access: tenant, scope: shared.ScopeApiKeysRead, status: http.StatusOK, response: []dto.ApiKeyResponse{}},
+	{method: http.MethodPost, path: baseUrl + "/api-key/:apiKeyId/rotate", tag: "api-keys",
+		summary: "Replace the secret of an api key", access: tenant, scope: shared.ScopeApiKeysWrite,
+		status: http.StatusOK, response: dto.ApiKeySecretResponse{}},
+	{method: http.MethodDelete, path: baseUrl + "/api-key/:apiKeyId", tag: "api-keys", summary: "Revoke an api key",
+		access: tenant, scope: shared.ScopeApiKeysWrite, status: http.StatusOK, response: Message{}},
+
+	{method: http.MethodPost, path: baseUrl + "/account", tag: "accounts", summary: "Create an account",
+		access: tenant, scope: shared.ScopeAccountsWrite, request: dto.AccountRequest{},
+		optionalBody: true, status: http.StatusCreated, response: dto.AccountResponse{}},
+	{method: http.MethodGet, path: baseUrl + "/account/:accountId", tag: "accounts", summary: "Find an account",
+		access: tenant, scope: shared.ScopeAccountsRead, ifNoneMatch: true, status: http.StatusOK,
+		response: dto.AccountResponse{}},
+	{method: http.MethodGet, path: baseUrl + "/account", tag: "accounts", summary: "List the accounts",
+		access: tenant, scope: shared.ScopeAccountsRead, status: http.StatusOK, response: []dto.AccountResponse{}},
+	{method: http.MethodPut, path: baseUrl + "/account/:accountId", tag: "accounts", summary: "Activate an account",
+		access: tenant, scope: shared.ScopeAccountsWrite, ifMatch: true, status: http.StatusOK,
+		response: dto.AccountResponse{}},
+	{method: http.MethodDelete, path: baseUrl + "/account/:accountId", tag: "accounts", summary: "Freeze an account",
+		access: tenant, scope: shared.ScopeAccountsWrite, ifMatch: true, status: http.StatusOK,
+		response: dto.AccountResponse{}},
+	{method: http.MethodPost, path: baseUrl + "/account/:accountId/status", tag: "accounts",
+		summary: "Change the status of an account", access: tenant, scope: shared.ScopeAccountsWrite,
+		ifMatch: true, request: dto.AccountStatusRequest{}, status: http.StatusOK, response: dto.AccountResponse{}},
+	{method: http.MethodGet, path: baseUrl + "/account/:accountId/status", tag: "accounts",
+		summary: "List the status changes of an account", access: tenant, scope: shared.ScopeAccountsRead,
+		status: http.StatusOK, response: []dto.AccountStatusHistoryResponse{}},
+
+	{method: http.MethodGet, path: baseUrl + "/privacy/account/:accountId/export", tag: "privacy",
+		summary: "Export the data of an account", access: tenant, scope: shared.ScopePrivacyRead,
+		query: []Parameter{{
+			Name:   "format",
+			In:     "query",
+			Schema: &Schema{Type: "string", Enum: []string{"json", "zip"}},
+		}},
+		status: http.StatusOK, response: content{
+			"application/json": dto.AccountDataExportResponse{},
+			"application/zip":  binary,
+		}},
+	{method: http.MethodPost, path: baseUrl + "/privacy/account/:accountId/erasure", tag: "privacy",
+		summary: "Erase the personal data of an account", access: tenant, scope: shared.ScopePrivacyWrite,
+		request: dto.ErasureRequest{}, status: http.StatusOK, response: dto.DataSubjectRequestResponse{}},
+	{method: http.MethodGet, path: baseUrl + "/privacy/account/:accountId/requests", tag: "privacy",
+		summary: "List the data subject requests of an account", access: tenant, scope: shared.ScopePrivacyRead,
+		status: http.StatusOK, response: []dto.DataSubjectRequestResponse{}},
+
+	{method: http.MethodPost, path: baseUrl + "/card/:accountId", tag: "cards", summary: "Create a card",
+		access: tenant, scope: shared.ScopeCardsWrite, status: http.StatusCreated, response: dto.CardResponse{}},
+	{method: http.MethodGet, path: baseUrl + "/card/:cardId/account/:accountId", tag: "cards",
+		summary: "Find a card", access: tenant, scope: shared.ScopeCardsRead, ifNoneMatch: true,
+		status: http.StatusOK, response: dto.CardResponse{}},
+	{method: http.MethodGet, path: baseUrl + "/card/account/:accountId", tag: "cards",
+		summary: "List the cards of an account", access: tenant, scope: shared.ScopeCardsRead,
+		status: http.StatusOK, response: []dto.CardResponse{}},
+
+	{method: http.MethodPost, path: baseUrl + "/transaction/account/:accountId", tag: "transactions",
+		summary: "Create a transaction", access: tenant, scope: shared.ScopeTransactionsWrite,
+		request: dto.TransactioRequest{}, status: http.StatusCreated, response: dto.TransactionResponse{}},
+	{method: http.MethodGet, path: baseUrl + "/transaction/:transactionId/account/:accountId/card/:cardId",
+		tag: "transactions", summary: "Find a transaction", access: tenant, scope: shared.ScopeTransactionsRead,
+		status: http.StatusOK, response: dto.TransactionResponse{}},
+	{method: http.MethodGet, path: baseUrl + "/transaction/account/:accountId/card/:cardId", tag: "transactions",
+		summary: "List the transactions of a card", access: tenant, scope: shared.ScopeTransactionsRead,
+		status: http.StatusOK, response: []dto.TransactionResponse{}},
+}
@@ -0,0 +1,194 @@
+package docs
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/validation"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemas turns the dto structs into components, reading the same json and
+// validate tags the handlers bind with so the spec cannot drift from them.
+type schemas map[string]*Schema
+
+func (s schemas) ref(value any) *Schema {
+	return s.of(reflect.TypeOf(value))
+}
+
+func (s schemas) of(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Pointer:
+		schema := s.of(t.Elem())
+		// siblings of $ref are ignored in 3.0, so only inline schemas are
+		// marked nullable
+		schema.Nullable = len(schema.Ref) == 0
+		return schema
+	case t.Kind() == reflect.Slice:
+		return &Schema{Type: "array", Items: s.of(t.Elem())}
+	case t.Kind() == reflect.Map:
+		return &Schema{Type: "object"}
+	case t.Kind() == reflect.Struct:
+		if _, found := s[t.Name()]; !found {
+			// registered before the fields so recursive types end
+			s[t.Name()] = &Schema{}
+			*s[t.Name()] = *s.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	case t.Kind() == reflect.String:
+		return &Schema{Type: "string"}
+	case t.Kind() == reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case t.Kind() == reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return &Schema{Type: "number"}
+	}
+
+	return &Schema{}
+}
+
+func (s schemas) object(t reflect.Type) *Schema {
+	object := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+		if name == "-" {
+			continue
+		}
+
+		// embedded responses are flattened like encoding/json does
+		if field.Anonymous && len(name) == 0 {
+			embedded := s.object(field.Type)
+			for property, schema := range embedded.Properties {
+				object.Properties[property] = schema
+			}
+			object.Required = append(object.Required, embedded.Required...)
+			continue
+		}
+
+		if len(name) == 0 {
+			name = field.Name
+		}
+
+		schema := s.of(field.Type)
+
+		if constrain(schema, field.Tag.Get("validate")) {
+			object.Required = append(object.Required, name)
+		}
+
+		object.Properties[name] = schema
+	}
+
+	return object
+}
+
+// constrain documents the validate rules of a field and tells whether it is
+// required. Rules after dive apply to the items of a list.
+func constrain(schema *Schema, tag string) bool {
+	required := false
+	target := schema
+
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+
+		switch name {
+		case "required":
+			if target == schema {
+				required = true
+			}
+		case "dive":
+			if schema.Items != nil {
+				target = schema.Items
+			}
+		case "min", "gte":
+			setBound(target, param, true)
+		case "max", "lte":
+			setBound(target, param, false)
+		case "oneof":
+			target.Enum = strings.Fields(param)
+		case "email":
+			target.Format = "email"
+		case "iso4217":
+			target.Pattern = "^[A-Z]{3}$"
+			target.Description = "ISO 4217 currency code, like BRL"
+		case "timezone":
+			target.Description = "IANA time zone, like America/Sao_Paulo"
+		case "bcp47_language_tag":
+			target.Description = "BCP 47 language tag, like pt-BR"
+		case "hex_color":
+			target.Pattern = "^#[0-9A-Fa-f]{6}$"
+		case "account_status":
+			target.Enum = []string{shared.AccountStatusPending, shared.AccountStatusActive,
+				shared.AccountStatusFrozen, shared.AccountStatusSuspended, shared.AccountStatusClosed}
+		case "role":
+			target.Enum = []string{shared.RoleOwner, shared.RoleAdmin, shared.RoleOperator, shared.RoleViewer}
+		case "scope":
+			target.Enum = scopes()
+		case "transaction_kind":
+			setBound(target, "1", true)
+			setBound(target, strconv.Itoa(validation.MaxTransactionKindLength), false)
+			target.Description = "printable text without leading or trailing spaces"
+		case "positive_money":
+			setBound(target, "1", true)
+			target.Description = "amount in cents"
+		case "rate_limit_burst":
+			target.Description = "at least 1 when rate_limit_per_second is set, 0 disables the limit"
+		case "existing_card":
+			target.Description = "a card of the account in the path"
+		}
+	}
+
+	return required
+}
+
+func setBound(schema *Schema, param string, lower bool) {
+	value, err := strconv.ParseInt(param, 10, 64)
+
+	if err != nil {
+		return
+	}
+
+	switch {
+	case schema.Type == "string" && lower:
+		schema.MinLength = &value
+	case schema.Type == "string":
+		schema.MaxLength = &value
+	case schema.Type == "array" && lower:
+		schema.MinItems = &value
+	case schema.Type == "array":
+		schema.MaxItems = &value
+	case lower:
+		schema.Minimum = &value
+	default:
+		schema.Maximum = &value
+	}
+}
+
+func scopes() []string {
+	return []string{
+		shared.ScopeAccountsRead, shared.ScopeAccountsWrite,
+		shared.ScopeCardsRead, shared.ScopeCardsWrite,
+		shared.ScopeTransactionsRead, shared.ScopeTransactionsWrite,
+		shared.ScopeReportsRead,
+		shared.ScopeApiKeysRead, shared.ScopeApiKeysWrite,
+		shared.ScopeUsersRead, shared.ScopeUsersWrite,
+		shared.ScopeSettingsRead, shared.ScopeSettingsWrite,
+		shared.ScopePrivacyRead, shared.ScopePrivacyWrite,
+	}
+}
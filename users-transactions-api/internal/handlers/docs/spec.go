@@ -0,0 +1,291 @@
+package docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+type access int
+
+const (
+	public access = iota
+	// session routes take a session token or an api key
+	session
+	// tenant routes also take the tenant header and answer the rate limit
+	// and quota problems
+	tenant
+	platformAdmin
+)
+
+// operation describes one route of the router, path is the gin path.
+type operation struct {
+	method       string
+	path         string
+	tag          string
+	summary      string
+	access       access
+	tenantHeader bool
+	scope        string
+	query        []Parameter
+	request      any
+	optionalBody bool
+	status       int
+	response     any
+	ifMatch      bool
+	ifNoneMatch  bool
+}
+
+// content documents a body by media type, the values are either a *Schema
+// or a dto.
+type content map[string]any
+
+// Message is the body of the routes that only confirm an action.
+type Message struct {
+	Message string `json:"message"`
+}
+
+var (
+	pathParam = regexp.MustCompile(`:([A-Za-z]+)`)
+
+	binary = &Schema{Type: "string", Format: "binary"}
+)
+
+// Path converts a gin path to its OpenAPI template, :accountId becomes
+// {accountId}.
+func Path(ginPath string) string {
+	return pathParam.ReplaceAllString(ginPath, "{$1}")
+}
+
+func build(info Info, tags []Tag, operations []operation) ([]byte, error) {
+	s := make(schemas)
+	problem := s.ref(tools.Problem{})
+
+	document := Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Tags:    tags,
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			Schemas: s,
+			Parameters: map[string]Parameter{
+				"RequestId": {
+					Name:        shared.RequestIdHeader,
+					In:          "header",
+					Description: "Correlates the logs of the request, one is created when missing.",
+					Schema:      &Schema{Type: "string", Pattern: "^[A-Za-z0-9._:-]{1,128}$"},
+				},
+				"TenantId": {
+					Name:        "tenant-id",
+					In:          "header",
+					Description: "Id of the tenant the request acts on.",
+					Required:    true,
+					Schema:      &Schema{Type: "integer", Format: "int32", Minimum: int64Pointer(1)},
+				},
+			},
+			Responses: map[string]Response{
+				"Problem": {
+					Description: "RFC 7807 problem, the type tells the kind of error.",
+					Content:     map[string]MediaType{tools.ProblemContentType: {Schema: problem}},
+				},
+			},
+			SecuritySchemes: map[string]SecurityScheme{
+				"credential": {
+					Type:        "http",
+					Scheme:      "bearer",
+					Description: "A session token from /auth/login or an api key of the tenant.",
+				},
+				"platformAdmin": {
+					Type:        "http",
+					Scheme:      "bearer",
+					Description: "The platform admin token, it is not bound to any tenant.",
+				},
+			},
+		},
+	}
+
+	for _, op := range operations {
+		path := Path(op.path)
+
+		if _, found := document.Paths[path]; !found {
+			document.Paths[path] = make(PathItem)
+		}
+
+		document.Paths[path][strings.ToLower(op.method)] = op.build(s)
+	}
+
+	return json.MarshalIndent(document, "", "  ")
+}
+
+func (op operation) build(s schemas) *Operation {
+	result := &Operation{
+		Tags:        []string{op.tag},
+		Summary:     op.summary,
+		OperationId: operationId(op.method, op.path),
+		Responses:   make(map[string]Response),
+	}
+
+	if len(op.scope) > 0 {
+		result.Summary = fmt.Sprintf("%s (scope %s)", op.summary, op.scope)
+	}
+
+	switch op.access {
+	case session, tenant:
+		result.Security = []map[string][]string{{"credential": {}}}
+	case platformAdmin:
+		result.Security = []map[string][]string{{"platformAdmin": {}}}
+	}
+
+	result.Parameters = append(result.Parameters, Parameter{Ref: "#/components/parameters/RequestId"})
+
+	if op.access == tenant || op.tenantHeader {
+		result.Parameters = append(result.Parameters, Parameter{Ref: "#/components/parameters/TenantId"})
+	}
+
+	for _, match := range pathParam.FindAllStringSubmatch(op.path, -1) {
+		result.Parameters = append(result.Parameters, Parameter{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "integer", Format: "int32", Minimum: int64Pointer(1)},
+		})
+	}
+
+	result.Parameters = append(result.Parameters, op.query...)
+
+	if op.ifMatch {
+		result.Parameters = append(result.Parameters, Parameter{
+			Name:        "If-Match",
+			In:          "header",
+			Description: "Strong ETag of the last read, the change fails with 412 when it is stale.",
+			Schema:      &Schema{Type: "string"},
+		})
+	}
+
+	if op.ifNoneMatch {
+		result.Parameters = append(result.Parameters, Parameter{
+			Name:        "If-None-Match",
+			In:          "header",
+			Description: "ETags already cached by the client.",
+			Schema:      &Schema{Type: "string"},
+		})
+	}
+
+	if op.request != nil {
+		result.RequestBody = &RequestBody{Required: !op.optionalBody, Content: s.content(op.request)}
+	}
+
+	success := Response{Description: http.StatusText(op.status)}
+
+	if op.response != nil {
+		success.Content = s.content(op.response)
+	}
+
+	if op.ifMatch || op.ifNoneMatch {
+		success.Headers = map[string]Header{
+			"ETag": {Description: "Version of the resource.", Schema: &Schema{Type: "string"}},
+		}
+	}
+
+	result.Responses[strconv.Itoa(op.status)] = success
+
+	if op.ifNoneMatch {
+		result.Responses[strconv.Itoa(http.StatusNotModified)] = Response{
+			Description: http.StatusText(http.StatusNotModified),
+		}
+	}
+
+	for _, status := range op.problems() {
+		result.Responses[strconv.Itoa(status)] = Response{Ref: "#/components/responses/Problem"}
+	}
+
+	return result
+}
+
+// problems lists the error statuses the route can answer.
+func (op operation) problems() []int {
+	statuses := map[int]struct{}{http.StatusInternalServerError: {}}
+
+	if op.request != nil || len(op.query) > 0 || strings.Contains(op.path, ":") ||
+		op.access == tenant || op.tenantHeader {
+		statuses[http.StatusBadRequest] = struct{}{}
+	}
+
+	if op.access != public {
+		statuses[http.StatusUnauthorized] = struct{}{}
+	}
+
+	if op.access == tenant {
+		statuses[http.StatusForbidden] = struct{}{}
+		statuses[http.StatusNotFound] = struct{}{}
+		statuses[http.StatusTooManyRequests] = struct{}{}
+	}
+
+	if strings.Contains(op.path, ":") {
+		statuses[http.StatusNotFound] = struct{}{}
+	}
+
+	if op.method != http.MethodGet && op.access != public {
+		statuses[http.StatusConflict] = struct{}{}
+	}
+
+	if op.ifMatch {
+		statuses[http.StatusPreconditionFailed] = struct{}{}
+	}
+
+	result := make([]int, 0, len(statuses))
+	for status := range statuses {
+		result = append(result, status)
+	}
+	sort.Ints(result)
+
+	return result
+}
+
+func (s schemas) content(body any) map[string]MediaType {
+	c, ok := body.(content)
+
+	if !ok {
+		c = content{"application/json": body}
+	}
+
+	result := make(map[string]MediaType, len(c))
+
+	for mediaType, value := range c {
+		schema, ok := value.(*Schema)
+
+		if !ok {
+			schema = s.ref(value)
+		}
+
+		result[mediaType] = MediaType{Schema: schema}
+	}
+
+	return result
+}
+
+// operationId is built from the path, like get_account_accountId_status.
+func operationId(method string, ginPath string) string {
+	path := strings.TrimPrefix(ginPath, baseUrl)
+	path = strings.NewReplacer(":", "", "-", "_", ".", "_").Replace(path)
+
+	parts := []string{strings.ToLower(method)}
+	for _, part := range strings.Split(path, "/") {
+		if len(part) > 0 {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, "_")
+}
+
+func int64Pointer(value int64) *int64 {
+	return &value
+}
@@ -0,0 +1,53 @@
+package docs
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"mime"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+)
+
+// The files of swagger-ui-dist 5.18.2 (Apache-2.0, see swagger-ui/LICENSE)
+// are served by the service, the page loads nothing from a CDN.
+//
+//go:embed swagger-ui.html swagger-ui
+var swaggerFiles embed.FS
+
+// SwaggerAssets are the files the Swagger UI page loads, each is served
+// under /docs/.
+var SwaggerAssets = []string{"swagger-ui.css", "swagger-ui-bundle.js"}
+
+// renderSwaggerUI fills the page with the title of the service.
+func renderSwaggerUI(title string) []byte {
+	page := template.Must(template.ParseFS(swaggerFiles, "swagger-ui.html"))
+
+	var buf bytes.Buffer
+	if err := page.Execute(&buf, struct{ Title string }{title}); err != nil {
+		panic(err)
+	}
+
+	return buf.Bytes()
+}
+
+func SwaggerUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", swaggerUI)
+}
+
+// SwaggerAsset serves the file of SwaggerAssets named by the last segment
+// of the route.
+func SwaggerAsset(c *gin.Context) {
+	name := path.Base(c.FullPath())
+	asset, err := swaggerFiles.ReadFile("swagger-ui/" + name)
+
+	if err != nil {
+		c.Status(http.StatusNotFound)
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.Data(http.StatusOK, mime.TypeByExtension(path.Ext(name)), asset)
+}
@@ -1,11 +1,39 @@
 package dto
 
-import infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+import (
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+type AccountRequest struct {
+	Status string `json:"status" validate:"omitempty,oneof=pending active"`
+}
+
+type AccountStatusRequest struct {
+	Status string `json:"status" validate:"required,account_status"`
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+// AccountStatusShortcutRequest is the optional body of the status shortcuts,
+// which already know the status.
+type AccountStatusShortcutRequest struct {
+	Reason string `json:"reason" validate:"max=255"`
+}
 
 type AccountResponse struct {
 	ID       int32  `json:"id"`
 	TenantID int32  `json:"tenant_id"`
 	Status   string `json:"status"`
+	Version  int32  `json:"version"`
+}
+
+type AccountStatusHistoryResponse struct {
+	FromStatus *string   `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	Actor      string    `json:"actor"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 func AccountToResponse(account infra.Account) AccountResponse {
@@ -13,5 +41,21 @@ func AccountToResponse(account infra.Account) AccountResponse {
 		ID:       account.ID,
 		TenantID: account.TenantID,
 		Status:   account.Status,
+		Version:  account.Version,
+	}
+}
+
+func AccountStatusHistoryToResponse(history infra.AccountStatusHistory) AccountStatusHistoryResponse {
+	response := AccountStatusHistoryResponse{
+		ToStatus:  history.ToStatus,
+		Reason:    history.Reason,
+		Actor:     history.Actor,
+		CreatedAt: history.CreatedAt,
 	}
+
+	if history.FromStatus.Valid {
+		response.FromStatus = &history.FromStatus.String
+	}
+
+	return response
 }
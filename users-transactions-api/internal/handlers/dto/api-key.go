@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+type ApiKeyRequest struct {
+	Name   string   `json:"name" validate:"required,max=255"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,scope"`
+}
+
+type ApiKeyResponse struct {
+	ID         int32      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+type ApiKeySecretResponse struct {
+	ApiKeyResponse
+	Key string `json:"key"`
+}
+
+func ApiKeyToResponse(apiKey infra.ApiKey) ApiKeyResponse {
+	response := ApiKeyResponse{
+		ID:        apiKey.ID,
+		Name:      apiKey.Name,
+		Prefix:    apiKey.Prefix,
+		Scopes:    apiKey.Scopes,
+		CreatedAt: apiKey.CreatedAt,
+	}
+
+	if apiKey.LastUsedAt.Valid {
+		response.LastUsedAt = &apiKey.LastUsedAt.Time
+	}
+
+	if apiKey.RevokedAt.Valid {
+		response.RevokedAt = &apiKey.RevokedAt.Time
+	}
+
+	return response
+}
+
+func ApiKeyToSecretResponse(apiKey infra.ApiKey, key string) ApiKeySecretResponse {
+	return ApiKeySecretResponse{
+		ApiKeyResponse: ApiKeyToResponse(apiKey),
+		Key:            key,
+	}
+}
@@ -1,17 +1,24 @@
 package dto
 
-import infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+import (
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/utils"
+)
 
 type CardResponse struct {
-	ID        int32 `json:"id"`
-	AccountID int32 `json:"account_id"`
-	Amount    int64 `json:"amount"`
+	ID              int32  `json:"id"`
+	AccountID       int32  `json:"account_id"`
+	Amount          int64  `json:"amount"`
+	FormattedAmount string `json:"formatted_amount"`
+	Version         int32  `json:"version"`
 }
 
-func CardToResponse(card infra.Card) CardResponse {
+func CardToResponse(card infra.Card, formatter *utils.LocaleFormatter) CardResponse {
 	return CardResponse{
-		ID:        card.ID,
-		AccountID: card.AccountID,
-		Amount:    card.Amount,
+		ID:              card.ID,
+		AccountID:       card.AccountID,
+		Amount:          card.Amount,
+		FormattedAmount: formatter.Money(card.Amount),
+		Version:         card.Version,
 	}
 }
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/health"
+)
+
+type HealthResponse struct {
+	Status string                         `json:"status"`
+	Checks map[string]HealthCheckResponse `json:"checks,omitempty"`
+}
+
+type HealthCheckResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+func ReportToResponse(report usecases.Report) HealthResponse {
+	checks := make(map[string]HealthCheckResponse)
+
+	for name, check := range report.Checks {
+		checks[name] = HealthCheckResponse{
+			Status: check.Status,
+			Error:  check.Error,
+		}
+	}
+
+	return HealthResponse{
+		Status: report.Status,
+		Checks: checks,
+	}
+}
@@ -0,0 +1,141 @@
+package dto
+
+import (
+	"database/sql"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/privacy"
+)
+
+type ErasureRequest struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+type DataSubjectRequestResponse struct {
+	ID          int32      `json:"id"`
+	AccountID   int32      `json:"account_id"`
+	Kind        string     `json:"kind"`
+	Format      *string    `json:"format"`
+	Reason      *string    `json:"reason"`
+	Actor       string     `json:"actor"`
+	RetainUntil *time.Time `json:"retain_until"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type AccountExportResponse struct {
+	ID        int32      `json:"id"`
+	TenantID  int32      `json:"tenant_id"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+	ErasedAt  *time.Time `json:"erased_at"`
+}
+
+type CardExportResponse struct {
+	ID        int32      `json:"id"`
+	Amount    int64      `json:"amount"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+type TransactionExportResponse struct {
+	ID        int32      `json:"id"`
+	CardID    int32      `json:"card_id"`
+	Kind      string     `json:"kind"`
+	Value     int64      `json:"value"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+type AccountDataExportResponse struct {
+	GeneratedAt         time.Time                      `json:"generated_at"`
+	Account             AccountExportResponse          `json:"account"`
+	Cards               []CardExportResponse           `json:"cards"`
+	Transactions        []TransactionExportResponse    `json:"transactions"`
+	StatusHistory       []AccountStatusHistoryResponse `json:"status_history"`
+	DataSubjectRequests []DataSubjectRequestResponse   `json:"data_subject_requests"`
+}
+
+func DataSubjectRequestToResponse(request infra.DataSubjectRequest) DataSubjectRequestResponse {
+	response := DataSubjectRequestResponse{
+		ID:          request.ID,
+		AccountID:   request.AccountID,
+		Kind:        request.Kind,
+		Actor:       request.Actor,
+		RetainUntil: nullTimeToPointer(request.RetainUntil),
+		CreatedAt:   request.CreatedAt,
+	}
+
+	if request.Format.Valid {
+		response.Format = &request.Format.String
+	}
+
+	if request.Reason.Valid {
+		response.Reason = &request.Reason.String
+	}
+
+	return response
+}
+
+func AccountDataExportToResponse(export usecases.AccountDataExport) AccountDataExportResponse {
+	response := AccountDataExportResponse{
+		GeneratedAt: export.GeneratedAt,
+		Account: AccountExportResponse{
+			ID:        export.Account.ID,
+			TenantID:  export.Account.TenantID,
+			Status:    export.Account.Status,
+			CreatedAt: export.Account.CreatedAt,
+			UpdatedAt: nullTimeToPointer(export.Account.UpdatedAt),
+			DeletedAt: nullTimeToPointer(export.Account.DeletedAt),
+			ErasedAt:  nullTimeToPointer(export.Account.ErasedAt),
+		},
+		Cards:               make([]CardExportResponse, 0, len(export.Cards)),
+		Transactions:        make([]TransactionExportResponse, 0, len(export.Transactions)),
+		StatusHistory:       make([]AccountStatusHistoryResponse, 0, len(export.StatusHistory)),
+		DataSubjectRequests: make([]DataSubjectRequestResponse, 0, len(export.Requests)),
+	}
+
+	for _, c := range export.Cards {
+		response.Cards = append(response.Cards, CardExportResponse{
+			ID:        c.ID,
+			Amount:    c.Amount,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: nullTimeToPointer(c.UpdatedAt),
+			DeletedAt: nullTimeToPointer(c.DeletedAt),
+		})
+	}
+
+	for _, t := range export.Transactions {
+		response.Transactions = append(response.Transactions, TransactionExportResponse{
+			ID:        t.ID,
+			CardID:    t.CardID,
+			Kind:      t.Kind,
+			Value:     t.Value,
+			CreatedAt: t.CreatedAt,
+			UpdatedAt: nullTimeToPointer(t.UpdatedAt),
+			DeletedAt: nullTimeToPointer(t.DeletedAt),
+		})
+	}
+
+	for _, h := range export.StatusHistory {
+		response.StatusHistory = append(response.StatusHistory, AccountStatusHistoryToResponse(h))
+	}
+
+	for _, r := range export.Requests {
+		response.DataSubjectRequests = append(response.DataSubjectRequests, DataSubjectRequestToResponse(r))
+	}
+
+	return response
+}
+
+func nullTimeToPointer(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"database/sql"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+type TenantSettingsRequest struct {
+	Currency       string `json:"currency" validate:"required,iso4217"`
+	Timezone       string `json:"timezone" validate:"required,timezone"`
+	Locale         string `json:"locale" validate:"required,bcp47_language_tag"`
+	ClosingDay     int32  `json:"closing_day" validate:"min=1,max=28"`
+	DisplayName    string `json:"display_name" validate:"max=255"`
+	PrimaryColor   string `json:"primary_color" validate:"required,hex_color"`
+	SecondaryColor string `json:"secondary_color" validate:"required,hex_color"`
+}
+
+type TenantSettingsResponse struct {
+	Currency       string               `json:"currency"`
+	Timezone       string               `json:"timezone"`
+	Locale         string               `json:"locale"`
+	ClosingDay     int32                `json:"closing_day"`
+	DisplayName    string               `json:"display_name"`
+	PrimaryColor   string               `json:"primary_color"`
+	SecondaryColor string               `json:"secondary_color"`
+	Limits         TenantLimitsResponse `json:"limits"`
+}
+
+type TenantLimitsRequest struct {
+	RateLimitPerSecond    int32 `json:"rate_limit_per_second" validate:"gte=0"`
+	RateLimitBurst        int32 `json:"rate_limit_burst" validate:"gte=0,rate_limit_burst"`
+	MaxAccounts           int32 `json:"max_accounts" validate:"gte=0"`
+	MaxCardsPerAccount    int32 `json:"max_cards_per_account" validate:"gte=0"`
+	MaxTransactionsPerDay int32 `json:"max_transactions_per_day" validate:"gte=0"`
+}
+
+type TenantLimitsResponse struct {
+	RateLimitPerSecond    int32 `json:"rate_limit_per_second"`
+	RateLimitBurst        int32 `json:"rate_limit_burst"`
+	MaxAccounts           int32 `json:"max_accounts"`
+	MaxCardsPerAccount    int32 `json:"max_cards_per_account"`
+	MaxTransactionsPerDay int32 `json:"max_transactions_per_day"`
+}
+
+func TenantSettingsToResponse(settings infra.TenantSetting) TenantSettingsResponse {
+	return TenantSettingsResponse{
+		Currency:       settings.Currency,
+		Timezone:       settings.Timezone,
+		Locale:         settings.Locale,
+		ClosingDay:     settings.ClosingDay,
+		DisplayName:    settings.DisplayName.String,
+		PrimaryColor:   settings.PrimaryColor,
+		SecondaryColor: settings.SecondaryColor,
+		Limits: TenantLimitsResponse{
+			RateLimitPerSecond:    settings.RateLimitPerSecond,
+			RateLimitBurst:        settings.RateLimitBurst,
+			MaxAccounts:           settings.MaxAccounts,
+			MaxCardsPerAccount:    settings.MaxCardsPerAccount,
+			MaxTransactionsPerDay: settings.MaxTransactionsPerDay,
+		},
+	}
+}
+
+func RequestToTenantSettings(request TenantSettingsRequest) infra.TenantSetting {
+	return infra.TenantSetting{
+		Currency:   request.Currency,
+		Timezone:   request.Timezone,
+		Locale:     request.Locale,
+		ClosingDay: request.ClosingDay,
+		DisplayName: sql.NullString{
+			String: request.DisplayName,
+			Valid:  len(request.DisplayName) > 0,
+		},
+		PrimaryColor:   request.PrimaryColor,
+		SecondaryColor: request.SecondaryColor,
+	}
+}
+
+func RequestToTenantLimits(request TenantLimitsRequest) infra.TenantSetting {
+	return infra.TenantSetting{
+		RateLimitPerSecond:    request.RateLimitPerSecond,
+		RateLimitBurst:        request.RateLimitBurst,
+		MaxAccounts:           request.MaxAccounts,
+		MaxCardsPerAccount:    request.MaxCardsPerAccount,
+		MaxTransactionsPerDay: request.MaxTransactionsPerDay,
+	}
+}
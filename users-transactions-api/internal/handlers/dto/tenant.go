@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+type TenantRequest struct {
+	Name       string `json:"name" validate:"required,max=255"`
+	AdminEmail string `json:"admin_email" validate:"required,email"`
+}
+
+type RenameTenantRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+type TenantResponse struct {
+	ID          int32      `json:"id"`
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	SuspendedAt *time.Time `json:"suspended_at"`
+}
+
+type CreatedTenantResponse struct {
+	TenantResponse
+	Settings TenantSettingsResponse `json:"settings"`
+	Admin    UserResponse           `json:"admin"`
+}
+
+func TenantToResponse(tenant infra.Tenant) TenantResponse {
+	response := TenantResponse{
+		ID:        tenant.ID,
+		Name:      tenant.Name,
+		Status:    tenant.Status,
+		CreatedAt: tenant.CreatedAt,
+	}
+
+	if tenant.SuspendedAt.Valid {
+		response.SuspendedAt = &tenant.SuspendedAt.Time
+	}
+
+	return response
+}
+
+func CreatedTenantToResponse(result infra.CreateTenantTxResult) CreatedTenantResponse {
+	return CreatedTenantResponse{
+		TenantResponse: TenantToResponse(result.Tenant),
+		Settings:       TenantSettingsToResponse(result.Settings),
+		Admin:          UserToResponse(result.Admin),
+	}
+}
@@ -1,26 +1,53 @@
 package dto
 
-import infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+import (
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/utils"
+)
 
 type TransactioRequest struct {
-	CardId int32  `json:"card_id"`
-	Kind   string `json:"kind"`
-	Value  int64  `json:"value"`
+	CardId int32  `json:"card_id" validate:"required,existing_card"`
+	Kind   string `json:"kind" validate:"required,transaction_kind"`
+	Value  int64  `json:"value" validate:"positive_money"`
+	// Direction defaults to debit
+	Direction string `json:"direction" validate:"omitempty,oneof=debit credit"`
+}
+
+func (r TransactioRequest) TransactionDirection() shared.TransactionDirection {
+	if r.Direction == "" {
+		return shared.TransactionDirectionDebit
+	}
+
+	return shared.TransactionDirection(r.Direction)
 }
 
 type TransactionResponse struct {
-	ID     int32  `json:"id"`
-	CardId int32  `json:"card_id"`
-	Kind   string `json:"kind"`
-	Value  int64  `json:"value"`
+	ID             int32     `json:"id"`
+	CardId         int32     `json:"card_id"`
+	Kind           string    `json:"kind"`
+	Direction      string    `json:"direction"`
+	Value          int64     `json:"value"`
+	FormattedValue string    `json:"formatted_value"`
+	CreatedAt      time.Time `json:"created_at"`
 }
 
-func TransactionToResponse(transaction infra.Transaction) TransactionResponse {
+// TransactionToResponse answers with the positive amount and its direction,
+// as in the request.
+func TransactionToResponse(transaction infra.Transaction, formatter *utils.LocaleFormatter) TransactionResponse {
+	direction := shared.TransactionDirectionOf(transaction.Value)
+	value := direction.SignedValue(transaction.Value)
+
 	return TransactionResponse{
-		ID:     transaction.ID,
-		CardId: transaction.CardID,
-		Kind:   transaction.Kind,
-		Value:  transaction.Value,
+		ID:             transaction.ID,
+		CardId:         transaction.CardID,
+		Kind:           transaction.Kind,
+		Direction:      string(direction),
+		Value:          value,
+		FormattedValue: formatter.Money(value),
+		CreatedAt:      formatter.Time(transaction.CreatedAt),
 	}
 }
 
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/health"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	readinessUsecase *usecases.ReadinessUsecase
+}
+
+func NewHealthHandler(readinessUsecase *usecases.ReadinessUsecase) *HealthHandler {
+	return &HealthHandler{
+		readinessUsecase: readinessUsecase,
+	}
+}
+
+// Live only tells the process is serving, a failing dependency must not
+// get it restarted.
+func (hh *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.HealthResponse{Status: usecases.StatusUp})
+}
+
+func (hh *HealthHandler) Ready(c *gin.Context) {
+	report := hh.readinessUsecase.Check(c.Request.Context())
+
+	status := http.StatusOK
+
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, dto.ReportToResponse(report))
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/health"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthHandler(t *testing.T) {
+	t.Parallel()
+
+	migrations := fstest.MapFS{"20240817181058_create_tenants_table.sql": &fstest.MapFile{}}
+
+	t.Run("[Live] Up without checking the database", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		sut := NewHealthHandler(usecases.NewReadinessUsecase(mockRepo, migrations))
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+		c.Request = httptest.NewRequest("GET", "/healthz", nil)
+
+		sut.Live(c)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		mockRepo.AssertNotCalled(t, "Ping")
+	})
+
+	t.Run("[Ready] Ready", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		sut := NewHealthHandler(usecases.NewReadinessUsecase(mockRepo, migrations))
+
+		mockRepo.On("Ping").Return(nil)
+		mockRepo.On("AppliedMigrations").Return(map[int64]bool{20240817181058: true}, nil)
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+		c.Request = httptest.NewRequest("GET", "/readyz", nil)
+
+		sut.Ready(c)
+
+		var body dto.HealthResponse
+		json.Unmarshal(res.Body.Bytes(), &body)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, usecases.StatusUp, body.Status)
+	})
+
+	t.Run("[Ready] Unavailable while the database is down", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		sut := NewHealthHandler(usecases.NewReadinessUsecase(mockRepo, migrations))
+
+		mockRepo.On("Ping").Return(errors.New("connection refused"))
+		mockRepo.On("AppliedMigrations").Return(nil, errors.New("connection refused"))
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+		c.Request = httptest.NewRequest("GET", "/readyz", nil)
+
+		sut.Ready(c)
+
+		var body dto.HealthResponse
+		json.Unmarshal(res.Body.Bytes(), &body)
+
+		assert.Equal(t, http.StatusServiceUnavailable, res.Code)
+		assert.Equal(t, usecases.StatusDown, body.Status)
+		assert.Equal(t, "connection refused", body.Checks["database"].Error)
+	})
+}
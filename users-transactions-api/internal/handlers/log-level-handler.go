@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/gin-gonic/gin"
+)
+
+// LogLevelHandler reads and changes the level of the default logger
+// without a restart, the change is lost when the process stops.
+type LogLevelHandler struct {
+	level *slog.LevelVar
+}
+
+func NewLogLevelHandler(level *slog.LevelVar) *LogLevelHandler {
+	return &LogLevelHandler{
+		level: level,
+	}
+}
+
+func (lh *LogLevelHandler) Get(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.LogLevelResponse{Level: strings.ToLower(lh.level.Level().String())})
+}
+
+func (lh *LogLevelHandler) Update(c *gin.Context) {
+	var request dto.LogLevelRequest
+
+	if !tools.BindJSON(c, &request) {
+		return
+	}
+
+	var level slog.Level
+
+	err := level.UnmarshalText([]byte(request.Level))
+
+	if err != nil {
+		c.Error(&shared.ValidationError{
+			Errors: map[string]string{"level": "must be one of debug, info, warn, error"},
+		})
+		return
+	}
+
+	previous := lh.level.Level()
+	lh.level.Set(level)
+
+	slog.WarnContext(c.Request.Context(), "log level changed",
+		slog.String("from", previous.String()),
+		slog.String("to", level.String()),
+	)
+
+	c.JSON(http.StatusOK, dto.LogLevelResponse{Level: strings.ToLower(level.String())})
+}
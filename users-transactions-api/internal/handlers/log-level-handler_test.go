@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogLevelHandler(t *testing.T) {
+	t.Parallel()
+
+	serve := func(sut *LogLevelHandler, method string, body string) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.Use(tools.Problems())
+		router.GET("/admin/log-level", sut.Get)
+		router.PUT("/admin/log-level", sut.Update)
+
+		res := httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/admin/log-level", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(res, req)
+
+		return res
+	}
+
+	t.Run("[Get] Current level", func(t *testing.T) {
+		level := new(slog.LevelVar)
+		level.Set(slog.LevelWarn)
+
+		res := serve(NewLogLevelHandler(level), http.MethodGet, "")
+
+		var body dto.LogLevelResponse
+		json.Unmarshal(res.Body.Bytes(), &body)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "warn", body.Level)
+	})
+
+	t.Run("[Update] Changes the level", func(t *testing.T) {
+		level := new(slog.LevelVar)
+
+		res := serve(NewLogLevelHandler(level), http.MethodPut, `{"level": "DEBUG"}`)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, slog.LevelDebug, level.Level())
+	})
+
+	t.Run("[Update] Unknown level", func(t *testing.T) {
+		level := new(slog.LevelVar)
+
+		res := serve(NewLogLevelHandler(level), http.MethodPut, `{"level": "verbose"}`)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, slog.LevelInfo, level.Level())
+	})
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/privacy"
+	"github.com/gin-gonic/gin"
+)
+
+type PrivacyHandler struct {
+	exportAccountDataUsecase       *usecases.ExportAccountDataUsecase
+	eraseAccountDataUsecase        *usecases.EraseAccountDataUsecase
+	findDataSubjectRequestsUsecase *usecases.FindDataSubjectRequestsUsecase
+}
+
+func NewPrivacyHandler(exportAccountDataUsecase *usecases.ExportAccountDataUsecase,
+	eraseAccountDataUsecase *usecases.EraseAccountDataUsecase,
+	findDataSubjectRequestsUsecase *usecases.FindDataSubjectRequestsUsecase) *PrivacyHandler {
+	return &PrivacyHandler{
+		exportAccountDataUsecase:       exportAccountDataUsecase,
+		eraseAccountDataUsecase:        eraseAccountDataUsecase,
+		findDataSubjectRequestsUsecase: findDataSubjectRequestsUsecase,
+	}
+}
+
+func (ph *PrivacyHandler) Export(c *gin.Context) {
+	tenantId, valid := tools.CheckTenantHeader(c)
+
+	if !valid {
+		return
+	}
+
+	principal, valid := tools.GetPrincipal(c)
+
+	if !valid {
+		return
+	}
+
+	accountId, valid := tools.ParamId(c, "accountId")
+
+	if !valid {
+		return
+	}
+
+	format, valid := tools.Query(c, "format", usecases.FormatJSON, "oneof=json zip")
+
+	if !valid {
+		return
+	}
+
+	export, err := ph.exportAccountDataUsecase.Export(c.Request.Context(), tenantId, accountId, format, principal.Actor())
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := dto.AccountDataExportToResponse(*export)
+	filename := fmt.Sprintf("account-%d-export.%s", accountId, format)
+
+	if format == usecases.FormatJSON {
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	data, err := exportToZip(response)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, "application/zip", data)
+}
+
+func (ph *PrivacyHandler) Erase(c *gin.Context) {
+	tenantId, valid := tools.CheckTenantHeader(c)
+
+	if !valid {
+		return
+	}
+
+	principal, valid := tools.GetPrincipal(c)
+
+	if !valid {
+		return
+	}
+
+	accountId, valid := tools.ParamId(c, "accountId")
+
+	if !valid {
+		return
+	}
+
+	var request dto.ErasureRequest
+
+	if !tools.BindJSON(c, &request) {
+		return
+	}
+
+	version, valid := tools.IfMatchVersion(c)
+
+	if !valid {
+		return
+	}
+
+	erasure, err := ph.eraseAccountDataUsecase.Erase(c.Request.Context(), tenantId, accountId, request.Reason,
+		principal.Actor(), version)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.DataSubjectRequestToResponse(*erasure))
+}
+
+func (ph *PrivacyHandler) FindRequests(c *gin.Context) {
+	tenantId, valid := tools.CheckTenantHeader(c)
+
+	if !valid {
+		return
+	}
+
+	accountId, valid := tools.ParamId(c, "accountId")
+
+	if !valid {
+		return
+	}
+
+	requests, err := ph.findDataSubjectRequestsUsecase.FindAll(c.Request.Context(), tenantId, accountId)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	requestsResponse := make([]dto.DataSubjectRequestResponse, 0, len(requests))
+	for _, r := range requests {
+		requestsResponse = append(requestsResponse, dto.DataSubjectRequestToResponse(r))
+	}
+
+	c.JSON(http.StatusOK, requestsResponse)
+}
+
+// exportToZip writes one json file per section so the bundle can be read
+// without tooling.
+func exportToZip(export dto.AccountDataExportResponse) ([]byte, error) {
+	files := []struct {
+		name    string
+		content any
+	}{
+		{"account.json", export.Account},
+		{"cards.json", export.Cards},
+		{"transactions.json", export.Transactions},
+		{"status_history.json", export.StatusHistory},
+		{"data_subject_requests.json", export.DataSubjectRequests},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	for _, f := range files {
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     f.name,
+			Method:   zip.Deflate,
+			Modified: export.GeneratedAt,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(f.content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
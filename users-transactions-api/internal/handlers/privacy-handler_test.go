@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/privacy"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivacyHandler(t *testing.T) {
+	t.Parallel()
+
+	principal := &shared.Principal{TenantId: 1, UserId: 1, Role: shared.RoleAdmin}
+	account := infra.Account{ID: 1, TenantID: 1, Status: shared.AccountStatusActive}
+	cards := []infra.Card{{ID: 1, AccountID: 1, Amount: 200}}
+	transactions := []infra.Transaction{{ID: 1, CardID: 1, Kind: "Streaming Z", Value: 200}}
+	exportRequest := infra.DataSubjectRequest{
+		ID:        1,
+		TenantID:  1,
+		AccountID: 1,
+		Kind:      usecases.RequestKindExport,
+		Format:    sql.NullString{String: usecases.FormatZIP, Valid: true},
+		Actor:     "user:1",
+	}
+
+	newSut := func(mockRepo *mocks.MockRepository) *PrivacyHandler {
+		return NewPrivacyHandler(usecases.NewExportAccountDataUsecase(mockRepo),
+			usecases.NewEraseAccountDataUsecase(mockRepo, time.Hour),
+			usecases.NewFindDataSubjectRequestsUsecase(mockRepo))
+	}
+
+	newExportMock := func() *mocks.MockRepository {
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("GetAccount").Return(account, nil)
+		mockRepo.On("GetCards").Return(cards, nil)
+		mockRepo.On("GetAccountTransactions").Return(transactions, nil)
+		mockRepo.On("GetAccountStatusHistory").Return(nil, nil)
+		mockRepo.On("CreateDataSubjectRequest").Return(exportRequest, nil)
+		mockRepo.On("GetDataSubjectRequests").Return([]infra.DataSubjectRequest{exportRequest}, nil)
+		return mockRepo
+	}
+
+	newRequest := func(method string, target string, body io.Reader) (*httptest.ResponseRecorder, *gin.Context) {
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest(method, target, body)
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("tenant-id", "1")
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), principal))
+		c.Params = []gin.Param{{
+			Key:   "accountId",
+			Value: "1",
+		}}
+
+		return res, c
+	}
+
+	t.Run("[Export] Invalid format", func(t *testing.T) {
+		res, c := newRequest("GET", "/privacy/account/1/export?format=xml", nil)
+
+		newSut(new(mocks.MockRepository)).Export(c)
+		tools.RenderProblem(c)
+
+		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
+	})
+
+	t.Run("[Export] Account not found by id", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("GetAccount").Return(nil, sql.ErrNoRows)
+
+		res, c := newRequest("GET", "/privacy/account/1/export", nil)
+
+		newSut(mockRepo).Export(c)
+		tools.RenderProblem(c)
+
+		assert.Equal(t, http.StatusNotFound, res.Result().StatusCode)
+	})
+
+	t.Run("[Export] Success as json", func(t *testing.T) {
+		res, c := newRequest("GET", "/privacy/account/1/export", nil)
+
+		newSut(newExportMock()).Export(c)
+		tools.RenderProblem(c)
+
+		var responseBody dto.AccountDataExportResponse
+		err := json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
+		assert.Equal(t, `attachment; filename="account-1-export.json"`, res.Header().Get("Content-Disposition"))
+		assert.Equal(t, account.ID, responseBody.Account.ID)
+		assert.Len(t, responseBody.Cards, 1)
+		assert.Equal(t, "Streaming Z", responseBody.Transactions[0].Kind)
+		assert.Len(t, responseBody.DataSubjectRequests, 1)
+	})
+
+	t.Run("[Export] Success as zip", func(t *testing.T) {
+		res, c := newRequest("GET", "/privacy/account/1/export?format=zip", nil)
+
+		newSut(newExportMock()).Export(c)
+		tools.RenderProblem(c)
+
+		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
+		assert.Equal(t, "application/zip", res.Header().Get("Content-Type"))
+
+		body := res.Body.Bytes()
+		zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+
+		assert.NoError(t, err)
+
+		names := make([]string, 0, len(zr.File))
+		for _, f := range zr.File {
+			names = append(names, f.Name)
+		}
+
+		assert.Equal(t, []string{"account.json", "cards.json", "transactions.json",
+			"status_history.json", "data_subject_requests.json"}, names)
+
+		f, err := zr.Open("transactions.json")
+		assert.NoError(t, err)
+
+		var exported []dto.TransactionExportResponse
+		assert.NoError(t, json.NewDecoder(f).Decode(&exported))
+		assert.Equal(t, int64(200), exported[0].Value)
+	})
+
+	t.Run("[Erase] Missing reason", func(t *testing.T) {
+		res, c := newRequest("POST", "/privacy/account/1/erasure", bytes.NewBufferString(`{}`))
+
+		newSut(new(mocks.MockRepository)).Erase(c)
+		tools.RenderProblem(c)
+
+		var responseBody tools.Problem
+		err := json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
+		assert.Contains(t, responseBody.Errors, tools.FieldError{Field: "reason", Detail: "cannot be empty"})
+	})
+
+	t.Run("[Erase] Account already erased", func(t *testing.T) {
+		erasedAccount := account
+		erasedAccount.ErasedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("GetAccountForUpdate").Return(erasedAccount, nil)
+
+		res, c := newRequest("POST", "/privacy/account/1/erasure",
+			bytes.NewBufferString(`{"reason":"customer request"}`))
+
+		newSut(mockRepo).Erase(c)
+		tools.RenderProblem(c)
+
+		assert.Equal(t, http.StatusConflict, res.Result().StatusCode)
+	})
+
+	t.Run("[Erase] Success", func(t *testing.T) {
+		retainUntil := time.Now().Add(time.Hour).UTC()
+		erasure := infra.DataSubjectRequest{
+			ID:          2,
+			TenantID:    1,
+			AccountID:   1,
+			Kind:        usecases.RequestKindErasure,
+			Reason:      sql.NullString{String: "customer request", Valid: true},
+			Actor:       "user:1",
+			RetainUntil: sql.NullTime{Time: retainUntil, Valid: true},
+		}
+
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("GetAccountForUpdate").Return(account, nil)
+		mockRepo.On("PseudonymizeAccountTransactions").Return(int64(1), nil)
+		mockRepo.On("PseudonymizeAccountStatusHistory").Return(nil)
+		mockRepo.On("UpdateAccount").Return(account, nil)
+		mockRepo.On("CreateAccountStatusHistory").Return(infra.AccountStatusHistory{}, nil)
+		mockRepo.On("MarkAccountErased").Return(account, nil)
+		mockRepo.On("CreateDataSubjectRequest").Return(erasure, nil)
+
+		res, c := newRequest("POST", "/privacy/account/1/erasure",
+			bytes.NewBufferString(`{"reason":"customer request"}`))
+
+		newSut(mockRepo).Erase(c)
+		tools.RenderProblem(c)
+
+		var responseBody dto.DataSubjectRequestResponse
+		err := json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
+		assert.Equal(t, usecases.RequestKindErasure, responseBody.Kind)
+		assert.Equal(t, "customer request", *responseBody.Reason)
+		assert.Equal(t, retainUntil, responseBody.RetainUntil.UTC())
+	})
+
+	t.Run("[FindRequests] Success", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("GetDataSubjectRequests").Return([]infra.DataSubjectRequest{exportRequest}, nil)
+
+		res, c := newRequest("GET", "/privacy/account/1/requests", nil)
+
+		newSut(mockRepo).FindRequests(c)
+		tools.RenderProblem(c)
+
+		var responseBody []dto.DataSubjectRequestResponse
+		err := json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
+		assert.Len(t, responseBody, 1)
+		assert.Equal(t, usecases.FormatZIP, *responseBody[0].Format)
+	})
+}
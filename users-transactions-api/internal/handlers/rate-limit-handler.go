@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+type RateLimitHandler struct {
+	rateLimitUsecase *usecases.RateLimitUsecase
+}
+
+func NewRateLimitHandler(rateLimitUsecase *usecases.RateLimitUsecase) *RateLimitHandler {
+	return &RateLimitHandler{
+		rateLimitUsecase: rateLimitUsecase,
+	}
+}
+
+// Limit rejects the requests of tenants that used up their rate limit.
+func (rh *RateLimitHandler) Limit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantId, valid := tools.CheckTenantHeader(c)
+
+		if !valid {
+			c.Abort()
+			return
+		}
+
+		err := rh.rateLimitUsecase.Allow(c.Request.Context(), tenantId)
+
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
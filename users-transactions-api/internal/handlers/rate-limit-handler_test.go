@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	rateLimitUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/ratelimit"
+	settingsUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/settings"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitHandler(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+	findTenantSettingsUsecase := settingsUsecases.NewFindTenantSettingsUsecase(mockRepo,
+		settingsUsecases.NewSettingsCache(0))
+	rateLimitUsecase := rateLimitUsecases.NewRateLimitUsecase(rateLimitUsecases.NewMemoryLimiter(),
+		findTenantSettingsUsecase)
+	sut := NewRateLimitHandler(rateLimitUsecase)
+
+	mockRepo.On("GetTenantSettings").Return(infra.TenantSetting{
+		TenantID:           1,
+		RateLimitPerSecond: 1,
+		RateLimitBurst:     1,
+	}, nil)
+
+	request := func() *httptest.ResponseRecorder {
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("GET", "/", nil)
+		c.Request.Header.Set("tenant-id", "1")
+
+		middleware := sut.Limit()
+		middleware(c)
+		tools.RenderProblem(c)
+
+		if !c.IsAborted() {
+			c.Status(http.StatusOK)
+		}
+
+		return res
+	}
+
+	t.Run("[Limit] Allow request within limit", func(t *testing.T) {
+		res := request()
+
+		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
+	})
+
+	t.Run("[Limit] Reject request over limit", func(t *testing.T) {
+		res := request()
+
+		var responseBody tools.Problem
+		err := json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusTooManyRequests, res.Result().StatusCode)
+		assert.Equal(t, "1", res.Header().Get("Retry-After"))
+		assert.Equal(t, "rate limit exceeded", responseBody.Detail)
+	})
+}
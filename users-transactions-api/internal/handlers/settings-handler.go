@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/settings"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type SettingsHandler struct {
+	findTenantSettingsUsecase   *usecases.FindTenantSettingsUsecase
+	updateTenantSettingsUsecase *usecases.UpdateTenantSettingsUsecase
+	uploadTenantLogoUsecase     *usecases.UploadTenantLogoUsecase
+	findTenantLogoUsecase       *usecases.FindTenantLogoUsecase
+	deleteTenantLogoUsecase     *usecases.DeleteTenantLogoUsecase
+	updateTenantLimitsUsecase   *usecases.UpdateTenantLimitsUsecase
+	cacheMaxAge                 int
+}
+
+func NewSettingsHandler(findTenantSettingsUsecase *usecases.FindTenantSettingsUsecase,
+	updateTenantSettingsUsecase *usecases.UpdateTenantSettingsUsecase,
+	uploadTenantLogoUsecase *usecases.UploadTenantLogoUsecase,
+	findTenantLogoUsecase *usecases.FindTenantLogoUsecase,
+	deleteTenantLogoUsecase *usecases.DeleteTenantLogoUsecase,
+	updateTenantLimitsUsecase *usecases.UpdateTenantLimitsUsecase,
+	cache *usecases.SettingsCache) *SettingsHandler {
+	return &SettingsHandler{
+		findTenantSettingsUsecase:   findTenantSettingsUsecase,
+		updateTenantSettingsUsecase: updateTenantSettingsUsecase,
+		uploadTenantLogoUsecase:     uploadTenantLogoUsecase,
+		findTenantLogoUsecase:       findTenantLogoUsecase,
+		deleteTenantLogoUsecase:     deleteTenantLogoUsecase,
+		updateTenantLimitsUsecase:   updateTenantLimitsUsecase,
+		cacheMaxAge:                 int(cache.TTL().Seconds()),
+	}
+}
+
+// LoadSettings makes the tenant timezone and locale available to the
+// handlers that format their responses.
+func (sh *SettingsHandler) LoadSettings() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantId, valid := tools.CheckTenantHeader(c)
+
+		if !valid {
+			c.Abort()
+			return
+		}
+
+		formatter := utils.DefaultLocaleFormatter()
+
+		settings, err := sh.findTenantSettingsUsecase.FindOne(c.Request.Context(), tenantId)
+
+		if err == nil {
+			formatter = utils.NewLocaleFormatter(settings.Timezone, settings.Locale, settings.Currency)
+		} else if _, ok := err.(*shared.EntityNotFoundError); !ok {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		tools.SetLocaleFormatter(c, formatter)
+		c.Header("Content-Language", formatter.Locale())
+
+		c.Next()
+	}
+}
+
+func (sh *SettingsHandler) FindOne(c *gin.Context) {
+	tenantId, valid := tools.CheckTenantHeader(c)
+
+	if !valid {
+		return
+	}
+
+	settings, err := sh.findTenantSettingsUsecase.FindOne(c.Request.Context(), tenantId)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", sh.cacheMaxAge))
+	c.JSON(http.StatusOK, dto.TenantSettingsToResponse(*settings))
+}
+
+func (sh *SettingsHandler) Update(c *gin.Context) {
+	tenantId, valid := tools.CheckTenantHeader(c)
+
+	if !valid {
+		return
+	}
+
+	var request dto.TenantSettingsRequest
+
+	if !tools.BindJSON(c, &request) {
+		return
+	}
+
+	updatedSettings, err := sh.updateTenantSettingsUsecase.Update(c.Request.Context(), tenantId, dto.RequestToTenantSettings(request))
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TenantSettingsToResponse(*updatedSettings))
+}
+
+func (sh *SettingsHandler) UploadLogo(c *gin.Context) {
+	tenantId, valid := tools.CheckTenantHeader(c)
+
+	if !valid {
+		return
+	}
+
+	// read one byte past the limit so oversized logos fail validation
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, usecases.MaxLogoSize+1))
+
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	_, err = sh.uploadTenantLogoUsecase.Upload(c.Request.Context(), tenantId, data)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (sh *SettingsHandler) FindLogo(c *gin.Context) {
+	tenantId, valid := tools.CheckTenantHeader(c)
+
+	if !valid {
+		return
+	}
+
+	logo, err := sh.findTenantLogoUsecase.FindOne(c.Request.Context(), tenantId)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", sh.cacheMaxAge))
+	c.Header("Last-Modified", logo.UpdatedAt.UTC().Format(http.TimeFormat))
+	c.Data(http.StatusOK, logo.ContentType, logo.Data)
+}
+
+func (sh *SettingsHandler) DeleteLogo(c *gin.Context) {
+	tenantId, valid := tools.CheckTenantHeader(c)
+
+	if !valid {
+		return
+	}
+
+	err := sh.deleteTenantLogoUsecase.Delete(c.Request.Context(), tenantId)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateLimits is served under the platform admin routes, the tenant comes
+// from the path instead of the tenant-id header.
+func (sh *SettingsHandler) UpdateLimits(c *gin.Context) {
+	tenantId, valid := tools.ParamId(c, "tenantId")
+
+	if !valid {
+		return
+	}
+
+	var request dto.TenantLimitsRequest
+
+	if !tools.BindJSON(c, &request) {
+		return
+	}
+
+	updatedSettings, err := sh.updateTenantLimitsUsecase.Update(c.Request.Context(), tenantId, dto.RequestToTenantLimits(request))
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TenantSettingsToResponse(*updatedSettings))
+}
@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/settings"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettingsHandler(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+
+	// a zero ttl keeps every subtest hitting the repository
+	cache := usecases.NewSettingsCache(0)
+	findTenantSettingsUsecase := usecases.NewFindTenantSettingsUsecase(mockRepo, cache)
+	updateTenantSettingsUsecase := usecases.NewUpdateTenantSettingsUsecase(mockRepo, cache)
+	uploadTenantLogoUsecase := usecases.NewUploadTenantLogoUsecase(mockRepo)
+	findTenantLogoUsecase := usecases.NewFindTenantLogoUsecase(mockRepo)
+	deleteTenantLogoUsecase := usecases.NewDeleteTenantLogoUsecase(mockRepo)
+	updateTenantLimitsUsecase := usecases.NewUpdateTenantLimitsUsecase(mockRepo, cache)
+	sut := NewSettingsHandler(findTenantSettingsUsecase, updateTenantSettingsUsecase,
+		uploadTenantLogoUsecase, findTenantLogoUsecase, deleteTenantLogoUsecase, updateTenantLimitsUsecase, cache)
+
+	settings := infra.TenantSetting{
+		TenantID:       1,
+		Currency:       "USD",
+		Timezone:       "America/New_York",
+		Locale:         "en-US",
+		ClosingDay:     5,
+		PrimaryColor:   "#000000",
+		SecondaryColor: "#FFFFFF",
+	}
+
+	t.Run("[LoadSettings] Defaults when tenant has no settings", func(t *testing.T) {
+		mockRepo.On("GetTenantSettings").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetTenantSettings").Unset()
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("GET", "/", nil)
+		c.Request.Header.Set("tenant-id", "1")
+
+		middleware := sut.LoadSettings()
+		middleware(c)
+		tools.RenderProblem(c)
+
+		assert.False(t, c.IsAborted())
+		assert.Equal(t, "pt-BR", res.Header().Get("Content-Language"))
+		assert.Equal(t, "R$ 10,00", tools.GetLocaleFormatter(c).Money(1000))
+	})
+
+	t.Run("[LoadSettings] Tenant settings formatter", func(t *testing.T) {
+		mockRepo.On("GetTenantSettings").Return(settings, nil)
+		defer mockRepo.On("GetTenantSettings").Unset()
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("GET", "/", nil)
+		c.Request.Header.Set("tenant-id", "1")
+
+		middleware := sut.LoadSettings()
+		middleware(c)
+		tools.RenderProblem(c)
+
+		assert.False(t, c.IsAborted())
+		assert.Equal(t, "en-US", res.Header().Get("Content-Language"))
+		assert.Equal(t, "$ 10.00", tools.GetLocaleFormatter(c).Money(1000))
+	})
+
+	t.Run("[Update] Invalid settings", func(t *testing.T) {
+		body, err := json.Marshal(dto.TenantSettingsRequest{
+			Currency:       "USD",
+			Timezone:       "Nowhere/City",
+			Locale:         "en-US",
+			ClosingDay:     5,
+			PrimaryColor:   "#000000",
+			SecondaryColor: "#FFFFFF",
+		})
+
+		assert.NoError(t, err)
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("PUT", "/", bytes.NewBuffer(body))
+		c.Request.Header.Set("tenant-id", "1")
+
+		sut.Update(c)
+		tools.RenderProblem(c)
+
+		var responseBody tools.Problem
+		err = json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
+		assert.Equal(t, tools.ProblemTypeValidation, responseBody.Type)
+		assert.Equal(t, "timezone", responseBody.Errors[0].Field)
+	})
+
+	t.Run("[Update] Settings updated successfully", func(t *testing.T) {
+		mockRepo.On("UpdateTenantSettings").Return(settings, nil)
+		defer mockRepo.On("UpdateTenantSettings").Unset()
+
+		body, err := json.Marshal(dto.TenantSettingsToResponse(settings))
+
+		assert.NoError(t, err)
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("PUT", "/", bytes.NewBuffer(body))
+		c.Request.Header.Set("tenant-id", "1")
+
+		sut.Update(c)
+		tools.RenderProblem(c)
+
+		var responseBody dto.TenantSettingsResponse
+		err = json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
+		assert.Equal(t, dto.TenantSettingsToResponse(settings), responseBody)
+	})
+
+	t.Run("[UploadLogo] Unsupported logo", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("PUT", "/", bytes.NewBufferString("plain text"))
+		c.Request.Header.Set("tenant-id", "1")
+
+		sut.UploadLogo(c)
+		tools.RenderProblem(c)
+
+		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
+	})
+
+	t.Run("[FindLogo] Logo not found", func(t *testing.T) {
+		mockRepo.On("GetTenantLogo").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetTenantLogo").Unset()
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("GET", "/", nil)
+		c.Request.Header.Set("tenant-id", "1")
+
+		sut.FindLogo(c)
+		tools.RenderProblem(c)
+
+		assert.Equal(t, http.StatusNotFound, res.Result().StatusCode)
+	})
+
+	t.Run("[FindLogo] Logo found", func(t *testing.T) {
+		logo := infra.TenantLogo{
+			TenantID:    1,
+			ContentType: "image/png",
+			Data:        []byte("\x89PNG\x0D\x0A\x1A\x0A"),
+			UpdatedAt:   time.Date(2024, 10, 19, 12, 0, 0, 0, time.UTC),
+		}
+
+		mockRepo.On("GetTenantLogo").Return(logo, nil)
+		defer mockRepo.On("GetTenantLogo").Unset()
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("GET", "/", nil)
+		c.Request.Header.Set("tenant-id", "1")
+
+		sut.FindLogo(c)
+		tools.RenderProblem(c)
+
+		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
+		assert.Equal(t, "image/png", res.Header().Get("Content-Type"))
+		assert.Equal(t, "Sat, 19 Oct 2024 12:00:00 GMT", res.Header().Get("Last-Modified"))
+		assert.Equal(t, logo.Data, res.Body.Bytes())
+	})
+
+	t.Run("[UpdateLimits] Invalid limits", func(t *testing.T) {
+		body, err := json.Marshal(dto.TenantLimitsRequest{
+			RateLimitPerSecond: 10,
+			MaxAccounts:        -1,
+		})
+
+		assert.NoError(t, err)
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("PUT", "/", bytes.NewBuffer(body))
+		c.Params = []gin.Param{
+			{
+				Key:   "tenantId",
+				Value: "1",
+			},
+		}
+
+		sut.UpdateLimits(c)
+		tools.RenderProblem(c)
+
+		var responseBody tools.Problem
+		err = json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
+		assert.Equal(t, []tools.FieldError{
+			{Field: "max_accounts", Detail: "cannot be negative"},
+			{Field: "rate_limit_burst", Detail: "must be at least 1 when the rate limit is enabled"},
+		}, responseBody.Errors)
+	})
+
+	t.Run("[UpdateLimits] Limits updated successfully", func(t *testing.T) {
+		limitedSettings := settings
+		limitedSettings.RateLimitPerSecond = 10
+		limitedSettings.RateLimitBurst = 20
+		limitedSettings.MaxAccounts = 5
+
+		mockRepo.On("UpdateTenantLimits").Return(limitedSettings, nil)
+		defer mockRepo.On("UpdateTenantLimits").Unset()
+
+		body, err := json.Marshal(dto.TenantLimitsRequest{
+			RateLimitPerSecond: 10,
+			RateLimitBurst:     20,
+			MaxAccounts:        5,
+		})
+
+		assert.NoError(t, err)
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("PUT", "/", bytes.NewBuffer(body))
+		c.Params = []gin.Param{
+			{
+				Key:   "tenantId",
+				Value: "1",
+			},
+		}
+
+		sut.UpdateLimits(c)
+		tools.RenderProblem(c)
+
+		var responseBody dto.TenantSettingsResponse
+		err = json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
+		assert.Equal(t, int32(5), responseBody.Limits.MaxAccounts)
+	})
+}
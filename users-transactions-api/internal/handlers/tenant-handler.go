@@ -1,50 +1,55 @@
 package handlers
 
 import (
-	"log/slog"
+	"context"
+
 	"net/http"
-	"strconv"
 
-	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
 	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/tenant"
 	"github.com/gin-gonic/gin"
 )
 
 type TenantHandler struct {
-	findOneTenantUsecase *usecases.FindOneTenantUseCase
+	findOneTenantUsecase    *usecases.FindOneTenantUseCase
+	createTenantUsecase     *usecases.CreateTenantUsecase
+	findAllTenantsUsecase   *usecases.FindAllTenantsUsecase
+	renameTenantUsecase     *usecases.RenameTenantUsecase
+	suspendTenantUsecase    *usecases.SuspendTenantUsecase
+	reactivateTenantUsecase *usecases.ReactivateTenantUsecase
 }
 
-func NewTenantHandler(findOneTenantUsecase *usecases.FindOneTenantUseCase) *TenantHandler {
+func NewTenantHandler(findOneTenantUsecase *usecases.FindOneTenantUseCase,
+	createTenantUsecase *usecases.CreateTenantUsecase,
+	findAllTenantsUsecase *usecases.FindAllTenantsUsecase,
+	renameTenantUsecase *usecases.RenameTenantUsecase,
+	suspendTenantUsecase *usecases.SuspendTenantUsecase,
+	reactivateTenantUsecase *usecases.ReactivateTenantUsecase) *TenantHandler {
 	return &TenantHandler{
-		findOneTenantUsecase: findOneTenantUsecase,
+		findOneTenantUsecase:    findOneTenantUsecase,
+		createTenantUsecase:     createTenantUsecase,
+		findAllTenantsUsecase:   findAllTenantsUsecase,
+		renameTenantUsecase:     renameTenantUsecase,
+		suspendTenantUsecase:    suspendTenantUsecase,
+		reactivateTenantUsecase: reactivateTenantUsecase,
 	}
 }
 
 func (th *TenantHandler) FindTenant() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		tenantId, err := strconv.ParseInt(c.GetHeader("tenant-id"), 0, 32)
+		tenantId, valid := tools.CheckTenantHeader(c)
 
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tenant id"})
+		if !valid {
 			c.Abort()
 			return
 		}
 
-		_, err = th.findOneTenantUsecase.FindOne(int32(tenantId))
+		_, err := th.findOneTenantUsecase.FindOne(c.Request.Context(), tenantId)
 
 		if err != nil {
-			if ae, ok := err.(*shared.EntityNotFoundError); ok {
-				c.JSON(http.StatusBadRequest, gin.H{"error": ae.Error()})
-				c.Abort()
-				return
-			}
-
-			slog.Error(
-				"tenant handler",
-				slog.String("method", "FindTenant"),
-				slog.String("error", err.Error()),
-			)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error"})
+			c.Error(err)
 			c.Abort()
 			return
 		}
@@ -52,3 +57,85 @@ func (th *TenantHandler) FindTenant() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+func (th *TenantHandler) Create(c *gin.Context) {
+	var request dto.TenantRequest
+
+	if !tools.BindJSON(c, &request) {
+		return
+	}
+
+	result, err := th.createTenantUsecase.Create(c.Request.Context(), request.Name, request.AdminEmail)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CreatedTenantToResponse(*result))
+}
+
+func (th *TenantHandler) FindAll(c *gin.Context) {
+	tenants, err := th.findAllTenantsUsecase.FindAll(c.Request.Context())
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	tenantsResponse := make([]dto.TenantResponse, 0)
+	for _, tenant := range tenants {
+		tenantsResponse = append(tenantsResponse, dto.TenantToResponse(tenant))
+	}
+
+	c.JSON(http.StatusOK, tenantsResponse)
+}
+
+func (th *TenantHandler) Rename(c *gin.Context) {
+	tenantId, valid := tools.ParamId(c, "tenantId")
+
+	if !valid {
+		return
+	}
+
+	var request dto.RenameTenantRequest
+
+	if !tools.BindJSON(c, &request) {
+		return
+	}
+
+	renamedTenant, err := th.renameTenantUsecase.Rename(c.Request.Context(), tenantId, request.Name)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TenantToResponse(*renamedTenant))
+}
+
+func (th *TenantHandler) Suspend(c *gin.Context) {
+	th.updateStatus(c, "Suspend", th.suspendTenantUsecase.Suspend)
+}
+
+func (th *TenantHandler) Reactivate(c *gin.Context) {
+	th.updateStatus(c, "Reactivate", th.reactivateTenantUsecase.Reactivate)
+}
+
+func (th *TenantHandler) updateStatus(c *gin.Context, method string,
+	update func(ctx context.Context, tenantId int32) (*infra.Tenant, error)) {
+	tenantId, valid := tools.ParamId(c, "tenantId")
+
+	if !valid {
+		return
+	}
+
+	updatedTenant, err := update(c.Request.Context(), tenantId)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TenantToResponse(*updatedTenant))
+}
@@ -1,19 +1,21 @@
 package tools
 
 import (
-	"net/http"
 	"strconv"
 
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/validation"
 	"github.com/gin-gonic/gin"
 )
 
 func CheckTenantHeader(c *gin.Context) (int32, bool) {
-	tenantId, err := strconv.ParseInt(c.GetHeader("tenant-id"), 0, 32)
+	value := c.GetHeader("tenant-id")
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tenant-id"})
+	if err := validation.Var("tenant-id", value, "id"); err != nil {
+		c.Error(err)
 		return -1, false
 	}
 
+	tenantId, _ := strconv.ParseInt(value, 0, 32)
+
 	return int32(tenantId), true
 }
@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ETag is the entity tag of a versioned entity.
+func ETag(version int32) string {
+	return fmt.Sprintf("%q", strconv.FormatInt(int64(version), 10))
+}
+
+// WeakETag is used when the representation also depends on something other
+// than the entity, like amounts formatted with the tenant locale.
+func WeakETag(version int32) string {
+	return "W/" + ETag(version)
+}
+
+// NotModified sets the ETag header and answers 304 when the client already
+// holds this version. Handlers stop when it returns true.
+func NotModified(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+	etag = strings.TrimPrefix(etag, "W/")
+
+	ifNoneMatch := c.GetHeader("If-None-Match")
+
+	if len(ifNoneMatch) == 0 {
+		return false
+	}
+
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		// If-None-Match uses the weak comparison
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+
+		if tag == "*" || tag == etag {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
+
+// IfMatchVersion returns the version required by If-Match, or zero when the
+// header is absent or "*". Tags that are not ours can never match, so they
+// answer 412 right away.
+func IfMatchVersion(c *gin.Context) (int32, bool) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+
+	if len(ifMatch) == 0 || ifMatch == "*" {
+		return 0, true
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(ifMatch, `"`), 10, 32)
+
+	if err != nil || version <= 0 || strings.HasPrefix(ifMatch, "W/") {
+		c.Error(&ifMatchError{})
+		return -1, false
+	}
+
+	return int32(version), true
+}
+
+// ifMatchError is a precondition failure that has no current version to
+// report, the tag sent could never match one of ours.
+type ifMatchError struct{}
+
+func (e *ifMatchError) Error() string {
+	return "If-Match does not match the current version"
+}
@@ -0,0 +1,17 @@
+package tools
+
+import (
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/gin-gonic/gin"
+)
+
+func GetPrincipal(c *gin.Context) (*shared.Principal, bool) {
+	principal, ok := shared.PrincipalFromContext(c.Request.Context())
+
+	if !ok {
+		c.Error(&shared.UnauthorizedError{Message: "missing credentials"})
+		return nil, false
+	}
+
+	return principal, true
+}
@@ -0,0 +1,22 @@
+package tools
+
+import (
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const localeFormatterKey = "locale-formatter"
+
+func SetLocaleFormatter(c *gin.Context, formatter *utils.LocaleFormatter) {
+	c.Set(localeFormatterKey, formatter)
+}
+
+// GetLocaleFormatter returns the formatter of the tenant settings loaded for
+// the request, or the default one when none was loaded.
+func GetLocaleFormatter(c *gin.Context) *utils.LocaleFormatter {
+	if formatter, ok := c.Get(localeFormatterKey); ok {
+		return formatter.(*utils.LocaleFormatter)
+	}
+
+	return utils.DefaultLocaleFormatter()
+}
@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"strconv"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// ParamId parses an id path parameter, recording a validation error for
+// the problem middleware when it is not a positive number.
+func ParamId(c *gin.Context, name string) (int32, bool) {
+	value := c.Param(name)
+
+	if err := validation.Var(name, value, "id"); err != nil {
+		c.Error(err)
+		return -1, false
+	}
+
+	id, _ := strconv.ParseInt(value, 0, 32)
+
+	return int32(id), true
+}
+
+// Query returns a query parameter, or defaultValue when it is absent,
+// checked against the validation rules in tag.
+func Query(c *gin.Context, name string, defaultValue string, tag string) (string, bool) {
+	value := c.DefaultQuery(name, defaultValue)
+
+	if err := validation.Var(name, value, tag); err != nil {
+		c.Error(err)
+		return "", false
+	}
+
+	return value, true
+}
+
+// BindJSON records malformed bodies as bind errors, they answer 400, and
+// then checks the validate tags of the request, all the failing fields are
+// reported together.
+func BindJSON(c *gin.Context, request any) bool {
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return false
+	}
+
+	if err := validation.Struct(c.Request.Context(), request); err != nil {
+		c.Error(err)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,217 @@
+package tools
+
+import (
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// Problem types are relative URIs so they stay the same across
+// environments, clients should branch on them instead of on the title.
+const (
+	ProblemTypeValidation         = "/problems/validation-error"
+	ProblemTypeBadRequest         = "/problems/bad-request"
+	ProblemTypeUnauthorized       = "/problems/unauthorized"
+	ProblemTypeForbidden          = "/problems/forbidden"
+	ProblemTypeNotFound           = "/problems/not-found"
+	ProblemTypeAlreadyExists      = "/problems/already-exists"
+	ProblemTypeAccountStatus      = "/problems/account-status"
+	ProblemTypeInvalidTransition  = "/problems/invalid-status-transition"
+	ProblemTypeConflict           = "/problems/conflict"
+	ProblemTypePreconditionFailed = "/problems/precondition-failed"
+	ProblemTypeQuotaExceeded      = "/problems/quota-exceeded"
+	ProblemTypeRateLimited        = "/problems/rate-limited"
+	ProblemTypeUpstreamTimeout    = "/problems/upstream-timeout"
+	ProblemTypeUpstream           = "/problems/upstream-unavailable"
+	ProblemTypeInternal           = "/problems/internal-error"
+)
+
+// Problem is the RFC 7807 body of every error response.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	RequestId string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+type FieldError struct {
+	Field  string `json:"field"`
+	Detail string `json:"detail"`
+}
+
+// Problems renders the last error recorded with c.Error as problem+json.
+// It must come before the middlewares that abort, right after RequestId.
+func Problems() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		RenderProblem(c)
+	}
+}
+
+// RenderProblem writes the problem of the last recorded error, unless the
+// handler already wrote a response.
+func RenderProblem(c *gin.Context) {
+	last := c.Errors.Last()
+
+	if last == nil || c.Writer.Written() {
+		return
+	}
+
+	problem := problemFor(c, last)
+	problem.Instance = c.Request.URL.Path
+	problem.RequestId, _ = shared.RequestIdFromContext(c.Request.Context())
+
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(problem.Status, problem)
+}
+
+func problemFor(c *gin.Context, ginErr *gin.Error) Problem {
+	err := ginErr.Err
+
+	if ginErr.IsType(gin.ErrorTypeBind) {
+		return newProblem(http.StatusBadRequest, ProblemTypeBadRequest, "Malformed request", err.Error())
+	}
+
+	var (
+		ve  *shared.ValidationError
+		enf *shared.EntityNotFoundError
+		ae  *shared.AlreadyExistsError
+		ase *shared.AccountStatusError
+		ite *shared.InvalidTransitionError
+		ue  *shared.UnauthorizedError
+		fe  *shared.ForbiddenError
+		pfe *shared.PreconditionFailedError
+		ime *ifMatchError
+		qe  *shared.QuotaExceededError
+		rle *shared.RateLimitError
+	)
+
+	switch {
+	case errors.As(err, &ve):
+		problem := newProblem(http.StatusBadRequest, ProblemTypeValidation, "Invalid request",
+			"one or more fields are invalid")
+		problem.Errors = fieldErrors(ve)
+		return problem
+	case errors.As(err, &enf):
+		return newProblem(http.StatusNotFound, ProblemTypeNotFound, "Resource not found", enf.Error())
+	case errors.As(err, &ae):
+		return newProblem(http.StatusConflict, ProblemTypeAlreadyExists, "Resource already exists", ae.Error())
+	case errors.As(err, &ase):
+		return newProblem(http.StatusConflict, ProblemTypeAccountStatus, "Operation not allowed", ase.Error())
+	case errors.As(err, &ite):
+		return newProblem(http.StatusConflict, ProblemTypeInvalidTransition, "Invalid status transition",
+			ite.Error())
+	case errors.As(err, &ue):
+		return newProblem(http.StatusUnauthorized, ProblemTypeUnauthorized, "Unauthorized", ue.Error())
+	case errors.As(err, &fe):
+		return newProblem(http.StatusForbidden, ProblemTypeForbidden, "Forbidden", fe.Error())
+	case errors.As(err, &pfe):
+		// the current ETag lets the client refetch and retry
+		c.Header("ETag", ETag(pfe.Version))
+		return newProblem(http.StatusPreconditionFailed, ProblemTypePreconditionFailed, "Precondition failed",
+			pfe.Error())
+	case errors.As(err, &ime):
+		return newProblem(http.StatusPreconditionFailed, ProblemTypePreconditionFailed, "Precondition failed",
+			ime.Error())
+	case errors.As(err, &qe):
+		// quotas reset over time are retried later, the others only an
+		// admin can raise
+		if qe.RetryAfter > 0 {
+			setRetryAfter(c, qe.RetryAfter.Seconds())
+			return newProblem(http.StatusTooManyRequests, ProblemTypeQuotaExceeded, "Quota exceeded", qe.Error())
+		}
+		return newProblem(http.StatusForbidden, ProblemTypeQuotaExceeded, "Quota exceeded", qe.Error())
+	case errors.As(err, &rle):
+		setRetryAfter(c, rle.RetryAfter.Seconds())
+		return newProblem(http.StatusTooManyRequests, ProblemTypeRateLimited, "Too many requests", rle.Error())
+	}
+
+	if s, ok := status.FromError(err); ok {
+		return grpcProblem(c, s)
+	}
+
+	logInternalError(c, err)
+	return newProblem(http.StatusInternalServerError, ProblemTypeInternal, "Internal Server Error", "")
+}
+
+// grpcProblem maps the status of a failed upstream call. Client errors keep
+// their message, the server ones are logged and hidden.
+func grpcProblem(c *gin.Context, s *status.Status) Problem {
+	switch s.Code() {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return newProblem(http.StatusBadRequest, ProblemTypeBadRequest, "Malformed request", s.Message())
+	case codes.Unauthenticated:
+		return newProblem(http.StatusUnauthorized, ProblemTypeUnauthorized, "Unauthorized", s.Message())
+	case codes.PermissionDenied:
+		return newProblem(http.StatusForbidden, ProblemTypeForbidden, "Forbidden", s.Message())
+	case codes.NotFound:
+		return newProblem(http.StatusNotFound, ProblemTypeNotFound, "Resource not found", s.Message())
+	case codes.AlreadyExists:
+		return newProblem(http.StatusConflict, ProblemTypeAlreadyExists, "Resource already exists", s.Message())
+	case codes.FailedPrecondition, codes.Aborted:
+		return newProblem(http.StatusConflict, ProblemTypeConflict, "Conflict", s.Message())
+	case codes.ResourceExhausted:
+		return newProblem(http.StatusTooManyRequests, ProblemTypeRateLimited, "Too many requests", s.Message())
+	case codes.DeadlineExceeded:
+		logInternalError(c, s.Err())
+		return newProblem(http.StatusGatewayTimeout, ProblemTypeUpstreamTimeout, "Upstream timeout", "")
+	case codes.Unavailable:
+		logInternalError(c, s.Err())
+		return newProblem(http.StatusServiceUnavailable, ProblemTypeUpstream, "Upstream unavailable", "")
+	}
+
+	logInternalError(c, s.Err())
+	return newProblem(http.StatusInternalServerError, ProblemTypeInternal, "Internal Server Error", "")
+}
+
+func newProblem(status int, problemType string, title string, detail string) Problem {
+	return Problem{
+		Type:   problemType,
+		Title:  title,
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// fieldErrors are sorted so the same request always gets the same body.
+func fieldErrors(ve *shared.ValidationError) []FieldError {
+	fields := make([]FieldError, 0, len(ve.Errors))
+
+	for field, detail := range ve.Errors {
+		fields = append(fields, FieldError{Field: field, Detail: detail})
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Field < fields[j].Field
+	})
+
+	return fields
+}
+
+func setRetryAfter(c *gin.Context, seconds float64) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(seconds))))
+}
+
+func logInternalError(c *gin.Context, err error) {
+	slog.ErrorContext(
+		c.Request.Context(),
+		"internal server error",
+		slog.String("method", c.Request.Method),
+		slog.String("path", c.FullPath()),
+		slog.String("error", err.Error()),
+	)
+}
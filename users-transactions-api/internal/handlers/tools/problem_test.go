@@ -0,0 +1,145 @@
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProblems(t *testing.T) {
+	t.Parallel()
+
+	serve := func(handler gin.HandlerFunc) (*httptest.ResponseRecorder, Problem) {
+		res := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(res)
+
+		router.Use(RequestId(), Problems())
+		router.GET("/account/:accountId", handler)
+
+		req := httptest.NewRequest("GET", "/account/1", nil)
+		req.Header.Set(shared.RequestIdHeader, "req-1")
+		router.ServeHTTP(res, req)
+
+		var problem Problem
+		_ = json.NewDecoder(res.Body).Decode(&problem)
+
+		return res, problem
+	}
+
+	t.Run("Entity not found", func(t *testing.T) {
+		res, problem := serve(func(c *gin.Context) {
+			c.Error(&shared.EntityNotFoundError{Object: "account", Id: 1})
+		})
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, ProblemContentType, res.Header().Get("Content-Type"))
+		assert.Equal(t, Problem{
+			Type:      ProblemTypeNotFound,
+			Title:     "Resource not found",
+			Status:    http.StatusNotFound,
+			Detail:    "account not found with id 1",
+			Instance:  "/account/1",
+			RequestId: "req-1",
+		}, problem)
+	})
+
+	t.Run("Wrapped error", func(t *testing.T) {
+		res, problem := serve(func(c *gin.Context) {
+			c.Error(errors.Join(errors.New("find account"), &shared.ForbiddenError{Message: "not yours"}))
+		})
+
+		assert.Equal(t, http.StatusForbidden, res.Code)
+		assert.Equal(t, ProblemTypeForbidden, problem.Type)
+	})
+
+	t.Run("Precondition failed sets the current etag", func(t *testing.T) {
+		res, problem := serve(func(c *gin.Context) {
+			c.Error(&shared.PreconditionFailedError{Object: "account", Id: 1, Version: 3})
+		})
+
+		assert.Equal(t, http.StatusPreconditionFailed, res.Code)
+		assert.Equal(t, `"3"`, res.Header().Get("ETag"))
+		assert.Equal(t, ProblemTypePreconditionFailed, problem.Type)
+	})
+
+	t.Run("Quota exceeded", func(t *testing.T) {
+		res, _ := serve(func(c *gin.Context) {
+			c.Error(&shared.QuotaExceededError{Quota: "accounts", Limit: 10})
+		})
+
+		assert.Equal(t, http.StatusForbidden, res.Code)
+		assert.Empty(t, res.Header().Get("Retry-After"))
+
+		res, problem := serve(func(c *gin.Context) {
+			c.Error(&shared.QuotaExceededError{Quota: "transactions", Limit: 10, RetryAfter: 1500 * time.Millisecond})
+		})
+
+		assert.Equal(t, http.StatusTooManyRequests, res.Code)
+		assert.Equal(t, "2", res.Header().Get("Retry-After"))
+		assert.Equal(t, ProblemTypeQuotaExceeded, problem.Type)
+	})
+
+	t.Run("Bind error", func(t *testing.T) {
+		res, problem := serve(func(c *gin.Context) {
+			c.Error(errors.New("unexpected EOF")).SetType(gin.ErrorTypeBind)
+		})
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, ProblemTypeBadRequest, problem.Type)
+		assert.Equal(t, "unexpected EOF", problem.Detail)
+	})
+
+	t.Run("Invalid path parameter", func(t *testing.T) {
+		res, problem := serve(func(c *gin.Context) {
+			ParamId(c, "cardId")
+		})
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, []FieldError{{Field: "cardId", Detail: "must be a valid id"}}, problem.Errors)
+	})
+
+	t.Run("gRPC status", func(t *testing.T) {
+		res, problem := serve(func(c *gin.Context) {
+			c.Error(status.Error(codes.PermissionDenied, "report is not allowed"))
+		})
+
+		assert.Equal(t, http.StatusForbidden, res.Code)
+		assert.Equal(t, "report is not allowed", problem.Detail)
+
+		res, problem = serve(func(c *gin.Context) {
+			c.Error(status.Error(codes.Unavailable, "connection refused"))
+		})
+
+		assert.Equal(t, http.StatusServiceUnavailable, res.Code)
+		assert.Equal(t, ProblemTypeUpstream, problem.Type)
+		assert.Empty(t, problem.Detail)
+	})
+
+	t.Run("Unknown error is hidden", func(t *testing.T) {
+		res, problem := serve(func(c *gin.Context) {
+			c.Error(errors.New("pq: connection reset"))
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+		assert.Equal(t, ProblemTypeInternal, problem.Type)
+		assert.Empty(t, problem.Detail)
+	})
+
+	t.Run("Written response is kept", func(t *testing.T) {
+		res, _ := serve(func(c *gin.Context) {
+			c.Error(errors.New("logged only"))
+			c.JSON(http.StatusOK, "pong")
+		})
+
+		assert.Equal(t, http.StatusOK, res.Code)
+	})
+}
@@ -0,0 +1,24 @@
+package tools
+
+import (
+	"net/http"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/gin-gonic/gin"
+)
+
+// ReadYourWrites keeps the reads of the request on the primary database
+// when the client sends X-Read-Your-Writes: true, and for every request
+// that is not a GET or HEAD, whose checks must see the latest data.
+func ReadYourWrites() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		method := c.Request.Method
+		safe := method == http.MethodGet || method == http.MethodHead
+
+		if !safe || shared.IsReadYourWrites(c.GetHeader(shared.ReadYourWritesHeader)) {
+			c.Request = c.Request.WithContext(shared.ContextWithReadYourWrites(c.Request.Context()))
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,48 @@
+package tools
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadYourWrites(t *testing.T) {
+	serve := func(method string, header string) bool {
+		var readYourWrites bool
+
+		res := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(res)
+
+		router.Use(ReadYourWrites())
+		router.Handle(method, "/account", func(c *gin.Context) {
+			readYourWrites = shared.ReadYourWritesFromContext(c.Request.Context())
+		})
+
+		req := httptest.NewRequest(method, "/account", nil)
+		if len(header) > 0 {
+			req.Header.Set(shared.ReadYourWritesHeader, header)
+		}
+		router.ServeHTTP(res, req)
+
+		return readYourWrites
+	}
+
+	t.Run("Reads may go to the replica", func(t *testing.T) {
+		assert.False(t, serve("GET", ""))
+		assert.False(t, serve("GET", "false"))
+	})
+
+	t.Run("Client asks for read-your-writes", func(t *testing.T) {
+		assert.True(t, serve("GET", "true"))
+		assert.True(t, serve("GET", "TRUE"))
+	})
+
+	t.Run("Writes read from the primary", func(t *testing.T) {
+		assert.True(t, serve("POST", ""))
+		assert.True(t, serve("PUT", ""))
+		assert.True(t, serve("DELETE", ""))
+	})
+}
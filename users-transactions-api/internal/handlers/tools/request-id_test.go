@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestId(t *testing.T) {
+	serve := func(requestId string, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+		res := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(res)
+
+		router.Use(RequestId())
+		router.GET("/ping", handler)
+
+		req := httptest.NewRequest("GET", "/ping", nil)
+		if len(requestId) > 0 {
+			req.Header.Set(shared.RequestIdHeader, requestId)
+		}
+		router.ServeHTTP(res, req)
+
+		return res
+	}
+
+	t.Run("Keeps the client id", func(t *testing.T) {
+		var requestId string
+
+		res := serve("4bf92f35-77b3-4da6-a3ce-929d0e0e4736", func(c *gin.Context) {
+			requestId, _ = shared.RequestIdFromContext(c.Request.Context())
+		})
+
+		assert.Equal(t, "4bf92f35-77b3-4da6-a3ce-929d0e0e4736", requestId)
+		assert.Equal(t, requestId, res.Header().Get(shared.RequestIdHeader))
+	})
+
+	t.Run("Creates a missing id", func(t *testing.T) {
+		var requestId string
+
+		res := serve("", func(c *gin.Context) {
+			requestId, _ = shared.RequestIdFromContext(c.Request.Context())
+		})
+
+		assert.Len(t, requestId, 32)
+		assert.Equal(t, requestId, res.Header().Get(shared.RequestIdHeader))
+	})
+
+	t.Run("Replaces an invalid id", func(t *testing.T) {
+		res := serve("id\nwith new line", func(c *gin.Context) {})
+
+		assert.Len(t, res.Header().Get(shared.RequestIdHeader), 32)
+	})
+
+	t.Run("Logs the id", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(shared.NewRequestIdHandler(slog.NewJSONHandler(&buf, nil)))
+
+		serve("req-1", func(c *gin.Context) {
+			logger.With(slog.String("path", "/ping")).InfoContext(c.Request.Context(), "handled")
+		})
+
+		var record map[string]any
+		_ = json.Unmarshal(buf.Bytes(), &record)
+
+		assert.Equal(t, "req-1", record["request_id"])
+		assert.Equal(t, "/ping", record["path"])
+	})
+}
@@ -0,0 +1,32 @@
+package tools
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePlatformAdmin guards the routes that manage tenants themselves, they
+// are not bound to any tenant so the tenant credentials are not accepted.
+// An empty token disables those routes.
+func RequirePlatformAdmin(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		credential, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+		if !found || len(credential) == 0 {
+			c.Error(&shared.UnauthorizedError{Message: "missing credentials"})
+			c.Abort()
+			return
+		}
+
+		if len(token) == 0 || subtle.ConstantTimeCompare([]byte(credential), []byte(token)) != 1 {
+			c.Error(&shared.UnauthorizedError{Message: "invalid platform admin token"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
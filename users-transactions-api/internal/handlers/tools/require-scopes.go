@@ -0,0 +1,36 @@
+package tools
+
+import (
+	"net/http"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireScopes allows safe methods with the read scope and every other
+// method with the write scope.
+func RequireScopes(readScope string, writeScope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scope := writeScope
+
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			scope = readScope
+		}
+
+		principal, ok := shared.PrincipalFromContext(c.Request.Context())
+
+		if !ok {
+			c.Error(&shared.UnauthorizedError{Message: "missing credentials"})
+			c.Abort()
+			return
+		}
+
+		if !principal.HasScope(scope) {
+			c.Error(&shared.ForbiddenError{Message: "missing scope " + scope})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -1,13 +1,16 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	cardUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/cards"
 	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/transactions"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/validation"
 	"github.com/gin-gonic/gin"
 )
 
@@ -15,15 +18,18 @@ type TransactionHandler struct {
 	createTransactionUsecase *usecases.CreateTransactionUsecase
 	findTransactionUsecase   *usecases.FindTransactionUsecase
 	findTransactionsUsecase  *usecases.FindAllTransactionsUsecase
+	findCardUsecase          *cardUsecases.FindCardUsecase
 }
 
 func NewTransactionHandler(createTransactionUsecase *usecases.CreateTransactionUsecase,
 	findTransactionUsecase *usecases.FindTransactionUsecase,
-	findTransactionsUsecase *usecases.FindAllTransactionsUsecase) *TransactionHandler {
+	findTransactionsUsecase *usecases.FindAllTransactionsUsecase,
+	findCardUsecase *cardUsecases.FindCardUsecase) *TransactionHandler {
 	return &TransactionHandler{
 		createTransactionUsecase: createTransactionUsecase,
 		findTransactionUsecase:   findTransactionUsecase,
 		findTransactionsUsecase:  findTransactionsUsecase,
+		findCardUsecase:          findCardUsecase,
 	}
 }
 
@@ -34,38 +40,29 @@ func (th *TransactionHandler) Create(c *gin.Context) {
 		return
 	}
 
-	accountId, err := strconv.ParseInt(c.Param("accountId"), 0, 32)
+	accountId, valid := tools.ParamId(c, "accountId")
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account id"})
+	if !valid {
 		return
 	}
 
+	c.Request = c.Request.WithContext(validation.WithCardLookup(c.Request.Context(), th.cardLookup(tenantId, accountId)))
+
 	var request dto.TransactioRequest
 
-	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !tools.BindJSON(c, &request) {
 		return
 	}
 
-	transaction, err := th.createTransactionUsecase.Create(tenantId, int32(accountId), dto.RequestToTransaction(request))
+	transaction, err := th.createTransactionUsecase.Create(c.Request.Context(), tenantId, accountId,
+		request.TransactionDirection(), dto.RequestToTransaction(request))
 
 	if err != nil {
-		if enf, ok := err.(*shared.EntityNotFoundError); ok {
-			c.JSON(http.StatusNotFound, gin.H{"error": enf.Error()})
-			return
-		}
-
-		if ve, ok := err.(*shared.ValidationError); ok {
-			c.JSON(http.StatusNotFound, ve)
-			return
-		}
-
-		tools.LogInternalServerError(c, "transaction handler", "Create", err)
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, dto.TransactionToResponse(*transaction))
+	c.JSON(http.StatusCreated, dto.TransactionToResponse(*transaction, tools.GetLocaleFormatter(c)))
 }
 
 func (th *TransactionHandler) FindOne(c *gin.Context) {
@@ -75,40 +72,33 @@ func (th *TransactionHandler) FindOne(c *gin.Context) {
 		return
 	}
 
-	accountId, err := strconv.ParseInt(c.Param("accountId"), 0, 32)
+	accountId, valid := tools.ParamId(c, "accountId")
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account id"})
+	if !valid {
 		return
 	}
 
-	cardId, err := strconv.ParseInt(c.Param("cardId"), 0, 32)
+	cardId, valid := tools.ParamId(c, "cardId")
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account id"})
+	if !valid {
 		return
 	}
 
-	transactionId, err := strconv.ParseInt(c.Param("transactionId"), 0, 32)
+	transactionId, valid := tools.ParamId(c, "transactionId")
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account id"})
+	if !valid {
 		return
 	}
 
-	transaction, err := th.findTransactionUsecase.FindOne(tenantId, int32(accountId),
-		int32(cardId), int32(transactionId))
+	transaction, err := th.findTransactionUsecase.FindOne(c.Request.Context(), tenantId, accountId,
+		cardId, transactionId)
 
 	if err != nil {
-		if enf, ok := err.(*shared.EntityNotFoundError); ok {
-			c.JSON(http.StatusNotFound, gin.H{"error": enf.Error()})
-			return
-		}
-		tools.LogInternalServerError(c, "transaction handler", "Create", err)
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.TransactionToResponse(*transaction))
+	c.JSON(http.StatusOK, dto.TransactionToResponse(*transaction, tools.GetLocaleFormatter(c)))
 }
 
 func (th *TransactionHandler) FindAll(c *gin.Context) {
@@ -118,37 +108,48 @@ func (th *TransactionHandler) FindAll(c *gin.Context) {
 		return
 	}
 
-	accountId, err := strconv.ParseInt(c.Param("accountId"), 0, 32)
+	accountId, valid := tools.ParamId(c, "accountId")
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account id"})
+	if !valid {
 		return
 	}
 
-	cardId, err := strconv.ParseInt(c.Param("cardId"), 0, 32)
+	cardId, valid := tools.ParamId(c, "cardId")
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account id"})
+	if !valid {
 		return
 	}
 
-	transactions, err := th.findTransactionsUsecase.FindAll(tenantId, int32(accountId),
-		int32(cardId))
+	transactions, err := th.findTransactionsUsecase.FindAll(c.Request.Context(), tenantId, accountId,
+		cardId)
 
 	if err != nil {
-		if enf, ok := err.(*shared.EntityNotFoundError); ok {
-			c.JSON(http.StatusNotFound, gin.H{"error": enf.Error()})
-			return
-		}
-		tools.LogInternalServerError(c, "transaction handler", "Create", err)
+		c.Error(err)
 		return
 	}
 
+	formatter := tools.GetLocaleFormatter(c)
 	transactionsResponse := make([]dto.TransactionResponse, 0)
 
 	for _, transaction := range transactions {
-		transactionsResponse = append(transactionsResponse, dto.TransactionToResponse(transaction))
+		transactionsResponse = append(transactionsResponse, dto.TransactionToResponse(transaction, formatter))
 	}
 
 	c.JSON(http.StatusOK, transactionsResponse)
 }
+
+// cardLookup backs the existing_card rule of the request. A missing card is a
+// field error, anything else, like a missing account, is returned as is.
+func (th *TransactionHandler) cardLookup(tenantId int32, accountId int32) validation.CardLookup {
+	return func(ctx context.Context, cardId int32) (bool, error) {
+		_, err := th.findCardUsecase.FindOne(ctx, tenantId, accountId, cardId)
+
+		var enf *shared.EntityNotFoundError
+
+		if errors.As(err, &enf) && enf.Object == "card" {
+			return false, nil
+		}
+
+		return err == nil, err
+	}
+}
@@ -8,12 +8,17 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
 	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
 	accountUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/account"
 	cardUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/cards"
+	quotaUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/quota"
+	settingsUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/settings"
 	transactionUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/transactions"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -24,13 +29,20 @@ func TestTransactionHandler(t *testing.T) {
 
 	mockRepo := new(mocks.MockRepository)
 
+	// tenants without settings are not limited by quotas
+	mockRepo.On("GetTenantSettings").Return(nil, sql.ErrNoRows)
+	findTenantSettingsUsecase := settingsUsecases.NewFindTenantSettingsUsecase(mockRepo, settingsUsecases.NewSettingsCache(0))
+	quotaUsecase := quotaUsecases.NewQuotaUsecase(findTenantSettingsUsecase)
+
 	findAccountUsecase := accountUsecases.NewFindOneAccountUsecase(mockRepo)
 	findCardUsecase := cardUsecases.NewFindCardUsecase(mockRepo, findAccountUsecase)
-	createTransactionUsecase := transactionUsecases.NewCreateTransactionUsecase(mockRepo, findCardUsecase)
+	createTransactionUsecase := transactionUsecases.NewCreateTransactionUsecase(mockRepo, findCardUsecase,
+		quotaUsecase)
 	findTransactionUsecase := transactionUsecases.NewFindTransactionUsecase(mockRepo, findCardUsecase)
 	findTransactionsUsecase := transactionUsecases.NewFindAllTransactionsUsecase(mockRepo, findCardUsecase)
 
-	sut := NewTransactionHandler(createTransactionUsecase, findTransactionUsecase, findTransactionsUsecase)
+	sut := NewTransactionHandler(createTransactionUsecase, findTransactionUsecase, findTransactionsUsecase,
+		findCardUsecase)
 
 	account := infra.Account{
 		ID:       1,
@@ -45,16 +57,20 @@ func TestTransactionHandler(t *testing.T) {
 	}
 
 	transaction := infra.Transaction{
-		ID:     1,
-		CardID: 1,
-		Kind:   "Streaming Z",
-		Value:  50,
+		ID:        1,
+		CardID:    1,
+		Kind:      "Streaming Z",
+		Value:     50,
+		CreatedAt: time.Date(2024, 10, 19, 15, 0, 0, 0, time.UTC),
 	}
 
 	t.Run("[Create] Transaction created successfully", func(t *testing.T) {
 		mockRepo.On("GetAccount").Return(account, nil)
 		defer mockRepo.On("GetAccount").Unset()
 
+		mockRepo.On("GetAccountForShare").Return(account, nil)
+		defer mockRepo.On("GetAccountForShare").Unset()
+
 		mockRepo.On("GetCard").Return(card, nil)
 		defer mockRepo.On("GetCard").Unset()
 
@@ -80,19 +96,202 @@ func TestTransactionHandler(t *testing.T) {
 		}}
 
 		sut.Create(c)
+		tools.RenderProblem(c)
 
 		var responseBody dto.TransactionResponse
 		err = json.NewDecoder(res.Body).Decode(&responseBody)
+		responseBody.CreatedAt = responseBody.CreatedAt.UTC()
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusCreated, res.Result().StatusCode)
 		assert.Equal(t, dto.TransactionResponse{
-			ID:     transaction.ID,
+			ID:             transaction.ID,
+			CardId:         transaction.CardID,
+			Kind:           transaction.Kind,
+			Direction:      "debit",
+			Value:          transaction.Value,
+			FormattedValue: "R$ 0,50",
+			CreatedAt:      transaction.CreatedAt,
+		}, responseBody)
+	})
+
+	t.Run("[Create] Credit created on a frozen account", func(t *testing.T) {
+		frozenAccount := account
+		frozenAccount.Status = shared.AccountStatusFrozen
+
+		mockRepo.On("GetAccount").Return(frozenAccount, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetAccountForShare").Return(frozenAccount, nil)
+		defer mockRepo.On("GetAccountForShare").Unset()
+
+		mockRepo.On("GetCard").Return(card, nil)
+		defer mockRepo.On("GetCard").Unset()
+
+		credit := transaction
+		credit.Value = -transaction.Value
+
+		mockRepo.On("CreateTransactionTx").Return(credit, nil)
+		defer mockRepo.On("CreateTransactionTx").Unset()
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		body, err := json.Marshal(dto.TransactioRequest{
+			CardId:    transaction.CardID,
+			Kind:      transaction.Kind,
+			Value:     transaction.Value,
+			Direction: "credit",
+		})
+
+		assert.NoError(t, err)
+
+		c.Request = httptest.NewRequest("POST", "/transaction", bytes.NewReader(body))
+		c.Request.Header.Set("tenant-id", "1")
+		c.Params = []gin.Param{{
+			Key:   "accountId",
+			Value: fmt.Sprint(account.ID),
+		}}
+
+		sut.Create(c)
+		tools.RenderProblem(c)
+
+		var responseBody dto.TransactionResponse
+		err = json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, res.Result().StatusCode)
+		assert.Equal(t, "credit", responseBody.Direction)
+		assert.Equal(t, transaction.Value, responseBody.Value)
+		assert.Equal(t, "R$ 0,50", responseBody.FormattedValue)
+	})
+
+	t.Run("[Create] Error account suspended", func(t *testing.T) {
+		suspendedAccount := account
+		suspendedAccount.Status = "suspended"
+
+		mockRepo.On("GetAccount").Return(suspendedAccount, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetAccountForShare").Return(suspendedAccount, nil)
+		defer mockRepo.On("GetAccountForShare").Unset()
+
+		mockRepo.On("GetCard").Return(card, nil)
+		defer mockRepo.On("GetCard").Unset()
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		body, err := json.Marshal(dto.TransactioRequest{
 			CardId: transaction.CardID,
 			Kind:   transaction.Kind,
 			Value:  transaction.Value,
-		}, responseBody)
+		})
+
+		assert.NoError(t, err)
+
+		c.Request = httptest.NewRequest("POST", "/transaction", bytes.NewReader(body))
+		c.Request.Header.Set("tenant-id", "1")
+		c.Params = []gin.Param{{
+			Key:   "accountId",
+			Value: fmt.Sprint(account.ID),
+		}}
+
+		sut.Create(c)
+		tools.RenderProblem(c)
+
+		var responseBody tools.Problem
+		err = json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusConflict, res.Result().StatusCode)
+		assert.Equal(t, "debit is not allowed for a suspended account", responseBody.Detail)
+	})
+
+	t.Run("[Create] Invalid transaction", func(t *testing.T) {
+		mockRepo.On("GetAccount").Return(account, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetCard").Return(card, nil)
+		defer mockRepo.On("GetCard").Unset()
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		body, err := json.Marshal(dto.TransactioRequest{
+			CardId:    transaction.CardID,
+			Value:     -1,
+			Direction: "refund",
+		})
+
+		assert.NoError(t, err)
+
+		c.Request = httptest.NewRequest("POST", "/transaction", bytes.NewReader(body))
+		c.Request.Header.Set("tenant-id", "1")
+		c.Params = []gin.Param{{
+			Key:   "accountId",
+			Value: fmt.Sprint(account.ID),
+		}}
+
+		sut.Create(c)
+		tools.RenderProblem(c)
+
+		var responseBody tools.Problem
+		err = json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
+		assert.Equal(t, tools.ProblemContentType, res.Header().Get("Content-Type"))
+		assert.Equal(t, tools.ProblemTypeValidation, responseBody.Type)
+		assert.Equal(t, []tools.FieldError{
+			{Field: "direction", Detail: "must be one of debit or credit"},
+			{Field: "kind", Detail: "cannot be empty"},
+			{Field: "value", Detail: "must be greater than zero (0)"},
+		}, responseBody.Errors)
+	})
+
+	t.Run("[Create] Card not found in account", func(t *testing.T) {
+		mockRepo.On("GetAccount").Return(account, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetCard").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetCard").Unset()
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		body, err := json.Marshal(dto.TransactioRequest{
+			CardId: 99,
+			Kind:   transaction.Kind,
+			Value:  0,
+		})
+
+		assert.NoError(t, err)
+
+		c.Request = httptest.NewRequest("POST", "/transaction", bytes.NewReader(body))
+		c.Request.Header.Set("tenant-id", "1")
+		c.Params = []gin.Param{{
+			Key:   "accountId",
+			Value: fmt.Sprint(account.ID),
+		}}
+
+		sut.Create(c)
+		tools.RenderProblem(c)
+
+		var responseBody tools.Problem
+		err = json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
+		assert.Equal(t, []tools.FieldError{
+			{Field: "card_id", Detail: "card not found in this account"},
+			{Field: "value", Detail: "must be greater than zero (0)"},
+		}, responseBody.Errors)
 	})
 
 	t.Run("[FindOne] Success to find a transaction", func(t *testing.T) {
@@ -126,18 +325,23 @@ func TestTransactionHandler(t *testing.T) {
 		}
 
 		sut.FindOne(c)
+		tools.RenderProblem(c)
 
 		var responseBody dto.TransactionResponse
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
+		responseBody.CreatedAt = responseBody.CreatedAt.UTC()
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
 		assert.Equal(t, dto.TransactionResponse{
-			ID:     transaction.ID,
-			CardId: transaction.CardID,
-			Kind:   transaction.Kind,
-			Value:  transaction.Value,
+			ID:             transaction.ID,
+			CardId:         transaction.CardID,
+			Kind:           transaction.Kind,
+			Direction:      "debit",
+			Value:          transaction.Value,
+			FormattedValue: "R$ 0,50",
+			CreatedAt:      transaction.CreatedAt,
 		}, responseBody)
 	})
 
@@ -172,15 +376,16 @@ func TestTransactionHandler(t *testing.T) {
 		}
 
 		sut.FindOne(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusNotFound, res.Result().StatusCode)
 		assert.Equal(t, fmt.Sprintf("transaction not found with id %d", transaction.ID),
-			responseBody["error"])
+			responseBody.Detail)
 	})
 
 	t.Run("[FindAll] Success to find all transactions by card id", func(t *testing.T) {
@@ -212,19 +417,24 @@ func TestTransactionHandler(t *testing.T) {
 		}
 
 		sut.FindAll(c)
+		tools.RenderProblem(c)
 
 		var responseBody []dto.TransactionResponse
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
+		responseBody[0].CreatedAt = responseBody[0].CreatedAt.UTC()
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
 		assert.Equal(t, []dto.TransactionResponse{
 			{
-				ID:     transaction.ID,
-				CardId: transaction.CardID,
-				Kind:   transaction.Kind,
-				Value:  transaction.Value,
+				ID:             transaction.ID,
+				CardId:         transaction.CardID,
+				Kind:           transaction.Kind,
+				Direction:      "debit",
+				Value:          transaction.Value,
+				FormattedValue: "R$ 0,50",
+				CreatedAt:      transaction.CreatedAt,
 			},
 		}, responseBody)
 	})
@@ -253,14 +463,15 @@ func TestTransactionHandler(t *testing.T) {
 		}
 
 		sut.FindAll(c)
+		tools.RenderProblem(c)
 
-		var responseBody map[string]string
+		var responseBody tools.Problem
 		err := json.NewDecoder(res.Body).Decode(&responseBody)
 
 		assert.NoError(t, err)
 
 		assert.Equal(t, http.StatusNotFound, res.Result().StatusCode)
 		assert.Equal(t, fmt.Sprintf("card not found with id %d", transaction.ID),
-			responseBody["error"])
+			responseBody.Detail)
 	})
 }
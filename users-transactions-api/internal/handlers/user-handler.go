@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/user"
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	inviteUserUsecase     *usecases.InviteUserUsecase
+	findAllUsersUsecase   *usecases.FindAllUsersUsecase
+	updateUserRoleUsecase *usecases.UpdateUserRoleUsecase
+	disableUserUsecase    *usecases.DisableUserUsecase
+}
+
+func NewUserHandler(inviteUserUsecase *usecases.InviteUserUsecase,
+	findAllUsersUsecase *usecases.FindAllUsersUsecase,
+	updateUserRoleUsecase *usecases.UpdateUserRoleUsecase,
+	disableUserUsecase *usecases.DisableUserUsecase) *UserHandler {
+	return &UserHandler{
+		inviteUserUsecase:     inviteUserUsecase,
+		findAllUsersUsecase:   findAllUsersUsecase,
+		updateUserRoleUsecase: updateUserRoleUsecase,
+		disableUserUsecase:    disableUserUsecase,
+	}
+}
+
+func (uh *UserHandler) Invite(c *gin.Context) {
+	principal, valid := tools.GetPrincipal(c)
+
+	if !valid {
+		return
+	}
+
+	var request dto.InviteUserRequest
+
+	if !tools.BindJSON(c, &request) {
+		return
+	}
+
+	savedUser, err := uh.inviteUserUsecase.Invite(c.Request.Context(), principal, request.Email, request.Role)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.UserToResponse(*savedUser))
+}
+
+func (uh *UserHandler) FindAll(c *gin.Context) {
+	principal, valid := tools.GetPrincipal(c)
+
+	if !valid {
+		return
+	}
+
+	users, err := uh.findAllUsersUsecase.FindAll(c.Request.Context(), principal.TenantId)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	usersResponse := make([]dto.UserResponse, 0)
+	for _, user := range users {
+		usersResponse = append(usersResponse, dto.UserToResponse(user))
+	}
+
+	c.JSON(http.StatusOK, usersResponse)
+}
+
+func (uh *UserHandler) UpdateRole(c *gin.Context) {
+	principal, valid := tools.GetPrincipal(c)
+
+	if !valid {
+		return
+	}
+
+	userId, valid := tools.ParamId(c, "userId")
+
+	if !valid {
+		return
+	}
+
+	var request dto.UpdateUserRoleRequest
+
+	if !tools.BindJSON(c, &request) {
+		return
+	}
+
+	updatedUser, err := uh.updateUserRoleUsecase.UpdateRole(c.Request.Context(), principal, userId, request.Role)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.UserToResponse(*updatedUser))
+}
+
+func (uh *UserHandler) Disable(c *gin.Context) {
+	principal, valid := tools.GetPrincipal(c)
+
+	if !valid {
+		return
+	}
+
+	userId, valid := tools.ParamId(c, "userId")
+
+	if !valid {
+		return
+	}
+
+	err := uh.disableUserUsecase.Disable(c.Request.Context(), principal, userId)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("User with id %d was disabled successfully", userId)})
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/dto"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/handlers/tools"
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/user"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserHandler(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+	mockMailer := new(mocks.MockMailer)
+	inviteUserUsecase := usecases.NewInviteUserUsecase(mockRepo, mockRepo, mockMailer)
+	findAllUsersUsecase := usecases.NewFindAllUsersUsecase(mockRepo)
+	updateUserRoleUsecase := usecases.NewUpdateUserRoleUsecase(mockRepo)
+	disableUserUsecase := usecases.NewDisableUserUsecase(mockRepo)
+
+	sut := NewUserHandler(inviteUserUsecase, findAllUsersUsecase, updateUserRoleUsecase, disableUserUsecase)
+
+	admin := &shared.Principal{
+		TenantId: 1,
+		UserId:   1,
+		Role:     shared.RoleAdmin,
+		Scopes:   shared.RoleScopes(shared.RoleAdmin),
+	}
+
+	viewer := &shared.Principal{
+		TenantId: 1,
+		UserId:   3,
+		Role:     shared.RoleViewer,
+		Scopes:   shared.RoleScopes(shared.RoleViewer),
+	}
+
+	user := infra.User{
+		ID:       2,
+		TenantID: 1,
+		Email:    "john@example.com",
+		Role:     shared.RoleOperator,
+		Status:   "active",
+	}
+
+	t.Run("[Invite] Viewer cannot invite users", func(t *testing.T) {
+		body, _ := json.Marshal(dto.InviteUserRequest{Email: user.Email, Role: user.Role})
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("POST", "/user", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), viewer))
+
+		tools.RequireScopes(shared.ScopeUsersRead, shared.ScopeUsersWrite)(c)
+		tools.RenderProblem(c)
+
+		var responseBody tools.Problem
+		err := json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.True(t, c.IsAborted())
+		assert.Equal(t, http.StatusForbidden, res.Result().StatusCode)
+		assert.Equal(t, "missing scope users:write", responseBody.Detail)
+	})
+
+	t.Run("[Invite] Admin cannot invite owners", func(t *testing.T) {
+		body, _ := json.Marshal(dto.InviteUserRequest{Email: user.Email, Role: shared.RoleOwner})
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("POST", "/user", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), admin))
+
+		sut.Invite(c)
+		tools.RenderProblem(c)
+
+		var responseBody tools.Problem
+		err := json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusForbidden, res.Result().StatusCode)
+		assert.Equal(t, "only owners can invite owners", responseBody.Detail)
+	})
+
+	t.Run("[FindAll] Users found successfully", func(t *testing.T) {
+		mockRepo.On("GetUsers").Return([]infra.User{user}, nil)
+		defer mockRepo.On("GetUsers").Unset()
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("GET", "/user", nil)
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), admin))
+
+		sut.FindAll(c)
+		tools.RenderProblem(c)
+
+		var responseBody []dto.UserResponse
+		err := json.NewDecoder(res.Body).Decode(&responseBody)
+
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, res.Result().StatusCode)
+		assert.Equal(t, []dto.UserResponse{dto.UserToResponse(user)}, responseBody)
+	})
+
+	t.Run("[UpdateRole] Invalid user id", func(t *testing.T) {
+		body, _ := json.Marshal(dto.UpdateUserRoleRequest{Role: shared.RoleViewer})
+
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		c.Request = httptest.NewRequest("PUT", "/user/abc/role", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(), admin))
+		c.Params = gin.Params{{Key: "userId", Value: "abc"}}
+
+		sut.UpdateRole(c)
+		tools.RenderProblem(c)
+
+		assert.Equal(t, http.StatusBadRequest, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,422 @@
+// Package migrate applies the goose SQL migrations embedded in the binary.
+// It keeps the goose_db_version table of the goose cli, so databases
+// migrated by either can be handled by the other.
+package migrate
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// lockId is the key of the postgres advisory lock taken while migrating, so
+// replicas starting together apply each migration once.
+const lockId int64 = 8_245_901_736
+
+type Migration struct {
+	Version int64
+	Name    string
+	up      string
+	down    string
+	noTx    bool
+}
+
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New reads the migrations, files named <version>_<name>.sql, from the
+// root of fsys.
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+
+	for _, file := range files {
+		content, err := fs.ReadFile(fsys, file)
+
+		if err != nil {
+			return nil, err
+		}
+
+		migration, err := parse(file, string(content))
+
+		if err != nil {
+			return nil, err
+		}
+
+		migrations = append(migrations, migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version == migrations[i-1].Version {
+			return nil, fmt.Errorf("duplicate migration version %d", migrations[i].Version)
+		}
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// parse splits a goose migration in its up and down sections. The
+// StatementBegin and StatementEnd annotations are not needed, each section
+// is sent as a single multi-statement query.
+func parse(file string, content string) (Migration, error) {
+	name := strings.TrimSuffix(path.Base(file), ".sql")
+	prefix, _, _ := strings.Cut(name, "_")
+	version, err := strconv.ParseInt(prefix, 10, 64)
+
+	if err != nil || version < 1 {
+		return Migration{}, fmt.Errorf("migration %s has no version", file)
+	}
+
+	migration := Migration{Version: version, Name: name}
+
+	var up, down strings.Builder
+	var section *strings.Builder
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		annotation, isAnnotation := strings.CutPrefix(strings.TrimSpace(line), "-- +goose ")
+
+		if !isAnnotation {
+			if section != nil {
+				section.WriteString(line)
+				section.WriteString("\n")
+			}
+			continue
+		}
+
+		switch strings.ToUpper(strings.TrimSpace(annotation)) {
+		case "UP":
+			section = &up
+		case "DOWN":
+			section = &down
+		case "NO TRANSACTION":
+			migration.noTx = true
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Migration{}, fmt.Errorf("migration %s: %w", file, err)
+	}
+
+	if section == nil {
+		return Migration{}, fmt.Errorf("migration %s has no -- +goose Up annotation", file)
+	}
+
+	migration.up = up.String()
+	migration.down = down.String()
+
+	return migration, nil
+}
+
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Up applies the pending migrations in order and returns them.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if versions[migration.Version] {
+				continue
+			}
+
+			err := run(ctx, conn, migration, true)
+
+			if err != nil {
+				return err
+			}
+
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down rolls back the last applied migration, nil when there is none.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	var rolledBack *Migration
+
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		migration, err := m.last(ctx, conn)
+
+		if err != nil || migration == nil {
+			return err
+		}
+
+		rolledBack = migration
+
+		return run(ctx, conn, *migration, false)
+	})
+
+	return rolledBack, err
+}
+
+// Redo rolls back and applies again the last applied migration.
+func (m *Migrator) Redo(ctx context.Context) (*Migration, error) {
+	var redone *Migration
+
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		migration, err := m.last(ctx, conn)
+
+		if err != nil || migration == nil {
+			return err
+		}
+
+		redone = migration
+
+		err = run(ctx, conn, *migration, false)
+
+		if err != nil {
+			return err
+		}
+
+		return run(ctx, conn, *migration, true)
+	})
+
+	return redone, err
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Conn(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	err = ensureVersionTable(ctx, conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(ctx, `SELECT DISTINCT ON (version_id) version_id, is_applied, tstamp
+		FROM goose_db_version ORDER BY version_id, id DESC`)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	appliedAt := make(map[int64]time.Time)
+
+	for rows.Next() {
+		var version int64
+		var isApplied bool
+		var tstamp time.Time
+
+		if err := rows.Scan(&version, &isApplied, &tstamp); err != nil {
+			return nil, err
+		}
+
+		if isApplied {
+			appliedAt[version] = tstamp
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, len(m.migrations))
+
+	for i, migration := range m.migrations {
+		at, applied := appliedAt[migration.Version]
+		statuses[i] = Status{Migration: migration, Applied: applied, AppliedAt: at}
+	}
+
+	return statuses, nil
+}
+
+// last finds the applied migration with the highest version.
+func (m *Migrator) last(ctx context.Context, conn *sql.Conn) (*Migration, error) {
+	versions, err := appliedVersions(ctx, conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		if versions[m.migrations[i].Version] {
+			return &m.migrations[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// locked runs fn on a connection holding the advisory lock, which is
+// released with the connection.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockId)
+
+	if err != nil {
+		return fmt.Errorf("acquiring the migration lock: %w", err)
+	}
+
+	defer func() {
+		// the context may be done already, the unlock must still be sent
+		_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockId)
+	}()
+
+	err = ensureVersionTable(ctx, conn)
+
+	if err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// ensureVersionTable creates the goose table with its initial version 0,
+// like the goose cli does.
+func ensureVersionTable(ctx context.Context, conn *sql.Conn) error {
+	var exists bool
+
+	err := conn.QueryRowContext(ctx, "SELECT to_regclass('goose_db_version') IS NOT NULL").Scan(&exists)
+
+	if err != nil || exists {
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS goose_db_version (
+		id serial NOT NULL,
+		version_id bigint NOT NULL,
+		is_applied boolean NOT NULL,
+		tstamp timestamp NULL DEFAULT now(),
+		PRIMARY KEY(id)
+	)`)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, "INSERT INTO goose_db_version (version_id, is_applied) VALUES (0, true)")
+
+	return err
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]bool, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version_id, is_applied FROM goose_db_version ORDER BY id")
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]bool)
+
+	for rows.Next() {
+		var version int64
+		var isApplied bool
+
+		if err := rows.Scan(&version, &isApplied); err != nil {
+			return nil, err
+		}
+
+		applied[version] = isApplied
+	}
+
+	return applied, rows.Err()
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// run applies (up) or rolls back a migration together with its version
+// row, in a transaction unless the migration opts out.
+func run(ctx context.Context, conn *sql.Conn, migration Migration, up bool) error {
+	statements := migration.down
+	record := "DELETE FROM goose_db_version WHERE version_id = $1"
+	recordArgs := []any{migration.Version}
+
+	if up {
+		statements = migration.up
+		record = "INSERT INTO goose_db_version (version_id, is_applied) VALUES ($1, true)"
+	}
+
+	apply := func(db execer) error {
+		if len(strings.TrimSpace(statements)) > 0 {
+			// without arguments lib/pq sends a simple query, which may hold
+			// several statements
+			if _, err := db.ExecContext(ctx, statements); err != nil {
+				return err
+			}
+		}
+
+		_, err := db.ExecContext(ctx, record, recordArgs...)
+		return err
+	}
+
+	direction := "down"
+
+	if up {
+		direction = "up"
+	}
+
+	if migration.noTx {
+		if err := apply(conn); err != nil {
+			return fmt.Errorf("migration %s %s: %w", migration.Name, direction, err)
+		}
+		return nil
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if err := apply(tx); err != nil {
+		return errors.Join(fmt.Errorf("migration %s %s: %w", migration.Name, direction, err), tx.Rollback())
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,92 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/migrations"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Splits the up and down sections", func(t *testing.T) {
+		migration, err := parse("20240817181058_create_tenants_table.sql", `-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tenants (id SERIAL PRIMARY KEY);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE tenants;
+-- +goose StatementEnd
+`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(20240817181058), migration.Version)
+		assert.Equal(t, "20240817181058_create_tenants_table", migration.Name)
+		assert.Equal(t, "CREATE TABLE tenants (id SERIAL PRIMARY KEY);\n\n", migration.up)
+		assert.Equal(t, "DROP TABLE tenants;\n", migration.down)
+		assert.False(t, migration.noTx)
+	})
+
+	t.Run("Without transaction", func(t *testing.T) {
+		migration, err := parse("20240101000000_index.sql", `-- +goose NO TRANSACTION
+-- +goose Up
+CREATE INDEX CONCURRENTLY idx ON accounts (tenant_id);
+`)
+
+		assert.NoError(t, err)
+		assert.True(t, migration.noTx)
+		assert.Empty(t, migration.down)
+	})
+
+	t.Run("Without version", func(t *testing.T) {
+		_, err := parse("create_tenants_table.sql", "-- +goose Up\n")
+
+		assert.EqualError(t, err, "migration create_tenants_table.sql has no version")
+	})
+
+	t.Run("Without up annotation", func(t *testing.T) {
+		_, err := parse("20240101000000_empty.sql", "CREATE TABLE empty ();\n")
+
+		assert.EqualError(t, err, "migration 20240101000000_empty.sql has no -- +goose Up annotation")
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Sorts by version", func(t *testing.T) {
+		sut, err := New(nil, fstest.MapFS{
+			"20240201000000_b.sql": &fstest.MapFile{Data: []byte("-- +goose Up\n")},
+			"20240101000000_a.sql": &fstest.MapFile{Data: []byte("-- +goose Up\n")},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(20240101000000), sut.Migrations()[0].Version)
+		assert.Equal(t, int64(20240201000000), sut.Migrations()[1].Version)
+	})
+
+	t.Run("Rejects duplicate versions", func(t *testing.T) {
+		_, err := New(nil, fstest.MapFS{
+			"20240101000000_a.sql": &fstest.MapFile{Data: []byte("-- +goose Up\n")},
+			"20240101000000_b.sql": &fstest.MapFile{Data: []byte("-- +goose Up\n")},
+		})
+
+		assert.EqualError(t, err, "duplicate migration version 20240101000000")
+	})
+
+	t.Run("Every embedded migration can be rolled back", func(t *testing.T) {
+		sut, err := New(nil, migrations.FS)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, sut.Migrations())
+
+		for _, migration := range sut.Migrations() {
+			assert.NotEmpty(t, migration.up, migration.Name)
+			assert.NotEmpty(t, migration.down, migration.Name)
+		}
+	})
+}
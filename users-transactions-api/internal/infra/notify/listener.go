@@ -0,0 +1,90 @@
+// Package notify receives the notifications sent with NOTIFY or pg_notify
+// on a Postgres channel.
+package notify
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// pingInterval detects a connection that died silently, which would
+// otherwise stop the notifications without a reconnect.
+const pingInterval = 30 * time.Second
+
+// Listen receives the notifications of channel on a connection of its own,
+// reconnected when lost, and calls onNotify with their payload. The
+// notifications sent while disconnected are lost, so onReconnect is called
+// once the connection is back. It runs until the returned function is
+// called.
+func Listen(connString string, channel string, onNotify func(payload string), onReconnect func()) (stop func()) {
+	listener := pq.NewListener(connString, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			slog.Warn("notification listener disconnected",
+				slog.String("channel", channel),
+				slog.String("error", err.Error()),
+			)
+		}
+	})
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// Listen blocks until the first connection, the database may still be
+	// starting
+	go func() {
+		defer wg.Done()
+
+		err := listener.Listen(channel)
+
+		select {
+		case <-done:
+			// closed before the first connection
+			return
+		default:
+		}
+
+		if err != nil {
+			slog.Error("cannot listen to notifications",
+				slog.String("channel", channel),
+				slog.String("error", err.Error()),
+			)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case notification, open := <-listener.Notify:
+				if !open {
+					return
+				}
+
+				if notification == nil {
+					onReconnect()
+					continue
+				}
+
+				onNotify(notification.Extra)
+			case <-ticker.C:
+				go listener.Ping()
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		listener.Close()
+		wg.Wait()
+	}
+}
@@ -18,6 +18,23 @@ WHERE tenant_id = $1;
 UPDATE accounts 
 SET status = $2, 
 updated_at = $3, 
-deleted_at = $4 
+deleted_at = $4,
+version = version + 1
 WHERE id = $1
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: CountActiveAccounts :one
+SELECT COUNT(*) FROM accounts
+WHERE tenant_id = $1 AND deleted_at IS NULL;
+
+-- name: GetAccountForUpdate :one
+SELECT * FROM accounts
+WHERE tenant_id = $1 AND id = $2
+LIMIT 1
+FOR UPDATE;
+
+-- name: GetAccountForShare :one
+SELECT * FROM accounts
+WHERE tenant_id = $1 AND id = $2
+LIMIT 1
+FOR SHARE;
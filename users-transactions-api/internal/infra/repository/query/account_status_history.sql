@@ -0,0 +1,11 @@
+-- name: CreateAccountStatusHistory :one
+INSERT INTO account_status_history (
+    account_id, from_status, to_status, reason, actor
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetAccountStatusHistory :many
+SELECT * FROM account_status_history
+WHERE account_id = $1
+ORDER BY created_at, id;
@@ -0,0 +1,39 @@
+-- name: CreateApiKey :one
+INSERT INTO api_keys (
+    tenant_id, name, prefix, hashed_secret, scopes
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetApiKey :one
+SELECT * FROM api_keys
+WHERE tenant_id = $1 AND id = $2
+LIMIT 1;
+
+-- name: GetApiKeyByPrefix :one
+SELECT * FROM api_keys
+WHERE prefix = $1
+LIMIT 1;
+
+-- name: GetApiKeys :many
+SELECT * FROM api_keys
+WHERE tenant_id = $1
+ORDER BY id;
+
+-- name: RotateApiKey :one
+UPDATE api_keys
+SET prefix = $2,
+hashed_secret = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: RevokeApiKey :one
+UPDATE api_keys
+SET revoked_at = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: TouchApiKey :exec
+UPDATE api_keys
+SET last_used_at = $2
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $2);
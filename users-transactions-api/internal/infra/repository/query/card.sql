@@ -17,5 +17,20 @@ WHERE account_id = $1;
 -- name: AddAmount :one
 UPDATE cards 
 SET amount = amount + $2,
-updated_at = $3
-WHERE id = $1 RETURNING *;
\ No newline at end of file
+updated_at = $3,
+version = version + 1
+WHERE id = $1 RETURNING *;
+
+-- name: RecomputeCardAmount :one
+UPDATE cards
+SET amount = (
+    SELECT COALESCE(SUM(t.value), 0)::BIGINT FROM transactions t
+    WHERE t.card_id = cards.id AND t.deleted_at IS NULL
+),
+updated_at = $2,
+version = version + 1
+WHERE cards.id = $1 RETURNING *;
+
+-- name: CountCards :one
+SELECT COUNT(*) FROM cards
+WHERE account_id = $1 AND deleted_at IS NULL;
\ No newline at end of file
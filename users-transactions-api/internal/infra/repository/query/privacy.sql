@@ -0,0 +1,36 @@
+-- name: CreateDataSubjectRequest :one
+INSERT INTO data_subject_requests (
+    tenant_id, account_id, kind, format, reason, actor, retain_until
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetDataSubjectRequests :many
+SELECT * FROM data_subject_requests
+WHERE tenant_id = $1 AND account_id = $2
+ORDER BY created_at, id;
+
+-- name: GetAccountTransactions :many
+SELECT t.* FROM transactions t
+JOIN cards c ON t.card_id = c.id
+WHERE c.account_id = $1
+ORDER BY t.created_at, t.id;
+
+-- name: PseudonymizeAccountTransactions :execrows
+UPDATE transactions t
+SET kind = sqlc.arg(pseudonym),
+updated_at = sqlc.arg(updated_at)
+FROM cards c
+WHERE t.card_id = c.id AND c.account_id = sqlc.arg(account_id);
+
+-- name: PseudonymizeAccountStatusHistory :exec
+UPDATE account_status_history
+SET reason = sqlc.arg(pseudonym)
+WHERE account_id = sqlc.arg(account_id);
+
+-- name: MarkAccountErased :one
+UPDATE accounts
+SET erased_at = $2,
+version = version + 1
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,19 @@
+-- name: TakeRateLimitToken :one
+-- Refills the bucket for the elapsed time and takes one token in a single
+-- statement, so replicas sharing the database see a consistent bucket. The
+-- returned value is negative when the request must be rejected.
+INSERT INTO rate_limit_buckets (
+    tenant_id, tokens, updated_at
+) VALUES (
+    sqlc.arg(tenant_id), sqlc.arg(burst)::float8 - 1, now()
+)
+ON CONFLICT (tenant_id) DO UPDATE
+SET tokens = GREATEST(
+    LEAST(
+        sqlc.arg(burst)::float8,
+        rate_limit_buckets.tokens + EXTRACT(EPOCH FROM now() - rate_limit_buckets.updated_at)::float8 * sqlc.arg(rate)::float8
+    ) - 1,
+    -1
+),
+updated_at = now()
+RETURNING tokens;
@@ -1,4 +1,28 @@
 -- name: GetTenant :one
 SELECT * FROM
 tenants WHERE id = $1
-LIMIT 1;
\ No newline at end of file
+LIMIT 1;
+
+-- name: CreateTenant :one
+INSERT INTO tenants (
+    name
+) VALUES (
+    $1
+) RETURNING *;
+
+-- name: GetTenants :many
+SELECT * FROM
+tenants
+ORDER BY id;
+
+-- name: UpdateTenantName :one
+UPDATE tenants
+SET name = $2, updated_at = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateTenantStatus :one
+UPDATE tenants
+SET status = $2, suspended_at = $3, updated_at = $4
+WHERE id = $1
+RETURNING *;
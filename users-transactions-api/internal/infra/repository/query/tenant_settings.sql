@@ -0,0 +1,51 @@
+-- name: CreateTenantSettings :one
+INSERT INTO tenant_settings (
+    tenant_id
+) VALUES (
+    $1
+) RETURNING *;
+
+-- name: GetTenantSettings :one
+SELECT * FROM
+tenant_settings WHERE tenant_id = $1
+LIMIT 1;
+
+
+-- name: UpdateTenantSettings :one
+UPDATE tenant_settings
+SET currency = $2, timezone = $3, locale = $4, closing_day = $5,
+display_name = $6, primary_color = $7, secondary_color = $8, updated_at = $9
+WHERE tenant_id = $1
+RETURNING *;
+
+-- name: UpdateTenantLimits :one
+UPDATE tenant_settings
+SET rate_limit_per_second = $2, rate_limit_burst = $3, max_accounts = $4,
+max_cards_per_account = $5, max_transactions_per_day = $6, updated_at = $7
+WHERE tenant_id = $1
+RETURNING *;
+
+-- name: UpsertTenantLogo :one
+INSERT INTO tenant_logos (
+    tenant_id,
+    content_type,
+    data,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (tenant_id) DO UPDATE
+SET content_type = EXCLUDED.content_type, data = EXCLUDED.data, updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: GetTenantLogo :one
+SELECT * FROM
+tenant_logos WHERE tenant_id = $1
+LIMIT 1;
+
+-- name: DeleteTenantLogo :exec
+DELETE FROM tenant_logos
+WHERE tenant_id = $1;
+
+-- name: LockTenantQuota :exec
+SELECT pg_advisory_xact_lock(hashtext('tenant_quota'), sqlc.arg(tenant_id)::int);
@@ -21,8 +21,24 @@ SELECT
 t.id,
 t.card_id,
 t.kind,
-t.value
+t.value,
+t.created_at
 FROM transactions t
 JOIN cards c ON t.card_id = c.id
 JOIN accounts a ON c.account_id = a.id
 WHERE a.tenant_id = $1 AND a.id = sqlc.arg(accountId);
+
+
+-- name: CountTransactionsSince :one
+SELECT COUNT(*) FROM transactions t
+JOIN cards c ON t.card_id = c.id
+JOIN accounts a ON c.account_id = a.id
+WHERE a.tenant_id = $1 AND t.created_at >= sqlc.arg(since);
+-- name: CreateTransactionAdjustment :one
+INSERT INTO transaction_adjustments (
+    transaction_id,
+    actor,
+    reason
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
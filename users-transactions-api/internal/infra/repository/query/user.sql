@@ -0,0 +1,70 @@
+-- name: CreateUser :one
+INSERT INTO users (
+    tenant_id, email, role, status
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetUser :one
+SELECT * FROM users
+WHERE tenant_id = $1 AND id = $2
+LIMIT 1;
+
+-- name: GetUserById :one
+SELECT * FROM users
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetUserByEmail :one
+SELECT * FROM users
+WHERE tenant_id = $1 AND email = $2
+LIMIT 1;
+
+-- name: GetUsers :many
+SELECT * FROM users
+WHERE tenant_id = $1
+ORDER BY id;
+
+-- name: UpdateUserRole :one
+UPDATE users
+SET role = $2,
+updated_at = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateUserStatus :one
+UPDATE users
+SET status = $2,
+updated_at = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateUserPassword :one
+UPDATE users
+SET password_hash = $2,
+status = $3,
+updated_at = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateUserToken :one
+INSERT INTO user_tokens (
+    user_id, kind, hashed_token, expires_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetUserToken :one
+SELECT * FROM user_tokens
+WHERE hashed_token = $1 AND kind = $2
+LIMIT 1;
+
+-- name: UseUserToken :execrows
+UPDATE user_tokens
+SET used_at = $2
+WHERE id = $1 AND used_at IS NULL;
+
+-- name: RevokeUserTokens :exec
+UPDATE user_tokens
+SET used_at = $3
+WHERE user_id = $1 AND kind = $2 AND used_at IS NULL;
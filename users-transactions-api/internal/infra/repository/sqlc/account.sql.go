@@ -10,12 +10,24 @@ import (
 	"database/sql"
 )
 
+const countActiveAccounts = `-- name: CountActiveAccounts :one
+SELECT COUNT(*) FROM accounts
+WHERE tenant_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) CountActiveAccounts(ctx context.Context, tenantID int32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countActiveAccounts, tenantID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAccount = `-- name: CreateAccount :one
 INSERT INTO accounts (
     tenant_id, status
 ) VALUES (
     $1, $2
-) RETURNING id, tenant_id, status, created_at, updated_at, deleted_at
+) RETURNING id, tenant_id, status, created_at, updated_at, deleted_at, erased_at, version
 `
 
 type CreateAccountParams struct {
@@ -33,12 +45,14 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.ErasedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, tenant_id, status, created_at, updated_at, deleted_at FROM accounts 
+SELECT id, tenant_id, status, created_at, updated_at, deleted_at, erased_at, version FROM accounts 
 WHERE tenant_id = $1 AND id = $2
 LIMIT 1
 `
@@ -58,12 +72,70 @@ func (q *Queries) GetAccount(ctx context.Context, arg GetAccountParams) (Account
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.ErasedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getAccountForShare = `-- name: GetAccountForShare :one
+SELECT id, tenant_id, status, created_at, updated_at, deleted_at, erased_at, version FROM accounts
+WHERE tenant_id = $1 AND id = $2
+LIMIT 1
+FOR SHARE
+`
+
+type GetAccountForShareParams struct {
+	TenantID int32 `json:"tenant_id"`
+	ID       int32 `json:"id"`
+}
+
+func (q *Queries) GetAccountForShare(ctx context.Context, arg GetAccountForShareParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccountForShare, arg.TenantID, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ErasedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getAccountForUpdate = `-- name: GetAccountForUpdate :one
+SELECT id, tenant_id, status, created_at, updated_at, deleted_at, erased_at, version FROM accounts
+WHERE tenant_id = $1 AND id = $2
+LIMIT 1
+FOR UPDATE
+`
+
+type GetAccountForUpdateParams struct {
+	TenantID int32 `json:"tenant_id"`
+	ID       int32 `json:"id"`
+}
+
+func (q *Queries) GetAccountForUpdate(ctx context.Context, arg GetAccountForUpdateParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccountForUpdate, arg.TenantID, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ErasedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const getAccounts = `-- name: GetAccounts :many
-SELECT id, tenant_id, status, created_at, updated_at, deleted_at FROM accounts 
+SELECT id, tenant_id, status, created_at, updated_at, deleted_at, erased_at, version FROM accounts 
 WHERE tenant_id = $1
 `
 
@@ -83,6 +155,8 @@ func (q *Queries) GetAccounts(ctx context.Context, tenantID int32) ([]Account, e
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.ErasedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -101,9 +175,10 @@ const updateAccount = `-- name: UpdateAccount :one
 UPDATE accounts 
 SET status = $2, 
 updated_at = $3, 
-deleted_at = $4 
+deleted_at = $4,
+version = version + 1
 WHERE id = $1
-RETURNING id, tenant_id, status, created_at, updated_at, deleted_at
+RETURNING id, tenant_id, status, created_at, updated_at, deleted_at, erased_at, version
 `
 
 type UpdateAccountParams struct {
@@ -128,6 +203,8 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.ErasedAt,
+		&i.Version,
 	)
 	return i, err
 }
@@ -0,0 +1,85 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: account_status_history.sql
+
+package infra
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createAccountStatusHistory = `-- name: CreateAccountStatusHistory :one
+INSERT INTO account_status_history (
+    account_id, from_status, to_status, reason, actor
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, account_id, from_status, to_status, reason, actor, created_at
+`
+
+type CreateAccountStatusHistoryParams struct {
+	AccountID  int32          `json:"account_id"`
+	FromStatus sql.NullString `json:"from_status"`
+	ToStatus   string         `json:"to_status"`
+	Reason     string         `json:"reason"`
+	Actor      string         `json:"actor"`
+}
+
+func (q *Queries) CreateAccountStatusHistory(ctx context.Context, arg CreateAccountStatusHistoryParams) (AccountStatusHistory, error) {
+	row := q.db.QueryRowContext(ctx, createAccountStatusHistory,
+		arg.AccountID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Reason,
+		arg.Actor,
+	)
+	var i AccountStatusHistory
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.FromStatus,
+		&i.ToStatus,
+		&i.Reason,
+		&i.Actor,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getAccountStatusHistory = `-- name: GetAccountStatusHistory :many
+SELECT id, account_id, from_status, to_status, reason, actor, created_at FROM account_status_history
+WHERE account_id = $1
+ORDER BY created_at, id
+`
+
+func (q *Queries) GetAccountStatusHistory(ctx context.Context, accountID int32) ([]AccountStatusHistory, error) {
+	rows, err := q.db.QueryContext(ctx, getAccountStatusHistory, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AccountStatusHistory{}
+	for rows.Next() {
+		var i AccountStatusHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Reason,
+			&i.Actor,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,61 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountStatusHistoryRepository(t *testing.T) {
+
+	t.Run("[CreateAccountStatusHistory] should record a status change", func(t *testing.T) {
+		account := createTestAccount(t, 1)
+
+		history, err := testQueries.CreateAccountStatusHistory(context.Background(), CreateAccountStatusHistoryParams{
+			AccountID:  account.ID,
+			FromStatus: sql.NullString{String: "active", Valid: true},
+			ToStatus:   "frozen",
+			Reason:     "card reported lost",
+			Actor:      "user:1",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, account.ID, history.AccountID)
+		assert.Equal(t, "frozen", history.ToStatus)
+		assert.NotEmpty(t, history.CreatedAt)
+	})
+
+	t.Run("[GetAccountStatusHistory] should list changes in order", func(t *testing.T) {
+		account := createTestAccount(t, 1)
+
+		for _, status := range []string{"frozen", "active"} {
+			_, err := testQueries.CreateAccountStatusHistory(context.Background(), CreateAccountStatusHistoryParams{
+				AccountID: account.ID,
+				ToStatus:  status,
+				Reason:    "test",
+				Actor:     "user:1",
+			})
+			assert.NoError(t, err)
+		}
+
+		history, err := testQueries.GetAccountStatusHistory(context.Background(), account.ID)
+
+		assert.NoError(t, err)
+		assert.Len(t, history, 2)
+		assert.Equal(t, "frozen", history[0].ToStatus)
+		assert.Equal(t, "active", history[1].ToStatus)
+	})
+
+	t.Run("[UpdateAccount] should reject unknown statuses", func(t *testing.T) {
+		account := createTestAccount(t, 1)
+
+		_, err := testQueries.UpdateAccount(context.Background(), UpdateAccountParams{
+			ID:     account.ID,
+			Status: "inactive",
+		})
+
+		assert.Error(t, err)
+	})
+}
@@ -63,7 +63,7 @@ func TestAccountRepository(t *testing.T) {
 		account := createTestAccount(t, 1)
 
 		updateArgs := UpdateAccountParams{
-			Status: "inactive",
+			Status: "closed",
 			ID:     account.ID,
 		}
 
@@ -72,5 +72,6 @@ func TestAccountRepository(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotEmpty(t, updatedAccount)
 		assert.Equal(t, updateArgs.Status, updatedAccount.Status)
+		assert.Equal(t, account.Version+1, updatedAccount.Version)
 	})
 }
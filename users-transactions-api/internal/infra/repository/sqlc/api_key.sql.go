@@ -0,0 +1,218 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: api_key.sql
+
+package infra
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+const createApiKey = `-- name: CreateApiKey :one
+INSERT INTO api_keys (
+    tenant_id, name, prefix, hashed_secret, scopes
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, tenant_id, name, prefix, hashed_secret, scopes, created_at, last_used_at, revoked_at
+`
+
+type CreateApiKeyParams struct {
+	TenantID     int32    `json:"tenant_id"`
+	Name         string   `json:"name"`
+	Prefix       string   `json:"prefix"`
+	HashedSecret string   `json:"hashed_secret"`
+	Scopes       []string `json:"scopes"`
+}
+
+func (q *Queries) CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, createApiKey,
+		arg.TenantID,
+		arg.Name,
+		arg.Prefix,
+		arg.HashedSecret,
+		pq.Array(arg.Scopes),
+	)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Name,
+		&i.Prefix,
+		&i.HashedSecret,
+		pq.Array(&i.Scopes),
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const getApiKey = `-- name: GetApiKey :one
+SELECT id, tenant_id, name, prefix, hashed_secret, scopes, created_at, last_used_at, revoked_at FROM api_keys
+WHERE tenant_id = $1 AND id = $2
+LIMIT 1
+`
+
+type GetApiKeyParams struct {
+	TenantID int32 `json:"tenant_id"`
+	ID       int32 `json:"id"`
+}
+
+func (q *Queries) GetApiKey(ctx context.Context, arg GetApiKeyParams) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, getApiKey, arg.TenantID, arg.ID)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Name,
+		&i.Prefix,
+		&i.HashedSecret,
+		pq.Array(&i.Scopes),
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const getApiKeyByPrefix = `-- name: GetApiKeyByPrefix :one
+SELECT id, tenant_id, name, prefix, hashed_secret, scopes, created_at, last_used_at, revoked_at FROM api_keys
+WHERE prefix = $1
+LIMIT 1
+`
+
+func (q *Queries) GetApiKeyByPrefix(ctx context.Context, prefix string) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, getApiKeyByPrefix, prefix)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Name,
+		&i.Prefix,
+		&i.HashedSecret,
+		pq.Array(&i.Scopes),
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const getApiKeys = `-- name: GetApiKeys :many
+SELECT id, tenant_id, name, prefix, hashed_secret, scopes, created_at, last_used_at, revoked_at FROM api_keys
+WHERE tenant_id = $1
+ORDER BY id
+`
+
+func (q *Queries) GetApiKeys(ctx context.Context, tenantID int32) ([]ApiKey, error) {
+	rows, err := q.db.QueryContext(ctx, getApiKeys, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ApiKey{}
+	for rows.Next() {
+		var i ApiKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.Name,
+			&i.Prefix,
+			&i.HashedSecret,
+			pq.Array(&i.Scopes),
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeApiKey = `-- name: RevokeApiKey :one
+UPDATE api_keys
+SET revoked_at = $2
+WHERE id = $1
+RETURNING id, tenant_id, name, prefix, hashed_secret, scopes, created_at, last_used_at, revoked_at
+`
+
+type RevokeApiKeyParams struct {
+	ID        int32        `json:"id"`
+	RevokedAt sql.NullTime `json:"revoked_at"`
+}
+
+func (q *Queries) RevokeApiKey(ctx context.Context, arg RevokeApiKeyParams) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, revokeApiKey, arg.ID, arg.RevokedAt)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Name,
+		&i.Prefix,
+		&i.HashedSecret,
+		pq.Array(&i.Scopes),
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const rotateApiKey = `-- name: RotateApiKey :one
+UPDATE api_keys
+SET prefix = $2,
+hashed_secret = $3
+WHERE id = $1
+RETURNING id, tenant_id, name, prefix, hashed_secret, scopes, created_at, last_used_at, revoked_at
+`
+
+type RotateApiKeyParams struct {
+	ID           int32  `json:"id"`
+	Prefix       string `json:"prefix"`
+	HashedSecret string `json:"hashed_secret"`
+}
+
+func (q *Queries) RotateApiKey(ctx context.Context, arg RotateApiKeyParams) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, rotateApiKey, arg.ID, arg.Prefix, arg.HashedSecret)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Name,
+		&i.Prefix,
+		&i.HashedSecret,
+		pq.Array(&i.Scopes),
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const touchApiKey = `-- name: TouchApiKey :exec
+UPDATE api_keys
+SET last_used_at = $2
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $2)
+`
+
+type TouchApiKeyParams struct {
+	ID         int32        `json:"id"`
+	LastUsedAt sql.NullTime `json:"last_used_at"`
+}
+
+func (q *Queries) TouchApiKey(ctx context.Context, arg TouchApiKeyParams) error {
+	_, err := q.db.ExecContext(ctx, touchApiKey, arg.ID, arg.LastUsedAt)
+	return err
+}
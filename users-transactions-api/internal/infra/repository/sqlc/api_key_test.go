@@ -0,0 +1,89 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestApiKey(t *testing.T, tenantId int32) ApiKey {
+	arg := CreateApiKeyParams{
+		TenantID:     tenantId,
+		Name:         "billing",
+		Prefix:       fmt.Sprintf("utk_%012d", time.Now().UnixNano()%1e12),
+		HashedSecret: "hashed-secret",
+		Scopes:       []string{"accounts:read", "transactions:write"},
+	}
+
+	apiKey, err := testQueries.CreateApiKey(context.Background(), arg)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, apiKey)
+	assert.Equal(t, arg.Prefix, apiKey.Prefix)
+	assert.Equal(t, arg.Scopes, apiKey.Scopes)
+	assert.False(t, apiKey.LastUsedAt.Valid)
+	assert.False(t, apiKey.RevokedAt.Valid)
+
+	return apiKey
+}
+
+func TestApiKeyRepository(t *testing.T) {
+
+	t.Run("[GetApiKeyByPrefix] should find api key by prefix", func(t *testing.T) {
+		apiKey := createTestApiKey(t, 1)
+
+		foundApiKey, err := testQueries.GetApiKeyByPrefix(context.Background(), apiKey.Prefix)
+
+		assert.NoError(t, err)
+		assert.Equal(t, apiKey, foundApiKey)
+	})
+
+	t.Run("[GetApiKey] should not find api key from another tenant", func(t *testing.T) {
+		apiKey := createTestApiKey(t, 1)
+
+		_, err := testQueries.GetApiKey(context.Background(), GetApiKeyParams{
+			TenantID: 2,
+			ID:       apiKey.ID,
+		})
+
+		assert.EqualError(t, err, sql.ErrNoRows.Error())
+	})
+
+	t.Run("[RevokeApiKey] should set revoked at", func(t *testing.T) {
+		apiKey := createTestApiKey(t, 1)
+
+		revokedApiKey, err := testQueries.RevokeApiKey(context.Background(), RevokeApiKeyParams{
+			ID:        apiKey.ID,
+			RevokedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, revokedApiKey.RevokedAt.Valid)
+	})
+
+	t.Run("[TouchApiKey] should never move last used at backwards", func(t *testing.T) {
+		apiKey := createTestApiKey(t, 1)
+		now := time.Now().UTC()
+
+		err := testQueries.TouchApiKey(context.Background(), TouchApiKeyParams{
+			ID:         apiKey.ID,
+			LastUsedAt: sql.NullTime{Time: now, Valid: true},
+		})
+		assert.NoError(t, err)
+
+		err = testQueries.TouchApiKey(context.Background(), TouchApiKeyParams{
+			ID:         apiKey.ID,
+			LastUsedAt: sql.NullTime{Time: now.Add(-time.Hour), Valid: true},
+		})
+		assert.NoError(t, err)
+
+		foundApiKey, err := testQueries.GetApiKeyByPrefix(context.Background(), apiKey.Prefix)
+
+		assert.NoError(t, err)
+		assert.WithinDuration(t, now, foundApiKey.LastUsedAt.Time, time.Millisecond)
+	})
+}
@@ -13,8 +13,9 @@ import (
 const addAmount = `-- name: AddAmount :one
 UPDATE cards 
 SET amount = amount + $2,
-updated_at = $3
-WHERE id = $1 RETURNING id, account_id, amount, created_at, updated_at, deleted_at
+updated_at = $3,
+version = version + 1
+WHERE id = $1 RETURNING id, account_id, amount, created_at, updated_at, deleted_at, version
 `
 
 type AddAmountParams struct {
@@ -33,16 +34,29 @@ func (q *Queries) AddAmount(ctx context.Context, arg AddAmountParams) (Card, err
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
+const countCards = `-- name: CountCards :one
+SELECT COUNT(*) FROM cards
+WHERE account_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) CountCards(ctx context.Context, accountID int32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCards, accountID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCard = `-- name: CreateCard :one
 INSERT INTO cards (
     account_id
 ) VALUES (
     $1
-) RETURNING id, account_id, amount, created_at, updated_at, deleted_at
+) RETURNING id, account_id, amount, created_at, updated_at, deleted_at, version
 `
 
 func (q *Queries) CreateCard(ctx context.Context, accountID int32) (Card, error) {
@@ -55,12 +69,13 @@ func (q *Queries) CreateCard(ctx context.Context, accountID int32) (Card, error)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const getCard = `-- name: GetCard :one
-SELECT id, account_id, amount, created_at, updated_at, deleted_at FROM cards 
+SELECT id, account_id, amount, created_at, updated_at, deleted_at, version FROM cards 
 WHERE account_id = $1 AND id = $2
 LIMIT 1
 `
@@ -80,12 +95,13 @@ func (q *Queries) GetCard(ctx context.Context, arg GetCardParams) (Card, error)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const getCards = `-- name: GetCards :many
-SELECT id, account_id, amount, created_at, updated_at, deleted_at FROM cards 
+SELECT id, account_id, amount, created_at, updated_at, deleted_at, version FROM cards 
 WHERE account_id = $1
 `
 
@@ -105,6 +121,7 @@ func (q *Queries) GetCards(ctx context.Context, accountID int32) ([]Card, error)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -118,3 +135,34 @@ func (q *Queries) GetCards(ctx context.Context, accountID int32) ([]Card, error)
 	}
 	return items, nil
 }
+
+const recomputeCardAmount = `-- name: RecomputeCardAmount :one
+UPDATE cards
+SET amount = (
+    SELECT COALESCE(SUM(t.value), 0)::BIGINT FROM transactions t
+    WHERE t.card_id = cards.id AND t.deleted_at IS NULL
+),
+updated_at = $2,
+version = version + 1
+WHERE cards.id = $1 RETURNING id, account_id, amount, created_at, updated_at, deleted_at, version
+`
+
+type RecomputeCardAmountParams struct {
+	ID        int32        `json:"id"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+}
+
+func (q *Queries) RecomputeCardAmount(ctx context.Context, arg RecomputeCardAmountParams) (Card, error) {
+	row := q.db.QueryRowContext(ctx, recomputeCardAmount, arg.ID, arg.UpdatedAt)
+	var i Card
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
+}
@@ -79,4 +79,38 @@ func TestCardRepository(t *testing.T) {
 		assert.NotEmpty(t, updatedCard)
 		assert.Equal(t, arg.Amount, updatedCard.Amount)
 	})
+
+	t.Run("[RecomputeCardAmount] should set the amount to the sum of the transactions", func(t *testing.T) {
+		account := createTestAccount(t, 3)
+		card := createTestCard(t, account.ID)
+
+		for _, value := range []int64{100, 250} {
+			_, err := testQueries.CreateTransaction(context.Background(), CreateTransactionParams{
+				CardID: card.ID,
+				Kind:   "adjustment",
+				Value:  value,
+			})
+
+			assert.NoError(t, err)
+		}
+
+		_, err := testQueries.AddAmount(context.Background(), AddAmountParams{
+			Amount: 1000,
+			ID:     card.ID,
+		})
+
+		assert.NoError(t, err)
+
+		recomputedCard, err := testQueries.RecomputeCardAmount(context.Background(), RecomputeCardAmountParams{
+			ID: card.ID,
+			UpdatedAt: sql.NullTime{
+				Time:  time.Now().UTC(),
+				Valid: true,
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(350), recomputedCard.Amount)
+		assert.Equal(t, card.Version+2, recomputedCard.Version)
+	})
 }
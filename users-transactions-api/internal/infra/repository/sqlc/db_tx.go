@@ -6,44 +6,157 @@ import (
 	"fmt"
 	"log/slog"
 	"time"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/telemetry"
 )
 
 type QuerierTx interface {
-	CreateTransactionTx(ctx context.Context, arg CreateTransactionParams) (Transaction, error)
+	CreateTransactionTx(ctx context.Context, arg CreateTransactionParams, check func(q Querier) error) (Transaction, error)
+	AdjustTransactionTx(ctx context.Context, arg AdjustTransactionTxParams) (Transaction, error)
+	CreateTenantTx(ctx context.Context, arg CreateTenantTxParams) (CreateTenantTxResult, error)
+	SetPasswordTx(ctx context.Context, arg SetPasswordTxParams) (User, error)
+	InviteUserTx(ctx context.Context, arg InviteUserTxParams) (User, error)
+	ReinviteUserTx(ctx context.Context, arg ReinviteUserTxParams) error
+	WithTenant(ctx context.Context, tenantId int32, fn func(q Querier) error) error
+}
+
+// CreateTenantTxParams carries the initial admin of the tenant; their
+// TenantID and UserID are filled inside the transaction.
+type CreateTenantTxParams struct {
+	Name       string
+	Admin      CreateUserParams
+	AdminToken CreateUserTokenParams
+}
+
+type AdjustTransactionTxParams struct {
+	Transaction CreateTransactionParams
+	Actor       string
+	Reason      string
+}
+
+type CreateTenantTxResult struct {
+	Tenant   Tenant
+	Settings TenantSetting
+	Admin    User
+}
+
+// database is a *sql.DB, or a ReplicaPool choosing one per statement.
+type database interface {
+	DBTX
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	PingContext(ctx context.Context) error
 }
 
 type Tx struct {
 	*Queries
-	db *sql.DB
+	db database
 }
 
 func NewTx(db *sql.DB) *Tx {
 	return &Tx{
 		db:      db,
-		Queries: New(db),
+		Queries: New(telemetry.TraceDB(db)),
 	}
 }
 
-func (tx *Tx) CreateTransactionTx(ctx context.Context, arg CreateTransactionParams) (Transaction, error) {
+// CreateTransactionTx saves the transaction and adds its value to the card
+// amount. check, when not nil, runs first in the same transaction and
+// aborts it with its error.
+func (tx *Tx) CreateTransactionTx(ctx context.Context, arg CreateTransactionParams,
+	check func(q Querier) error) (Transaction, error) {
 	var transaction Transaction
 	var err error
 
 	err = tx.execTx(ctx, func(q *Queries) error {
+		if check != nil {
+			if err = check(q); err != nil {
+				return err
+			}
+		}
+
+		transaction, err = insertTransaction(ctx, q, arg)
+
+		return err
+	})
+
+	return transaction, err
+}
+
+// AdjustTransactionTx saves an adjustment like CreateTransactionTx, with
+// the operator and the reason recorded along.
+func (tx *Tx) AdjustTransactionTx(ctx context.Context, arg AdjustTransactionTxParams) (Transaction, error) {
+	var transaction Transaction
+	var err error
 
-		transaction, err = q.CreateTransaction(ctx, arg)
+	err = tx.execTx(ctx, func(q *Queries) error {
+		transaction, err = insertTransaction(ctx, q, arg.Transaction)
 
 		if err != nil {
 			return err
 		}
 
-		_, err = q.AddAmount(ctx, AddAmountParams{
-			ID:     arg.CardID,
-			Amount: arg.Value,
-			UpdatedAt: sql.NullTime{
-				Time: time.Now().UTC(),
-			},
+		_, err = q.CreateTransactionAdjustment(ctx, CreateTransactionAdjustmentParams{
+			TransactionID: transaction.ID,
+			Actor:         arg.Actor,
+			Reason:        arg.Reason,
 		})
 
+		return err
+	})
+
+	return transaction, err
+}
+
+func insertTransaction(ctx context.Context, q *Queries, arg CreateTransactionParams) (Transaction, error) {
+	transaction, err := q.CreateTransaction(ctx, arg)
+
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	_, err = q.AddAmount(ctx, AddAmountParams{
+		ID:     arg.CardID,
+		Amount: arg.Value,
+		UpdatedAt: sql.NullTime{
+			Time: time.Now().UTC(),
+		},
+	})
+
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	return transaction, nil
+}
+
+func (tx *Tx) CreateTenantTx(ctx context.Context, arg CreateTenantTxParams) (CreateTenantTxResult, error) {
+	var result CreateTenantTxResult
+	var err error
+
+	err = tx.execTx(ctx, func(q *Queries) error {
+
+		result.Tenant, err = q.CreateTenant(ctx, arg.Name)
+
+		if err != nil {
+			return err
+		}
+
+		result.Settings, err = q.CreateTenantSettings(ctx, result.Tenant.ID)
+
+		if err != nil {
+			return err
+		}
+
+		arg.Admin.TenantID = result.Tenant.ID
+		result.Admin, err = q.CreateUser(ctx, arg.Admin)
+
+		if err != nil {
+			return err
+		}
+
+		arg.AdminToken.UserID = result.Admin.ID
+		_, err = q.CreateUserToken(ctx, arg.AdminToken)
+
 		if err != nil {
 			return err
 		}
@@ -51,7 +164,7 @@ func (tx *Tx) CreateTransactionTx(ctx context.Context, arg CreateTransactionPara
 		return nil
 	})
 
-	return transaction, err
+	return result, err
 }
 
 func (transactionTx *Tx) execTx(ctx context.Context, fn func(*Queries) error) error {
@@ -61,18 +174,25 @@ func (transactionTx *Tx) execTx(ctx context.Context, fn func(*Queries) error) er
 		return err
 	}
 
-	q := New(tx)
-	err = fn(q)
+	if tenantId, ok := TenantFromContext(ctx); ok {
+		err = scopeTx(ctx, tx, tenantId)
+	}
+
+	if err == nil {
+		err = fn(New(telemetry.TraceDB(tx)))
+	}
 
 	if err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
-			slog.Error(
+			slog.ErrorContext(
+				ctx,
 				"error transaction tx",
 				slog.String("err", err.Error()),
 				slog.String("errTx", rbErr.Error()),
 			)
 			return fmt.Errorf("tx error: %v, rb err: %v", err, rbErr)
 		}
+		return err
 	}
 
 	return tx.Commit()
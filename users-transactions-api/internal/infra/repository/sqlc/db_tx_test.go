@@ -2,7 +2,9 @@ package infra
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -31,7 +33,7 @@ func TestTransactionTxRepository(t *testing.T) {
 		for i := 0; i < n; i++ {
 			go func() {
 				ctx := context.Background()
-				result, err := transactionTx.CreateTransactionTx(ctx, arg)
+				result, err := transactionTx.CreateTransactionTx(ctx, arg, nil)
 
 				errs <- err
 				results <- result
@@ -58,3 +60,145 @@ func TestTransactionTxRepository(t *testing.T) {
 		assert.Equal(t, int64(n*int(value)), card2.Amount)
 	})
 }
+
+func TestTenantTxRepository(t *testing.T) {
+	tenantTx := NewTx(testDb)
+
+	newTenantTxParams := func(hashedToken string) CreateTenantTxParams {
+		return CreateTenantTxParams{
+			Name: "Tenant W",
+			Admin: CreateUserParams{
+				Email:  "owner@example.com",
+				Role:   "owner",
+				Status: "invited",
+			},
+			AdminToken: CreateUserTokenParams{
+				Kind:        "invitation",
+				HashedToken: hashedToken,
+				ExpiresAt:   time.Now().UTC().Add(time.Hour),
+			},
+		}
+	}
+
+	t.Run("[CreateTenantTx] should create tenant, settings and owner", func(t *testing.T) {
+		arg := newTenantTxParams(fmt.Sprintf("%064d", time.Now().UnixNano()))
+
+		result, err := tenantTx.CreateTenantTx(context.Background(), arg)
+
+		assert.NoError(t, err)
+		assert.Equal(t, arg.Name, result.Tenant.Name)
+		assert.Equal(t, result.Tenant.ID, result.Settings.TenantID)
+		assert.Equal(t, result.Tenant.ID, result.Admin.TenantID)
+		assert.Equal(t, "owner", result.Admin.Role)
+
+		userToken, err := testQueries.GetUserToken(context.Background(), GetUserTokenParams{
+			HashedToken: arg.AdminToken.HashedToken,
+			Kind:        "invitation",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, result.Admin.ID, userToken.UserID)
+	})
+
+	t.Run("[CreateTenantTx] should rollback when the invitation cannot be saved", func(t *testing.T) {
+		hashedToken := fmt.Sprintf("%064d", time.Now().UnixNano())
+
+		_, err := tenantTx.CreateTenantTx(context.Background(), newTenantTxParams(hashedToken))
+		assert.NoError(t, err)
+
+		tenantsBefore, err := testQueries.GetTenants(context.Background())
+		assert.NoError(t, err)
+
+		_, err = tenantTx.CreateTenantTx(context.Background(), newTenantTxParams(hashedToken))
+		assert.Error(t, err)
+
+		tenantsAfter, err := testQueries.GetTenants(context.Background())
+		assert.NoError(t, err)
+
+		assert.Equal(t, len(tenantsBefore), len(tenantsAfter))
+	})
+}
+
+func TestLockTenantQuota(t *testing.T) {
+	transactionTx := NewTx(testDb)
+
+	t.Run("[LockTenantQuota] should count concurrent creations one after the other", func(t *testing.T) {
+		tenant, err := testQueries.CreateTenant(context.Background(), "Quota Lock")
+
+		assert.NoError(t, err)
+
+		n := 20
+		limit := int64(5)
+		errs := make(chan error, n)
+
+		for i := 0; i < n; i++ {
+			go func() {
+				ctx := context.Background()
+
+				errs <- transactionTx.WithTenant(ctx, tenant.ID, func(q Querier) error {
+					err := q.LockTenantQuota(ctx, tenant.ID)
+
+					if err != nil {
+						return err
+					}
+
+					count, err := q.CountActiveAccounts(ctx, tenant.ID)
+
+					if err != nil || count >= limit {
+						return err
+					}
+
+					_, err = q.CreateAccount(ctx, CreateAccountParams{TenantID: tenant.ID, Status: "active"})
+					return err
+				})
+			}()
+		}
+
+		for i := 0; i < n; i++ {
+			assert.NoError(t, <-errs)
+		}
+
+		count, err := testQueries.CountActiveAccounts(context.Background(), tenant.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, limit, count)
+	})
+}
+
+func TestAdjustTransactionTx(t *testing.T) {
+	transactionTx := NewTx(testDb)
+
+	t.Run("[AdjustTransactionTx] should record the adjustment and subtract negative values", func(t *testing.T) {
+		account := createTestAccount(t, 1)
+		card := createTestCard(t, account.ID)
+
+		transaction, err := transactionTx.AdjustTransactionTx(context.Background(), AdjustTransactionTxParams{
+			Transaction: CreateTransactionParams{
+				CardID: card.ID,
+				Kind:   "adjustment",
+				Value:  -150,
+			},
+			Actor:  "admin_cli:ops",
+			Reason: "duplicated charge",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(-150), transaction.Value)
+
+		updatedCard, err := transactionTx.GetCard(context.Background(), GetCardParams{
+			AccountID: account.ID,
+			ID:        card.ID,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(-150), updatedCard.Amount)
+
+		var actor, reason string
+		err = testDb.QueryRow("SELECT actor, reason FROM transaction_adjustments WHERE transaction_id = $1",
+			transaction.ID).Scan(&actor, &reason)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "admin_cli:ops", actor)
+		assert.Equal(t, "duplicated charge", reason)
+	})
+}
@@ -0,0 +1,9 @@
+package infra
+
+import "database/sql"
+
+// ContainerDB exposes the database of the container to the external tests
+// of the package, which exercise the usecases built on it.
+func ContainerDB() *sql.DB {
+	return testDb
+}
@@ -0,0 +1,98 @@
+package infra_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/genproto"
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/grpc"
+	tenantUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/tenant"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type searchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*genproto.SearchTransactionInfoResponse
+}
+
+func (s *searchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *searchStream) Send(response *genproto.SearchTransactionInfoResponse) error {
+	s.sent = append(s.sent, response)
+	return nil
+}
+
+// TestGrpcTenantScope runs the gRPC search against the database, its
+// queries go through the tenant scoped transactions like the REST ones.
+func TestGrpcTenantScope(t *testing.T) {
+	ctx := context.Background()
+	queries := infra.New(infra.ContainerDB())
+	tx := infra.NewTx(infra.ContainerDB())
+	sut := usecases.NewTransactionInfo(tx, tx, tenantUsecases.NewFindOneTenantUseCase(tx, nil))
+
+	createAccount := func(tenantId int32) infra.Account {
+		account, err := queries.CreateAccount(ctx, infra.CreateAccountParams{TenantID: tenantId, Status: "active"})
+		assert.NoError(t, err)
+
+		card, err := queries.CreateCard(ctx, account.ID)
+		assert.NoError(t, err)
+
+		_, err = queries.CreateTransaction(ctx, infra.CreateTransactionParams{
+			CardID: card.ID,
+			Kind:   "Streaming Z",
+			Value:  50,
+		})
+		assert.NoError(t, err)
+
+		return account
+	}
+
+	ownAccount := createAccount(1)
+	otherAccount := createAccount(2)
+
+	search := func(tenantId int32, accountId int32) (*searchStream, error) {
+		stream := &searchStream{
+			ctx: shared.ContextWithPrincipal(ctx, &shared.Principal{TenantId: 1, ApiKeyId: 1}),
+		}
+
+		err := sut.SearchTransactionInfo(&genproto.SearchTransactionInfoRequest{
+			Filter: &genproto.Filter{TenantId: uint32(tenantId), AccountId: uint32(accountId)},
+		}, stream)
+
+		return stream, err
+	}
+
+	t.Run("should stream transactions of own account", func(t *testing.T) {
+		stream, err := search(1, ownAccount.ID)
+
+		assert.NoError(t, err)
+
+		if assert.Len(t, stream.sent, 1) {
+			// the currency and the locale depend on the settings other tests left
+			assert.NotEmpty(t, stream.sent[0].GetTransactionInfo().GetFormattedValue())
+			assert.NotEmpty(t, stream.sent[0].GetTransactionInfo().GetFormattedCreatedAt())
+		}
+	})
+
+	t.Run("should not stream transactions of an account of another tenant", func(t *testing.T) {
+		stream, err := search(1, otherAccount.ID)
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Empty(t, stream.sent)
+	})
+
+	t.Run("should refuse the tenant of another caller", func(t *testing.T) {
+		stream, err := search(2, otherAccount.ID)
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Empty(t, stream.sent)
+	})
+}
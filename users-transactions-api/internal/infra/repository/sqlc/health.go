@@ -0,0 +1,36 @@
+package infra
+
+import (
+	"context"
+)
+
+// Ping checks that a connection to the database can be established.
+func (tx *Tx) Ping(ctx context.Context) error {
+	return tx.db.PingContext(ctx)
+}
+
+// AppliedMigrations reads the goose version table, where the last row of a
+// version tells whether it is applied or was rolled back.
+func (tx *Tx) AppliedMigrations(ctx context.Context) (map[int64]bool, error) {
+	rows, err := tx.db.QueryContext(ctx, "SELECT version_id, is_applied FROM goose_db_version ORDER BY id")
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]bool)
+
+	for rows.Next() {
+		var version int64
+		var isApplied bool
+
+		if err := rows.Scan(&version, &isApplied); err != nil {
+			return nil, err
+		}
+
+		applied[version] = isApplied
+	}
+
+	return applied, rows.Err()
+}
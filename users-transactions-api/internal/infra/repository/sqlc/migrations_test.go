@@ -0,0 +1,60 @@
+package infra
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/migrate"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/migrations"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrations(t *testing.T) {
+	migrator, err := migrate.New(testDb, migrations.FS)
+	assert.NoError(t, err)
+
+	t.Run("[Status] every migration is applied by the test setup", func(t *testing.T) {
+		statuses, err := migrator.Status(context.Background())
+
+		assert.NoError(t, err)
+		assert.Len(t, statuses, len(migrator.Migrations()))
+
+		for _, status := range statuses {
+			assert.True(t, status.Applied, status.Name)
+			assert.False(t, status.AppliedAt.IsZero(), status.Name)
+		}
+	})
+
+	t.Run("[Up] concurrent runs wait for the lock and apply nothing", func(t *testing.T) {
+		var wg sync.WaitGroup
+
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				applied, err := migrator.Up(context.Background())
+
+				assert.NoError(t, err)
+				assert.Empty(t, applied)
+			}()
+		}
+
+		wg.Wait()
+	})
+
+	t.Run("[Redo] rolls back and applies the last migration", func(t *testing.T) {
+		all := migrator.Migrations()
+
+		redone, err := migrator.Redo(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, all[len(all)-1].Version, redone.Version)
+
+		applied, err := NewTx(testDb).AppliedMigrations(context.Background())
+
+		assert.NoError(t, err)
+		assert.True(t, applied[redone.Version])
+	})
+}
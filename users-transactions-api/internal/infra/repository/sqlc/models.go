@@ -16,6 +16,30 @@ type Account struct {
 	CreatedAt time.Time    `json:"created_at"`
 	UpdatedAt sql.NullTime `json:"updated_at"`
 	DeletedAt sql.NullTime `json:"deleted_at"`
+	ErasedAt  sql.NullTime `json:"erased_at"`
+	Version   int32        `json:"version"`
+}
+
+type AccountStatusHistory struct {
+	ID         int32          `json:"id"`
+	AccountID  int32          `json:"account_id"`
+	FromStatus sql.NullString `json:"from_status"`
+	ToStatus   string         `json:"to_status"`
+	Reason     string         `json:"reason"`
+	Actor      string         `json:"actor"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+type ApiKey struct {
+	ID           int32        `json:"id"`
+	TenantID     int32        `json:"tenant_id"`
+	Name         string       `json:"name"`
+	Prefix       string       `json:"prefix"`
+	HashedSecret string       `json:"hashed_secret"`
+	Scopes       []string     `json:"scopes"`
+	CreatedAt    time.Time    `json:"created_at"`
+	LastUsedAt   sql.NullTime `json:"last_used_at"`
+	RevokedAt    sql.NullTime `json:"revoked_at"`
 }
 
 type Card struct {
@@ -25,11 +49,59 @@ type Card struct {
 	CreatedAt time.Time    `json:"created_at"`
 	UpdatedAt sql.NullTime `json:"updated_at"`
 	DeletedAt sql.NullTime `json:"deleted_at"`
+	Version   int32        `json:"version"`
+}
+
+type DataSubjectRequest struct {
+	ID          int32          `json:"id"`
+	TenantID    int32          `json:"tenant_id"`
+	AccountID   int32          `json:"account_id"`
+	Kind        string         `json:"kind"`
+	Format      sql.NullString `json:"format"`
+	Reason      sql.NullString `json:"reason"`
+	Actor       string         `json:"actor"`
+	RetainUntil sql.NullTime   `json:"retain_until"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+type RateLimitBucket struct {
+	TenantID  int32     `json:"tenant_id"`
+	Tokens    float64   `json:"tokens"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type Tenant struct {
-	ID   int32  `json:"id"`
-	Name string `json:"name"`
+	ID          int32        `json:"id"`
+	Name        string       `json:"name"`
+	Status      string       `json:"status"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   sql.NullTime `json:"updated_at"`
+	SuspendedAt sql.NullTime `json:"suspended_at"`
+}
+
+type TenantLogo struct {
+	TenantID    int32     `json:"tenant_id"`
+	ContentType string    `json:"content_type"`
+	Data        []byte    `json:"data"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type TenantSetting struct {
+	TenantID              int32          `json:"tenant_id"`
+	Currency              string         `json:"currency"`
+	Timezone              string         `json:"timezone"`
+	Locale                string         `json:"locale"`
+	ClosingDay            int32          `json:"closing_day"`
+	CreatedAt             time.Time      `json:"created_at"`
+	UpdatedAt             sql.NullTime   `json:"updated_at"`
+	DisplayName           sql.NullString `json:"display_name"`
+	PrimaryColor          string         `json:"primary_color"`
+	SecondaryColor        string         `json:"secondary_color"`
+	RateLimitPerSecond    int32          `json:"rate_limit_per_second"`
+	RateLimitBurst        int32          `json:"rate_limit_burst"`
+	MaxAccounts           int32          `json:"max_accounts"`
+	MaxCardsPerAccount    int32          `json:"max_cards_per_account"`
+	MaxTransactionsPerDay int32          `json:"max_transactions_per_day"`
 }
 
 type Transaction struct {
@@ -41,3 +113,32 @@ type Transaction struct {
 	UpdatedAt sql.NullTime `json:"updated_at"`
 	DeletedAt sql.NullTime `json:"deleted_at"`
 }
+
+type TransactionAdjustment struct {
+	ID            int32     `json:"id"`
+	TransactionID int32     `json:"transaction_id"`
+	Actor         string    `json:"actor"`
+	Reason        string    `json:"reason"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type User struct {
+	ID           int32          `json:"id"`
+	TenantID     int32          `json:"tenant_id"`
+	Email        string         `json:"email"`
+	PasswordHash sql.NullString `json:"password_hash"`
+	Role         string         `json:"role"`
+	Status       string         `json:"status"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    sql.NullTime   `json:"updated_at"`
+}
+
+type UserToken struct {
+	ID          int32        `json:"id"`
+	UserID      int32        `json:"user_id"`
+	Kind        string       `json:"kind"`
+	HashedToken string       `json:"hashed_token"`
+	ExpiresAt   time.Time    `json:"expires_at"`
+	UsedAt      sql.NullTime `json:"used_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
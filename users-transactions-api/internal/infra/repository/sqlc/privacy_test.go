@@ -0,0 +1,78 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivacyRepository(t *testing.T) {
+
+	t.Run("[PseudonymizeAccountTransactions] should replace kinds and keep values", func(t *testing.T) {
+		account := createTestAccount(t, 1)
+		card := createTestCard(t, account.ID)
+		accountId := account.ID
+
+		transaction, err := testQueries.CreateTransaction(context.Background(), CreateTransactionParams{
+			CardID: card.ID,
+			Kind:   "Streaming Z",
+			Value:  42,
+		})
+		assert.NoError(t, err)
+
+		n, err := testQueries.PseudonymizeAccountTransactions(context.Background(), PseudonymizeAccountTransactionsParams{
+			Pseudonym: "[erased]",
+			UpdatedAt: sql.NullTime{Time: time.Now(), Valid: true},
+			AccountID: accountId,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+
+		transactions, err := testQueries.GetAccountTransactions(context.Background(), accountId)
+
+		assert.NoError(t, err)
+		assert.Len(t, transactions, 1)
+		assert.Equal(t, "[erased]", transactions[0].Kind)
+		assert.Equal(t, transaction.Value, transactions[0].Value)
+	})
+
+	t.Run("[CreateDataSubjectRequest] should record requests per account", func(t *testing.T) {
+		account := createTestAccount(t, 1)
+
+		for _, kind := range []string{"export", "erasure"} {
+			_, err := testQueries.CreateDataSubjectRequest(context.Background(), CreateDataSubjectRequestParams{
+				TenantID:  account.TenantID,
+				AccountID: account.ID,
+				Kind:      kind,
+				Actor:     "user:1",
+			})
+			assert.NoError(t, err)
+		}
+
+		requests, err := testQueries.GetDataSubjectRequests(context.Background(), GetDataSubjectRequestsParams{
+			TenantID:  account.TenantID,
+			AccountID: account.ID,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, requests, 2)
+		assert.Equal(t, "export", requests[0].Kind)
+		assert.Equal(t, "erasure", requests[1].Kind)
+	})
+
+	t.Run("[MarkAccountErased] should set erased_at", func(t *testing.T) {
+		account := createTestAccount(t, 1)
+
+		erasedAccount, err := testQueries.MarkAccountErased(context.Background(), MarkAccountErasedParams{
+			ID:       account.ID,
+			ErasedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, erasedAccount.ErasedAt.Valid)
+	})
+}
@@ -10,18 +10,68 @@ import (
 
 type Querier interface {
 	AddAmount(ctx context.Context, arg AddAmountParams) (Card, error)
+	CountActiveAccounts(ctx context.Context, tenantID int32) (int64, error)
+	CountCards(ctx context.Context, accountID int32) (int64, error)
+	CountTransactionsSince(ctx context.Context, arg CountTransactionsSinceParams) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAccountStatusHistory(ctx context.Context, arg CreateAccountStatusHistoryParams) (AccountStatusHistory, error)
+	CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error)
 	CreateCard(ctx context.Context, accountID int32) (Card, error)
+	CreateDataSubjectRequest(ctx context.Context, arg CreateDataSubjectRequestParams) (DataSubjectRequest, error)
+	CreateTenant(ctx context.Context, name string) (Tenant, error)
+	CreateTenantSettings(ctx context.Context, tenantID int32) (TenantSetting, error)
 	CreateTransaction(ctx context.Context, arg CreateTransactionParams) (Transaction, error)
+	CreateTransactionAdjustment(ctx context.Context, arg CreateTransactionAdjustmentParams) (TransactionAdjustment, error)
+	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateUserToken(ctx context.Context, arg CreateUserTokenParams) (UserToken, error)
+	DeleteTenantLogo(ctx context.Context, tenantID int32) error
 	GetAccount(ctx context.Context, arg GetAccountParams) (Account, error)
+	GetAccountForShare(ctx context.Context, arg GetAccountForShareParams) (Account, error)
+	GetAccountForUpdate(ctx context.Context, arg GetAccountForUpdateParams) (Account, error)
+	GetAccountStatusHistory(ctx context.Context, accountID int32) ([]AccountStatusHistory, error)
+	GetAccountTransactions(ctx context.Context, accountID int32) ([]Transaction, error)
 	GetAccounts(ctx context.Context, tenantID int32) ([]Account, error)
+	GetApiKey(ctx context.Context, arg GetApiKeyParams) (ApiKey, error)
+	GetApiKeyByPrefix(ctx context.Context, prefix string) (ApiKey, error)
+	GetApiKeys(ctx context.Context, tenantID int32) ([]ApiKey, error)
 	GetCard(ctx context.Context, arg GetCardParams) (Card, error)
 	GetCards(ctx context.Context, accountID int32) ([]Card, error)
+	GetDataSubjectRequests(ctx context.Context, arg GetDataSubjectRequestsParams) ([]DataSubjectRequest, error)
 	GetTenant(ctx context.Context, id int32) (Tenant, error)
+	GetTenantLogo(ctx context.Context, tenantID int32) (TenantLogo, error)
+	GetTenantSettings(ctx context.Context, tenantID int32) (TenantSetting, error)
+	GetTenants(ctx context.Context) ([]Tenant, error)
 	GetTransaction(ctx context.Context, arg GetTransactionParams) (Transaction, error)
 	GetTransactions(ctx context.Context, cardID int32) ([]Transaction, error)
+	GetUser(ctx context.Context, arg GetUserParams) (User, error)
+	GetUserByEmail(ctx context.Context, arg GetUserByEmailParams) (User, error)
+	GetUserById(ctx context.Context, id int32) (User, error)
+	GetUserToken(ctx context.Context, arg GetUserTokenParams) (UserToken, error)
+	GetUsers(ctx context.Context, tenantID int32) ([]User, error)
+	LockTenantQuota(ctx context.Context, tenantID int32) error
+	MarkAccountErased(ctx context.Context, arg MarkAccountErasedParams) (Account, error)
+	PseudonymizeAccountStatusHistory(ctx context.Context, arg PseudonymizeAccountStatusHistoryParams) error
+	PseudonymizeAccountTransactions(ctx context.Context, arg PseudonymizeAccountTransactionsParams) (int64, error)
+	RecomputeCardAmount(ctx context.Context, arg RecomputeCardAmountParams) (Card, error)
+	RevokeApiKey(ctx context.Context, arg RevokeApiKeyParams) (ApiKey, error)
+	RevokeUserTokens(ctx context.Context, arg RevokeUserTokensParams) error
+	RotateApiKey(ctx context.Context, arg RotateApiKeyParams) (ApiKey, error)
 	SearchTransactions(ctx context.Context, arg SearchTransactionsParams) ([]SearchTransactionsRow, error)
+	// Refills the bucket for the elapsed time and takes one token in a single
+	// statement, so replicas sharing the database see a consistent bucket. The
+	// returned value is negative when the request must be rejected.
+	TakeRateLimitToken(ctx context.Context, arg TakeRateLimitTokenParams) (float64, error)
+	TouchApiKey(ctx context.Context, arg TouchApiKeyParams) error
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateTenantLimits(ctx context.Context, arg UpdateTenantLimitsParams) (TenantSetting, error)
+	UpdateTenantName(ctx context.Context, arg UpdateTenantNameParams) (Tenant, error)
+	UpdateTenantSettings(ctx context.Context, arg UpdateTenantSettingsParams) (TenantSetting, error)
+	UpdateTenantStatus(ctx context.Context, arg UpdateTenantStatusParams) (Tenant, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
+	UpdateUserStatus(ctx context.Context, arg UpdateUserStatusParams) (User, error)
+	UpsertTenantLogo(ctx context.Context, arg UpsertTenantLogoParams) (TenantLogo, error)
+	UseUserToken(ctx context.Context, arg UseUserTokenParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,44 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: rate_limit.sql
+
+package infra
+
+import (
+	"context"
+)
+
+const takeRateLimitToken = `-- name: TakeRateLimitToken :one
+INSERT INTO rate_limit_buckets (
+    tenant_id, tokens, updated_at
+) VALUES (
+    $1, $2::float8 - 1, now()
+)
+ON CONFLICT (tenant_id) DO UPDATE
+SET tokens = GREATEST(
+    LEAST(
+        $2::float8,
+        rate_limit_buckets.tokens + EXTRACT(EPOCH FROM now() - rate_limit_buckets.updated_at)::float8 * $3::float8
+    ) - 1,
+    -1
+),
+updated_at = now()
+RETURNING tokens
+`
+
+type TakeRateLimitTokenParams struct {
+	TenantID int32   `json:"tenant_id"`
+	Burst    float64 `json:"burst"`
+	Rate     float64 `json:"rate"`
+}
+
+// Refills the bucket for the elapsed time and takes one token in a single
+// statement, so replicas sharing the database see a consistent bucket. The
+// returned value is negative when the request must be rejected.
+func (q *Queries) TakeRateLimitToken(ctx context.Context, arg TakeRateLimitTokenParams) (float64, error) {
+	row := q.db.QueryRowContext(ctx, takeRateLimitToken, arg.TenantID, arg.Burst, arg.Rate)
+	var tokens float64
+	err := row.Scan(&tokens)
+	return tokens, err
+}
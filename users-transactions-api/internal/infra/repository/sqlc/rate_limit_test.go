@@ -0,0 +1,34 @@
+package infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitRepository(t *testing.T) {
+
+	t.Run("[TakeRateLimitToken] should take tokens until the bucket is empty", func(t *testing.T) {
+		params := TakeRateLimitTokenParams{
+			TenantID: 3,
+			Burst:    2,
+			Rate:     0.001,
+		}
+
+		tokens, err := testQueries.TakeRateLimitToken(context.Background(), params)
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 1, tokens, 0.01)
+
+		tokens, err = testQueries.TakeRateLimitToken(context.Background(), params)
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 0, tokens, 0.01)
+
+		tokens, err = testQueries.TakeRateLimitToken(context.Background(), params)
+
+		assert.NoError(t, err)
+		assert.Less(t, tokens, 0.0)
+	})
+}
@@ -0,0 +1,150 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/metrics"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/telemetry"
+)
+
+// replicaCheckTimeout bounds a health check, a replica slower than that is
+// as good as down for the requests.
+const replicaCheckTimeout = 2 * time.Second
+
+// ReplicaPool routes the statements of the read only usecases to the
+// replica while its health check passes, and to the primary otherwise. The
+// reads of a read-your-writes context always go to the primary. Without a
+// replica everything goes to the primary.
+type ReplicaPool struct {
+	primary *sql.DB
+	// replica is nil when not configured
+	replica *sql.DB
+	healthy atomic.Bool
+}
+
+// NewReplicaPool starts on the primary, the reads move to the replica after
+// its first successful Check.
+func NewReplicaPool(primary *sql.DB, replica *sql.DB) *ReplicaPool {
+	return &ReplicaPool{
+		primary: primary,
+		replica: replica,
+	}
+}
+
+// NewReadTx is a Tx routed by the pool, for the read only usecases.
+func NewReadTx(pool *ReplicaPool) *Tx {
+	return &Tx{
+		db:      pool,
+		Queries: New(telemetry.TraceDB(pool)),
+	}
+}
+
+func (p *ReplicaPool) route(ctx context.Context) *sql.DB {
+	if p.replica == nil || !p.healthy.Load() || shared.ReadYourWritesFromContext(ctx) {
+		metrics.DBRead("primary")
+		return p.primary
+	}
+
+	metrics.DBRead("replica")
+	return p.replica
+}
+
+func (p *ReplicaPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return p.route(ctx).ExecContext(ctx, query, args...)
+}
+
+func (p *ReplicaPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.route(ctx).PrepareContext(ctx, query)
+}
+
+func (p *ReplicaPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return p.route(ctx).QueryContext(ctx, query, args...)
+}
+
+func (p *ReplicaPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return p.route(ctx).QueryRowContext(ctx, query, args...)
+}
+
+// BeginTx always starts a read only transaction, so a write sent through
+// the pool fails on the primary too instead of only while the replica is
+// down.
+func (p *ReplicaPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	readOnly := &sql.TxOptions{ReadOnly: true}
+
+	if opts != nil {
+		readOnly.Isolation = opts.Isolation
+	}
+
+	return p.route(ctx).BeginTx(ctx, readOnly)
+}
+
+func (p *ReplicaPool) PingContext(ctx context.Context) error {
+	return p.route(ctx).PingContext(ctx)
+}
+
+// Check pings the replica and moves the reads to the primary while it
+// fails, and back once it passes again.
+func (p *ReplicaPool) Check(ctx context.Context) error {
+	if p.replica == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+	defer cancel()
+
+	err := p.replica.PingContext(ctx)
+	healthy := err == nil
+
+	if p.healthy.Swap(healthy) != healthy {
+		if healthy {
+			slog.Info("database replica is healthy, reads go to the replica")
+		} else {
+			slog.Warn("database replica is unhealthy, reads fall back to the primary",
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	metrics.DBReplicaHealthy(healthy)
+
+	return err
+}
+
+// Watch checks the replica every interval until the returned function is
+// called. The first check runs before it returns, so the reads start on
+// the replica when it is up.
+func (p *ReplicaPool) Watch(interval time.Duration) (stop func()) {
+	if p.replica == nil {
+		return func() {}
+	}
+
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	_ = p.Check(context.Background())
+
+	go func() {
+		defer close(stopped)
+
+		for {
+			select {
+			case <-ticker.C:
+				_ = p.Check(context.Background())
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
@@ -0,0 +1,69 @@
+package infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/config"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicaPool(t *testing.T) {
+	// a second container stands for the replica, its rows differ from the
+	// primary ones so the database answering is known
+	replicaDb := config.SetupPgTestcontainers()
+
+	replicaTenant, err := New(replicaDb).CreateTenant(context.Background(), "Replica only")
+
+	assert.NoError(t, err)
+
+	pool := NewReplicaPool(testDb, replicaDb)
+	readTx := NewReadTx(pool)
+
+	answeredByReplica := func(ctx context.Context) bool {
+		tenant, err := readTx.GetTenant(ctx, replicaTenant.ID)
+		return err == nil && tenant.Name == replicaTenant.Name
+	}
+
+	t.Run("[ReplicaPool] should read from the primary before the first check", func(t *testing.T) {
+		assert.False(t, answeredByReplica(context.Background()))
+	})
+
+	t.Run("[ReplicaPool] should read from the healthy replica", func(t *testing.T) {
+		assert.NoError(t, pool.Check(context.Background()))
+
+		assert.True(t, answeredByReplica(context.Background()))
+	})
+
+	t.Run("[ReplicaPool] should read from the primary with read-your-writes", func(t *testing.T) {
+		ctx := shared.ContextWithReadYourWrites(context.Background())
+
+		assert.False(t, answeredByReplica(ctx))
+	})
+
+	t.Run("[ReplicaPool] should refuse writes in tenant transactions", func(t *testing.T) {
+		for _, ctx := range []context.Context{
+			context.Background(),
+			shared.ContextWithReadYourWrites(context.Background()),
+		} {
+			err := readTx.WithTenant(ctx, 1, func(q Querier) error {
+				_, err := q.CreateAccount(ctx, CreateAccountParams{TenantID: 1, Status: "active"})
+				return err
+			})
+
+			assert.ErrorContains(t, err, "read-only transaction")
+		}
+	})
+
+	t.Run("[ReplicaPool] should fall back to the primary when the replica is down", func(t *testing.T) {
+		replicaDb.Close()
+
+		assert.Error(t, pool.Check(context.Background()))
+		assert.False(t, answeredByReplica(context.Background()))
+
+		_, err := readTx.GetTenant(context.Background(), 1)
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,78 @@
+package infra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/seed"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeed(t *testing.T) {
+	opts := seed.Options{
+		Seed:                 3,
+		Tenants:              2,
+		AccountsPerTenant:    5,
+		CardsPerAccount:      2,
+		TransactionsPerMonth: 10,
+		Months:               3,
+		Until:                time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// the card amounts and the sums of the transactions of the seeded tenants
+	totals := func(summary seed.Summary) (int64, int64, int) {
+		var amounts, values int64
+		var transactions int
+
+		err := testDb.QueryRow(`SELECT
+				(SELECT COALESCE(SUM(c.amount), 0) FROM cards c JOIN accounts a ON a.id = c.account_id
+					WHERE a.tenant_id BETWEEN $1 AND $2),
+				(SELECT COALESCE(SUM(t.value), 0) FROM transactions t JOIN cards c ON c.id = t.card_id
+					JOIN accounts a ON a.id = c.account_id WHERE a.tenant_id BETWEEN $1 AND $2),
+				(SELECT COUNT(*) FROM transactions t JOIN cards c ON c.id = t.card_id
+					JOIN accounts a ON a.id = c.account_id WHERE a.tenant_id BETWEEN $1 AND $2)`,
+			summary.FirstTenantId, summary.FirstTenantId+int32(summary.Tenants)-1).
+			Scan(&amounts, &values, &transactions)
+
+		assert.NoError(t, err)
+
+		return amounts, values, transactions
+	}
+
+	t.Run("[Run] copies the dataset and sets the card amounts", func(t *testing.T) {
+		summary, err := seed.Run(context.Background(), testDb, opts)
+
+		assert.NoError(t, err)
+
+		amounts, values, transactions := totals(summary)
+
+		assert.Positive(t, summary.Transactions)
+		assert.Equal(t, summary.Transactions, transactions)
+		assert.Equal(t, values, amounts)
+
+		settings, err := testQueries.GetTenantSettings(context.Background(), summary.FirstTenantId)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, settings.Currency)
+
+		// rows created afterwards still get free ids
+		createTestAccount(t, summary.FirstTenantId)
+	})
+
+	t.Run("[Run] the same seed produces the same data again", func(t *testing.T) {
+		first, err := seed.Run(context.Background(), testDb, opts)
+		assert.NoError(t, err)
+
+		second, err := seed.Run(context.Background(), testDb, opts)
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, first.FirstTenantId, second.FirstTenantId)
+
+		firstAmounts, _, firstTransactions := totals(first)
+		secondAmounts, _, secondTransactions := totals(second)
+
+		assert.Equal(t, firstAmounts, secondAmounts)
+		assert.Equal(t, firstTransactions, secondTransactions)
+	})
+}
@@ -7,10 +7,33 @@ package infra
 
 import (
 	"context"
+	"database/sql"
 )
 
+const createTenant = `-- name: CreateTenant :one
+INSERT INTO tenants (
+    name
+) VALUES (
+    $1
+) RETURNING id, name, status, created_at, updated_at, suspended_at
+`
+
+func (q *Queries) CreateTenant(ctx context.Context, name string) (Tenant, error) {
+	row := q.db.QueryRowContext(ctx, createTenant, name)
+	var i Tenant
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SuspendedAt,
+	)
+	return i, err
+}
+
 const getTenant = `-- name: GetTenant :one
-SELECT id, name FROM
+SELECT id, name, status, created_at, updated_at, suspended_at FROM
 tenants WHERE id = $1
 LIMIT 1
 `
@@ -18,6 +41,109 @@ LIMIT 1
 func (q *Queries) GetTenant(ctx context.Context, id int32) (Tenant, error) {
 	row := q.db.QueryRowContext(ctx, getTenant, id)
 	var i Tenant
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SuspendedAt,
+	)
+	return i, err
+}
+
+const getTenants = `-- name: GetTenants :many
+SELECT id, name, status, created_at, updated_at, suspended_at FROM
+tenants
+ORDER BY id
+`
+
+func (q *Queries) GetTenants(ctx context.Context) ([]Tenant, error) {
+	rows, err := q.db.QueryContext(ctx, getTenants)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tenant{}
+	for rows.Next() {
+		var i Tenant
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SuspendedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateTenantName = `-- name: UpdateTenantName :one
+UPDATE tenants
+SET name = $2, updated_at = $3
+WHERE id = $1
+RETURNING id, name, status, created_at, updated_at, suspended_at
+`
+
+type UpdateTenantNameParams struct {
+	ID        int32        `json:"id"`
+	Name      string       `json:"name"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+}
+
+func (q *Queries) UpdateTenantName(ctx context.Context, arg UpdateTenantNameParams) (Tenant, error) {
+	row := q.db.QueryRowContext(ctx, updateTenantName, arg.ID, arg.Name, arg.UpdatedAt)
+	var i Tenant
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SuspendedAt,
+	)
+	return i, err
+}
+
+const updateTenantStatus = `-- name: UpdateTenantStatus :one
+UPDATE tenants
+SET status = $2, suspended_at = $3, updated_at = $4
+WHERE id = $1
+RETURNING id, name, status, created_at, updated_at, suspended_at
+`
+
+type UpdateTenantStatusParams struct {
+	ID          int32        `json:"id"`
+	Status      string       `json:"status"`
+	SuspendedAt sql.NullTime `json:"suspended_at"`
+	UpdatedAt   sql.NullTime `json:"updated_at"`
+}
+
+func (q *Queries) UpdateTenantStatus(ctx context.Context, arg UpdateTenantStatusParams) (Tenant, error) {
+	row := q.db.QueryRowContext(ctx, updateTenantStatus,
+		arg.ID,
+		arg.Status,
+		arg.SuspendedAt,
+		arg.UpdatedAt,
+	)
+	var i Tenant
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SuspendedAt,
+	)
 	return i, err
 }
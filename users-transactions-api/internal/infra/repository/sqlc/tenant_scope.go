@@ -0,0 +1,48 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+)
+
+// tenantRole is the database role used by tenant scoped transactions. It is
+// subject to the row level security policies of accounts, cards and
+// transactions, unlike the owner of the tables.
+const tenantRole = "tenant_user"
+
+type tenantContextKey struct{}
+
+// ContextWithTenant marks the transactions started with the returned context
+// as scoped to the tenant.
+func ContextWithTenant(ctx context.Context, tenantId int32) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantId)
+}
+
+func TenantFromContext(ctx context.Context) (int32, bool) {
+	tenantId, ok := ctx.Value(tenantContextKey{}).(int32)
+	return tenantId, ok
+}
+
+// WithTenant runs fn in a transaction where the database only exposes the
+// rows of the tenant, even for queries that do not filter by it.
+func (transactionTx *Tx) WithTenant(ctx context.Context, tenantId int32, fn func(q Querier) error) error {
+	return transactionTx.execTx(ContextWithTenant(ctx, tenantId), func(q *Queries) error {
+		return fn(q)
+	})
+}
+
+// scopeTx switches the transaction to the tenant role and sets app.tenant_id,
+// both reverted when the transaction ends.
+func scopeTx(ctx context.Context, tx *sql.Tx, tenantId int32) error {
+	_, err := tx.ExecContext(ctx, "SET LOCAL ROLE "+tenantRole)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "SELECT set_config('app.tenant_id', $1, true)",
+		strconv.FormatInt(int64(tenantId), 10))
+
+	return err
+}
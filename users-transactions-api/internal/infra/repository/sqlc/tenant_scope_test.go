@@ -0,0 +1,157 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTenantScope checks the row level security policies directly, with
+// queries that do not filter by tenant, as a use case that forgot its
+// checks would run them.
+func TestTenantScope(t *testing.T) {
+	ctx := context.Background()
+	testTx := NewTx(testDb)
+
+	ownAccount, err := testQueries.CreateAccount(ctx, CreateAccountParams{TenantID: 1, Status: "active"})
+	assert.NoError(t, err)
+
+	otherAccount, err := testQueries.CreateAccount(ctx, CreateAccountParams{TenantID: 2, Status: "active"})
+	assert.NoError(t, err)
+
+	otherCard, err := testQueries.CreateCard(ctx, otherAccount.ID)
+	assert.NoError(t, err)
+
+	otherTransaction, err := testQueries.CreateTransaction(ctx, CreateTransactionParams{
+		CardID: otherCard.ID,
+		Kind:   "Streaming Z",
+		Value:  50,
+	})
+	assert.NoError(t, err)
+
+	t.Run("should read own rows", func(t *testing.T) {
+		err := testTx.WithTenant(ctx, 1, func(q Querier) error {
+			account, err := q.GetAccount(ctx, GetAccountParams{TenantID: 1, ID: ownAccount.ID})
+
+			assert.Equal(t, ownAccount.ID, account.ID)
+			return err
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not read account of another tenant", func(t *testing.T) {
+		err := testTx.WithTenant(ctx, 1, func(q Querier) error {
+			_, err := q.GetAccount(ctx, GetAccountParams{TenantID: 2, ID: otherAccount.ID})
+			return err
+		})
+
+		assert.EqualError(t, err, sql.ErrNoRows.Error())
+	})
+
+	t.Run("should not list accounts of another tenant", func(t *testing.T) {
+		err := testTx.WithTenant(ctx, 1, func(q Querier) error {
+			accounts, err := q.GetAccounts(ctx, 2)
+
+			assert.Empty(t, accounts)
+			return err
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not read card of another tenant", func(t *testing.T) {
+		err := testTx.WithTenant(ctx, 1, func(q Querier) error {
+			_, err := q.GetCard(ctx, GetCardParams{AccountID: otherAccount.ID, ID: otherCard.ID})
+			return err
+		})
+
+		assert.EqualError(t, err, sql.ErrNoRows.Error())
+	})
+
+	t.Run("should not read transactions of another tenant", func(t *testing.T) {
+		err := testTx.WithTenant(ctx, 1, func(q Querier) error {
+			transactions, err := q.GetTransactions(ctx, otherCard.ID)
+
+			assert.Empty(t, transactions)
+			return err
+		})
+
+		assert.NoError(t, err)
+
+		err = testTx.WithTenant(ctx, 1, func(q Querier) error {
+			_, err := q.GetTransaction(ctx, GetTransactionParams{CardID: otherCard.ID, ID: otherTransaction.ID})
+			return err
+		})
+
+		assert.EqualError(t, err, sql.ErrNoRows.Error())
+	})
+
+	t.Run("should not update card of another tenant", func(t *testing.T) {
+		err := testTx.WithTenant(ctx, 1, func(q Querier) error {
+			_, err := q.AddAmount(ctx, AddAmountParams{
+				ID:        otherCard.ID,
+				Amount:    1000,
+				UpdatedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+			})
+			return err
+		})
+
+		assert.EqualError(t, err, sql.ErrNoRows.Error())
+
+		card, err := testQueries.GetCard(ctx, GetCardParams{AccountID: otherAccount.ID, ID: otherCard.ID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, otherCard.Amount, card.Amount)
+	})
+
+	t.Run("should not insert rows for another tenant", func(t *testing.T) {
+		err := testTx.WithTenant(ctx, 1, func(q Querier) error {
+			_, err := q.CreateAccount(ctx, CreateAccountParams{TenantID: 2, Status: "active"})
+			return err
+		})
+
+		assert.ErrorContains(t, err, "row-level security")
+
+		err = testTx.WithTenant(ctx, 1, func(q Querier) error {
+			_, err := q.CreateCard(ctx, otherAccount.ID)
+			return err
+		})
+
+		assert.ErrorContains(t, err, "row-level security")
+	})
+
+	t.Run("should not create transaction for card of another tenant", func(t *testing.T) {
+		_, err := testTx.CreateTransactionTx(ContextWithTenant(ctx, 1), CreateTransactionParams{
+			CardID: otherCard.ID,
+			Kind:   "Streaming X",
+			Value:  10,
+		}, nil)
+
+		assert.ErrorContains(t, err, "row-level security")
+
+		transactions, err := testQueries.GetTransactions(ctx, otherCard.ID)
+
+		assert.NoError(t, err)
+		assert.Len(t, transactions, 1)
+	})
+
+	t.Run("should keep the scope inside the transaction", func(t *testing.T) {
+		err := testTx.WithTenant(ctx, 2, func(q Querier) error {
+			_, err := q.GetCard(ctx, GetCardParams{AccountID: otherAccount.ID, ID: otherCard.ID})
+			return err
+		})
+
+		assert.NoError(t, err)
+
+		var tenantId sql.NullString
+
+		err = testDb.QueryRowContext(ctx, "SELECT NULLIF(current_setting('app.tenant_id', true), '')").Scan(&tenantId)
+
+		assert.NoError(t, err)
+		assert.False(t, tenantId.Valid)
+	})
+}
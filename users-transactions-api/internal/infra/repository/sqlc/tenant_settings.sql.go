@@ -0,0 +1,249 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: tenant_settings.sql
+
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createTenantSettings = `-- name: CreateTenantSettings :one
+INSERT INTO tenant_settings (
+    tenant_id
+) VALUES (
+    $1
+) RETURNING tenant_id, currency, timezone, locale, closing_day, created_at, updated_at, display_name, primary_color, secondary_color, rate_limit_per_second, rate_limit_burst, max_accounts, max_cards_per_account, max_transactions_per_day
+`
+
+func (q *Queries) CreateTenantSettings(ctx context.Context, tenantID int32) (TenantSetting, error) {
+	row := q.db.QueryRowContext(ctx, createTenantSettings, tenantID)
+	var i TenantSetting
+	err := row.Scan(
+		&i.TenantID,
+		&i.Currency,
+		&i.Timezone,
+		&i.Locale,
+		&i.ClosingDay,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DisplayName,
+		&i.PrimaryColor,
+		&i.SecondaryColor,
+		&i.RateLimitPerSecond,
+		&i.RateLimitBurst,
+		&i.MaxAccounts,
+		&i.MaxCardsPerAccount,
+		&i.MaxTransactionsPerDay,
+	)
+	return i, err
+}
+
+const deleteTenantLogo = `-- name: DeleteTenantLogo :exec
+DELETE FROM tenant_logos
+WHERE tenant_id = $1
+`
+
+func (q *Queries) DeleteTenantLogo(ctx context.Context, tenantID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteTenantLogo, tenantID)
+	return err
+}
+
+const getTenantLogo = `-- name: GetTenantLogo :one
+SELECT tenant_id, content_type, data, updated_at FROM
+tenant_logos WHERE tenant_id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetTenantLogo(ctx context.Context, tenantID int32) (TenantLogo, error) {
+	row := q.db.QueryRowContext(ctx, getTenantLogo, tenantID)
+	var i TenantLogo
+	err := row.Scan(
+		&i.TenantID,
+		&i.ContentType,
+		&i.Data,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTenantSettings = `-- name: GetTenantSettings :one
+SELECT tenant_id, currency, timezone, locale, closing_day, created_at, updated_at, display_name, primary_color, secondary_color, rate_limit_per_second, rate_limit_burst, max_accounts, max_cards_per_account, max_transactions_per_day FROM
+tenant_settings WHERE tenant_id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetTenantSettings(ctx context.Context, tenantID int32) (TenantSetting, error) {
+	row := q.db.QueryRowContext(ctx, getTenantSettings, tenantID)
+	var i TenantSetting
+	err := row.Scan(
+		&i.TenantID,
+		&i.Currency,
+		&i.Timezone,
+		&i.Locale,
+		&i.ClosingDay,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DisplayName,
+		&i.PrimaryColor,
+		&i.SecondaryColor,
+		&i.RateLimitPerSecond,
+		&i.RateLimitBurst,
+		&i.MaxAccounts,
+		&i.MaxCardsPerAccount,
+		&i.MaxTransactionsPerDay,
+	)
+	return i, err
+}
+
+const lockTenantQuota = `-- name: LockTenantQuota :exec
+SELECT pg_advisory_xact_lock(hashtext('tenant_quota'), $1::int)
+`
+
+func (q *Queries) LockTenantQuota(ctx context.Context, tenantID int32) error {
+	_, err := q.db.ExecContext(ctx, lockTenantQuota, tenantID)
+	return err
+}
+
+const updateTenantLimits = `-- name: UpdateTenantLimits :one
+UPDATE tenant_settings
+SET rate_limit_per_second = $2, rate_limit_burst = $3, max_accounts = $4,
+max_cards_per_account = $5, max_transactions_per_day = $6, updated_at = $7
+WHERE tenant_id = $1
+RETURNING tenant_id, currency, timezone, locale, closing_day, created_at, updated_at, display_name, primary_color, secondary_color, rate_limit_per_second, rate_limit_burst, max_accounts, max_cards_per_account, max_transactions_per_day
+`
+
+type UpdateTenantLimitsParams struct {
+	TenantID              int32        `json:"tenant_id"`
+	RateLimitPerSecond    int32        `json:"rate_limit_per_second"`
+	RateLimitBurst        int32        `json:"rate_limit_burst"`
+	MaxAccounts           int32        `json:"max_accounts"`
+	MaxCardsPerAccount    int32        `json:"max_cards_per_account"`
+	MaxTransactionsPerDay int32        `json:"max_transactions_per_day"`
+	UpdatedAt             sql.NullTime `json:"updated_at"`
+}
+
+func (q *Queries) UpdateTenantLimits(ctx context.Context, arg UpdateTenantLimitsParams) (TenantSetting, error) {
+	row := q.db.QueryRowContext(ctx, updateTenantLimits,
+		arg.TenantID,
+		arg.RateLimitPerSecond,
+		arg.RateLimitBurst,
+		arg.MaxAccounts,
+		arg.MaxCardsPerAccount,
+		arg.MaxTransactionsPerDay,
+		arg.UpdatedAt,
+	)
+	var i TenantSetting
+	err := row.Scan(
+		&i.TenantID,
+		&i.Currency,
+		&i.Timezone,
+		&i.Locale,
+		&i.ClosingDay,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DisplayName,
+		&i.PrimaryColor,
+		&i.SecondaryColor,
+		&i.RateLimitPerSecond,
+		&i.RateLimitBurst,
+		&i.MaxAccounts,
+		&i.MaxCardsPerAccount,
+		&i.MaxTransactionsPerDay,
+	)
+	return i, err
+}
+
+const updateTenantSettings = `-- name: UpdateTenantSettings :one
+UPDATE tenant_settings
+SET currency = $2, timezone = $3, locale = $4, closing_day = $5,
+display_name = $6, primary_color = $7, secondary_color = $8, updated_at = $9
+WHERE tenant_id = $1
+RETURNING tenant_id, currency, timezone, locale, closing_day, created_at, updated_at, display_name, primary_color, secondary_color, rate_limit_per_second, rate_limit_burst, max_accounts, max_cards_per_account, max_transactions_per_day
+`
+
+type UpdateTenantSettingsParams struct {
+	TenantID       int32          `json:"tenant_id"`
+	Currency       string         `json:"currency"`
+	Timezone       string         `json:"timezone"`
+	Locale         string         `json:"locale"`
+	ClosingDay     int32          `json:"closing_day"`
+	DisplayName    sql.NullString `json:"display_name"`
+	PrimaryColor   string         `json:"primary_color"`
+	SecondaryColor string         `json:"secondary_color"`
+	UpdatedAt      sql.NullTime   `json:"updated_at"`
+}
+
+func (q *Queries) UpdateTenantSettings(ctx context.Context, arg UpdateTenantSettingsParams) (TenantSetting, error) {
+	row := q.db.QueryRowContext(ctx, updateTenantSettings,
+		arg.TenantID,
+		arg.Currency,
+		arg.Timezone,
+		arg.Locale,
+		arg.ClosingDay,
+		arg.DisplayName,
+		arg.PrimaryColor,
+		arg.SecondaryColor,
+		arg.UpdatedAt,
+	)
+	var i TenantSetting
+	err := row.Scan(
+		&i.TenantID,
+		&i.Currency,
+		&i.Timezone,
+		&i.Locale,
+		&i.ClosingDay,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DisplayName,
+		&i.PrimaryColor,
+		&i.SecondaryColor,
+		&i.RateLimitPerSecond,
+		&i.RateLimitBurst,
+		&i.MaxAccounts,
+		&i.MaxCardsPerAccount,
+		&i.MaxTransactionsPerDay,
+	)
+	return i, err
+}
+
+const upsertTenantLogo = `-- name: UpsertTenantLogo :one
+INSERT INTO tenant_logos (
+    tenant_id,
+    content_type,
+    data,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (tenant_id) DO UPDATE
+SET content_type = EXCLUDED.content_type, data = EXCLUDED.data, updated_at = EXCLUDED.updated_at
+RETURNING tenant_id, content_type, data, updated_at
+`
+
+type UpsertTenantLogoParams struct {
+	TenantID    int32     `json:"tenant_id"`
+	ContentType string    `json:"content_type"`
+	Data        []byte    `json:"data"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (q *Queries) UpsertTenantLogo(ctx context.Context, arg UpsertTenantLogoParams) (TenantLogo, error) {
+	row := q.db.QueryRowContext(ctx, upsertTenantLogo,
+		arg.TenantID,
+		arg.ContentType,
+		arg.Data,
+		arg.UpdatedAt,
+	)
+	var i TenantLogo
+	err := row.Scan(
+		&i.TenantID,
+		&i.ContentType,
+		&i.Data,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,80 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantSettingsRepository(t *testing.T) {
+
+	t.Run("[GetTenantSettings] should find default settings of seeded tenant", func(t *testing.T) {
+		settings, err := testQueries.GetTenantSettings(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "BRL", settings.Currency)
+		assert.Equal(t, "America/Sao_Paulo", settings.Timezone)
+		assert.Equal(t, "pt-BR", settings.Locale)
+		assert.Equal(t, "#000000", settings.PrimaryColor)
+	})
+
+	t.Run("[UpdateTenantSettings] should update settings", func(t *testing.T) {
+		tenant, err := testQueries.CreateTenant(context.Background(), "Tenant Settings")
+
+		assert.NoError(t, err)
+
+		_, err = testQueries.CreateTenantSettings(context.Background(), tenant.ID)
+
+		assert.NoError(t, err)
+
+		settings, err := testQueries.UpdateTenantSettings(context.Background(), UpdateTenantSettingsParams{
+			TenantID:       tenant.ID,
+			Currency:       "USD",
+			Timezone:       "America/New_York",
+			Locale:         "en-US",
+			ClosingDay:     10,
+			DisplayName:    sql.NullString{String: "Settings Inc.", Valid: true},
+			PrimaryColor:   "#112233",
+			SecondaryColor: "#445566",
+			UpdatedAt:      sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "USD", settings.Currency)
+		assert.Equal(t, int32(10), settings.ClosingDay)
+		assert.Equal(t, "Settings Inc.", settings.DisplayName.String)
+	})
+
+	t.Run("[UpsertTenantLogo] should create, replace and delete logo", func(t *testing.T) {
+		_, err := testQueries.GetTenantLogo(context.Background(), 1)
+
+		assert.EqualError(t, err, sql.ErrNoRows.Error())
+
+		for _, data := range [][]byte{[]byte("first"), []byte("second")} {
+			_, err = testQueries.UpsertTenantLogo(context.Background(), UpsertTenantLogoParams{
+				TenantID:    1,
+				ContentType: "image/png",
+				Data:        data,
+				UpdatedAt:   time.Now().UTC(),
+			})
+
+			assert.NoError(t, err)
+		}
+
+		logo, err := testQueries.GetTenantLogo(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("second"), logo.Data)
+
+		err = testQueries.DeleteTenantLogo(context.Background(), 1)
+
+		assert.NoError(t, err)
+
+		_, err = testQueries.GetTenantLogo(context.Background(), 1)
+
+		assert.EqualError(t, err, sql.ErrNoRows.Error())
+	})
+}
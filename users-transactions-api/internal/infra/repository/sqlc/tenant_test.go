@@ -3,8 +3,12 @@ package infra
 import (
 	"context"
 	"database/sql"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/config"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/notify"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,4 +28,87 @@ func TestTenantRepository(t *testing.T) {
 		assert.Empty(t, tenant)
 		assert.EqualError(t, err, sql.ErrNoRows.Error())
 	})
+
+	t.Run("[UpdateTenantName] should rename tenant", func(t *testing.T) {
+		tenant, err := testQueries.CreateTenant(context.Background(), "Tenant Z")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "active", tenant.Status)
+
+		renamedTenant, err := testQueries.UpdateTenantName(context.Background(), UpdateTenantNameParams{
+			ID:        tenant.ID,
+			Name:      "Tenant Y",
+			UpdatedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Tenant Y", renamedTenant.Name)
+		assert.True(t, renamedTenant.UpdatedAt.Valid)
+	})
+
+	t.Run("[UpdateTenantStatus] should suspend tenant", func(t *testing.T) {
+		tenant, err := testQueries.CreateTenant(context.Background(), "Tenant X")
+
+		assert.NoError(t, err)
+
+		now := time.Now().UTC()
+
+		suspendedTenant, err := testQueries.UpdateTenantStatus(context.Background(), UpdateTenantStatusParams{
+			ID:          tenant.ID,
+			Status:      "suspended",
+			SuspendedAt: sql.NullTime{Time: now, Valid: true},
+			UpdatedAt:   sql.NullTime{Time: now, Valid: true},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "suspended", suspendedTenant.Status)
+		assert.True(t, suspendedTenant.SuspendedAt.Valid)
+	})
+
+	t.Run("[tenant_changed] should notify the id of the changed tenant", func(t *testing.T) {
+		tenant, err := testQueries.CreateTenant(context.Background(), "Tenant W")
+
+		assert.NoError(t, err)
+
+		notified := make(chan string, 10)
+		stop := notify.Listen(config.PgTestcontainersConnString(testDb), "tenant_changed", func(payload string) {
+			notified <- payload
+		}, func() {})
+		defer stop()
+
+		// the listener connects in the background, the change is repeated
+		// until it is notified
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		timeout := time.After(10 * time.Second)
+
+		for {
+			now := time.Now().UTC()
+			_, err := testQueries.UpdateTenantStatus(context.Background(), UpdateTenantStatusParams{
+				ID:          tenant.ID,
+				Status:      "suspended",
+				SuspendedAt: sql.NullTime{Time: now, Valid: true},
+				UpdatedAt:   sql.NullTime{Time: now, Valid: true},
+			})
+
+			assert.NoError(t, err)
+
+			select {
+			case payload := <-notified:
+				assert.Equal(t, strconv.Itoa(int(tenant.ID)), payload)
+				return
+			case <-timeout:
+				t.Fatal("tenant change not notified")
+			case <-ticker.C:
+			}
+		}
+	})
+
+	t.Run("[GetTenantSettings] should find seed tenant settings", func(t *testing.T) {
+		settings, err := testQueries.GetTenantSettings(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "BRL", settings.Currency)
+		assert.Equal(t, "America/Sao_Paulo", settings.Timezone)
+	})
 }
@@ -7,8 +7,28 @@ package infra
 
 import (
 	"context"
+	"time"
 )
 
+const countTransactionsSince = `-- name: CountTransactionsSince :one
+SELECT COUNT(*) FROM transactions t
+JOIN cards c ON t.card_id = c.id
+JOIN accounts a ON c.account_id = a.id
+WHERE a.tenant_id = $1 AND t.created_at >= $2
+`
+
+type CountTransactionsSinceParams struct {
+	TenantID int32     `json:"tenant_id"`
+	Since    time.Time `json:"since"`
+}
+
+func (q *Queries) CountTransactionsSince(ctx context.Context, arg CountTransactionsSinceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countTransactionsSince, arg.TenantID, arg.Since)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTransaction = `-- name: CreateTransaction :one
 INSERT INTO transactions (
     card_id,
@@ -40,6 +60,35 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 	return i, err
 }
 
+const createTransactionAdjustment = `-- name: CreateTransactionAdjustment :one
+INSERT INTO transaction_adjustments (
+    transaction_id,
+    actor,
+    reason
+) VALUES (
+    $1, $2, $3
+) RETURNING id, transaction_id, actor, reason, created_at
+`
+
+type CreateTransactionAdjustmentParams struct {
+	TransactionID int32  `json:"transaction_id"`
+	Actor         string `json:"actor"`
+	Reason        string `json:"reason"`
+}
+
+func (q *Queries) CreateTransactionAdjustment(ctx context.Context, arg CreateTransactionAdjustmentParams) (TransactionAdjustment, error) {
+	row := q.db.QueryRowContext(ctx, createTransactionAdjustment, arg.TransactionID, arg.Actor, arg.Reason)
+	var i TransactionAdjustment
+	err := row.Scan(
+		&i.ID,
+		&i.TransactionID,
+		&i.Actor,
+		&i.Reason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getTransaction = `-- name: GetTransaction :one
 SELECT id, card_id, kind, value, created_at, updated_at, deleted_at FROM transactions 
 WHERE card_id = $1 AND id = $2
@@ -107,7 +156,8 @@ SELECT
 t.id,
 t.card_id,
 t.kind,
-t.value
+t.value,
+t.created_at
 FROM transactions t
 JOIN cards c ON t.card_id = c.id
 JOIN accounts a ON c.account_id = a.id
@@ -120,10 +170,11 @@ type SearchTransactionsParams struct {
 }
 
 type SearchTransactionsRow struct {
-	ID     int32  `json:"id"`
-	CardID int32  `json:"card_id"`
-	Kind   string `json:"kind"`
-	Value  int64  `json:"value"`
+	ID        int32     `json:"id"`
+	CardID    int32     `json:"card_id"`
+	Kind      string    `json:"kind"`
+	Value     int64     `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 func (q *Queries) SearchTransactions(ctx context.Context, arg SearchTransactionsParams) ([]SearchTransactionsRow, error) {
@@ -140,6 +191,7 @@ func (q *Queries) SearchTransactions(ctx context.Context, arg SearchTransactions
 			&i.CardID,
 			&i.Kind,
 			&i.Value,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
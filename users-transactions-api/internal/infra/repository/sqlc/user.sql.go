@@ -0,0 +1,356 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: user.sql
+
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createUser = `-- name: CreateUser :one
+INSERT INTO users (
+    tenant_id, email, role, status
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, tenant_id, email, password_hash, role, status, created_at, updated_at
+`
+
+type CreateUserParams struct {
+	TenantID int32  `json:"tenant_id"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+	Status   string `json:"status"`
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, createUser,
+		arg.TenantID,
+		arg.Email,
+		arg.Role,
+		arg.Status,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.Role,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createUserToken = `-- name: CreateUserToken :one
+INSERT INTO user_tokens (
+    user_id, kind, hashed_token, expires_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, user_id, kind, hashed_token, expires_at, used_at, created_at
+`
+
+type CreateUserTokenParams struct {
+	UserID      int32     `json:"user_id"`
+	Kind        string    `json:"kind"`
+	HashedToken string    `json:"hashed_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func (q *Queries) CreateUserToken(ctx context.Context, arg CreateUserTokenParams) (UserToken, error) {
+	row := q.db.QueryRowContext(ctx, createUserToken,
+		arg.UserID,
+		arg.Kind,
+		arg.HashedToken,
+		arg.ExpiresAt,
+	)
+	var i UserToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Kind,
+		&i.HashedToken,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getUser = `-- name: GetUser :one
+SELECT id, tenant_id, email, password_hash, role, status, created_at, updated_at FROM users
+WHERE tenant_id = $1 AND id = $2
+LIMIT 1
+`
+
+type GetUserParams struct {
+	TenantID int32 `json:"tenant_id"`
+	ID       int32 `json:"id"`
+}
+
+func (q *Queries) GetUser(ctx context.Context, arg GetUserParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUser, arg.TenantID, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.Role,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, tenant_id, email, password_hash, role, status, created_at, updated_at FROM users
+WHERE tenant_id = $1 AND email = $2
+LIMIT 1
+`
+
+type GetUserByEmailParams struct {
+	TenantID int32  `json:"tenant_id"`
+	Email    string `json:"email"`
+}
+
+func (q *Queries) GetUserByEmail(ctx context.Context, arg GetUserByEmailParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmail, arg.TenantID, arg.Email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.Role,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUserById = `-- name: GetUserById :one
+SELECT id, tenant_id, email, password_hash, role, status, created_at, updated_at FROM users
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetUserById(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserById, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.Role,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUserToken = `-- name: GetUserToken :one
+SELECT id, user_id, kind, hashed_token, expires_at, used_at, created_at FROM user_tokens
+WHERE hashed_token = $1 AND kind = $2
+LIMIT 1
+`
+
+type GetUserTokenParams struct {
+	HashedToken string `json:"hashed_token"`
+	Kind        string `json:"kind"`
+}
+
+func (q *Queries) GetUserToken(ctx context.Context, arg GetUserTokenParams) (UserToken, error) {
+	row := q.db.QueryRowContext(ctx, getUserToken, arg.HashedToken, arg.Kind)
+	var i UserToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Kind,
+		&i.HashedToken,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getUsers = `-- name: GetUsers :many
+SELECT id, tenant_id, email, password_hash, role, status, created_at, updated_at FROM users
+WHERE tenant_id = $1
+ORDER BY id
+`
+
+func (q *Queries) GetUsers(ctx context.Context, tenantID int32) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, getUsers, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.Email,
+			&i.PasswordHash,
+			&i.Role,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeUserTokens = `-- name: RevokeUserTokens :exec
+UPDATE user_tokens
+SET used_at = $3
+WHERE user_id = $1 AND kind = $2 AND used_at IS NULL
+`
+
+type RevokeUserTokensParams struct {
+	UserID int32        `json:"user_id"`
+	Kind   string       `json:"kind"`
+	UsedAt sql.NullTime `json:"used_at"`
+}
+
+func (q *Queries) RevokeUserTokens(ctx context.Context, arg RevokeUserTokensParams) error {
+	_, err := q.db.ExecContext(ctx, revokeUserTokens, arg.UserID, arg.Kind, arg.UsedAt)
+	return err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :one
+UPDATE users
+SET password_hash = $2,
+status = $3,
+updated_at = $4
+WHERE id = $1
+RETURNING id, tenant_id, email, password_hash, role, status, created_at, updated_at
+`
+
+type UpdateUserPasswordParams struct {
+	ID           int32          `json:"id"`
+	PasswordHash sql.NullString `json:"password_hash"`
+	Status       string         `json:"status"`
+	UpdatedAt    sql.NullTime   `json:"updated_at"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserPassword,
+		arg.ID,
+		arg.PasswordHash,
+		arg.Status,
+		arg.UpdatedAt,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.Role,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+UPDATE users
+SET role = $2,
+updated_at = $3
+WHERE id = $1
+RETURNING id, tenant_id, email, password_hash, role, status, created_at, updated_at
+`
+
+type UpdateUserRoleParams struct {
+	ID        int32        `json:"id"`
+	Role      string       `json:"role"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserRole, arg.ID, arg.Role, arg.UpdatedAt)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.Role,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateUserStatus = `-- name: UpdateUserStatus :one
+UPDATE users
+SET status = $2,
+updated_at = $3
+WHERE id = $1
+RETURNING id, tenant_id, email, password_hash, role, status, created_at, updated_at
+`
+
+type UpdateUserStatusParams struct {
+	ID        int32        `json:"id"`
+	Status    string       `json:"status"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+}
+
+func (q *Queries) UpdateUserStatus(ctx context.Context, arg UpdateUserStatusParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserStatus, arg.ID, arg.Status, arg.UpdatedAt)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.Role,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const useUserToken = `-- name: UseUserToken :execrows
+UPDATE user_tokens
+SET used_at = $2
+WHERE id = $1 AND used_at IS NULL
+`
+
+type UseUserTokenParams struct {
+	ID     int32        `json:"id"`
+	UsedAt sql.NullTime `json:"used_at"`
+}
+
+func (q *Queries) UseUserToken(ctx context.Context, arg UseUserTokenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, useUserToken, arg.ID, arg.UsedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
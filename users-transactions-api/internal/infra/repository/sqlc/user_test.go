@@ -0,0 +1,231 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestUser(t *testing.T, tenantId int32) User {
+	arg := CreateUserParams{
+		TenantID: tenantId,
+		Email:    fmt.Sprintf("user-%d@example.com", time.Now().UnixNano()),
+		Role:     "viewer",
+		Status:   "invited",
+	}
+
+	user, err := testQueries.CreateUser(context.Background(), arg)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, user)
+	assert.Equal(t, arg.Email, user.Email)
+	assert.Equal(t, arg.Role, user.Role)
+	assert.False(t, user.PasswordHash.Valid)
+
+	return user
+}
+
+func TestUserRepository(t *testing.T) {
+
+	t.Run("[CreateUser] should not duplicate email in the same tenant", func(t *testing.T) {
+		user := createTestUser(t, 1)
+
+		_, err := testQueries.CreateUser(context.Background(), CreateUserParams{
+			TenantID: user.TenantID,
+			Email:    user.Email,
+			Role:     "viewer",
+			Status:   "invited",
+		})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("[GetUser] should not find user from another tenant", func(t *testing.T) {
+		user := createTestUser(t, 1)
+
+		_, err := testQueries.GetUser(context.Background(), GetUserParams{
+			TenantID: 2,
+			ID:       user.ID,
+		})
+
+		assert.EqualError(t, err, sql.ErrNoRows.Error())
+	})
+
+	t.Run("[UpdateUserPassword] should activate user", func(t *testing.T) {
+		user := createTestUser(t, 1)
+
+		updatedUser, err := testQueries.UpdateUserPassword(context.Background(), UpdateUserPasswordParams{
+			ID:           user.ID,
+			PasswordHash: sql.NullString{String: "hashed-password", Valid: true},
+			Status:       "active",
+			UpdatedAt:    sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "active", updatedUser.Status)
+		assert.Equal(t, "hashed-password", updatedUser.PasswordHash.String)
+	})
+
+	t.Run("[UseUserToken] should mark token as used", func(t *testing.T) {
+		user := createTestUser(t, 1)
+
+		userToken, err := testQueries.CreateUserToken(context.Background(), CreateUserTokenParams{
+			UserID:      user.ID,
+			Kind:        "session",
+			HashedToken: fmt.Sprintf("%064d", time.Now().UnixNano()),
+			ExpiresAt:   time.Now().UTC().Add(time.Hour),
+		})
+
+		assert.NoError(t, err)
+
+		used, err := testQueries.UseUserToken(context.Background(), UseUserTokenParams{
+			ID:     userToken.ID,
+			UsedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), used)
+
+		foundToken, err := testQueries.GetUserToken(context.Background(), GetUserTokenParams{
+			HashedToken: userToken.HashedToken,
+			Kind:        "session",
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, foundToken.UsedAt.Valid)
+
+		used, err = testQueries.UseUserToken(context.Background(), UseUserTokenParams{
+			ID:     userToken.ID,
+			UsedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), used)
+	})
+}
+
+func TestUserTxRepository(t *testing.T) {
+	userTx := NewTx(testDb)
+
+	newTokenParams := func(kind string) CreateUserTokenParams {
+		return CreateUserTokenParams{
+			Kind:        kind,
+			HashedToken: fmt.Sprintf("%064d", time.Now().UnixNano()),
+			ExpiresAt:   time.Now().UTC().Add(time.Hour),
+		}
+	}
+
+	t.Run("[SetPasswordTx] should use the token only once", func(t *testing.T) {
+		user := createTestUser(t, 1)
+
+		tokenArg := newTokenParams("password_reset")
+		tokenArg.UserID = user.ID
+		userToken, err := testQueries.CreateUserToken(context.Background(), tokenArg)
+
+		assert.NoError(t, err)
+
+		n := 10
+		errs := make(chan error, n)
+
+		for i := 0; i < n; i++ {
+			go func() {
+				_, err := userTx.SetPasswordTx(context.Background(), SetPasswordTxParams{
+					Token: UseUserTokenParams{
+						ID:     userToken.ID,
+						UsedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+					},
+					Password: UpdateUserPasswordParams{
+						ID:           user.ID,
+						PasswordHash: sql.NullString{String: fmt.Sprintf("hashed-password-%d", i), Valid: true},
+						Status:       "active",
+						UpdatedAt:    sql.NullTime{Time: time.Now().UTC(), Valid: true},
+					},
+				})
+
+				errs <- err
+			}()
+		}
+
+		succeeded := 0
+
+		for i := 0; i < n; i++ {
+			err := <-errs
+
+			if err == nil {
+				succeeded++
+				continue
+			}
+
+			assert.ErrorIs(t, err, ErrUserTokenUsed)
+		}
+
+		assert.Equal(t, 1, succeeded)
+	})
+
+	t.Run("[InviteUserTx] should create the user with its token", func(t *testing.T) {
+		email := fmt.Sprintf("invited-%d@example.com", time.Now().UnixNano())
+		tokenArg := newTokenParams("invitation")
+
+		user, err := userTx.InviteUserTx(context.Background(), InviteUserTxParams{
+			User:  CreateUserParams{TenantID: 1, Email: email, Role: "viewer", Status: "invited"},
+			Token: tokenArg,
+		})
+
+		assert.NoError(t, err)
+
+		userToken, err := testQueries.GetUserToken(context.Background(), GetUserTokenParams{
+			HashedToken: tokenArg.HashedToken,
+			Kind:        "invitation",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, userToken.UserID)
+
+		_, err = userTx.InviteUserTx(context.Background(), InviteUserTxParams{
+			User:  CreateUserParams{TenantID: 1, Email: email, Role: "viewer", Status: "invited"},
+			Token: newTokenParams("invitation"),
+		})
+
+		assert.True(t, IsUniqueViolation(err))
+	})
+
+	t.Run("[ReinviteUserTx] should replace the pending invitation", func(t *testing.T) {
+		user := createTestUser(t, 1)
+
+		oldTokenArg := newTokenParams("invitation")
+		oldTokenArg.UserID = user.ID
+		_, err := testQueries.CreateUserToken(context.Background(), oldTokenArg)
+
+		assert.NoError(t, err)
+
+		newTokenArg := newTokenParams("invitation")
+		newTokenArg.UserID = user.ID
+
+		err = userTx.ReinviteUserTx(context.Background(), ReinviteUserTxParams{
+			Token:     newTokenArg,
+			RevokedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		})
+
+		assert.NoError(t, err)
+
+		oldToken, err := testQueries.GetUserToken(context.Background(), GetUserTokenParams{
+			HashedToken: oldTokenArg.HashedToken,
+			Kind:        "invitation",
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, oldToken.UsedAt.Valid)
+
+		newToken, err := testQueries.GetUserToken(context.Background(), GetUserTokenParams{
+			HashedToken: newTokenArg.HashedToken,
+			Kind:        "invitation",
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, newToken.UsedAt.Valid)
+	})
+}
@@ -0,0 +1,98 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// ErrUserTokenUsed is returned when another request used the token first.
+var ErrUserTokenUsed = errors.New("user token already used")
+
+// IsUniqueViolation reports whether err comes from a unique constraint.
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+type SetPasswordTxParams struct {
+	Token    UseUserTokenParams
+	Password UpdateUserPasswordParams
+}
+
+type InviteUserTxParams struct {
+	User  CreateUserParams
+	Token CreateUserTokenParams
+}
+
+// ReinviteUserTxParams replaces the pending invitations of the user with
+// Token, their UserID and Kind are taken from it.
+type ReinviteUserTxParams struct {
+	Token     CreateUserTokenParams
+	RevokedAt sql.NullTime
+}
+
+// SetPasswordTx uses the token and updates the password only if the token
+// was still unused, so it cannot be consumed twice concurrently.
+func (tx *Tx) SetPasswordTx(ctx context.Context, arg SetPasswordTxParams) (User, error) {
+	var user User
+
+	err := tx.execTx(ctx, func(q *Queries) error {
+		used, err := q.UseUserToken(ctx, arg.Token)
+
+		if err != nil {
+			return err
+		}
+
+		if used == 0 {
+			return ErrUserTokenUsed
+		}
+
+		user, err = q.UpdateUserPassword(ctx, arg.Password)
+
+		return err
+	})
+
+	return user, err
+}
+
+// InviteUserTx creates the invited user with its invitation token.
+func (tx *Tx) InviteUserTx(ctx context.Context, arg InviteUserTxParams) (User, error) {
+	var user User
+
+	err := tx.execTx(ctx, func(q *Queries) error {
+		var err error
+		user, err = q.CreateUser(ctx, arg.User)
+
+		if err != nil {
+			return err
+		}
+
+		arg.Token.UserID = user.ID
+		_, err = q.CreateUserToken(ctx, arg.Token)
+
+		return err
+	})
+
+	return user, err
+}
+
+func (tx *Tx) ReinviteUserTx(ctx context.Context, arg ReinviteUserTxParams) error {
+	return tx.execTx(ctx, func(q *Queries) error {
+		err := q.RevokeUserTokens(ctx, RevokeUserTokensParams{
+			UserID: arg.Token.UserID,
+			Kind:   arg.Token.Kind,
+			UsedAt: arg.RevokedAt,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateUserToken(ctx, arg.Token)
+
+		return err
+	})
+}
@@ -0,0 +1,90 @@
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// copySink writes the rows with COPY inside a transaction. The database
+// user must own the tables: COPY is refused on tables whose row level
+// security policies apply to the user.
+type copySink struct {
+	tx *sql.Tx
+}
+
+// reserve moves the id sequence of table n values ahead and returns the
+// first of them.
+func (s *copySink) reserve(ctx context.Context, table string, n int) (int32, error) {
+	var last int64
+
+	err := s.tx.QueryRowContext(ctx, `SELECT setval(pg_get_serial_sequence($1, 'id'),
+		nextval(pg_get_serial_sequence($1, 'id')) + $2 - 1)`, table, n).Scan(&last)
+
+	return int32(last - int64(n) + 1), err
+}
+
+func (s *copySink) copy(ctx context.Context, table string, columns []string,
+	rows func(emit func(values ...any) error) error) error {
+	stmt, err := s.tx.PrepareContext(ctx, pq.CopyIn(table, columns...))
+
+	if err != nil {
+		return err
+	}
+
+	err = rows(func(values ...any) error {
+		_, err := stmt.ExecContext(ctx, values...)
+		return err
+	})
+
+	if err != nil {
+		return errors.Join(err, stmt.Close())
+	}
+
+	// the call without values flushes the buffered rows
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		return errors.Join(err, stmt.Close())
+	}
+
+	return stmt.Close()
+}
+
+// Run writes the dataset in a single transaction, so a failure leaves no
+// partial data, and sets the card amounts to the sum of their
+// transactions.
+func Run(ctx context.Context, db *sql.DB, opts Options) (Summary, error) {
+	tx, err := db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return Summary{}, err
+	}
+
+	summary, err := Generate(ctx, &copySink{tx: tx}, opts)
+
+	if err == nil {
+		_, err = tx.ExecContext(ctx, `UPDATE cards c SET amount = t.total
+			FROM (
+				SELECT tr.card_id, SUM(tr.value) AS total FROM transactions tr
+				JOIN cards cc ON cc.id = tr.card_id
+				JOIN accounts a ON a.id = cc.account_id
+				WHERE a.tenant_id BETWEEN $1 AND $2
+				GROUP BY tr.card_id
+			) t
+			WHERE c.id = t.card_id`, summary.FirstTenantId, summary.FirstTenantId+int32(summary.Tenants)-1)
+	}
+
+	if err != nil {
+		return Summary{}, errors.Join(err, tx.Rollback())
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Summary{}, err
+	}
+
+	// the planner statistics are stale after a bulk load
+	_, err = db.ExecContext(ctx, "ANALYZE tenants, tenant_settings, accounts, account_status_history, cards, transactions")
+
+	return summary, err
+}
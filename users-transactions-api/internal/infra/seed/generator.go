@@ -0,0 +1,538 @@
+// Package seed generates synthetic tenants, accounts, cards and
+// transactions for local development, demos and performance tests. The
+// rows only depend on the options, so a seed always produces the same
+// dataset; only the ids depend on the database it is written to.
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+type Options struct {
+	Seed              int64
+	Tenants           int
+	AccountsPerTenant int
+	CardsPerAccount   int
+	// TransactionsPerMonth is the average of purchases of a card in a
+	// month, the subscriptions are charged on top of it.
+	TransactionsPerMonth int
+	Months               int
+	// Until is the exclusive end of the generated period.
+	Until time.Time
+}
+
+func (o Options) Validate() error {
+	var errs []error
+
+	positive := map[string]int{
+		"tenants":  o.Tenants,
+		"accounts": o.AccountsPerTenant,
+		"cards":    o.CardsPerAccount,
+		"months":   o.Months,
+	}
+
+	for _, name := range []string{"tenants", "accounts", "cards", "months"} {
+		if positive[name] < 1 {
+			errs = append(errs, fmt.Errorf("%s must be at least 1, got %d", name, positive[name]))
+		}
+	}
+
+	if o.TransactionsPerMonth < 0 {
+		errs = append(errs, fmt.Errorf("transactions must not be negative, got %d", o.TransactionsPerMonth))
+	}
+
+	if o.Until.IsZero() {
+		errs = append(errs, errors.New("until is required"))
+	}
+
+	if total := int64(o.Tenants) * int64(o.AccountsPerTenant) * int64(o.CardsPerAccount); total > math.MaxInt32 {
+		errs = append(errs, fmt.Errorf("%d cards do not fit the card ids", total))
+	}
+
+	return errors.Join(errs...)
+}
+
+type Summary struct {
+	FirstTenantId int32
+	Tenants       int
+	Accounts      int
+	Cards         int
+	Transactions  int
+	From          time.Time
+	Until         time.Time
+}
+
+// sink receives the generated rows. Ids are reserved up front so children
+// can reference their parents while the rows are streamed.
+type sink interface {
+	reserve(ctx context.Context, table string, n int) (int32, error)
+	copy(ctx context.Context, table string, columns []string, rows func(emit func(values ...any) error) error) error
+}
+
+// seedActor identifies the generated rows in the audit records.
+const seedActor = "seed"
+
+type locale struct {
+	currency string
+	timezone string
+	locale   string
+	weight   int
+}
+
+var locales = []locale{
+	{"BRL", "America/Sao_Paulo", "pt-BR", 80},
+	{"USD", "America/New_York", "en-US", 12},
+	{"EUR", "Europe/Lisbon", "pt-PT", 8},
+}
+
+var tenantPrefixes = []string{"Banco", "Fintech", "Cooperativa", "Financeira", "Carteira", "Conta"}
+var tenantNames = []string{"Aurora", "Horizonte", "Ipê", "Jatobá", "Maré", "Norte", "Pampa", "Sertão", "Serra",
+	"Litoral", "Cerrado", "Atlântico"}
+
+// purchase is a kind of card purchase. Values follow a log-normal
+// distribution around the median, in cents.
+type purchase struct {
+	kind   string
+	median float64
+	sigma  float64
+	weight int
+}
+
+var purchases = []purchase{
+	{"Supermercado", 18_000, 0.7, 22},
+	{"Restaurante", 6_500, 0.6, 16},
+	{"Padaria", 1_800, 0.5, 12},
+	{"Transporte por app", 2_400, 0.5, 12},
+	{"Posto de combustível", 20_000, 0.4, 9},
+	{"Farmácia", 7_000, 0.7, 8},
+	{"Loja online", 15_000, 1.0, 8},
+	{"Delivery", 5_500, 0.4, 7},
+	{"Vestuário", 22_000, 0.7, 3},
+	{"Cinema", 6_000, 0.3, 2},
+	{"Eletrônicos", 120_000, 0.8, 1},
+}
+
+// subscription is charged on the same day and with the same value every
+// month.
+type subscription struct {
+	kind  string
+	value int64
+}
+
+var subscriptions = []subscription{
+	{"Streaming Z", 3_990},
+	{"Streaming de música", 2_190},
+	{"Academia", 11_990},
+	{"Plano de celular", 5_990},
+	{"Armazenamento em nuvem", 990},
+	{"Clube de livros", 4_490},
+}
+
+const (
+	// share of the purchases whose value is multiplied by 10 to 50
+	outlierRate = 0.005
+	// accounts that are never activated have no transactions
+	pendingRate = 0.03
+	// accounts frozen or closed stop transacting when their status changes
+	frozenRate = 0.02
+	closedRate = 0.03
+)
+
+type account struct {
+	id        int32
+	tenantId  int32
+	createdAt time.Time
+	status    string
+	// changedAt is when a frozen or closed account left active
+	changedAt time.Time
+}
+
+type generator struct {
+	opts Options
+	rng  *rand.Rand
+	from time.Time
+}
+
+// Generate streams the dataset described by opts to s, table by table.
+func Generate(ctx context.Context, s sink, opts Options) (Summary, error) {
+	if err := opts.Validate(); err != nil {
+		return Summary{}, err
+	}
+
+	opts.Until = opts.Until.UTC()
+
+	g := &generator{
+		opts: opts,
+		rng:  rand.New(rand.NewSource(opts.Seed)),
+		from: opts.Until.AddDate(0, -opts.Months, 0),
+	}
+
+	summary := Summary{
+		Tenants:  opts.Tenants,
+		Accounts: opts.Tenants * opts.AccountsPerTenant,
+		Cards:    opts.Tenants * opts.AccountsPerTenant * opts.CardsPerAccount,
+		From:     g.from,
+		Until:    opts.Until,
+	}
+
+	tenantBase, err := s.reserve(ctx, "tenants", summary.Tenants)
+
+	if err != nil {
+		return Summary{}, err
+	}
+
+	accountBase, err := s.reserve(ctx, "accounts", summary.Accounts)
+
+	if err != nil {
+		return Summary{}, err
+	}
+
+	cardBase, err := s.reserve(ctx, "cards", summary.Cards)
+
+	if err != nil {
+		return Summary{}, err
+	}
+
+	summary.FirstTenantId = tenantBase
+
+	if err := g.tenants(ctx, s, tenantBase); err != nil {
+		return Summary{}, err
+	}
+
+	accounts := g.accounts(tenantBase, accountBase)
+
+	if err := g.copyAccounts(ctx, s, accounts); err != nil {
+		return Summary{}, err
+	}
+
+	cardCreatedAt, err := g.cards(ctx, s, accounts, cardBase)
+
+	if err != nil {
+		return Summary{}, err
+	}
+
+	summary.Transactions, err = g.transactions(ctx, s, accounts, cardBase, cardCreatedAt)
+
+	if err != nil {
+		return Summary{}, err
+	}
+
+	return summary, nil
+}
+
+func (g *generator) tenants(ctx context.Context, s sink, base int32) error {
+	settings := make([]locale, g.opts.Tenants)
+
+	err := s.copy(ctx, "tenants", []string{"id", "name", "status", "created_at"},
+		func(emit func(values ...any) error) error {
+			for i := 0; i < g.opts.Tenants; i++ {
+				name := fmt.Sprintf("%s %s %d", tenantPrefixes[g.rng.Intn(len(tenantPrefixes))],
+					tenantNames[g.rng.Intn(len(tenantNames))], i+1)
+				createdAt := g.before(g.from, 365, 180)
+				settings[i] = g.locale()
+
+				if err := emit(base+int32(i), name, "active", createdAt); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+
+	if err != nil {
+		return err
+	}
+
+	return s.copy(ctx, "tenant_settings", []string{"tenant_id", "currency", "timezone", "locale"},
+		func(emit func(values ...any) error) error {
+			for i, setting := range settings {
+				if err := emit(base+int32(i), setting.currency, setting.timezone, setting.locale); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+}
+
+func (g *generator) accounts(tenantBase int32, accountBase int32) []account {
+	accounts := make([]account, 0, g.opts.Tenants*g.opts.AccountsPerTenant)
+
+	for t := 0; t < g.opts.Tenants; t++ {
+		for a := 0; a < g.opts.AccountsPerTenant; a++ {
+			acc := account{
+				id:        accountBase + int32(len(accounts)),
+				tenantId:  tenantBase + int32(t),
+				createdAt: g.before(g.from, 0, 180),
+				status:    "active",
+			}
+
+			switch p := g.rng.Float64(); {
+			case p < pendingRate:
+				acc.status = "pending"
+			case p < pendingRate+frozenRate:
+				acc.status = "frozen"
+			case p < pendingRate+frozenRate+closedRate:
+				acc.status = "closed"
+			}
+
+			if acc.status == "frozen" || acc.status == "closed" {
+				acc.changedAt = g.between(g.from, g.opts.Until)
+			}
+
+			accounts = append(accounts, acc)
+		}
+	}
+
+	return accounts
+}
+
+func (g *generator) copyAccounts(ctx context.Context, s sink, accounts []account) error {
+	err := s.copy(ctx, "accounts", []string{"id", "tenant_id", "status", "created_at"},
+		func(emit func(values ...any) error) error {
+			for _, acc := range accounts {
+				if err := emit(acc.id, acc.tenantId, acc.status, acc.createdAt); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+
+	if err != nil {
+		return err
+	}
+
+	return s.copy(ctx, "account_status_history",
+		[]string{"account_id", "from_status", "to_status", "reason", "actor", "created_at"},
+		func(emit func(values ...any) error) error {
+			for _, acc := range accounts {
+				initial := "active"
+
+				if acc.status == "pending" {
+					initial = "pending"
+				}
+
+				if err := emit(acc.id, nil, initial, "account created", seedActor, acc.createdAt); err != nil {
+					return err
+				}
+
+				if acc.changedAt.IsZero() {
+					continue
+				}
+
+				reason := "suspected fraud"
+
+				if acc.status == "closed" {
+					reason = "closed by the customer"
+				}
+
+				if err := emit(acc.id, "active", acc.status, reason, seedActor, acc.changedAt); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+}
+
+// cards are created with no amount, which is set once the transactions
+// are written.
+func (g *generator) cards(ctx context.Context, s sink, accounts []account, base int32) ([]time.Time, error) {
+	createdAt := make([]time.Time, 0, len(accounts)*g.opts.CardsPerAccount)
+
+	err := s.copy(ctx, "cards", []string{"id", "account_id", "created_at"},
+		func(emit func(values ...any) error) error {
+			for _, acc := range accounts {
+				for c := 0; c < g.opts.CardsPerAccount; c++ {
+					at := acc.createdAt.Add(time.Duration(g.rng.Int63n(int64(72 * time.Hour))))
+					createdAt = append(createdAt, at)
+
+					if err := emit(base+int32(len(createdAt)-1), acc.id, at); err != nil {
+						return err
+					}
+				}
+			}
+
+			return nil
+		})
+
+	return createdAt, err
+}
+
+type transaction struct {
+	kind      string
+	value     int64
+	createdAt time.Time
+}
+
+func (g *generator) transactions(ctx context.Context, s sink, accounts []account, cardBase int32,
+	cardCreatedAt []time.Time) (int, error) {
+	count := 0
+
+	err := s.copy(ctx, "transactions", []string{"card_id", "kind", "value", "created_at"},
+		func(emit func(values ...any) error) error {
+			for i, createdAt := range cardCreatedAt {
+				acc := accounts[i/g.opts.CardsPerAccount]
+
+				if acc.status == "pending" {
+					continue
+				}
+
+				end := g.opts.Until
+
+				if !acc.changedAt.IsZero() {
+					end = acc.changedAt
+				}
+
+				for _, t := range g.card(createdAt, end) {
+					if err := emit(cardBase+int32(i), t.kind, t.value, t.createdAt); err != nil {
+						return err
+					}
+					count++
+				}
+			}
+
+			return nil
+		})
+
+	return count, err
+}
+
+// card generates the transactions of a card made between start and end,
+// in chronological order.
+func (g *generator) card(start time.Time, end time.Time) []transaction {
+	// some cards are used much more than others
+	activity := math.Exp(g.rng.NormFloat64() * 0.5)
+	mean := float64(g.opts.TransactionsPerMonth) * activity
+
+	type recurring struct {
+		subscription
+		day int
+	}
+
+	var recurrings []recurring
+
+	for _, i := range g.rng.Perm(len(subscriptions))[:g.rng.Intn(4)] {
+		recurrings = append(recurrings, recurring{subscription: subscriptions[i], day: 1 + g.rng.Intn(28)})
+	}
+
+	var result []transaction
+
+	for m := 0; m < g.opts.Months; m++ {
+		monthStart := g.from.AddDate(0, m, 0)
+		monthEnd := g.from.AddDate(0, m+1, 0)
+		var month []transaction
+
+		for _, r := range recurrings {
+			at := time.Date(monthStart.Year(), monthStart.Month(), r.day, 6, 0, 0, 0, time.UTC).
+				Add(time.Duration(g.rng.Intn(180)) * time.Minute)
+			month = append(month, transaction{kind: r.kind, value: r.value, createdAt: at})
+		}
+
+		for n := g.poisson(mean); n > 0; n-- {
+			p := g.purchase()
+			value := int64(p.median * math.Exp(g.rng.NormFloat64()*p.sigma))
+
+			if g.rng.Float64() < outlierRate {
+				value *= int64(10 + g.rng.Intn(41))
+			}
+
+			month = append(month, transaction{
+				kind:      p.kind,
+				value:     max(value, 100),
+				createdAt: g.daytime(monthStart, monthEnd),
+			})
+		}
+
+		sort.Slice(month, func(i, j int) bool {
+			return month[i].createdAt.Before(month[j].createdAt)
+		})
+
+		for _, t := range month {
+			if !t.createdAt.Before(start) && t.createdAt.Before(end) {
+				result = append(result, t)
+			}
+		}
+	}
+
+	return result
+}
+
+func (g *generator) locale() locale {
+	return weighted(g.rng, locales, func(l locale) int { return l.weight })
+}
+
+func (g *generator) purchase() purchase {
+	return weighted(g.rng, purchases, func(p purchase) int { return p.weight })
+}
+
+func weighted[T any](rng *rand.Rand, items []T, weight func(T) int) T {
+	total := 0
+
+	for _, item := range items {
+		total += weight(item)
+	}
+
+	n := rng.Intn(total)
+
+	for _, item := range items {
+		n -= weight(item)
+
+		if n < 0 {
+			return item
+		}
+	}
+
+	return items[len(items)-1]
+}
+
+// poisson draws the number of purchases of a month, with the normal
+// approximation for large means.
+func (g *generator) poisson(mean float64) int {
+	if mean <= 0 {
+		return 0
+	}
+
+	if mean > 30 {
+		return max(0, int(math.Round(mean+g.rng.NormFloat64()*math.Sqrt(mean))))
+	}
+
+	limit := math.Exp(-mean)
+	n := 0
+
+	for p := g.rng.Float64(); p > limit; p *= g.rng.Float64() {
+		n++
+	}
+
+	return n
+}
+
+// daytime picks an instant between start and end, mostly between 8h and
+// 22h.
+func (g *generator) daytime(start time.Time, end time.Time) time.Time {
+	days := int(end.Sub(start).Hours() / 24)
+	day := start.AddDate(0, 0, g.rng.Intn(days))
+	hour := 8 + g.rng.Intn(14)
+
+	if g.rng.Float64() < 0.1 {
+		hour = g.rng.Intn(24)
+	}
+
+	return day.Add(time.Duration(hour)*time.Hour + time.Duration(g.rng.Intn(3600))*time.Second)
+}
+
+// before picks an instant between minDays and minDays+rangeDays days before t.
+func (g *generator) before(t time.Time, minDays int, rangeDays int) time.Time {
+	return t.AddDate(0, 0, -minDays-g.rng.Intn(rangeDays+1)).Add(-time.Duration(g.rng.Intn(86400)) * time.Second)
+}
+
+func (g *generator) between(start time.Time, end time.Time) time.Time {
+	return start.Add(time.Duration(g.rng.Int63n(int64(end.Sub(start)))))
+}
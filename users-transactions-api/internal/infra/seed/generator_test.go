@@ -0,0 +1,220 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memorySink struct {
+	columns map[string][]string
+	rows    map[string][][]any
+}
+
+func newMemorySink() *memorySink {
+	return &memorySink{columns: make(map[string][]string), rows: make(map[string][][]any)}
+}
+
+func (s *memorySink) reserve(ctx context.Context, table string, n int) (int32, error) {
+	return 1, nil
+}
+
+func (s *memorySink) copy(ctx context.Context, table string, columns []string,
+	rows func(emit func(values ...any) error) error) error {
+	s.columns[table] = columns
+
+	return rows(func(values ...any) error {
+		s.rows[table] = append(s.rows[table], values)
+		return nil
+	})
+}
+
+// column returns the value of the named column in each row of table.
+func (s *memorySink) column(table string, name string) []any {
+	index := -1
+
+	for i, column := range s.columns[table] {
+		if column == name {
+			index = i
+		}
+	}
+
+	values := make([]any, len(s.rows[table]))
+
+	for i, row := range s.rows[table] {
+		values[i] = row[index]
+	}
+
+	return values
+}
+
+var until = time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+func options() Options {
+	return Options{
+		Seed:                 42,
+		Tenants:              3,
+		AccountsPerTenant:    20,
+		CardsPerAccount:      2,
+		TransactionsPerMonth: 15,
+		Months:               6,
+		Until:                until,
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("Same seed generates the same rows", func(t *testing.T) {
+		first, second := newMemorySink(), newMemorySink()
+
+		_, err := Generate(context.Background(), first, options())
+		assert.NoError(t, err)
+
+		_, err = Generate(context.Background(), second, options())
+		assert.NoError(t, err)
+
+		assert.Equal(t, first.rows, second.rows)
+	})
+
+	t.Run("Another seed generates other rows", func(t *testing.T) {
+		first, second := newMemorySink(), newMemorySink()
+		opts := options()
+
+		_, err := Generate(context.Background(), first, opts)
+		assert.NoError(t, err)
+
+		opts.Seed = 7
+		_, err = Generate(context.Background(), second, opts)
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, first.rows["transactions"], second.rows["transactions"])
+	})
+
+	t.Run("Volumes follow the options", func(t *testing.T) {
+		sink := newMemorySink()
+
+		summary, err := Generate(context.Background(), sink, options())
+
+		assert.NoError(t, err)
+		assert.Len(t, sink.rows["tenants"], 3)
+		assert.Len(t, sink.rows["tenant_settings"], 3)
+		assert.Len(t, sink.rows["accounts"], 60)
+		assert.Len(t, sink.rows["cards"], 120)
+		assert.Len(t, sink.rows["transactions"], summary.Transactions)
+		assert.GreaterOrEqual(t, len(sink.rows["account_status_history"]), 60)
+
+		// about 15 purchases a month for each active card, plus subscriptions
+		assert.Greater(t, summary.Transactions, 120*6*10)
+		assert.Equal(t, until.AddDate(0, -6, 0), summary.From)
+	})
+
+	t.Run("Transactions reference the cards and stay in the period", func(t *testing.T) {
+		sink := newMemorySink()
+
+		summary, err := Generate(context.Background(), sink, options())
+		assert.NoError(t, err)
+
+		for _, row := range sink.rows["transactions"] {
+			cardId, value, createdAt := row[0].(int32), row[2].(int64), row[3].(time.Time)
+
+			assert.GreaterOrEqual(t, cardId, int32(1))
+			assert.LessOrEqual(t, cardId, int32(summary.Cards))
+			assert.Positive(t, value)
+			assert.False(t, createdAt.Before(summary.From), createdAt)
+			assert.True(t, createdAt.Before(summary.Until), createdAt)
+		}
+	})
+
+	t.Run("Subscriptions are charged monthly with the same value", func(t *testing.T) {
+		sink := newMemorySink()
+
+		_, err := Generate(context.Background(), sink, options())
+		assert.NoError(t, err)
+
+		charges := make(map[int32]map[string][]int64)
+
+		for _, row := range sink.rows["transactions"] {
+			cardId, kind, value := row[0].(int32), row[1].(string), row[2].(int64)
+
+			for _, s := range subscriptions {
+				if s.kind == kind {
+					if charges[cardId] == nil {
+						charges[cardId] = make(map[string][]int64)
+					}
+					charges[cardId][kind] = append(charges[cardId][kind], value)
+				}
+			}
+		}
+
+		assert.NotEmpty(t, charges)
+
+		for _, kinds := range charges {
+			for kind, values := range kinds {
+				assert.LessOrEqual(t, len(values), 6, kind)
+
+				for _, value := range values {
+					assert.Equal(t, values[0], value, kind)
+				}
+			}
+		}
+	})
+
+	t.Run("Pending accounts have no transactions", func(t *testing.T) {
+		opts := options()
+		opts.AccountsPerTenant = 200
+		sink := newMemorySink()
+
+		_, err := Generate(context.Background(), sink, opts)
+		assert.NoError(t, err)
+
+		pending := make(map[int32]bool)
+
+		for _, row := range sink.rows["accounts"] {
+			if row[2] == "pending" {
+				pending[row[0].(int32)] = true
+			}
+		}
+
+		assert.NotEmpty(t, pending)
+
+		cardAccount := make(map[int32]int32)
+
+		for _, row := range sink.rows["cards"] {
+			cardAccount[row[0].(int32)] = row[1].(int32)
+		}
+
+		for _, cardId := range sink.column("transactions", "card_id") {
+			assert.False(t, pending[cardAccount[cardId.(int32)]])
+		}
+	})
+
+	t.Run("Invalid options", func(t *testing.T) {
+		opts := options()
+		opts.Tenants = 0
+		opts.Until = time.Time{}
+
+		_, err := Generate(context.Background(), newMemorySink(), opts)
+
+		assert.ErrorContains(t, err, "tenants must be at least 1")
+		assert.ErrorContains(t, err, "until is required")
+	})
+
+	t.Run("Sink error", func(t *testing.T) {
+		_, err := Generate(context.Background(), failingSink{}, options())
+
+		assert.EqualError(t, err, "copy failed")
+	})
+}
+
+type failingSink struct{}
+
+func (failingSink) reserve(ctx context.Context, table string, n int) (int32, error) {
+	return 1, nil
+}
+
+func (failingSink) copy(ctx context.Context, table string, columns []string,
+	rows func(emit func(values ...any) error) error) error {
+	return errors.New("copy failed")
+}
@@ -0,0 +1,26 @@
+package lifecycle
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// GracefulStop lets the running calls of the server finish, and cancels
+// the ones still running when ctx expires.
+func GracefulStop(ctx context.Context, server *grpc.Server) error {
+	stopped := make(chan struct{})
+
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,125 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DefaultShutdownTimeout bounds the whole shutdown when none is configured.
+const DefaultShutdownTimeout = 15 * time.Second
+
+type step struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Manager runs the servers of the binary and, once the context given to
+// Wait is done or a server fails, runs the shutdown steps in the order they
+// were registered, all within one timeout.
+type Manager struct {
+	timeout time.Duration
+	steps   []step
+	servers sync.WaitGroup
+	failed  chan error
+}
+
+func New(timeout time.Duration) *Manager {
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+
+	return &Manager{
+		timeout: timeout,
+		failed:  make(chan error, 1),
+	}
+}
+
+// Go runs a server until a shutdown step stops it. A server that returns an
+// error starts the shutdown of the others.
+func (m *Manager) Go(name string, serve func() error) {
+	m.servers.Add(1)
+
+	go func() {
+		defer m.servers.Done()
+
+		slog.Info("server started", slog.String("server", name))
+
+		err := serve()
+
+		if err != nil {
+			select {
+			case m.failed <- fmt.Errorf("%s: %w", name, err):
+			default:
+			}
+			return
+		}
+
+		slog.Info("server stopped", slog.String("server", name))
+	}()
+}
+
+// OnShutdown adds a step to the shutdown. The context of the step expires
+// at the end of the shutdown timeout.
+func (m *Manager) OnShutdown(name string, fn func(ctx context.Context) error) {
+	m.steps = append(m.steps, step{name: name, fn: fn})
+}
+
+// Wait blocks until ctx is done, usually on SIGTERM, or a server fails, then
+// shuts down. It returns the failure of the server and of the steps.
+func (m *Manager) Wait(ctx context.Context) error {
+	var cause error
+
+	select {
+	case <-ctx.Done():
+		slog.Info("shutdown requested", slog.Duration("timeout", m.timeout))
+	case cause = <-m.failed:
+		slog.Error("server failed, shutting down",
+			slog.String("error", cause.Error()),
+		)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	errs := []error{cause}
+
+	for _, step := range m.steps {
+		start := time.Now()
+		err := step.fn(ctx)
+
+		if err != nil {
+			slog.Error("shutdown step failed",
+				slog.String("step", step.name),
+				slog.Duration("duration", time.Since(start)),
+				slog.String("error", err.Error()),
+			)
+			errs = append(errs, fmt.Errorf("%s: %w", step.name, err))
+			continue
+		}
+
+		slog.Info("shutdown step done",
+			slog.String("step", step.name),
+			slog.Duration("duration", time.Since(start)),
+		)
+	}
+
+	stopped := make(chan struct{})
+
+	go func() {
+		m.servers.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		slog.Info("shutdown complete")
+	case <-ctx.Done():
+		errs = append(errs, errors.New("servers still running after the shutdown timeout"))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,111 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Runs the steps in order once the context is done", func(t *testing.T) {
+		sut := New(time.Second)
+		stop := make(chan struct{})
+		var steps []string
+
+		sut.Go("server", func() error {
+			<-stop
+			return nil
+		})
+		sut.OnShutdown("readiness", func(ctx context.Context) error {
+			steps = append(steps, "readiness")
+			return nil
+		})
+		sut.OnShutdown("server", func(ctx context.Context) error {
+			steps = append(steps, "server")
+			close(stop)
+			return nil
+		})
+		sut.OnShutdown("database", func(ctx context.Context) error {
+			steps = append(steps, "database")
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := sut.Wait(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"readiness", "server", "database"}, steps)
+	})
+
+	t.Run("A failed server shuts down the others", func(t *testing.T) {
+		sut := New(time.Second)
+		stop := make(chan struct{})
+
+		sut.Go("http", func() error {
+			return errors.New("address already in use")
+		})
+		sut.Go("grpc", func() error {
+			<-stop
+			return nil
+		})
+		sut.OnShutdown("grpc", func(ctx context.Context) error {
+			close(stop)
+			return nil
+		})
+
+		err := sut.Wait(context.Background())
+
+		assert.EqualError(t, err, "http: address already in use")
+	})
+
+	t.Run("Keeps going after a failed step", func(t *testing.T) {
+		sut := New(time.Second)
+		closed := false
+
+		sut.OnShutdown("telemetry", func(ctx context.Context) error {
+			return errors.New("collector unreachable")
+		})
+		sut.OnShutdown("database", func(ctx context.Context) error {
+			closed = true
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := sut.Wait(ctx)
+
+		assert.EqualError(t, err, "telemetry: collector unreachable")
+		assert.True(t, closed)
+	})
+
+	t.Run("Gives up on the timeout", func(t *testing.T) {
+		sut := New(50 * time.Millisecond)
+		stop := make(chan struct{})
+		defer close(stop)
+
+		sut.Go("server", func() error {
+			<-stop
+			return nil
+		})
+		sut.OnShutdown("server", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := sut.Wait(ctx)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "servers still running after the shutdown timeout")
+	})
+}
@@ -0,0 +1,191 @@
+// Package loadtest drives a mixed workload against the users transactions
+// and pdf generator apis and reports latency percentiles, error rates and
+// throughput per operation.
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type Config struct {
+	UsersUrl string
+	PdfUrl   string
+	// Rate is the target of requests per second, zero sends the next
+	// request as soon as a worker is free.
+	Rate        float64
+	Concurrency int
+	Duration    time.Duration
+	Mix         Mix
+	Spread      string
+	// Seed makes the sequence of operations and tenants repeatable.
+	Seed    int64
+	Tenants []Tenant
+}
+
+func (c Config) validate() error {
+	var errs []error
+
+	if c.Concurrency < 1 {
+		errs = append(errs, errors.New("concurrency must be at least 1"))
+	}
+
+	if c.Duration <= 0 {
+		errs = append(errs, errors.New("duration must be positive"))
+	}
+
+	if c.Rate < 0 {
+		errs = append(errs, errors.New("rate must not be negative"))
+	}
+
+	if len(c.Mix) == 0 {
+		errs = append(errs, errors.New("mix is empty"))
+	}
+
+	if c.Spread != SpreadUniform && c.Spread != SpreadZipf {
+		errs = append(errs, errors.New("spread must be uniform or zipf"))
+	}
+
+	withAccounts := 0
+
+	for _, tenant := range c.Tenants {
+		if len(tenant.Accounts) > 0 {
+			withAccounts++
+		}
+	}
+
+	if withAccounts == 0 {
+		errs = append(errs, errors.New("no tenant has an active account with cards"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Run sends the workload for the configured duration, or until ctx is
+// done, and waits for the requests in flight.
+func Run(ctx context.Context, client *http.Client, cfg Config) (Report, error) {
+	if err := cfg.validate(); err != nil {
+		return Report{}, err
+	}
+
+	var tenants []Tenant
+
+	for _, tenant := range cfg.Tenants {
+		if len(tenant.Accounts) > 0 {
+			tenants = append(tenants, tenant)
+		}
+	}
+
+	recorder := NewRecorder()
+	// the timer only stops new requests, the ones in flight are measured
+	// until they finish
+	dispatchCtx, cancel := context.WithTimeout(ctx, cfg.Duration)
+	defer cancel()
+
+	jobs := make(chan struct{}, cfg.Concurrency)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < cfg.Concurrency; i++ {
+		wg.Add(1)
+
+		go func(rng *rand.Rand) {
+			defer wg.Done()
+
+			w := &worker{cfg: cfg, client: client, recorder: recorder, rng: rng, tenants: tenants,
+				pickTenant: tenantPicker(cfg.Spread, rng, len(tenants))}
+
+			if cfg.Rate == 0 {
+				for dispatchCtx.Err() == nil {
+					w.send(ctx)
+				}
+				return
+			}
+
+			for range jobs {
+				w.send(ctx)
+			}
+		}(rand.New(rand.NewSource(cfg.Seed + int64(i))))
+	}
+
+	if cfg.Rate > 0 {
+		dispatch(dispatchCtx, cfg.Rate, jobs, recorder)
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return recorder.Report(time.Since(start)), nil
+}
+
+// dispatch releases one request per tick. A request due while every
+// worker is busy is dropped and counted, the backlog never grows.
+func dispatch(ctx context.Context, rate float64, jobs chan<- struct{}, recorder *Recorder) {
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			select {
+			case jobs <- struct{}{}:
+			default:
+				recorder.Drop()
+			}
+		}
+	}
+}
+
+type worker struct {
+	cfg        Config
+	client     *http.Client
+	recorder   *Recorder
+	rng        *rand.Rand
+	tenants    []Tenant
+	pickTenant func() int
+}
+
+func (w *worker) send(ctx context.Context) {
+	operation := w.cfg.Mix.pick(w.rng)
+	tenant := &w.tenants[w.pickTenant()]
+	account := tenant.Accounts[w.rng.Intn(len(tenant.Accounts))]
+	cardId := account.Cards[w.rng.Intn(len(account.Cards))]
+
+	request, err := newRequest(ctx, operation, w.cfg.UsersUrl, w.cfg.PdfUrl, tenant, account, cardId, w.rng)
+
+	if err != nil {
+		w.recorder.Record(operation, Result{Err: err})
+		return
+	}
+
+	start := time.Now()
+	response, err := w.client.Do(request)
+
+	if err != nil {
+		// an interrupted run is not a failure of the api
+		if ctx.Err() == nil {
+			w.recorder.Record(operation, Result{Latency: time.Since(start), Err: err})
+		}
+		return
+	}
+
+	// the latency includes reading the whole body, the pdf download
+	read, err := io.Copy(io.Discard, response.Body)
+	response.Body.Close()
+
+	result := Result{Status: response.StatusCode, Latency: time.Since(start), Bytes: read}
+
+	if err != nil && ctx.Err() == nil {
+		result.Err = err
+	}
+
+	w.recorder.Record(operation, result)
+}
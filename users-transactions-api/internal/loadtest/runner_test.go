@@ -0,0 +1,145 @@
+package loadtest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeApis answers the routes of both apis and keeps the tenant of each
+// request.
+type fakeApis struct {
+	mu      sync.Mutex
+	tenants map[string]int
+	users   *httptest.Server
+	pdf     *httptest.Server
+}
+
+func newFakeApis(t *testing.T) *fakeApis {
+	f := &fakeApis{tenants: make(map[string]int)}
+
+	f.users = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key-"+r.Header.Get("tenant-id") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		f.mu.Lock()
+		f.tenants[r.Header.Get("tenant-id")]++
+		f.mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/api/v1/account":
+			_ = json.NewEncoder(w).Encode([]map[string]any{
+				{"id": 1, "status": "active"},
+				{"id": 2, "status": "closed"},
+				{"id": 3, "status": "active"},
+			})
+		case strings.HasPrefix(r.URL.Path, "/api/v1/card/account/1"):
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"id": 10}, {"id": 11}})
+		case strings.HasPrefix(r.URL.Path, "/api/v1/card/account/"):
+			_ = json.NewEncoder(w).Encode([]map[string]any{})
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+		default:
+			_, _ = w.Write([]byte("[]"))
+		}
+	}))
+
+	f.pdf = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	t.Cleanup(f.users.Close)
+	t.Cleanup(f.pdf.Close)
+
+	return f
+}
+
+func TestDiscover(t *testing.T) {
+	f := newFakeApis(t)
+	tenant := &Tenant{ID: 1, ApiKey: "key-1"}
+
+	err := Discover(context.Background(), http.DefaultClient, f.users.URL, tenant, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Account{{ID: 1, Cards: []int32{10, 11}}}, tenant.Accounts)
+
+	err = Discover(context.Background(), http.DefaultClient, f.users.URL, &Tenant{ID: 1, ApiKey: "wrong"}, 10)
+
+	assert.ErrorContains(t, err, "401")
+}
+
+func TestRun(t *testing.T) {
+	f := newFakeApis(t)
+	mix, _ := ParseMix("create=25,list=25,accounts=25,report=25")
+
+	tenants := []Tenant{
+		{ID: 1, ApiKey: "key-1", Accounts: []Account{{ID: 1, Cards: []int32{10}}}},
+		{ID: 2, ApiKey: "key-2", Accounts: []Account{{ID: 1, Cards: []int32{10}}}},
+		// without accounts the tenant gets no load
+		{ID: 3, ApiKey: "key-3"},
+	}
+
+	config := Config{
+		UsersUrl:    f.users.URL,
+		PdfUrl:      f.pdf.URL,
+		Concurrency: 4,
+		Duration:    300 * time.Millisecond,
+		Mix:         mix,
+		Spread:      SpreadUniform,
+		Tenants:     tenants,
+	}
+
+	t.Run("As fast as the workers allow", func(t *testing.T) {
+		report, err := Run(context.Background(), http.DefaultClient, config)
+
+		assert.NoError(t, err)
+		assert.Greater(t, report.Requests, 100)
+		assert.Len(t, report.Operations, 4)
+
+		for _, operation := range report.Operations {
+			if operation.Name == OperationReport {
+				assert.Equal(t, 1.0, operation.ErrorRate)
+				assert.Equal(t, operation.Requests, operation.Statuses["500"])
+			} else {
+				assert.Zero(t, operation.Errors, operation.Name)
+			}
+		}
+
+		assert.Zero(t, f.tenants["3"])
+		assert.Positive(t, f.tenants["1"])
+		assert.Positive(t, f.tenants["2"])
+	})
+
+	t.Run("At the configured rate", func(t *testing.T) {
+		config := config
+		config.Rate = 50
+		config.Duration = 500 * time.Millisecond
+
+		report, err := Run(context.Background(), http.DefaultClient, config)
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 25, report.Requests+report.Dropped, 5)
+	})
+
+	t.Run("Invalid configuration", func(t *testing.T) {
+		config := config
+		config.Concurrency = 0
+		config.Spread = "normal"
+		config.Tenants = tenants[2:]
+
+		_, err := Run(context.Background(), http.DefaultClient, config)
+
+		assert.ErrorContains(t, err, "concurrency must be at least 1")
+		assert.ErrorContains(t, err, "spread must be uniform or zipf")
+		assert.ErrorContains(t, err, "no tenant has an active account with cards")
+	})
+}
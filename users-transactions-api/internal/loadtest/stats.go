@@ -0,0 +1,264 @@
+package loadtest
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Result is the outcome of one request. Err is set when no response was
+// received.
+type Result struct {
+	Status  int
+	Latency time.Duration
+	Bytes   int64
+	Err     error
+}
+
+func (r Result) failed() bool {
+	return r.Err != nil || r.Status >= 400
+}
+
+type operationStats struct {
+	latencies       []time.Duration
+	statuses        map[int]int
+	errors          int
+	transportErrors int
+	bytes           int64
+}
+
+// Recorder collects the results of the workers.
+type Recorder struct {
+	mu         sync.Mutex
+	operations map[string]*operationStats
+	dropped    int
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{operations: make(map[string]*operationStats)}
+}
+
+func (r *Recorder) Record(operation string, result Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats, found := r.operations[operation]
+
+	if !found {
+		stats = &operationStats{statuses: make(map[int]int)}
+		r.operations[operation] = stats
+	}
+
+	stats.latencies = append(stats.latencies, result.Latency)
+	stats.bytes += result.Bytes
+
+	if result.Err != nil {
+		stats.transportErrors++
+	} else {
+		stats.statuses[result.Status]++
+	}
+
+	if result.failed() {
+		stats.errors++
+	}
+}
+
+// Drop counts a request that was due but found every worker busy, so the
+// target rate was not reached.
+func (r *Recorder) Drop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.dropped++
+}
+
+// Latency is in milliseconds.
+type Latency struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+type OperationSummary struct {
+	Name            string         `json:"name"`
+	Requests        int            `json:"requests"`
+	Errors          int            `json:"errors"`
+	RateLimited     int            `json:"rate_limited"`
+	TransportErrors int            `json:"transport_errors"`
+	ErrorRate       float64        `json:"error_rate"`
+	Throughput      float64        `json:"throughput_rps"`
+	Bytes           int64          `json:"bytes"`
+	Statuses        map[string]int `json:"statuses"`
+	Latency         Latency        `json:"latency_ms"`
+}
+
+type Report struct {
+	Duration   float64            `json:"duration_seconds"`
+	Requests   int                `json:"requests"`
+	Errors     int                `json:"errors"`
+	Dropped    int                `json:"dropped"`
+	ErrorRate  float64            `json:"error_rate"`
+	Throughput float64            `json:"throughput_rps"`
+	Latency    Latency            `json:"latency_ms"`
+	Operations []OperationSummary `json:"operations"`
+}
+
+// Report summarizes the results recorded during elapsed.
+func (r *Recorder) Report(elapsed time.Duration) Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := Report{Duration: elapsed.Seconds(), Dropped: r.dropped, Operations: []OperationSummary{}}
+	var all []time.Duration
+
+	names := make([]string, 0, len(r.operations))
+
+	for name := range r.operations {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		stats := r.operations[name]
+		operation := OperationSummary{
+			Name:            name,
+			Requests:        len(stats.latencies),
+			Errors:          stats.errors,
+			RateLimited:     stats.statuses[http.StatusTooManyRequests],
+			TransportErrors: stats.transportErrors,
+			ErrorRate:       ratio(stats.errors, len(stats.latencies)),
+			Throughput:      throughput(len(stats.latencies), elapsed),
+			Bytes:           stats.bytes,
+			Statuses:        make(map[string]int),
+			Latency:         latency(stats.latencies),
+		}
+
+		for status, count := range stats.statuses {
+			operation.Statuses[strconv.Itoa(status)] = count
+		}
+
+		report.Operations = append(report.Operations, operation)
+		report.Requests += operation.Requests
+		report.Errors += operation.Errors
+		all = append(all, stats.latencies...)
+	}
+
+	report.ErrorRate = ratio(report.Errors, report.Requests)
+	report.Throughput = throughput(report.Requests, elapsed)
+	report.Latency = latency(all)
+
+	return report
+}
+
+func ratio(n int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return float64(n) / float64(total)
+}
+
+func throughput(requests int, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+
+	return float64(requests) / elapsed.Seconds()
+}
+
+func latency(latencies []time.Duration) Latency {
+	if len(latencies) == 0 {
+		return Latency{}
+	}
+
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum time.Duration
+
+	for _, l := range sorted {
+		sum += l
+	}
+
+	return Latency{
+		Min:  milliseconds(sorted[0]),
+		Mean: milliseconds(sum / time.Duration(len(sorted))),
+		P50:  milliseconds(percentile(sorted, 50)),
+		P90:  milliseconds(percentile(sorted, 90)),
+		P95:  milliseconds(percentile(sorted, 95)),
+		P99:  milliseconds(percentile(sorted, 99)),
+		Max:  milliseconds(sorted[len(sorted)-1]),
+	}
+}
+
+// percentile uses the nearest rank of the sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+
+	return sorted[max(rank, 1)-1]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Millisecond)*100) / 100
+}
+
+// WriteSummary writes the report as a table, one line per operation.
+func WriteSummary(w io.Writer, report Report) error {
+	fmt.Fprintf(w, "%d requests in %.1fs, %.1f req/s, %.2f%% errors, %d dropped\n\n", report.Requests,
+		report.Duration, report.Throughput, report.ErrorRate*100, report.Dropped)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "OPERATION\tREQUESTS\tREQ/S\tERRORS\t429\tP50 MS\tP90 MS\tP95 MS\tP99 MS\tMAX MS\t")
+
+	row := func(name string, requests int, rps float64, errorRate float64, rateLimited int, l Latency) {
+		fmt.Fprintf(tw, "%s\t%d\t%.1f\t%.2f%%\t%d\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t\n", name, requests, rps,
+			errorRate*100, rateLimited, l.P50, l.P90, l.P95, l.P99, l.Max)
+	}
+
+	rateLimited := 0
+
+	for _, operation := range report.Operations {
+		row(operation.Name, operation.Requests, operation.Throughput, operation.ErrorRate, operation.RateLimited,
+			operation.Latency)
+		rateLimited += operation.RateLimited
+	}
+
+	row("total", report.Requests, report.Throughput, report.ErrorRate, rateLimited, report.Latency)
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, operation := range report.Operations {
+		statuses := make([]string, 0, len(operation.Statuses))
+
+		for status, count := range operation.Statuses {
+			statuses = append(statuses, fmt.Sprintf("%s=%d", status, count))
+		}
+
+		sort.Strings(statuses)
+
+		if operation.TransportErrors > 0 {
+			statuses = append(statuses, fmt.Sprintf("transport errors=%d", operation.TransportErrors))
+		}
+
+		fmt.Fprintf(w, "\n%s statuses: %s", operation.Name, strings.Join(statuses, ", "))
+	}
+
+	_, err := fmt.Fprintln(w)
+
+	return err
+}
@@ -0,0 +1,77 @@
+package loadtest
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	recorder := NewRecorder()
+
+	for i := 1; i <= 100; i++ {
+		recorder.Record(OperationList, Result{Status: 200, Latency: time.Duration(i) * time.Millisecond, Bytes: 10})
+	}
+
+	recorder.Record(OperationCreate, Result{Status: 201, Latency: 5 * time.Millisecond})
+	recorder.Record(OperationCreate, Result{Status: 429, Latency: time.Millisecond})
+	recorder.Record(OperationCreate, Result{Latency: 3 * time.Millisecond, Err: errors.New("connection refused")})
+	recorder.Drop()
+
+	report := recorder.Report(2 * time.Second)
+
+	t.Run("Totals", func(t *testing.T) {
+		assert.Equal(t, 103, report.Requests)
+		assert.Equal(t, 2, report.Errors)
+		assert.Equal(t, 1, report.Dropped)
+		assert.InDelta(t, 51.5, report.Throughput, 0.001)
+		assert.InDelta(t, 2.0/103, report.ErrorRate, 0.0001)
+		assert.Equal(t, 1.0, report.Latency.Min)
+		assert.Equal(t, 100.0, report.Latency.Max)
+	})
+
+	t.Run("Operations sorted by name", func(t *testing.T) {
+		assert.Len(t, report.Operations, 2)
+
+		create := report.Operations[0]
+		assert.Equal(t, OperationCreate, create.Name)
+		assert.Equal(t, 3, create.Requests)
+		assert.Equal(t, 2, create.Errors)
+		assert.Equal(t, 1, create.RateLimited)
+		assert.Equal(t, 1, create.TransportErrors)
+		assert.Equal(t, map[string]int{"201": 1, "429": 1}, create.Statuses)
+
+		list := report.Operations[1]
+		assert.Equal(t, OperationList, list.Name)
+		assert.Equal(t, int64(1000), list.Bytes)
+		assert.Equal(t, 50.0, list.Latency.P50)
+		assert.Equal(t, 90.0, list.Latency.P90)
+		assert.Equal(t, 95.0, list.Latency.P95)
+		assert.Equal(t, 99.0, list.Latency.P99)
+		assert.Equal(t, 50.5, list.Latency.Mean)
+		assert.Equal(t, 50.0, list.Throughput)
+	})
+
+	t.Run("Summary", func(t *testing.T) {
+		var out bytes.Buffer
+
+		err := WriteSummary(&out, report)
+
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "103 requests in 2.0s, 51.5 req/s, 1.94% errors, 1 dropped")
+		assert.Contains(t, out.String(), "create statuses: 201=1, 429=1, transport errors=1")
+		assert.Contains(t, out.String(), "list statuses: 200=100")
+		assert.Regexp(t, `total\s+103\s+51.5`, out.String())
+	})
+}
+
+func TestEmptyReport(t *testing.T) {
+	report := NewRecorder().Report(time.Second)
+
+	assert.Zero(t, report.Requests)
+	assert.Zero(t, report.ErrorRate)
+	assert.Empty(t, report.Operations)
+}
@@ -0,0 +1,270 @@
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// OperationCreate posts a transaction to a card.
+	OperationCreate = "create"
+	// OperationList lists the transactions of a card.
+	OperationList = "list"
+	// OperationAccounts lists the accounts of the tenant.
+	OperationAccounts = "accounts"
+	// OperationReport downloads the pdf report of an account, which
+	// searches its transactions through the gRPC stream.
+	OperationReport = "report"
+
+	SpreadUniform = "uniform"
+	// SpreadZipf concentrates the load on the first tenants, like a few
+	// large customers among many small ones.
+	SpreadZipf = "zipf"
+)
+
+var operations = []string{OperationCreate, OperationList, OperationAccounts, OperationReport}
+
+type weightedOperation struct {
+	name   string
+	weight int
+}
+
+// Mix is the share of each operation in the workload.
+type Mix []weightedOperation
+
+// ParseMix reads a mix written as name=weight pairs separated by commas,
+// e.g. create=30,list=40,accounts=20,report=10.
+func ParseMix(s string) (Mix, error) {
+	var mix Mix
+
+	for _, pair := range strings.Split(s, ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(pair), "=")
+		weight, err := strconv.Atoi(value)
+
+		if !found || err != nil || weight < 0 {
+			return nil, fmt.Errorf("mix entry %q must be operation=weight", pair)
+		}
+
+		if !isOperation(name) {
+			return nil, fmt.Errorf("unknown operation %q, must be one of %s", name, strings.Join(operations, ", "))
+		}
+
+		if weight > 0 {
+			mix = append(mix, weightedOperation{name: name, weight: weight})
+		}
+	}
+
+	if len(mix) == 0 {
+		return nil, fmt.Errorf("mix %q has no operation with a positive weight", s)
+	}
+
+	return mix, nil
+}
+
+func isOperation(name string) bool {
+	for _, operation := range operations {
+		if operation == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m Mix) pick(rng *rand.Rand) string {
+	total := 0
+
+	for _, operation := range m {
+		total += operation.weight
+	}
+
+	n := rng.Intn(total)
+
+	for _, operation := range m {
+		n -= operation.weight
+
+		if n < 0 {
+			return operation.name
+		}
+	}
+
+	return m[len(m)-1].name
+}
+
+// ParseIds reads ids and ranges separated by commas, e.g. 1-5,8.
+func ParseIds(s string) ([]int32, error) {
+	var ids []int32
+
+	for _, part := range strings.Split(s, ",") {
+		first, last, isRange := strings.Cut(strings.TrimSpace(part), "-")
+
+		if !isRange {
+			last = first
+		}
+
+		from, err := strconv.ParseInt(first, 10, 32)
+		to, errTo := strconv.ParseInt(last, 10, 32)
+
+		if err != nil || errTo != nil || from < 1 || to < from {
+			return nil, fmt.Errorf("%q is not an id or a range of ids", part)
+		}
+
+		for id := from; id <= to; id++ {
+			ids = append(ids, int32(id))
+		}
+	}
+
+	return ids, nil
+}
+
+type Account struct {
+	ID    int32
+	Cards []int32
+}
+
+// Tenant is a tenant the load is sent to, with the api key used and the
+// accounts found by Discover.
+type Tenant struct {
+	ID       int32
+	ApiKey   string
+	Accounts []Account
+}
+
+// tenantPicker returns the index of the tenant of the next request.
+func tenantPicker(spread string, rng *rand.Rand, n int) func() int {
+	if spread == SpreadZipf && n > 1 {
+		zipf := rand.NewZipf(rng, 1.2, 1, uint64(n-1))
+		return func() int { return int(zipf.Uint64()) }
+	}
+
+	return func() int { return rng.Intn(n) }
+}
+
+// Discover finds up to maxAccounts active accounts of the tenant with at
+// least one card, through the api and with the tenant api key.
+func Discover(ctx context.Context, client *http.Client, usersUrl string, tenant *Tenant, maxAccounts int) error {
+	var accounts []struct {
+		ID     int32  `json:"id"`
+		Status string `json:"status"`
+	}
+
+	if err := getJSON(ctx, client, usersUrl+"/api/v1/account", tenant, &accounts); err != nil {
+		return err
+	}
+
+	tenant.Accounts = nil
+
+	for _, account := range accounts {
+		if len(tenant.Accounts) == maxAccounts {
+			break
+		}
+
+		if account.Status != "active" {
+			continue
+		}
+
+		var cards []struct {
+			ID int32 `json:"id"`
+		}
+
+		url := fmt.Sprintf("%s/api/v1/card/account/%d", usersUrl, account.ID)
+
+		if err := getJSON(ctx, client, url, tenant, &cards); err != nil {
+			return err
+		}
+
+		if len(cards) == 0 {
+			continue
+		}
+
+		found := Account{ID: account.ID}
+
+		for _, card := range cards {
+			found.Cards = append(found.Cards, card.ID)
+		}
+
+		tenant.Accounts = append(tenant.Accounts, found)
+	}
+
+	return nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, tenant *Tenant, v any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+	if err != nil {
+		return err
+	}
+
+	authorize(request, tenant)
+
+	response, err := client.Do(request)
+
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("GET %s answered %s: %s", url, response.Status, body)
+	}
+
+	return json.NewDecoder(response.Body).Decode(v)
+}
+
+func authorize(request *http.Request, tenant *Tenant) {
+	request.Header.Set("Authorization", "Bearer "+tenant.ApiKey)
+	request.Header.Set("tenant-id", strconv.Itoa(int(tenant.ID)))
+}
+
+// newRequest builds the request of an operation on a card of the tenant.
+func newRequest(ctx context.Context, operation string, usersUrl string, pdfUrl string, tenant *Tenant,
+	account Account, cardId int32, rng *rand.Rand) (*http.Request, error) {
+	var method, url string
+	var body io.Reader
+
+	switch operation {
+	case OperationCreate:
+		method = http.MethodPost
+		url = fmt.Sprintf("%s/api/v1/transaction/account/%d", usersUrl, account.ID)
+		payload, _ := json.Marshal(map[string]any{
+			"card_id": cardId,
+			"kind":    "Load test",
+			"value":   100 + rng.Int63n(50_000),
+		})
+		body = bytes.NewReader(payload)
+	case OperationList:
+		method = http.MethodGet
+		url = fmt.Sprintf("%s/api/v1/transaction/account/%d/card/%d", usersUrl, account.ID, cardId)
+	case OperationAccounts:
+		method = http.MethodGet
+		url = usersUrl + "/api/v1/account"
+	case OperationReport:
+		method = http.MethodGet
+		url = fmt.Sprintf("%s/api/v1/accounts/%d/tenant/%d/transactions.pdf", pdfUrl, account.ID, tenant.ID)
+	default:
+		return nil, fmt.Errorf("unknown operation %q", operation)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, url, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	authorize(request, tenant)
+
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	return request, nil
+}
@@ -0,0 +1,77 @@
+package loadtest
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMix(t *testing.T) {
+	t.Run("Weights", func(t *testing.T) {
+		mix, err := ParseMix("create=30, list=70, report=0")
+
+		assert.NoError(t, err)
+		assert.Equal(t, Mix{{OperationCreate, 30}, {OperationList, 70}}, mix)
+	})
+
+	t.Run("Picks follow the weights", func(t *testing.T) {
+		mix, _ := ParseMix("create=1,list=3")
+		rng := rand.New(rand.NewSource(1))
+		picks := make(map[string]int)
+
+		for i := 0; i < 10_000; i++ {
+			picks[mix.pick(rng)]++
+		}
+
+		assert.InDelta(t, 7_500, picks[OperationList], 250)
+		assert.InDelta(t, 2_500, picks[OperationCreate], 250)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, mix := range []string{"create", "create=x", "create=-1", "delete=1", "create=0"} {
+			_, err := ParseMix(mix)
+
+			assert.Error(t, err, mix)
+		}
+	})
+}
+
+func TestParseIds(t *testing.T) {
+	ids, err := ParseIds("1-3, 8")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{1, 2, 3, 8}, ids)
+
+	for _, invalid := range []string{"", "0", "3-1", "a-b", "1,x"} {
+		_, err := ParseIds(invalid)
+
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestTenantPicker(t *testing.T) {
+	count := func(spread string) []int {
+		rng := rand.New(rand.NewSource(1))
+		pick := tenantPicker(spread, rng, 5)
+		counts := make([]int, 5)
+
+		for i := 0; i < 10_000; i++ {
+			counts[pick()]++
+		}
+
+		return counts
+	}
+
+	uniform := count(SpreadUniform)
+
+	for _, n := range uniform {
+		assert.InDelta(t, 2_000, n, 200)
+	}
+
+	zipf := count(SpreadZipf)
+
+	assert.Greater(t, zipf[0], zipf[1])
+	assert.Greater(t, zipf[1], zipf[4])
+	assert.Greater(t, zipf[0], 4_000)
+}
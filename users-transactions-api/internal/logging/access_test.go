@@ -0,0 +1,39 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessLog(t *testing.T) {
+	var out bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(NewRedactHandler(slog.NewJSONHandler(&out, nil))))
+	defer slog.SetDefault(previous)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(AccessLog())
+	router.GET("/accounts/:accountId", func(c *gin.Context) {
+		c.String(http.StatusNotFound, "missing")
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/accounts/4111111111111111", nil))
+
+	var record map[string]any
+	json.Unmarshal(out.Bytes(), &record)
+
+	assert.Equal(t, "http request", record["msg"])
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, "/accounts/:accountId", record["route"])
+	assert.Equal(t, "/accounts/************1111", record["path"])
+	assert.Equal(t, 404.0, record["status"])
+	assert.Equal(t, 7.0, record["bytes"])
+}
@@ -0,0 +1,195 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const masked = "******"
+
+// keys whose values are always secrets, matched as substrings of the
+// lower case attribute key
+var secretKeys = []string{"password", "secret", "token", "authorization", "cookie", "api_key", "apikey"}
+
+var (
+	// 13 to 19 digits, optionally grouped by spaces or dashes, only masked
+	// when the Luhn check passes so ids and timestamps are kept
+	cardPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	// formatted CPFs are always masked, bare ones when the check digits match
+	cpfPattern     = regexp.MustCompile(`\b\d{3}\.\d{3}\.\d{3}-\d{2}\b`)
+	bareCpfPattern = regexp.MustCompile(`\b\d{11}\b`)
+	bearerPattern  = regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/=-]+`)
+	jwtPattern     = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+	// api keys keep their public prefix, which identifies them in the
+	// management endpoints
+	apiKeyPattern = regexp.MustCompile(`\b(utk_[0-9a-f]+)_[0-9a-f]+\b`)
+)
+
+// Redact masks the card numbers, CPFs and tokens found in s.
+func Redact(s string) string {
+	s = bearerPattern.ReplaceAllString(s, "Bearer "+masked)
+	s = jwtPattern.ReplaceAllString(s, masked)
+	s = apiKeyPattern.ReplaceAllString(s, "${1}_"+masked)
+	s = cpfPattern.ReplaceAllString(s, "***.***.***-**")
+
+	s = cardPattern.ReplaceAllStringFunc(s, func(match string) string {
+		digits := onlyDigits(match)
+
+		if !luhn(digits) {
+			return match
+		}
+
+		return strings.Repeat("*", len(digits)-4) + digits[len(digits)-4:]
+	})
+
+	s = bareCpfPattern.ReplaceAllStringFunc(s, func(match string) string {
+		if !validCpf(match) {
+			return match
+		}
+
+		return "***********"
+	})
+
+	return s
+}
+
+// RedactHandler masks sensitive data in the message and attributes of the
+// records before they reach the next handler.
+type RedactHandler struct {
+	next slog.Handler
+}
+
+func NewRedactHandler(next slog.Handler) *RedactHandler {
+	return &RedactHandler{next: next}
+}
+
+func (h *RedactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *RedactHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, Redact(record.Message), record.PC)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(attr))
+		return true
+	})
+
+	return h.next.Handle(ctx, redacted)
+}
+
+func (h *RedactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+
+	for i, attr := range attrs {
+		redacted[i] = redactAttr(attr)
+	}
+
+	return &RedactHandler{next: h.next.WithAttrs(redacted)}
+}
+
+func (h *RedactHandler) WithGroup(name string) slog.Handler {
+	return &RedactHandler{next: h.next.WithGroup(name)}
+}
+
+func redactAttr(attr slog.Attr) slog.Attr {
+	value := attr.Value.Resolve()
+
+	switch value.Kind() {
+	case slog.KindGroup:
+		group := value.Group()
+		redacted := make([]any, len(group))
+
+		for i, a := range group {
+			redacted[i] = redactAttr(a)
+		}
+
+		return slog.Group(attr.Key, redacted...)
+	case slog.KindString:
+		if isSecretKey(attr.Key) && len(value.String()) > 0 {
+			return slog.String(attr.Key, masked)
+		}
+
+		return slog.String(attr.Key, Redact(value.String()))
+	default:
+		if isSecretKey(attr.Key) {
+			return slog.String(attr.Key, masked)
+		}
+
+		// errors often quote the input that failed
+		if err, ok := value.Any().(error); ok && value.Kind() == slog.KindAny {
+			return slog.String(attr.Key, Redact(err.Error()))
+		}
+
+		return slog.Attr{Key: attr.Key, Value: value}
+	}
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, secret := range secretKeys {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func onlyDigits(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+func luhn(digits string) bool {
+	sum := 0
+	double := false
+
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+func validCpf(digits string) bool {
+	if strings.Count(digits, digits[:1]) == len(digits) {
+		return false
+	}
+
+	for _, length := range []int{9, 10} {
+		sum := 0
+
+		for i := 0; i < length; i++ {
+			sum += int(digits[i]-'0') * (length + 1 - i)
+		}
+
+		check := sum * 10 % 11 % 10
+
+		if check != int(digits[length]-'0') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Card number", "paid with 4111 1111 1111 1111", "paid with ************1111"},
+		{"Card number without separators", "card=5555555555554444", "card=************4444"},
+		{"Digits failing the Luhn check", "order 1234567890123", "order 1234567890123"},
+		{"Formatted CPF", "cpf 529.982.247-25", "cpf ***.***.***-**"},
+		{"Bare CPF", "cpf 52998224725", "cpf ***********"},
+		{"Bare digits that are not a CPF", "phone 11987654321", "phone 11987654321"},
+		{"Bearer token", "Authorization: Bearer abc.def-123", "Authorization: Bearer ******"},
+		{"JWT", "token eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig", "token ******"},
+		{"API key keeps the prefix", "key utk_0a1b2c3d4e5f_9f8e7d6c5b4a", "key utk_0a1b2c3d4e5f_******"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, Redact(c.input))
+		})
+	}
+}
+
+func TestRedactHandler(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	logger := slog.New(NewRedactHandler(slog.NewJSONHandler(&out, nil))).
+		With(slog.String("api_key", "utk_0a1b2c3d4e5f_9f8e7d6c5b4a"))
+
+	logger.Info("card 4111111111111111 declined",
+		slog.String("password", "hunter2"),
+		slog.Int("token_ttl", 3600),
+		slog.Group("customer", slog.String("document", "529.982.247-25")),
+		slog.Any("error", errors.New("invalid cpf 52998224725")),
+		slog.Int("account_id", 7),
+	)
+
+	var record map[string]any
+	json.Unmarshal(out.Bytes(), &record)
+
+	assert.Equal(t, "card ************1111 declined", record["msg"])
+	assert.Equal(t, masked, record["api_key"])
+	assert.Equal(t, masked, record["password"])
+	assert.Equal(t, masked, record["token_ttl"])
+	assert.Equal(t, map[string]any{"document": "***.***.***-**"}, record["customer"])
+	assert.Equal(t, "invalid cpf ***********", record["error"])
+	assert.Equal(t, 7.0, record["account_id"])
+}
@@ -0,0 +1,84 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatingFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Rotates past the size and keeps the backups", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "logs", "api.log")
+		sut, err := NewRotatingFile(path, 10, 2)
+		assert.NoError(t, err)
+		defer sut.Close()
+
+		for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+			_, err := sut.Write([]byte(line))
+			assert.NoError(t, err)
+		}
+
+		current, _ := os.ReadFile(path)
+		first, _ := os.ReadFile(path + ".1")
+		second, _ := os.ReadFile(path + ".2")
+
+		assert.Equal(t, "fourth\n", string(current))
+		assert.Equal(t, "third\n", string(first))
+		assert.Equal(t, "second\n", string(second))
+		assert.NoFileExists(t, path+".3")
+	})
+
+	t.Run("Appends to an existing file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "api.log")
+		os.WriteFile(path, []byte("before restart\n"), 0o644)
+
+		sut, err := NewRotatingFile(path, 1024, 1)
+		assert.NoError(t, err)
+
+		sut.Write([]byte("after restart\n"))
+		sut.Close()
+
+		content, _ := os.ReadFile(path)
+		assert.Equal(t, "before restart\nafter restart\n", string(content))
+	})
+
+	t.Run("Keeps writing after a failed rotation", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "api.log")
+		sut, err := NewRotatingFile(path, 10, 1)
+		assert.NoError(t, err)
+		defer sut.Close()
+
+		// a non-empty directory in place of the backup makes the rename fail
+		os.MkdirAll(filepath.Join(path+".1", "busy"), 0o755)
+
+		_, err = sut.Write([]byte("first\n"))
+		assert.NoError(t, err)
+
+		_, err = sut.Write([]byte("second\n"))
+		assert.Error(t, err)
+
+		os.RemoveAll(path + ".1")
+
+		_, err = sut.Write([]byte("third\n"))
+		assert.NoError(t, err)
+
+		current, _ := os.ReadFile(path)
+		first, _ := os.ReadFile(path + ".1")
+
+		assert.Equal(t, "third\n", string(current))
+		assert.Equal(t, "first\n", string(first))
+	})
+
+	t.Run("Fails after close", func(t *testing.T) {
+		sut, _ := NewRotatingFile(filepath.Join(t.TempDir(), "api.log"), 1024, 1)
+		sut.Close()
+
+		_, err := sut.Write([]byte("late\n"))
+
+		assert.ErrorIs(t, err, os.ErrClosed)
+	})
+}
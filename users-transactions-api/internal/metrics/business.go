@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MaxKindLabels is the number of transaction kinds with their own series.
+// Kinds are free merchant descriptions, the later ones count as "other".
+const MaxKindLabels = 50
+
+var (
+	transactionsCreated = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "transactions_created_total",
+		Help: "Transactions created, by kind and direction: debit or credit.",
+	}, []string{"kind", "direction"})
+
+	transactionsAmount = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "transactions_amount_total",
+		Help: "Value of the transactions created in minor units of the tenant currency, by kind and direction: debit or credit.",
+	}, []string{"kind", "direction"})
+
+	accountsInactivated = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "accounts_inactivated_total",
+		Help: "Active accounts moved to another status, by the new status.",
+	}, []string{"status"})
+
+	kinds = newBoundedLabel(MaxKindLabels)
+)
+
+// TransactionCreated records a transaction by its stored value, credits are
+// negative and counted by their amount under the credit direction.
+func TransactionCreated(kind string, value int64) {
+	kind = kinds.value(kind)
+	direction := "debit"
+
+	if value < 0 {
+		direction = "credit"
+		value = -value
+	}
+
+	transactionsCreated.WithLabelValues(kind, direction).Inc()
+	transactionsAmount.WithLabelValues(kind, direction).Add(float64(value))
+}
+
+func AccountInactivated(status string) {
+	accountsInactivated.WithLabelValues(status).Inc()
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	tenantCacheRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "tenant_cache_requests_total",
+		Help: "Tenant lookups served by the cache, by result: hit, negative_hit for a tenant cached as not found, or miss.",
+	}, []string{"result"})
+
+	tenantCacheEvictions = factory.NewCounter(prometheus.CounterOpts{
+		Name: "tenant_cache_evictions_total",
+		Help: "Tenants evicted from the cache to stay within its size.",
+	})
+
+	tenantCacheInvalidations = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "tenant_cache_invalidations_total",
+		Help: "Tenant cache invalidations, by reason: notification of a change, or purge after reconnecting.",
+	}, []string{"reason"})
+)
+
+func TenantCacheHit(negative bool) {
+	if negative {
+		tenantCacheRequests.WithLabelValues("negative_hit").Inc()
+		return
+	}
+
+	tenantCacheRequests.WithLabelValues("hit").Inc()
+}
+
+func TenantCacheMiss() {
+	tenantCacheRequests.WithLabelValues("miss").Inc()
+}
+
+func TenantCacheEvicted() {
+	tenantCacheEvictions.Inc()
+}
+
+func TenantCacheInvalidated(reason string) {
+	tenantCacheInvalidations.WithLabelValues(reason).Inc()
+}
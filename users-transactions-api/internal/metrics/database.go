@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	dbReplicaHealthy = factory.NewGauge(prometheus.GaugeOpts{
+		Name: "db_replica_healthy",
+		Help: "Whether the last health check of the database replica passed, the reads fall back to the primary while it is 0.",
+	})
+
+	dbReads = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_reads_total",
+		Help: "Statements of the read only usecases, by the database they were sent to: replica or primary.",
+	}, []string{"target"})
+)
+
+func DBReplicaHealthy(healthy bool) {
+	if healthy {
+		dbReplicaHealthy.Set(1)
+		return
+	}
+
+	dbReplicaHealthy.Set(0)
+}
+
+func DBRead(target string) {
+	dbReads.WithLabelValues(target).Inc()
+}
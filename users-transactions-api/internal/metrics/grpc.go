@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcServerHandled = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "gRPC calls completed by the server, by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcServerDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "gRPC call latency on the server, by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+// ObserveGrpcServer records a finished call, the method is the full name
+// of a registered service method so it is bounded.
+func ObserveGrpcServer(method string, err error, elapsed time.Duration) {
+	grpcServerHandled.WithLabelValues(method, status.Code(err).String()).Inc()
+	grpcServerDuration.WithLabelValues(method).Observe(elapsed.Seconds())
+}
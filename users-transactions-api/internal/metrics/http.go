@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MaxTenantLabels is the number of tenants with their own series, the
+// requests of the others are counted under "other".
+const MaxTenantLabels = 100
+
+var (
+	httpRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by route, status and tenant.",
+	}, []string{"method", "route", "status", "tenant"})
+
+	httpDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by route and tenant.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "tenant"})
+
+	tenants = newBoundedLabel(MaxTenantLabels)
+)
+
+// HTTP records every request under its route template, never the raw path,
+// and the tenant of the authenticated principal ("none" for the others).
+func HTTP() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+
+		if len(route) == 0 {
+			route = "unmatched"
+		}
+
+		tenant := "none"
+
+		if principal, ok := shared.PrincipalFromContext(c.Request.Context()); ok {
+			tenant = tenants.value(strconv.Itoa(int(principal.TenantId)))
+		}
+
+		httpRequests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status()), tenant).Inc()
+		httpDuration.WithLabelValues(c.Request.Method, route, tenant).Observe(time.Since(start).Seconds())
+	}
+}
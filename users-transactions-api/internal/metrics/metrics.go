@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"database/sql"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry holds the metrics served by Handler, apart from the default one
+// so tests and libraries do not leak series into it.
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry}))
+}
+
+// RegisterDB exports the connection pool stats of the database as the
+// go_sql_* metrics.
+func RegisterDB(db *sql.DB, name string) {
+	Registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// otherLabel replaces the values past the limit of a boundedLabel.
+const otherLabel = "other"
+
+// boundedLabel lets the first max values of an open ended label through and
+// folds the rest into "other", so the number of series stays bounded.
+type boundedLabel struct {
+	mu   sync.Mutex
+	max  int
+	seen map[string]struct{}
+}
+
+func newBoundedLabel(max int) *boundedLabel {
+	return &boundedLabel{
+		max:  max,
+		seen: make(map[string]struct{}),
+	}
+}
+
+func (b *boundedLabel) value(v string) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.seen[v]; ok {
+		return v
+	}
+
+	if len(b.seen) >= b.max {
+		return otherLabel
+	}
+
+	b.seen[v] = struct{}{}
+	return v
+}
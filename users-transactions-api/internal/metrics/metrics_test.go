@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(HTTP())
+	router.GET("/metrics", Handler())
+	router.GET("/accounts/:accountId", func(c *gin.Context) {
+		if tenant := c.GetHeader("tenant-id"); len(tenant) > 0 {
+			var tenantId int32
+			fmt.Sscan(tenant, &tenantId)
+			c.Request = c.Request.WithContext(shared.ContextWithPrincipal(c.Request.Context(),
+				&shared.Principal{TenantId: tenantId}))
+		}
+		c.Status(http.StatusOK)
+	})
+
+	serve := func(path string, tenant string) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if len(tenant) > 0 {
+			req.Header.Set("tenant-id", tenant)
+		}
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	t.Run("Labels the route template and the tenant", func(t *testing.T) {
+		serve("/accounts/1", "7")
+		serve("/accounts/2", "7")
+
+		assert.Equal(t, 2.0, testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/accounts/:accountId", "200", "7")))
+	})
+
+	t.Run("Unknown routes share one series", func(t *testing.T) {
+		serve("/random/path/1", "")
+		serve("/random/path/2", "")
+
+		assert.Equal(t, 2.0, testutil.ToFloat64(httpRequests.WithLabelValues("GET", "unmatched", "404", "none")))
+	})
+
+	t.Run("Tenants past the limit count as other", func(t *testing.T) {
+		// tenant 7 already took one of the slots
+		for i := 0; i < MaxTenantLabels; i++ {
+			serve("/accounts/1", fmt.Sprint(1000+i))
+		}
+
+		assert.Equal(t, 1.0, testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/accounts/:accountId", "200", otherLabel)))
+	})
+
+	t.Run("Serves the metrics", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.True(t, strings.Contains(res.Body.String(), "http_request_duration_seconds_bucket"))
+		assert.True(t, strings.Contains(res.Body.String(), "go_goroutines"))
+	})
+}
+
+func TestTransactionCreated(t *testing.T) {
+	TransactionCreated("Streaming Z", 1500)
+	TransactionCreated("Streaming Z", 500)
+	TransactionCreated("Streaming Z", -300)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(transactionsCreated.WithLabelValues("Streaming Z", "debit")))
+	assert.Equal(t, 2000.0, testutil.ToFloat64(transactionsAmount.WithLabelValues("Streaming Z", "debit")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(transactionsCreated.WithLabelValues("Streaming Z", "credit")))
+	assert.Equal(t, 300.0, testutil.ToFloat64(transactionsAmount.WithLabelValues("Streaming Z", "credit")))
+}
+
+func TestBoundedLabel(t *testing.T) {
+	label := newBoundedLabel(2)
+
+	assert.Equal(t, "a", label.value("a"))
+	assert.Equal(t, "b", label.value("b"))
+	assert.Equal(t, otherLabel, label.value("c"))
+	assert.Equal(t, "a", label.value("a"))
+}
@@ -0,0 +1,15 @@
+package mocks
+
+import (
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/ports"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMailer struct {
+	mock.Mock
+}
+
+func (mock *MockMailer) Send(mail ports.Mail) error {
+	args := mock.Called()
+	return args.Error(0)
+}
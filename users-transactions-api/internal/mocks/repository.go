@@ -23,6 +23,138 @@ func (mock *MockRepository) GetTenant(ctx context.Context, id int32) (infra.Tena
 	return infra.Tenant{}, args.Error(1)
 }
 
+func (mock *MockRepository) CreateTenant(ctx context.Context, name string) (infra.Tenant, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.Tenant), args.Error(1)
+	}
+
+	return infra.Tenant{}, args.Error(1)
+}
+
+func (mock *MockRepository) GetTenants(ctx context.Context) ([]infra.Tenant, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.([]infra.Tenant), args.Error(1)
+	}
+
+	return []infra.Tenant{}, args.Error(1)
+}
+
+func (mock *MockRepository) UpdateTenantName(ctx context.Context, arg infra.UpdateTenantNameParams) (infra.Tenant, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.Tenant), args.Error(1)
+	}
+
+	return infra.Tenant{}, args.Error(1)
+}
+
+func (mock *MockRepository) UpdateTenantStatus(ctx context.Context, arg infra.UpdateTenantStatusParams) (infra.Tenant, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.Tenant), args.Error(1)
+	}
+
+	return infra.Tenant{}, args.Error(1)
+}
+
+func (mock *MockRepository) CreateTenantTx(ctx context.Context, arg infra.CreateTenantTxParams) (infra.CreateTenantTxResult, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.CreateTenantTxResult), args.Error(1)
+	}
+
+	return infra.CreateTenantTxResult{}, args.Error(1)
+}
+
+// Tenant settings
+func (mock *MockRepository) CreateTenantSettings(ctx context.Context, tenantID int32) (infra.TenantSetting, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.TenantSetting), args.Error(1)
+	}
+
+	return infra.TenantSetting{}, args.Error(1)
+}
+
+func (mock *MockRepository) LockTenantQuota(ctx context.Context, tenantID int32) error {
+	args := mock.Called()
+	return args.Error(0)
+}
+
+func (mock *MockRepository) GetTenantSettings(ctx context.Context, tenantID int32) (infra.TenantSetting, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.TenantSetting), args.Error(1)
+	}
+
+	return infra.TenantSetting{}, args.Error(1)
+}
+
+func (mock *MockRepository) UpdateTenantSettings(ctx context.Context, arg infra.UpdateTenantSettingsParams) (infra.TenantSetting, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.TenantSetting), args.Error(1)
+	}
+
+	return infra.TenantSetting{}, args.Error(1)
+}
+
+func (mock *MockRepository) UpdateTenantLimits(ctx context.Context, arg infra.UpdateTenantLimitsParams) (infra.TenantSetting, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.TenantSetting), args.Error(1)
+	}
+
+	return infra.TenantSetting{}, args.Error(1)
+}
+
+func (mock *MockRepository) UpsertTenantLogo(ctx context.Context, arg infra.UpsertTenantLogoParams) (infra.TenantLogo, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.TenantLogo), args.Error(1)
+	}
+
+	return infra.TenantLogo{}, args.Error(1)
+}
+
+func (mock *MockRepository) GetTenantLogo(ctx context.Context, tenantID int32) (infra.TenantLogo, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.TenantLogo), args.Error(1)
+	}
+
+	return infra.TenantLogo{}, args.Error(1)
+}
+
+func (mock *MockRepository) DeleteTenantLogo(ctx context.Context, tenantID int32) error {
+	args := mock.Called()
+	return args.Error(0)
+}
+
 // Account
 func (mock *MockRepository) CreateAccount(ctx context.Context, arg infra.CreateAccountParams) (infra.Account, error) {
 	args := mock.Called()
@@ -46,6 +178,50 @@ func (mock *MockRepository) GetAccount(ctx context.Context, arg infra.GetAccount
 	return infra.Account{}, args.Error(1)
 }
 
+func (mock *MockRepository) CountActiveAccounts(ctx context.Context, tenantID int32) (int64, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(int64), args.Error(1)
+	}
+
+	return 0, args.Error(1)
+}
+
+func (mock *MockRepository) CountCards(ctx context.Context, accountID int32) (int64, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(int64), args.Error(1)
+	}
+
+	return 0, args.Error(1)
+}
+
+func (mock *MockRepository) CountTransactionsSince(ctx context.Context, arg infra.CountTransactionsSinceParams) (int64, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(int64), args.Error(1)
+	}
+
+	return 0, args.Error(1)
+}
+
+func (mock *MockRepository) TakeRateLimitToken(ctx context.Context, arg infra.TakeRateLimitTokenParams) (float64, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(float64), args.Error(1)
+	}
+
+	return 0, args.Error(1)
+}
+
 func (mock *MockRepository) GetAccounts(ctx context.Context, tenantID int32) ([]infra.Account, error) {
 	args := mock.Called()
 	result := args.Get(0)
@@ -68,6 +244,53 @@ func (mock *MockRepository) UpdateAccount(ctx context.Context, arg infra.UpdateA
 	return infra.Account{}, args.Error(1)
 }
 
+func (mock *MockRepository) GetAccountForUpdate(ctx context.Context, arg infra.GetAccountForUpdateParams) (infra.Account, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.Account), args.Error(1)
+	}
+
+	return infra.Account{}, args.Error(1)
+}
+
+func (mock *MockRepository) GetAccountForShare(ctx context.Context, arg infra.GetAccountForShareParams) (infra.Account, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.Account), args.Error(1)
+	}
+
+	return infra.Account{}, args.Error(1)
+}
+
+// Account status history
+func (mock *MockRepository) CreateAccountStatusHistory(ctx context.Context,
+	arg infra.CreateAccountStatusHistoryParams) (infra.AccountStatusHistory, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.AccountStatusHistory), args.Error(1)
+	}
+
+	return infra.AccountStatusHistory{}, args.Error(1)
+}
+
+func (mock *MockRepository) GetAccountStatusHistory(ctx context.Context,
+	accountID int32) ([]infra.AccountStatusHistory, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.([]infra.AccountStatusHistory), args.Error(1)
+	}
+
+	return []infra.AccountStatusHistory{}, args.Error(1)
+}
+
 // Card
 func (mock *MockRepository) CreateCard(ctx context.Context, accountId int32) (infra.Card, error) {
 	args := mock.Called()
@@ -113,6 +336,17 @@ func (mock *MockRepository) AddAmount(ctx context.Context, arg infra.AddAmountPa
 	return infra.Card{}, args.Error(1)
 }
 
+func (mock *MockRepository) RecomputeCardAmount(ctx context.Context, arg infra.RecomputeCardAmountParams) (infra.Card, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.Card), args.Error(1)
+	}
+
+	return infra.Card{}, args.Error(1)
+}
+
 // Transaction
 func (mock *MockRepository) CreateTransaction(ctx context.Context, arg infra.CreateTransactionParams) (infra.Transaction, error) {
 	args := mock.Called()
@@ -125,7 +359,43 @@ func (mock *MockRepository) CreateTransaction(ctx context.Context, arg infra.Cre
 	return infra.Transaction{}, args.Error(1)
 }
 
-func (mock *MockRepository) CreateTransactionTx(ctx context.Context, arg infra.CreateTransactionParams) (infra.Transaction, error) {
+// WithTenant runs fn against the mock itself, the tenant isolation is
+// covered by the repository tests.
+func (mock *MockRepository) WithTenant(ctx context.Context, tenantId int32, fn func(q infra.Querier) error) error {
+	return fn(mock)
+}
+
+func (mock *MockRepository) CreateTransactionAdjustment(ctx context.Context,
+	arg infra.CreateTransactionAdjustmentParams) (infra.TransactionAdjustment, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.TransactionAdjustment), args.Error(1)
+	}
+
+	return infra.TransactionAdjustment{}, args.Error(1)
+}
+
+func (mock *MockRepository) AdjustTransactionTx(ctx context.Context, arg infra.AdjustTransactionTxParams) (infra.Transaction, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.Transaction), args.Error(1)
+	}
+
+	return infra.Transaction{}, args.Error(1)
+}
+
+func (mock *MockRepository) CreateTransactionTx(ctx context.Context, arg infra.CreateTransactionParams,
+	check func(q infra.Querier) error) (infra.Transaction, error) {
+	if check != nil {
+		if err := check(mock); err != nil {
+			return infra.Transaction{}, err
+		}
+	}
+
 	args := mock.Called()
 	result := args.Get(0)
 
@@ -168,3 +438,305 @@ func (mock *MockRepository) SearchTransactions(ctx context.Context, arg infra.Se
 
 	return []infra.SearchTransactionsRow{}, args.Error(1)
 }
+
+// Privacy
+func (mock *MockRepository) CreateDataSubjectRequest(ctx context.Context,
+	arg infra.CreateDataSubjectRequestParams) (infra.DataSubjectRequest, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.DataSubjectRequest), args.Error(1)
+	}
+
+	return infra.DataSubjectRequest{}, args.Error(1)
+}
+
+func (mock *MockRepository) GetDataSubjectRequests(ctx context.Context,
+	arg infra.GetDataSubjectRequestsParams) ([]infra.DataSubjectRequest, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.([]infra.DataSubjectRequest), args.Error(1)
+	}
+
+	return []infra.DataSubjectRequest{}, args.Error(1)
+}
+
+func (mock *MockRepository) GetAccountTransactions(ctx context.Context, accountID int32) ([]infra.Transaction, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.([]infra.Transaction), args.Error(1)
+	}
+
+	return []infra.Transaction{}, args.Error(1)
+}
+
+func (mock *MockRepository) PseudonymizeAccountTransactions(ctx context.Context,
+	arg infra.PseudonymizeAccountTransactionsParams) (int64, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(int64), args.Error(1)
+	}
+
+	return 0, args.Error(1)
+}
+
+func (mock *MockRepository) PseudonymizeAccountStatusHistory(ctx context.Context,
+	arg infra.PseudonymizeAccountStatusHistoryParams) error {
+	args := mock.Called()
+	return args.Error(0)
+}
+
+func (mock *MockRepository) MarkAccountErased(ctx context.Context, arg infra.MarkAccountErasedParams) (infra.Account, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.Account), args.Error(1)
+	}
+
+	return infra.Account{}, args.Error(1)
+}
+
+// Api key
+func (mock *MockRepository) CreateApiKey(ctx context.Context, arg infra.CreateApiKeyParams) (infra.ApiKey, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.ApiKey), args.Error(1)
+	}
+
+	return infra.ApiKey{}, args.Error(1)
+}
+
+func (mock *MockRepository) GetApiKey(ctx context.Context, arg infra.GetApiKeyParams) (infra.ApiKey, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.ApiKey), args.Error(1)
+	}
+
+	return infra.ApiKey{}, args.Error(1)
+}
+
+func (mock *MockRepository) GetApiKeyByPrefix(ctx context.Context, prefix string) (infra.ApiKey, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.ApiKey), args.Error(1)
+	}
+
+	return infra.ApiKey{}, args.Error(1)
+}
+
+func (mock *MockRepository) GetApiKeys(ctx context.Context, tenantID int32) ([]infra.ApiKey, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.([]infra.ApiKey), args.Error(1)
+	}
+
+	return []infra.ApiKey{}, args.Error(1)
+}
+
+func (mock *MockRepository) RotateApiKey(ctx context.Context, arg infra.RotateApiKeyParams) (infra.ApiKey, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.ApiKey), args.Error(1)
+	}
+
+	return infra.ApiKey{}, args.Error(1)
+}
+
+func (mock *MockRepository) RevokeApiKey(ctx context.Context, arg infra.RevokeApiKeyParams) (infra.ApiKey, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.ApiKey), args.Error(1)
+	}
+
+	return infra.ApiKey{}, args.Error(1)
+}
+
+func (mock *MockRepository) TouchApiKey(ctx context.Context, arg infra.TouchApiKeyParams) error {
+	args := mock.Called()
+	return args.Error(0)
+}
+
+// User
+func (mock *MockRepository) CreateUser(ctx context.Context, arg infra.CreateUserParams) (infra.User, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.User), args.Error(1)
+	}
+
+	return infra.User{}, args.Error(1)
+}
+
+func (mock *MockRepository) CreateUserToken(ctx context.Context, arg infra.CreateUserTokenParams) (infra.UserToken, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.UserToken), args.Error(1)
+	}
+
+	return infra.UserToken{}, args.Error(1)
+}
+
+func (mock *MockRepository) GetUser(ctx context.Context, arg infra.GetUserParams) (infra.User, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.User), args.Error(1)
+	}
+
+	return infra.User{}, args.Error(1)
+}
+
+func (mock *MockRepository) GetUserByEmail(ctx context.Context, arg infra.GetUserByEmailParams) (infra.User, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.User), args.Error(1)
+	}
+
+	return infra.User{}, args.Error(1)
+}
+
+func (mock *MockRepository) GetUserById(ctx context.Context, id int32) (infra.User, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.User), args.Error(1)
+	}
+
+	return infra.User{}, args.Error(1)
+}
+
+func (mock *MockRepository) GetUserToken(ctx context.Context, arg infra.GetUserTokenParams) (infra.UserToken, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.UserToken), args.Error(1)
+	}
+
+	return infra.UserToken{}, args.Error(1)
+}
+
+func (mock *MockRepository) GetUsers(ctx context.Context, tenantID int32) ([]infra.User, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.([]infra.User), args.Error(1)
+	}
+
+	return []infra.User{}, args.Error(1)
+}
+
+func (mock *MockRepository) UpdateUserPassword(ctx context.Context, arg infra.UpdateUserPasswordParams) (infra.User, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.User), args.Error(1)
+	}
+
+	return infra.User{}, args.Error(1)
+}
+
+func (mock *MockRepository) UpdateUserRole(ctx context.Context, arg infra.UpdateUserRoleParams) (infra.User, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.User), args.Error(1)
+	}
+
+	return infra.User{}, args.Error(1)
+}
+
+func (mock *MockRepository) UpdateUserStatus(ctx context.Context, arg infra.UpdateUserStatusParams) (infra.User, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.User), args.Error(1)
+	}
+
+	return infra.User{}, args.Error(1)
+}
+
+func (mock *MockRepository) UseUserToken(ctx context.Context, arg infra.UseUserTokenParams) (int64, error) {
+	args := mock.Called()
+	return int64(args.Int(0)), args.Error(1)
+}
+
+func (mock *MockRepository) RevokeUserTokens(ctx context.Context, arg infra.RevokeUserTokensParams) error {
+	args := mock.Called()
+	return args.Error(0)
+}
+
+func (mock *MockRepository) SetPasswordTx(ctx context.Context, arg infra.SetPasswordTxParams) (infra.User, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.User), args.Error(1)
+	}
+
+	return infra.User{}, args.Error(1)
+}
+
+func (mock *MockRepository) InviteUserTx(ctx context.Context, arg infra.InviteUserTxParams) (infra.User, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(infra.User), args.Error(1)
+	}
+
+	return infra.User{}, args.Error(1)
+}
+
+func (mock *MockRepository) ReinviteUserTx(ctx context.Context, arg infra.ReinviteUserTxParams) error {
+	args := mock.Called()
+	return args.Error(0)
+}
+
+// Health
+func (mock *MockRepository) Ping(ctx context.Context) error {
+	args := mock.Called()
+	return args.Error(0)
+}
+
+func (mock *MockRepository) AppliedMigrations(ctx context.Context) (map[int64]bool, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(map[int64]bool), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
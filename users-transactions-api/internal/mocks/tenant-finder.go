@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"context"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTenantFinder struct {
+	mock.Mock
+}
+
+func (mock *MockTenantFinder) FindOne(ctx context.Context, tenantId int32) (*infra.Tenant, error) {
+	args := mock.Called()
+	result := args.Get(0)
+
+	if result != nil {
+		return result.(*infra.Tenant), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
@@ -0,0 +1,11 @@
+package ports
+
+type Mailer interface {
+	Send(Mail) error
+}
+
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
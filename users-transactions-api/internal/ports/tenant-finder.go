@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+// TenantFinder is implemented by the FindOneTenantUseCase, it lets the
+// packages imported by the tenant usecases refuse suspended tenants.
+type TenantFinder interface {
+	FindOne(ctx context.Context, tenantId int32) (*infra.Tenant, error)
+}
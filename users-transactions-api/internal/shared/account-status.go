@@ -0,0 +1,75 @@
+package shared
+
+import "slices"
+
+const (
+	AccountStatusPending   = "pending"
+	AccountStatusActive    = "active"
+	AccountStatusFrozen    = "frozen"
+	AccountStatusSuspended = "suspended"
+	AccountStatusClosed    = "closed"
+)
+
+// AccountOperation is something an account may be blocked from doing
+// depending on its status.
+type AccountOperation string
+
+const (
+	AccountOperationCreateCard AccountOperation = "card creation"
+	AccountOperationDebit      AccountOperation = "debit"
+	AccountOperationCredit     AccountOperation = "credit"
+	AccountOperationReport     AccountOperation = "report"
+)
+
+// accountTransitions lists the statuses each status can move to. Closed is
+// terminal.
+var accountTransitions = map[string][]string{
+	AccountStatusPending:   {AccountStatusActive, AccountStatusClosed},
+	AccountStatusActive:    {AccountStatusFrozen, AccountStatusSuspended, AccountStatusClosed},
+	AccountStatusFrozen:    {AccountStatusActive, AccountStatusSuspended, AccountStatusClosed},
+	AccountStatusSuspended: {AccountStatusActive, AccountStatusClosed},
+	AccountStatusClosed:    {},
+}
+
+// accountBlockedOperations lists what each status forbids. A frozen account
+// still receives credits so pending refunds are not lost, and a suspended
+// account is under review so even its reports are withheld.
+var accountBlockedOperations = map[string][]AccountOperation{
+	AccountStatusPending: {
+		AccountOperationCreateCard, AccountOperationDebit, AccountOperationCredit,
+	},
+	AccountStatusActive: {},
+	AccountStatusFrozen: {
+		AccountOperationCreateCard, AccountOperationDebit,
+	},
+	AccountStatusSuspended: {
+		AccountOperationCreateCard, AccountOperationDebit, AccountOperationCredit, AccountOperationReport,
+	},
+	AccountStatusClosed: {
+		AccountOperationCreateCard, AccountOperationDebit, AccountOperationCredit,
+	},
+}
+
+func IsAccountStatus(status string) bool {
+	_, ok := accountTransitions[status]
+	return ok
+}
+
+func CanTransitionAccount(from string, to string) bool {
+	return slices.Contains(accountTransitions[from], to)
+}
+
+// CheckAccountOperation returns an AccountStatusError when the status blocks
+// the operation. Unknown statuses block everything.
+func CheckAccountOperation(status string, operation AccountOperation) error {
+	blocked, ok := accountBlockedOperations[status]
+
+	if !ok || slices.Contains(blocked, operation) {
+		return &AccountStatusError{
+			Status:    status,
+			Operation: operation,
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,9 @@
 package shared
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type AlreadyExistsError struct {
 	Object string
@@ -36,8 +39,68 @@ func (e *EntityNotFoundError) Error() string {
 	return fmt.Sprintf("%s not found with id %v", e.Object, e.Id)
 }
 
-type InactiveAccountError struct{}
+type AccountStatusError struct {
+	Status    string
+	Operation AccountOperation
+}
+
+func (e *AccountStatusError) Error() string {
+	return fmt.Sprintf("%s is not allowed for a %s account", e.Operation, e.Status)
+}
+
+type InvalidTransitionError struct {
+	From string
+	To   string
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("account cannot change from %s to %s", e.From, e.To)
+}
+
+type UnauthorizedError struct {
+	Message string
+}
+
+func (e *UnauthorizedError) Error() string {
+	return e.Message
+}
+
+type ForbiddenError struct {
+	Message string
+}
+
+func (e *ForbiddenError) Error() string {
+	return e.Message
+}
+
+// QuotaExceededError is returned when a tenant reached one of its hard
+// limits. RetryAfter is zero for limits that are not reset over time.
+type QuotaExceededError struct {
+	Quota      string
+	Limit      int32
+	RetryAfter time.Duration
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s quota of %d exceeded", e.Quota, e.Limit)
+}
+
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return "rate limit exceeded"
+}
+
+// PreconditionFailedError is returned when a change was made against a
+// stale version of an entity. Version is the current one.
+type PreconditionFailedError struct {
+	Object  string
+	Id      interface{}
+	Version int32
+}
 
-func (e *InactiveAccountError) Error() string {
-	return "Card cannot be created to an inactive account"
+func (e *PreconditionFailedError) Error() string {
+	return fmt.Sprintf("%s with id %v was modified, current version is %d", e.Object, e.Id, e.Version)
 }
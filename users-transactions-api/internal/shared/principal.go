@@ -0,0 +1,41 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Principal is the authenticated caller of a request, either an api key
+// (ApiKeyId set) or a user session (UserId and Role set).
+type Principal struct {
+	TenantId int32
+	ApiKeyId int32
+	UserId   int32
+	Role     string
+	Scopes   []string
+}
+
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// Actor identifies the principal in audit records.
+func (p *Principal) Actor() string {
+	if p.UserId != 0 {
+		return fmt.Sprintf("user:%d", p.UserId)
+	}
+
+	return fmt.Sprintf("api_key:%d", p.ApiKeyId)
+}
+
+type principalKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok
+}
@@ -0,0 +1,33 @@
+package shared
+
+import (
+	"context"
+	"strings"
+)
+
+const (
+	// ReadYourWritesHeader set to true sends the reads of the request to
+	// the primary database, so they see the writes of earlier requests.
+	ReadYourWritesHeader = "X-Read-Your-Writes"
+	// ReadYourWritesMetadata is the same header in gRPC calls.
+	ReadYourWritesMetadata = "x-read-your-writes"
+)
+
+type readYourWritesKey struct{}
+
+// ContextWithReadYourWrites keeps the reads made with the returned context
+// on the primary database.
+func ContextWithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readYourWritesKey{}, true)
+}
+
+func ReadYourWritesFromContext(ctx context.Context) bool {
+	readYourWrites, _ := ctx.Value(readYourWritesKey{}).(bool)
+	return readYourWrites
+}
+
+// IsReadYourWrites tells whether the value of the header or metadata asks
+// for read-your-writes.
+func IsReadYourWrites(value string) bool {
+	return strings.EqualFold(strings.TrimSpace(value), "true")
+}
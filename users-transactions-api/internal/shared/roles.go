@@ -0,0 +1,50 @@
+package shared
+
+const (
+	RoleOwner    = "owner"
+	RoleAdmin    = "admin"
+	RoleOperator = "operator"
+	RoleViewer   = "viewer"
+)
+
+var readScopes = []string{
+	ScopeAccountsRead,
+	ScopeCardsRead,
+	ScopeTransactionsRead,
+	ScopeReportsRead,
+	ScopeSettingsRead,
+}
+
+var operatorScopes = append([]string{
+	ScopeAccountsWrite,
+	ScopeCardsWrite,
+	ScopeTransactionsWrite,
+}, readScopes...)
+
+var adminScopes = append([]string{
+	ScopeApiKeysRead,
+	ScopeApiKeysWrite,
+	ScopeUsersRead,
+	ScopeUsersWrite,
+	ScopeSettingsWrite,
+	ScopePrivacyRead,
+	ScopePrivacyWrite,
+}, operatorScopes...)
+
+var roleScopes = map[string][]string{
+	RoleOwner:    adminScopes,
+	RoleAdmin:    adminScopes,
+	RoleOperator: operatorScopes,
+	RoleViewer:   readScopes,
+}
+
+func IsValidRole(role string) bool {
+	_, ok := roleScopes[role]
+	return ok
+}
+
+// RoleScopes returns the permissions granted to a role, using the same
+// vocabulary as api key scopes.
+func RoleScopes(role string) []string {
+	return roleScopes[role]
+}
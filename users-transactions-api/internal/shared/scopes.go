@@ -0,0 +1,42 @@
+package shared
+
+const (
+	ScopeAccountsRead      = "accounts:read"
+	ScopeAccountsWrite     = "accounts:write"
+	ScopeCardsRead         = "cards:read"
+	ScopeCardsWrite        = "cards:write"
+	ScopeTransactionsRead  = "transactions:read"
+	ScopeTransactionsWrite = "transactions:write"
+	ScopeReportsRead       = "reports:read"
+	ScopeApiKeysRead       = "api-keys:read"
+	ScopeApiKeysWrite      = "api-keys:write"
+	ScopeUsersRead         = "users:read"
+	ScopeUsersWrite        = "users:write"
+	ScopeSettingsRead      = "settings:read"
+	ScopeSettingsWrite     = "settings:write"
+	ScopePrivacyRead       = "privacy:read"
+	ScopePrivacyWrite      = "privacy:write"
+)
+
+var scopes = map[string]struct{}{
+	ScopeAccountsRead:      {},
+	ScopeAccountsWrite:     {},
+	ScopeCardsRead:         {},
+	ScopeCardsWrite:        {},
+	ScopeTransactionsRead:  {},
+	ScopeTransactionsWrite: {},
+	ScopeReportsRead:       {},
+	ScopeApiKeysRead:       {},
+	ScopeApiKeysWrite:      {},
+	ScopeUsersRead:         {},
+	ScopeUsersWrite:        {},
+	ScopeSettingsRead:      {},
+	ScopeSettingsWrite:     {},
+	ScopePrivacyRead:       {},
+	ScopePrivacyWrite:      {},
+}
+
+func IsValidScope(scope string) bool {
+	_, ok := scopes[scope]
+	return ok
+}
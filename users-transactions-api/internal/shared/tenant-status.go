@@ -0,0 +1,6 @@
+package shared
+
+const (
+	TenantStatusActive    = "active"
+	TenantStatusSuspended = "suspended"
+)
@@ -0,0 +1,44 @@
+package shared
+
+// TransactionDirection tells whether a transaction charges the card or
+// gives money back to it. Credits are stored with a negative value, so the
+// card amount stays the sum of its transactions.
+type TransactionDirection string
+
+const (
+	TransactionDirectionDebit  TransactionDirection = "debit"
+	TransactionDirectionCredit TransactionDirection = "credit"
+)
+
+var transactionDirectionOperations = map[TransactionDirection]AccountOperation{
+	TransactionDirectionDebit:  AccountOperationDebit,
+	TransactionDirectionCredit: AccountOperationCredit,
+}
+
+func IsTransactionDirection(direction TransactionDirection) bool {
+	_, ok := transactionDirectionOperations[direction]
+	return ok
+}
+
+// Operation is the account operation checked against the account status.
+func (d TransactionDirection) Operation() AccountOperation {
+	return transactionDirectionOperations[d]
+}
+
+// SignedValue is the value stored for a transaction of value cents.
+func (d TransactionDirection) SignedValue(value int64) int64 {
+	if d == TransactionDirectionCredit {
+		return -value
+	}
+
+	return value
+}
+
+// TransactionDirectionOf is the direction of a stored transaction value.
+func TransactionDirectionOf(value int64) TransactionDirection {
+	if value < 0 {
+		return TransactionDirectionCredit
+	}
+
+	return TransactionDirectionDebit
+}
@@ -0,0 +1,114 @@
+package telemetry
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DBTX is the database interface of the sqlc queries, implemented by both
+// *sql.DB and *sql.Tx.
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+// TraceDB records a span for every statement, named after the sqlc query.
+func TraceDB(db DBTX) DBTX {
+	return traceDB(db, otel.GetTracerProvider())
+}
+
+func traceDB(db DBTX, provider trace.TracerProvider) DBTX {
+	return &tracedDB{
+		db:     db,
+		tracer: provider.Tracer(instrumentationName),
+	}
+}
+
+type tracedDB struct {
+	db     DBTX
+	tracer trace.Tracer
+}
+
+func (t *tracedDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := t.start(ctx, query)
+	result, err := t.db.ExecContext(ctx, query, args...)
+	end(span, err)
+	return result, err
+}
+
+func (t *tracedDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	ctx, span := t.start(ctx, query)
+	stmt, err := t.db.PrepareContext(ctx, query)
+	end(span, err)
+	return stmt, err
+}
+
+func (t *tracedDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := t.start(ctx, query)
+	rows, err := t.db.QueryContext(ctx, query, args...)
+	end(span, err)
+	return rows, err
+}
+
+func (t *tracedDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span := t.start(ctx, query)
+	row := t.db.QueryRowContext(ctx, query, args...)
+	end(span, row.Err())
+	return row
+}
+
+func (t *tracedDB) start(ctx context.Context, query string) (context.Context, trace.Span) {
+	name, statement := parseQuery(query)
+
+	return t.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperation(operation(statement)),
+			semconv.DBStatement(statement),
+		),
+	)
+}
+
+func end(span trace.Span, err error) {
+	if err != nil && err != sql.ErrNoRows {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// parseQuery splits the "-- name: GetAccount :one" header written by sqlc
+// from the statement. Statements without it are named after their operation.
+func parseQuery(query string) (name string, statement string) {
+	statement = strings.TrimSpace(query)
+	header, rest, found := strings.Cut(statement, "\n")
+
+	if found && strings.HasPrefix(header, "-- name: ") {
+		fields := strings.Fields(strings.TrimPrefix(header, "-- name: "))
+
+		if len(fields) > 0 {
+			return "sql." + fields[0], strings.TrimSpace(rest)
+		}
+	}
+
+	return "sql." + operation(statement), statement
+}
+
+func operation(statement string) string {
+	fields := strings.Fields(statement)
+
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,97 @@
+package telemetry
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type fakeDB struct {
+	err error
+}
+
+func (f *fakeDB) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, f.err
+}
+
+func (f *fakeDB) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, f.err
+}
+
+func (f *fakeDB) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, f.err
+}
+
+func (f *fakeDB) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
+	return &sql.Row{}
+}
+
+func TestTraceDB(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := NewTracerProvider("test", exporter)
+
+	t.Run("Names the span after the sqlc query", func(t *testing.T) {
+		defer exporter.Reset()
+
+		ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+		db := traceDB(&fakeDB{}, provider)
+
+		_, err := db.QueryContext(ctx, "-- name: ListAccounts :many\nSELECT id FROM accounts\nWHERE tenant_id = $1\n", 1)
+		parent.End()
+
+		spans := exporter.GetSpans()
+
+		assert.NoError(t, err)
+		assert.Len(t, spans, 2)
+		assert.Equal(t, "sql.ListAccounts", spans[0].Name)
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+		assert.Contains(t, spans[0].Attributes, attribute.String("db.system", "postgresql"))
+		assert.Contains(t, spans[0].Attributes, attribute.String("db.operation", "SELECT"))
+		assert.Contains(t, spans[0].Attributes, attribute.String("db.statement", "SELECT id FROM accounts\nWHERE tenant_id = $1"))
+	})
+
+	t.Run("Names plain statements after the operation", func(t *testing.T) {
+		defer exporter.Reset()
+
+		db := traceDB(&fakeDB{}, provider)
+		db.QueryRowContext(context.Background(), "select set_config('app.tenant_id', $1, true)", "1")
+
+		spans := exporter.GetSpans()
+
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "sql.SELECT", spans[0].Name)
+		assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	})
+
+	t.Run("Records the error", func(t *testing.T) {
+		defer exporter.Reset()
+
+		db := traceDB(&fakeDB{err: errors.New("connection refused")}, provider)
+		_, err := db.ExecContext(context.Background(), "-- name: DeleteAccount :exec\nDELETE FROM accounts")
+
+		spans := exporter.GetSpans()
+
+		assert.Error(t, err)
+		assert.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+		assert.Equal(t, "connection refused", spans[0].Status.Description)
+	})
+
+	t.Run("Not found rows are not errors", func(t *testing.T) {
+		defer exporter.Reset()
+
+		db := traceDB(&fakeDB{err: sql.ErrNoRows}, provider)
+		db.ExecContext(context.Background(), "-- name: DeleteAccount :exec\nDELETE FROM accounts")
+
+		spans := exporter.GetSpans()
+
+		assert.Len(t, spans, 1)
+		assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	})
+}
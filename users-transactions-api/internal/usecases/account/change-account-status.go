@@ -0,0 +1,160 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/metrics"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+// StatusChange is a requested account status along with who asked for it
+// and why, both kept in the status history. A non zero Version makes the
+// change conditional on the account still being at that version.
+type StatusChange struct {
+	Status  string
+	Reason  string
+	Actor   string
+	Version int32
+}
+
+type ChangeAccountStatusUsecase struct {
+	repo infra.QuerierTx
+}
+
+func NewChangeAccountStatusUsecase(repo infra.QuerierTx) *ChangeAccountStatusUsecase {
+	return &ChangeAccountStatusUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *ChangeAccountStatusUsecase) Change(ctx context.Context, tenantId int32, accountId int32,
+	change StatusChange) (*infra.Account, error) {
+	err := statusChangeInputValidation(change)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedAccount infra.Account
+	var previousStatus string
+
+	err = uc.repo.WithTenant(ctx, tenantId, func(q infra.Querier) error {
+		// the row lock keeps concurrent changes from both passing the
+		// transition check
+		account, err := q.GetAccountForUpdate(ctx, infra.GetAccountForUpdateParams{
+			TenantID: tenantId,
+			ID:       accountId,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if change.Version != 0 && change.Version != account.Version {
+			return &shared.PreconditionFailedError{
+				Object:  "account",
+				Id:      accountId,
+				Version: account.Version,
+			}
+		}
+
+		previousStatus = account.Status
+
+		if !shared.CanTransitionAccount(account.Status, change.Status) {
+			return &shared.InvalidTransitionError{
+				From: account.Status,
+				To:   change.Status,
+			}
+		}
+
+		currentTime := sql.NullTime{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		}
+
+		deletedAt := account.DeletedAt
+
+		if change.Status == shared.AccountStatusClosed {
+			deletedAt = currentTime
+		}
+
+		updatedAccount, err = q.UpdateAccount(ctx, infra.UpdateAccountParams{
+			ID:        account.ID,
+			Status:    change.Status,
+			UpdatedAt: currentTime,
+			DeletedAt: deletedAt,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateAccountStatusHistory(ctx, infra.CreateAccountStatusHistoryParams{
+			AccountID: account.ID,
+			FromStatus: sql.NullString{
+				String: account.Status,
+				Valid:  true,
+			},
+			ToStatus: change.Status,
+			Reason:   change.Reason,
+			Actor:    change.Actor,
+		})
+
+		return err
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &shared.EntityNotFoundError{
+				Object: "account",
+				Id:     accountId,
+			}
+		}
+
+		switch err.(type) {
+		case *shared.InvalidTransitionError, *shared.PreconditionFailedError:
+			return nil, err
+		}
+
+		slog.ErrorContext(
+			ctx,
+			"error when change account status",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	if previousStatus == shared.AccountStatusActive && updatedAccount.Status != shared.AccountStatusActive {
+		metrics.AccountInactivated(updatedAccount.Status)
+	}
+
+	return &updatedAccount, nil
+}
+
+func statusChangeInputValidation(change StatusChange) error {
+	valErr := &shared.ValidationError{
+		Errors: make(map[string]string),
+	}
+
+	if !shared.IsAccountStatus(change.Status) {
+		valErr.AddError("status", "must be one of pending, active, frozen, suspended or closed")
+	}
+
+	if len(change.Reason) == 0 {
+		valErr.AddError("reason", "cannot be empty")
+	}
+
+	if len(change.Reason) > 255 {
+		valErr.AddError("reason", "must have at most 255 characters")
+	}
+
+	if valErr.HasErrors() {
+		return valErr
+	}
+
+	return nil
+}
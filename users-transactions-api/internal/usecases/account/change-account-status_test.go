@@ -0,0 +1,183 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeAccountStatusUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+	account := infra.Account{
+		ID:       1,
+		TenantID: 1,
+		Status:   shared.AccountStatusActive,
+	}
+	change := StatusChange{
+		Status: shared.AccountStatusFrozen,
+		Reason: "card reported lost",
+		Actor:  "user:1",
+	}
+
+	sut := NewChangeAccountStatusUsecase(mockRepo)
+
+	t.Run("Error input validation", func(t *testing.T) {
+		result, err := sut.Change(context.Background(), account.TenantID, account.ID, StatusChange{Status: "inactive"})
+
+		assert.Nil(t, result)
+		assert.Equal(t, &shared.ValidationError{
+			Errors: map[string]string{
+				"status": "must be one of pending, active, frozen, suspended or closed",
+				"reason": "cannot be empty",
+			},
+		}, err)
+	})
+
+	t.Run("Error account not found by id", func(t *testing.T) {
+		mockRepo.On("GetAccountForUpdate").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetAccountForUpdate").Unset()
+
+		result, err := sut.Change(context.Background(), account.TenantID, account.ID, change)
+
+		assert.Nil(t, result)
+		assert.Equal(t, "account not found with id 1", err.Error())
+	})
+
+	t.Run("Error transition not allowed", func(t *testing.T) {
+		closedAccount := account
+		closedAccount.Status = shared.AccountStatusClosed
+
+		mockRepo.On("GetAccountForUpdate").Return(closedAccount, nil)
+		defer mockRepo.On("GetAccountForUpdate").Unset()
+
+		result, err := sut.Change(context.Background(), account.TenantID, account.ID, StatusChange{
+			Status: shared.AccountStatusActive,
+			Reason: "customer came back",
+			Actor:  "user:1",
+		})
+
+		assert.Nil(t, result)
+		assert.Equal(t, &shared.InvalidTransitionError{
+			From: shared.AccountStatusClosed,
+			To:   shared.AccountStatusActive,
+		}, err)
+	})
+
+	t.Run("Error stale version", func(t *testing.T) {
+		versionedAccount := account
+		versionedAccount.Version = 3
+
+		mockRepo.On("GetAccountForUpdate").Return(versionedAccount, nil)
+		defer mockRepo.On("GetAccountForUpdate").Unset()
+
+		staleChange := change
+		staleChange.Version = 2
+
+		result, err := sut.Change(context.Background(), account.TenantID, account.ID, staleChange)
+
+		assert.Nil(t, result)
+		assert.Equal(t, &shared.PreconditionFailedError{
+			Object:  "account",
+			Id:      account.ID,
+			Version: 3,
+		}, err)
+	})
+
+	t.Run("Error to record status history", func(t *testing.T) {
+		expectedErr := errors.New("internal repo error")
+
+		mockRepo.On("GetAccountForUpdate").Return(account, nil)
+		defer mockRepo.On("GetAccountForUpdate").Unset()
+
+		mockRepo.On("UpdateAccount").Return(account, nil)
+		defer mockRepo.On("UpdateAccount").Unset()
+
+		mockRepo.On("CreateAccountStatusHistory").Return(nil, expectedErr)
+		defer mockRepo.On("CreateAccountStatusHistory").Unset()
+
+		result, err := sut.Change(context.Background(), account.TenantID, account.ID, change)
+
+		assert.Nil(t, result)
+		assert.Equal(t, expectedErr.Error(), err.Error())
+	})
+
+	t.Run("Success to change account status", func(t *testing.T) {
+		frozenAccount := account
+		frozenAccount.Status = shared.AccountStatusFrozen
+
+		mockRepo.On("GetAccountForUpdate").Return(account, nil)
+		defer mockRepo.On("GetAccountForUpdate").Unset()
+
+		mockRepo.On("UpdateAccount").Return(frozenAccount, nil)
+		defer mockRepo.On("UpdateAccount").Unset()
+
+		mockRepo.On("CreateAccountStatusHistory").Return(infra.AccountStatusHistory{}, nil)
+		defer mockRepo.On("CreateAccountStatusHistory").Unset()
+
+		result, err := sut.Change(context.Background(), account.TenantID, account.ID, change)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &frozenAccount, result)
+	})
+}
+
+func TestAccountStatusRules(t *testing.T) {
+	t.Parallel()
+
+	transitions := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{shared.AccountStatusPending, shared.AccountStatusActive, true},
+		{shared.AccountStatusPending, shared.AccountStatusFrozen, false},
+		{shared.AccountStatusActive, shared.AccountStatusFrozen, true},
+		{shared.AccountStatusActive, shared.AccountStatusPending, false},
+		{shared.AccountStatusFrozen, shared.AccountStatusActive, true},
+		{shared.AccountStatusSuspended, shared.AccountStatusFrozen, false},
+		{shared.AccountStatusSuspended, shared.AccountStatusClosed, true},
+		{shared.AccountStatusClosed, shared.AccountStatusActive, false},
+		{shared.AccountStatusActive, shared.AccountStatusActive, false},
+	}
+
+	for _, tt := range transitions {
+		assert.Equal(t, tt.allowed, shared.CanTransitionAccount(tt.from, tt.to), "%s -> %s", tt.from, tt.to)
+	}
+
+	blocked := map[string][]shared.AccountOperation{
+		shared.AccountStatusPending:   {shared.AccountOperationCreateCard, shared.AccountOperationDebit, shared.AccountOperationCredit},
+		shared.AccountStatusActive:    {},
+		shared.AccountStatusFrozen:    {shared.AccountOperationCreateCard, shared.AccountOperationDebit},
+		shared.AccountStatusSuspended: {shared.AccountOperationCreateCard, shared.AccountOperationDebit, shared.AccountOperationCredit, shared.AccountOperationReport},
+		shared.AccountStatusClosed:    {shared.AccountOperationCreateCard, shared.AccountOperationDebit, shared.AccountOperationCredit},
+	}
+
+	operations := []shared.AccountOperation{
+		shared.AccountOperationCreateCard,
+		shared.AccountOperationDebit,
+		shared.AccountOperationCredit,
+		shared.AccountOperationReport,
+	}
+
+	for status, blockedOperations := range blocked {
+		for _, operation := range operations {
+			err := shared.CheckAccountOperation(status, operation)
+
+			if slices.Contains(blockedOperations, operation) {
+				assert.Error(t, err, "%s should block %s", status, operation)
+			} else {
+				assert.NoError(t, err, "%s should allow %s", status, operation)
+			}
+		}
+	}
+
+	assert.Error(t, shared.CheckAccountOperation("inactive", shared.AccountOperationReport))
+}
@@ -0,0 +1,33 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+// CheckAccountOperation runs in the transaction of the operation and checks
+// that the account status allows it. The account row is read with a shared
+// lock: a status change, which locks it for update, waits for the operation
+// to commit, and an operation started after the change sees the new status.
+func CheckAccountOperation(ctx context.Context, q infra.Querier, tenantId int32, accountId int32,
+	operation shared.AccountOperation) error {
+	account, err := q.GetAccountForShare(ctx, infra.GetAccountForShareParams{
+		TenantID: tenantId,
+		ID:       accountId,
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &shared.EntityNotFoundError{
+				Object: "account",
+				Id:     accountId,
+			}
+		}
+		return err
+	}
+
+	return shared.CheckAccountOperation(account.Status, operation)
+}
@@ -5,26 +5,71 @@ import (
 	"log/slog"
 
 	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	quotaUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/quota"
 )
 
 type CreateAccountUsecase struct {
-	repo infra.Querier
+	repo         infra.QuerierTx
+	quotaUsecase *quotaUsecases.QuotaUsecase
 }
 
-func NewCreateAccountUsecase(repo infra.Querier) *CreateAccountUsecase {
+func NewCreateAccountUsecase(repo infra.QuerierTx, quotaUsecase *quotaUsecases.QuotaUsecase) *CreateAccountUsecase {
 	return &CreateAccountUsecase{
-		repo: repo,
+		repo:         repo,
+		quotaUsecase: quotaUsecase,
 	}
 }
 
-func (uc *CreateAccountUsecase) Create(tenantId int32) (*infra.Account, error) {
-	savedAccount, err := uc.repo.CreateAccount(context.Background(), infra.CreateAccountParams{
-		TenantID: tenantId,
-		Status:   "active",
+// Create opens an account as active, or as pending when it still has to be
+// reviewed before use.
+func (uc *CreateAccountUsecase) Create(ctx context.Context, tenantId int32, status string, actor string) (*infra.Account, error) {
+	if len(status) == 0 {
+		status = shared.AccountStatusActive
+	}
+
+	if status != shared.AccountStatusActive && status != shared.AccountStatusPending {
+		valErr := &shared.ValidationError{
+			Errors: make(map[string]string),
+		}
+		valErr.AddError("status", "must be pending or active")
+		return nil, valErr
+	}
+
+	var savedAccount infra.Account
+
+	err := uc.repo.WithTenant(ctx, tenantId, func(q infra.Querier) (err error) {
+		err = uc.quotaUsecase.CheckAccounts(ctx, q, tenantId)
+
+		if err != nil {
+			return err
+		}
+
+		savedAccount, err = q.CreateAccount(ctx, infra.CreateAccountParams{
+			TenantID: tenantId,
+			Status:   status,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateAccountStatusHistory(ctx, infra.CreateAccountStatusHistoryParams{
+			AccountID: savedAccount.ID,
+			ToStatus:  status,
+			Reason:    "account opened",
+			Actor:     actor,
+		})
+		return err
 	})
 
+	if _, ok := err.(*shared.QuotaExceededError); ok {
+		return nil, err
+	}
+
 	if err != nil {
-		slog.Error(
+		slog.ErrorContext(
+			ctx,
 			"error creating account",
 			slog.String("err", err.Error()),
 		)
@@ -1,11 +1,16 @@
 package usecases
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"testing"
 
 	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	quotaUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/quota"
+	settingsUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/settings"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -13,13 +18,18 @@ func TestCreateAccountUsecase(t *testing.T) {
 	t.Parallel()
 
 	mockRepo := new(mocks.MockRepository)
+
+	// tenants without settings are not limited by quotas
+	mockRepo.On("GetTenantSettings").Return(nil, sql.ErrNoRows)
+	findTenantSettingsUsecase := settingsUsecases.NewFindTenantSettingsUsecase(mockRepo, settingsUsecases.NewSettingsCache(0))
+	quotaUsecase := quotaUsecases.NewQuotaUsecase(findTenantSettingsUsecase)
 	account := infra.Account{
 		ID:       1,
 		TenantID: 1,
 		Status:   "active",
 	}
 
-	sut := NewCreateAccountUsecase(mockRepo)
+	sut := NewCreateAccountUsecase(mockRepo, quotaUsecase)
 
 	t.Run("Error to create account", func(t *testing.T) {
 		expectedErr := errors.New("internal repo error")
@@ -27,7 +37,31 @@ func TestCreateAccountUsecase(t *testing.T) {
 		mockRepo.On("CreateAccount").Return(nil, expectedErr)
 		defer mockRepo.On("CreateAccount").Unset()
 
-		result, err := sut.Create(1)
+		result, err := sut.Create(context.Background(), 1, "", "user:1")
+
+		assert.Nil(t, result)
+		assert.Equal(t, expectedErr.Error(), err.Error())
+	})
+
+	t.Run("Error invalid initial status", func(t *testing.T) {
+		result, err := sut.Create(context.Background(), 1, shared.AccountStatusFrozen, "user:1")
+
+		assert.Nil(t, result)
+		assert.Equal(t, &shared.ValidationError{
+			Errors: map[string]string{"status": "must be pending or active"},
+		}, err)
+	})
+
+	t.Run("Error to record status history", func(t *testing.T) {
+		expectedErr := errors.New("internal repo error")
+
+		mockRepo.On("CreateAccount").Return(account, nil)
+		defer mockRepo.On("CreateAccount").Unset()
+
+		mockRepo.On("CreateAccountStatusHistory").Return(nil, expectedErr)
+		defer mockRepo.On("CreateAccountStatusHistory").Unset()
+
+		result, err := sut.Create(context.Background(), 1, "", "user:1")
 
 		assert.Nil(t, result)
 		assert.Equal(t, expectedErr.Error(), err.Error())
@@ -37,7 +71,10 @@ func TestCreateAccountUsecase(t *testing.T) {
 		mockRepo.On("CreateAccount").Return(account, nil)
 		defer mockRepo.On("CreateAccount").Unset()
 
-		result, err := sut.Create(1)
+		mockRepo.On("CreateAccountStatusHistory").Return(infra.AccountStatusHistory{}, nil)
+		defer mockRepo.On("CreateAccountStatusHistory").Unset()
+
+		result, err := sut.Create(context.Background(), 1, "", "user:1")
 
 		assert.Nil(t, err)
 		assert.Equal(t, account, *result)
@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+type FindAccountStatusHistoryUsecase struct {
+	repo infra.QuerierTx
+}
+
+func NewFindAccountStatusHistoryUsecase(repo infra.QuerierTx) *FindAccountStatusHistoryUsecase {
+	return &FindAccountStatusHistoryUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *FindAccountStatusHistoryUsecase) FindAll(ctx context.Context, tenantId int32,
+	accountId int32) ([]infra.AccountStatusHistory, error) {
+	var history []infra.AccountStatusHistory
+
+	err := uc.repo.WithTenant(ctx, tenantId, func(q infra.Querier) error {
+		account, err := q.GetAccount(ctx, infra.GetAccountParams{
+			TenantID: tenantId,
+			ID:       accountId,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		history, err = q.GetAccountStatusHistory(ctx, account.ID)
+		return err
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &shared.EntityNotFoundError{
+				Object: "account",
+				Id:     accountId,
+			}
+		}
+		slog.ErrorContext(
+			ctx,
+			"error to find account status history",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	return history, nil
+}
@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindAccountStatusHistoryUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+	account := infra.Account{
+		ID:       1,
+		TenantID: 1,
+		Status:   shared.AccountStatusFrozen,
+	}
+	history := []infra.AccountStatusHistory{
+		{ID: 1, AccountID: 1, ToStatus: shared.AccountStatusActive, Reason: "account opened", Actor: "user:1"},
+		{
+			ID:         2,
+			AccountID:  1,
+			FromStatus: sql.NullString{String: shared.AccountStatusActive, Valid: true},
+			ToStatus:   shared.AccountStatusFrozen,
+			Reason:     "card reported lost",
+			Actor:      "api_key:3",
+		},
+	}
+
+	sut := NewFindAccountStatusHistoryUsecase(mockRepo)
+
+	t.Run("Error account not found by id", func(t *testing.T) {
+		mockRepo.On("GetAccount").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetAccount").Unset()
+
+		result, err := sut.FindAll(context.Background(), account.TenantID, account.ID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, "account not found with id 1", err.Error())
+	})
+
+	t.Run("Error to find status history", func(t *testing.T) {
+		expectedErr := errors.New("internal repo error")
+
+		mockRepo.On("GetAccount").Return(account, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetAccountStatusHistory").Return(nil, expectedErr)
+		defer mockRepo.On("GetAccountStatusHistory").Unset()
+
+		result, err := sut.FindAll(context.Background(), account.TenantID, account.ID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, expectedErr.Error(), err.Error())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.On("GetAccount").Return(account, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetAccountStatusHistory").Return(history, nil)
+		defer mockRepo.On("GetAccountStatusHistory").Unset()
+
+		result, err := sut.FindAll(context.Background(), account.TenantID, account.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, history, result)
+	})
+}
@@ -8,22 +8,28 @@ import (
 )
 
 type FindAllAccountsUsecase struct {
-	repo infra.Querier
+	repo infra.QuerierTx
 }
 
-func NewFindAllAccountsUsecase(repo infra.Querier) *FindAllAccountsUsecase {
+func NewFindAllAccountsUsecase(repo infra.QuerierTx) *FindAllAccountsUsecase {
 	return &FindAllAccountsUsecase{
 		repo: repo,
 	}
 }
 
-func (uc *FindAllAccountsUsecase) FindAll(tenantId int32) ([]infra.Account, error) {
+func (uc *FindAllAccountsUsecase) FindAll(ctx context.Context, tenantId int32) ([]infra.Account, error) {
 	accounts := make([]infra.Account, 0)
 
-	result, err := uc.repo.GetAccounts(context.Background(), tenantId)
+	var result []infra.Account
+
+	err := uc.repo.WithTenant(ctx, tenantId, func(q infra.Querier) (err error) {
+		result, err = q.GetAccounts(ctx, tenantId)
+		return err
+	})
 
 	if err != nil {
-		slog.Error(
+		slog.ErrorContext(
+			ctx,
 			"error to find all accounts",
 			slog.String("err", err.Error()),
 		)
@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -30,7 +31,7 @@ func TestFindAllAccountsUsecase(t *testing.T) {
 		mockRepo.On("GetAccounts").Return(nil, expectedErr)
 		defer mockRepo.On("GetAccounts").Unset()
 
-		result, err := sut.FindAll(tenantId)
+		result, err := sut.FindAll(context.Background(), tenantId)
 
 		assert.Nil(t, result)
 		assert.Equal(t, expectedErr.Error(), err.Error())
@@ -40,7 +41,7 @@ func TestFindAllAccountsUsecase(t *testing.T) {
 		mockRepo.On("GetAccounts").Return(accounts, nil)
 		defer mockRepo.On("GetAccounts").Unset()
 
-		result, err := sut.FindAll(tenantId)
+		result, err := sut.FindAll(context.Background(), tenantId)
 
 		assert.Nil(t, err)
 		assert.Len(t, result, 1)
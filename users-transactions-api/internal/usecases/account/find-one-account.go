@@ -10,19 +10,24 @@ import (
 )
 
 type FindOneAccountUsecase struct {
-	repo infra.Querier
+	repo infra.QuerierTx
 }
 
-func NewFindOneAccountUsecase(repo infra.Querier) *FindOneAccountUsecase {
+func NewFindOneAccountUsecase(repo infra.QuerierTx) *FindOneAccountUsecase {
 	return &FindOneAccountUsecase{
 		repo: repo,
 	}
 }
 
-func (uc *FindOneAccountUsecase) FindOne(tenantId int32, accountId int32) (*infra.Account, error) {
-	account, err := uc.repo.GetAccount(context.Background(), infra.GetAccountParams{
-		TenantID: tenantId,
-		ID:       accountId,
+func (uc *FindOneAccountUsecase) FindOne(ctx context.Context, tenantId int32, accountId int32) (*infra.Account, error) {
+	var account infra.Account
+
+	err := uc.repo.WithTenant(ctx, tenantId, func(q infra.Querier) (err error) {
+		account, err = q.GetAccount(ctx, infra.GetAccountParams{
+			TenantID: tenantId,
+			ID:       accountId,
+		})
+		return err
 	})
 
 	if err != nil {
@@ -32,7 +37,8 @@ func (uc *FindOneAccountUsecase) FindOne(tenantId int32, accountId int32) (*infr
 				Id:     accountId,
 			}
 		}
-		slog.Error(
+		slog.ErrorContext(
+			ctx,
 			"error to find account by id",
 			slog.String("err", err.Error()),
 		)
@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"testing"
@@ -30,7 +31,7 @@ func TestFindOneAccountUsecase(t *testing.T) {
 		mockRepo.On("GetAccount").Return(nil, expectedErr)
 		defer mockRepo.On("GetAccount").Unset()
 
-		result, err := sut.FindOne(tenantId, account.ID)
+		result, err := sut.FindOne(context.Background(), tenantId, account.ID)
 
 		assert.Empty(t, result)
 		assert.Equal(t, expectedErr.Error(), err.Error())
@@ -40,7 +41,7 @@ func TestFindOneAccountUsecase(t *testing.T) {
 		mockRepo.On("GetAccount").Return(nil, sql.ErrNoRows)
 		defer mockRepo.On("GetAccount").Unset()
 
-		result, err := sut.FindOne(tenantId, account.ID)
+		result, err := sut.FindOne(context.Background(), tenantId, account.ID)
 
 		expectedErr := &shared.EntityNotFoundError{
 			Object: "account",
@@ -55,7 +56,7 @@ func TestFindOneAccountUsecase(t *testing.T) {
 		mockRepo.On("GetAccount").Return(account, nil)
 		defer mockRepo.On("GetAccount").Unset()
 
-		result, err := sut.FindOne(tenantId, account.ID)
+		result, err := sut.FindOne(context.Background(), tenantId, account.ID)
 
 		assert.Nil(t, err)
 		assert.Equal(t, *result, account)
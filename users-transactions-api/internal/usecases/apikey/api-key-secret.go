@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+)
+
+const apiKeyPrefix = "utk_"
+
+type apiKeySecret struct {
+	Plain        string
+	Prefix       string
+	HashedSecret string
+}
+
+func generateApiKey() (*apiKeySecret, error) {
+	id := make([]byte, 6)
+	secret := make([]byte, 32)
+
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	prefix := apiKeyPrefix + hex.EncodeToString(id)
+	plainSecret := hex.EncodeToString(secret)
+
+	return &apiKeySecret{
+		Plain:        prefix + "_" + plainSecret,
+		Prefix:       prefix,
+		HashedSecret: hashSecret(plainSecret),
+	}, nil
+}
+
+func parseApiKey(key string) (prefix string, secret string, ok bool) {
+	rest, found := strings.CutPrefix(key, apiKeyPrefix)
+
+	if !found {
+		return "", "", false
+	}
+
+	id, secret, found := strings.Cut(rest, "_")
+
+	if !found || len(id) == 0 || len(secret) == 0 {
+		return "", "", false
+	}
+
+	return apiKeyPrefix + id, secret, true
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func secretMatches(secret string, hashedSecret string) bool {
+	return subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(hashedSecret)) == 1
+}
+
+func IsApiKey(key string) bool {
+	return strings.HasPrefix(key, apiKeyPrefix)
+}
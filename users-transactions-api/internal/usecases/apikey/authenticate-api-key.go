@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+type AuthenticateApiKeyUsecase struct {
+	repo    infra.Querier
+	tracker *LastUsedTracker
+}
+
+func NewAuthenticateApiKeyUsecase(repo infra.Querier, tracker *LastUsedTracker) *AuthenticateApiKeyUsecase {
+	return &AuthenticateApiKeyUsecase{
+		repo:    repo,
+		tracker: tracker,
+	}
+}
+
+func (uc *AuthenticateApiKeyUsecase) Authenticate(ctx context.Context, key string) (*shared.Principal, error) {
+	invalidKeyErr := &shared.UnauthorizedError{Message: "invalid api key"}
+
+	prefix, secret, ok := parseApiKey(key)
+
+	if !ok {
+		return nil, invalidKeyErr
+	}
+
+	apiKey, err := uc.repo.GetApiKeyByPrefix(ctx, prefix)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, invalidKeyErr
+		}
+		slog.ErrorContext(
+			ctx,
+			"error to find api key by prefix",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	if apiKey.RevokedAt.Valid || !secretMatches(secret, apiKey.HashedSecret) {
+		return nil, invalidKeyErr
+	}
+
+	uc.tracker.Track(apiKey.ID, time.Now().UTC())
+
+	return &shared.Principal{
+		TenantId: apiKey.TenantID,
+		ApiKeyId: apiKey.ID,
+		Scopes:   apiKey.Scopes,
+	}, nil
+}
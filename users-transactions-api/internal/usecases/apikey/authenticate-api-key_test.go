@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticateApiKeyUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+	tracker := NewLastUsedTracker(mockRepo)
+
+	secret, err := generateApiKey()
+	assert.NoError(t, err)
+
+	apiKey := infra.ApiKey{
+		ID:           1,
+		TenantID:     1,
+		Prefix:       secret.Prefix,
+		HashedSecret: secret.HashedSecret,
+		Scopes:       []string{shared.ScopeAccountsRead},
+	}
+
+	sut := NewAuthenticateApiKeyUsecase(mockRepo, tracker)
+
+	t.Run("Error malformed api key", func(t *testing.T) {
+		result, err := sut.Authenticate(context.Background(), "not-an-api-key")
+
+		_, ok := err.(*shared.UnauthorizedError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+
+	t.Run("Error unknown api key", func(t *testing.T) {
+		mockRepo.On("GetApiKeyByPrefix").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetApiKeyByPrefix").Unset()
+
+		result, err := sut.Authenticate(context.Background(), secret.Plain)
+
+		_, ok := err.(*shared.UnauthorizedError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+
+	t.Run("Error wrong secret", func(t *testing.T) {
+		mockRepo.On("GetApiKeyByPrefix").Return(apiKey, nil)
+		defer mockRepo.On("GetApiKeyByPrefix").Unset()
+
+		result, err := sut.Authenticate(context.Background(), secret.Prefix+"_wrong")
+
+		_, ok := err.(*shared.UnauthorizedError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+
+	t.Run("Error revoked api key", func(t *testing.T) {
+		revokedApiKey := apiKey
+		revokedApiKey.RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+		mockRepo.On("GetApiKeyByPrefix").Return(revokedApiKey, nil)
+		defer mockRepo.On("GetApiKeyByPrefix").Unset()
+
+		result, err := sut.Authenticate(context.Background(), secret.Plain)
+
+		_, ok := err.(*shared.UnauthorizedError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+
+	t.Run("Success and flush last usage once", func(t *testing.T) {
+		mockRepo.On("GetApiKeyByPrefix").Return(apiKey, nil)
+		defer mockRepo.On("GetApiKeyByPrefix").Unset()
+
+		mockRepo.On("TouchApiKey").Return(nil)
+		defer mockRepo.On("TouchApiKey").Unset()
+
+		for i := 0; i < 3; i++ {
+			result, err := sut.Authenticate(context.Background(), secret.Plain)
+
+			assert.NoError(t, err)
+			assert.Equal(t, apiKey.TenantID, result.TenantId)
+			assert.True(t, result.HasScope(shared.ScopeAccountsRead))
+		}
+
+		mockRepo.AssertNotCalled(t, "TouchApiKey")
+
+		tracker.Flush(context.Background())
+
+		mockRepo.AssertNumberOfCalls(t, "TouchApiKey", 1)
+	})
+}
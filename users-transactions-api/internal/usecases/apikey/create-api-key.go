@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+type CreateApiKeyUsecase struct {
+	repo infra.Querier
+}
+
+func NewCreateApiKeyUsecase(repo infra.Querier) *CreateApiKeyUsecase {
+	return &CreateApiKeyUsecase{
+		repo: repo,
+	}
+}
+
+// Create returns the saved key together with its plain value, which is
+// never stored and cannot be recovered afterwards.
+func (uc *CreateApiKeyUsecase) Create(ctx context.Context, tenantId int32, name string, scopes []string) (*infra.ApiKey, string, error) {
+	err := apiKeyInputValidation(name, scopes)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	secret, err := generateApiKey()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	savedApiKey, err := uc.repo.CreateApiKey(ctx, infra.CreateApiKeyParams{
+		TenantID:     tenantId,
+		Name:         name,
+		Prefix:       secret.Prefix,
+		HashedSecret: secret.HashedSecret,
+		Scopes:       scopes,
+	})
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error creating api key",
+			slog.String("err", err.Error()),
+		)
+		return nil, "", err
+	}
+
+	return &savedApiKey, secret.Plain, nil
+}
+
+func apiKeyInputValidation(name string, scopes []string) error {
+	valErr := &shared.ValidationError{
+		Errors: make(map[string]string),
+	}
+
+	if len(name) == 0 {
+		valErr.AddError("name", "cannot be empty")
+	}
+
+	if len(scopes) == 0 {
+		valErr.AddError("scopes", "cannot be empty")
+	}
+
+	for _, scope := range scopes {
+		if !shared.IsValidScope(scope) {
+			valErr.AddError("scopes", "unknown scope "+scope)
+			break
+		}
+	}
+
+	if valErr.HasErrors() {
+		return valErr
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateApiKeyUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+	apiKey := infra.ApiKey{
+		ID:       1,
+		TenantID: 1,
+		Name:     "billing",
+		Prefix:   "utk_0a1b2c3d4e5f",
+		Scopes:   []string{shared.ScopeAccountsRead},
+	}
+
+	sut := NewCreateApiKeyUsecase(mockRepo)
+
+	t.Run("Error input validation", func(t *testing.T) {
+		result, key, err := sut.Create(context.Background(), 1, "", []string{"accounts:delete"})
+
+		expectedErr := &shared.ValidationError{
+			Errors: map[string]string{
+				"name":   "cannot be empty",
+				"scopes": "unknown scope accounts:delete",
+			},
+		}
+
+		assert.Nil(t, result)
+		assert.Empty(t, key)
+		assert.Equal(t, expectedErr, err)
+	})
+
+	t.Run("Error to create api key", func(t *testing.T) {
+		expectedErr := errors.New("internal repo error")
+
+		mockRepo.On("CreateApiKey").Return(nil, expectedErr)
+		defer mockRepo.On("CreateApiKey").Unset()
+
+		result, key, err := sut.Create(context.Background(), 1, apiKey.Name, apiKey.Scopes)
+
+		assert.Nil(t, result)
+		assert.Empty(t, key)
+		assert.Equal(t, expectedErr.Error(), err.Error())
+	})
+
+	t.Run("Success create new api key", func(t *testing.T) {
+		mockRepo.On("CreateApiKey").Return(apiKey, nil)
+		defer mockRepo.On("CreateApiKey").Unset()
+
+		result, key, err := sut.Create(context.Background(), 1, apiKey.Name, apiKey.Scopes)
+
+		assert.NoError(t, err)
+		assert.Equal(t, apiKey, *result)
+		assert.True(t, strings.HasPrefix(key, apiKeyPrefix))
+	})
+}
@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+type FindAllApiKeysUsecase struct {
+	repo infra.Querier
+}
+
+func NewFindAllApiKeysUsecase(repo infra.Querier) *FindAllApiKeysUsecase {
+	return &FindAllApiKeysUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *FindAllApiKeysUsecase) FindAll(ctx context.Context, tenantId int32) ([]infra.ApiKey, error) {
+	apiKeys := make([]infra.ApiKey, 0)
+
+	result, err := uc.repo.GetApiKeys(ctx, tenantId)
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error to find all api keys",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	apiKeys = append(apiKeys, result...)
+
+	return apiKeys, nil
+}
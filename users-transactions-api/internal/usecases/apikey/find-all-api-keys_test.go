@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindAllApiKeysUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+	apiKeys := []infra.ApiKey{
+		{
+			ID:       1,
+			TenantID: 1,
+			Name:     "billing",
+		},
+	}
+
+	sut := NewFindAllApiKeysUsecase(mockRepo)
+
+	t.Run("Error to find all api keys", func(t *testing.T) {
+		expectedErr := errors.New("internal repo error")
+
+		mockRepo.On("GetApiKeys").Return(nil, expectedErr)
+		defer mockRepo.On("GetApiKeys").Unset()
+
+		result, err := sut.FindAll(context.Background(), 1)
+
+		assert.Nil(t, result)
+		assert.Equal(t, expectedErr.Error(), err.Error())
+	})
+
+	t.Run("Success find all api keys", func(t *testing.T) {
+		mockRepo.On("GetApiKeys").Return(apiKeys, nil)
+		defer mockRepo.On("GetApiKeys").Unset()
+
+		result, err := sut.FindAll(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, apiKeys[0], result[0])
+	})
+}
@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+func findApiKey(ctx context.Context, repo infra.Querier, tenantId int32, apiKeyId int32) (*infra.ApiKey, error) {
+	apiKey, err := repo.GetApiKey(ctx, infra.GetApiKeyParams{
+		TenantID: tenantId,
+		ID:       apiKeyId,
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &shared.EntityNotFoundError{
+				Object: "api key",
+				Id:     apiKeyId,
+			}
+		}
+		slog.ErrorContext(
+			ctx,
+			"error to find api key by id",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	return &apiKey, nil
+}
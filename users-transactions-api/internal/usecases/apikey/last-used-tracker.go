@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"sync"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+// LastUsedTracker keeps the last usage of each api key in memory and writes
+// it to the database in batches, so authenticating a request never writes.
+type LastUsedTracker struct {
+	repo    infra.Querier
+	mutex   sync.Mutex
+	pending map[int32]time.Time
+}
+
+func NewLastUsedTracker(repo infra.Querier) *LastUsedTracker {
+	return &LastUsedTracker{
+		repo:    repo,
+		pending: make(map[int32]time.Time),
+	}
+}
+
+func (t *LastUsedTracker) Track(apiKeyId int32, usedAt time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if last, ok := t.pending[apiKeyId]; !ok || usedAt.After(last) {
+		t.pending[apiKeyId] = usedAt
+	}
+}
+
+func (t *LastUsedTracker) Flush(ctx context.Context) {
+	t.mutex.Lock()
+	pending := t.pending
+	t.pending = make(map[int32]time.Time)
+	t.mutex.Unlock()
+
+	for apiKeyId, usedAt := range pending {
+		err := t.repo.TouchApiKey(ctx, infra.TouchApiKeyParams{
+			ID: apiKeyId,
+			LastUsedAt: sql.NullTime{
+				Time:  usedAt,
+				Valid: true,
+			},
+		})
+
+		if err != nil {
+			slog.Error(
+				"error to flush api key last usage",
+				slog.Int("apiKeyId", int(apiKeyId)),
+				slog.String("err", err.Error()),
+			)
+		}
+	}
+}
+
+// Start flushes the pending usages every interval until the returned
+// function is called, which also performs a last flush.
+func (t *LastUsedTracker) Start(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-ticker.C:
+				t.Flush(context.Background())
+			case <-done:
+				ticker.Stop()
+				t.Flush(context.Background())
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
@@ -0,0 +1,51 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+type RevokeApiKeyUsecase struct {
+	repo infra.Querier
+}
+
+func NewRevokeApiKeyUsecase(repo infra.Querier) *RevokeApiKeyUsecase {
+	return &RevokeApiKeyUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *RevokeApiKeyUsecase) Revoke(ctx context.Context, tenantId int32, apiKeyId int32) error {
+	apiKey, err := findApiKey(ctx, uc.repo, tenantId, apiKeyId)
+
+	if err != nil {
+		return err
+	}
+
+	if apiKey.RevokedAt.Valid {
+		return nil
+	}
+
+	_, err = uc.repo.RevokeApiKey(ctx, infra.RevokeApiKeyParams{
+		ID: apiKey.ID,
+		RevokedAt: sql.NullTime{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		},
+	})
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error when revoke api key",
+			slog.String("err", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokeApiKeyUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+	apiKey := infra.ApiKey{
+		ID:       1,
+		TenantID: 1,
+		Name:     "billing",
+	}
+
+	sut := NewRevokeApiKeyUsecase(mockRepo)
+
+	t.Run("Error api key not found", func(t *testing.T) {
+		mockRepo.On("GetApiKey").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetApiKey").Unset()
+
+		err := sut.Revoke(context.Background(), apiKey.TenantID, apiKey.ID)
+
+		assert.Equal(t, "api key not found with id 1", err.Error())
+	})
+
+	t.Run("Error to revoke api key", func(t *testing.T) {
+		expectedErr := errors.New("internal repo error")
+
+		mockRepo.On("GetApiKey").Return(apiKey, nil)
+		defer mockRepo.On("GetApiKey").Unset()
+
+		mockRepo.On("RevokeApiKey").Return(nil, expectedErr)
+		defer mockRepo.On("RevokeApiKey").Unset()
+
+		err := sut.Revoke(context.Background(), apiKey.TenantID, apiKey.ID)
+
+		assert.Equal(t, expectedErr.Error(), err.Error())
+	})
+
+	t.Run("Success to revoke api key", func(t *testing.T) {
+		mockRepo.On("GetApiKey").Return(apiKey, nil)
+		defer mockRepo.On("GetApiKey").Unset()
+
+		mockRepo.On("RevokeApiKey").Return(apiKey, nil)
+		defer mockRepo.On("RevokeApiKey").Unset()
+
+		err := sut.Revoke(context.Background(), apiKey.TenantID, apiKey.ID)
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+type RotateApiKeyUsecase struct {
+	repo infra.Querier
+}
+
+func NewRotateApiKeyUsecase(repo infra.Querier) *RotateApiKeyUsecase {
+	return &RotateApiKeyUsecase{
+		repo: repo,
+	}
+}
+
+// Rotate replaces the secret of a key, keeping its id, name and scopes.
+// The previous plain value stops working immediately.
+func (uc *RotateApiKeyUsecase) Rotate(ctx context.Context, tenantId int32, apiKeyId int32) (*infra.ApiKey, string, error) {
+	apiKey, err := findApiKey(ctx, uc.repo, tenantId, apiKeyId)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if apiKey.RevokedAt.Valid {
+		return nil, "", &shared.ValidationError{
+			Errors: map[string]string{"api_key": "revoked keys cannot be rotated"},
+		}
+	}
+
+	secret, err := generateApiKey()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	rotatedApiKey, err := uc.repo.RotateApiKey(ctx, infra.RotateApiKeyParams{
+		ID:           apiKey.ID,
+		Prefix:       secret.Prefix,
+		HashedSecret: secret.HashedSecret,
+	})
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error when rotate api key",
+			slog.String("err", err.Error()),
+		)
+		return nil, "", err
+	}
+
+	return &rotatedApiKey, secret.Plain, nil
+}
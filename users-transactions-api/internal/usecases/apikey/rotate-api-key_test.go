@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotateApiKeyUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+	apiKey := infra.ApiKey{
+		ID:       1,
+		TenantID: 1,
+		Name:     "billing",
+		Prefix:   "utk_0a1b2c3d4e5f",
+	}
+
+	sut := NewRotateApiKeyUsecase(mockRepo)
+
+	t.Run("Error api key not found", func(t *testing.T) {
+		mockRepo.On("GetApiKey").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetApiKey").Unset()
+
+		result, key, err := sut.Rotate(context.Background(), apiKey.TenantID, apiKey.ID)
+
+		assert.Nil(t, result)
+		assert.Empty(t, key)
+		assert.Equal(t, "api key not found with id 1", err.Error())
+	})
+
+	t.Run("Error revoked api key", func(t *testing.T) {
+		revokedApiKey := apiKey
+		revokedApiKey.RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+		mockRepo.On("GetApiKey").Return(revokedApiKey, nil)
+		defer mockRepo.On("GetApiKey").Unset()
+
+		result, _, err := sut.Rotate(context.Background(), apiKey.TenantID, apiKey.ID)
+
+		_, ok := err.(*shared.ValidationError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+
+	t.Run("Error to rotate api key", func(t *testing.T) {
+		expectedErr := errors.New("internal repo error")
+
+		mockRepo.On("GetApiKey").Return(apiKey, nil)
+		defer mockRepo.On("GetApiKey").Unset()
+
+		mockRepo.On("RotateApiKey").Return(nil, expectedErr)
+		defer mockRepo.On("RotateApiKey").Unset()
+
+		result, _, err := sut.Rotate(context.Background(), apiKey.TenantID, apiKey.ID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, expectedErr.Error(), err.Error())
+	})
+
+	t.Run("Success to rotate api key", func(t *testing.T) {
+		mockRepo.On("GetApiKey").Return(apiKey, nil)
+		defer mockRepo.On("GetApiKey").Unset()
+
+		mockRepo.On("RotateApiKey").Return(apiKey, nil)
+		defer mockRepo.On("RotateApiKey").Unset()
+
+		result, key, err := sut.Rotate(context.Background(), apiKey.TenantID, apiKey.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, apiKey, *result)
+		assert.NotEmpty(t, key)
+	})
+}
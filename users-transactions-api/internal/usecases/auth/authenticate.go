@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	apiKeyUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/apikey"
+	userUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/user"
+)
+
+// AuthenticateUsecase resolves a bearer credential, either an api key or a
+// user session token, into the principal of the request.
+type AuthenticateUsecase struct {
+	authenticateApiKeyUsecase *apiKeyUsecases.AuthenticateApiKeyUsecase
+	authenticateUserUsecase   *userUsecases.AuthenticateUserUsecase
+}
+
+func NewAuthenticateUsecase(authenticateApiKeyUsecase *apiKeyUsecases.AuthenticateApiKeyUsecase,
+	authenticateUserUsecase *userUsecases.AuthenticateUserUsecase) *AuthenticateUsecase {
+	return &AuthenticateUsecase{
+		authenticateApiKeyUsecase: authenticateApiKeyUsecase,
+		authenticateUserUsecase:   authenticateUserUsecase,
+	}
+}
+
+func (uc *AuthenticateUsecase) Authenticate(ctx context.Context, credential string) (*shared.Principal, error) {
+	if apiKeyUsecases.IsApiKey(credential) {
+		return uc.authenticateApiKeyUsecase.Authenticate(ctx, credential)
+	}
+
+	return uc.authenticateUserUsecase.Authenticate(ctx, credential)
+}
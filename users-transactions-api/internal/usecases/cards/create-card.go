@@ -7,36 +7,50 @@ import (
 	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
 	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/account"
+	quotaUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/quota"
 )
 
 type CreateCardUsecase struct {
-	repo               infra.Querier
-	findAccountUsecase *usecases.FindOneAccountUsecase
+	repo         infra.QuerierTx
+	quotaUsecase *quotaUsecases.QuotaUsecase
 }
 
-func NewCreateCardUsecase(repo infra.Querier,
-	findAccountUsecase *usecases.FindOneAccountUsecase) *CreateCardUsecase {
+func NewCreateCardUsecase(repo infra.QuerierTx, quotaUsecase *quotaUsecases.QuotaUsecase) *CreateCardUsecase {
 	return &CreateCardUsecase{
-		repo:               repo,
-		findAccountUsecase: findAccountUsecase,
+		repo:         repo,
+		quotaUsecase: quotaUsecase,
 	}
 }
 
-func (uc *CreateCardUsecase) Create(tenantId int32, accountId int32) (*infra.Card, error) {
-	account, err := uc.findAccountUsecase.FindOne(tenantId, accountId)
+// Create checks the account status in the transaction that saves the card.
+func (uc *CreateCardUsecase) Create(ctx context.Context, tenantId int32, accountId int32) (*infra.Card, error) {
+	var savedCard infra.Card
 
-	if err != nil {
-		return nil, err
-	}
+	err := uc.repo.WithTenant(ctx, tenantId, func(q infra.Querier) (err error) {
+		err = usecases.CheckAccountOperation(ctx, q, tenantId, accountId, shared.AccountOperationCreateCard)
 
-	if account.Status == "inactive" {
-		return nil, &shared.InactiveAccountError{}
-	}
+		if err != nil {
+			return err
+		}
+
+		err = uc.quotaUsecase.CheckCards(ctx, q, tenantId, accountId)
 
-	savedCard, err := uc.repo.CreateCard(context.Background(), accountId)
+		if err != nil {
+			return err
+		}
+
+		savedCard, err = q.CreateCard(ctx, accountId)
+		return err
+	})
+
+	switch err.(type) {
+	case *shared.QuotaExceededError, *shared.EntityNotFoundError, *shared.AccountStatusError:
+		return nil, err
+	}
 
 	if err != nil {
-		slog.Error(
+		slog.ErrorContext(
+			ctx,
 			"error creating card",
 			slog.String("err", err.Error()),
 		)
@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -8,7 +9,8 @@ import (
 
 	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
-	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/account"
+	quotaUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/quota"
+	settingsUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/settings"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,9 +19,12 @@ func TestCreateCardUsecase(t *testing.T) {
 
 	mockRepo := new(mocks.MockRepository)
 
-	findAccountUsecase := usecases.NewFindOneAccountUsecase(mockRepo)
+	// tenants without settings are not limited by quotas
+	mockRepo.On("GetTenantSettings").Return(nil, sql.ErrNoRows)
+	findTenantSettingsUsecase := settingsUsecases.NewFindTenantSettingsUsecase(mockRepo, settingsUsecases.NewSettingsCache(0))
+	quotaUsecase := quotaUsecases.NewQuotaUsecase(findTenantSettingsUsecase)
 
-	sut := NewCreateCardUsecase(mockRepo, findAccountUsecase)
+	sut := NewCreateCardUsecase(mockRepo, quotaUsecase)
 
 	card := infra.Card{
 		ID:        1,
@@ -34,10 +39,10 @@ func TestCreateCardUsecase(t *testing.T) {
 	}
 
 	t.Run("Error to find account", func(t *testing.T) {
-		mockRepo.On("GetAccount").Return(nil, sql.ErrNoRows)
-		defer mockRepo.On("GetAccount").Unset()
+		mockRepo.On("GetAccountForShare").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetAccountForShare").Unset()
 
-		result, err := sut.Create(1, card.AccountID)
+		result, err := sut.Create(context.Background(), 1, card.AccountID)
 
 		assert.Nil(t, result)
 		assert.Equal(t, fmt.Sprintf("account not found with id %d", card.AccountID),
@@ -45,41 +50,41 @@ func TestCreateCardUsecase(t *testing.T) {
 	})
 
 	t.Run("Error to save card", func(t *testing.T) {
-		mockRepo.On("GetAccount").Return(account, nil)
-		defer mockRepo.On("GetAccount").Unset()
+		mockRepo.On("GetAccountForShare").Return(account, nil)
+		defer mockRepo.On("GetAccountForShare").Unset()
 
 		mockRepo.On("CreateCard").Return(nil, errors.New("Internal error"))
 		defer mockRepo.On("CreateCard").Unset()
 
-		result, err := sut.Create(1, card.AccountID)
+		result, err := sut.Create(context.Background(), 1, card.AccountID)
 
 		assert.Nil(t, result)
 		assert.Equal(t, "Internal error", err.Error())
 	})
 
 	t.Run("Success to create card", func(t *testing.T) {
-		mockRepo.On("GetAccount").Return(account, nil)
-		defer mockRepo.On("GetAccount").Unset()
+		mockRepo.On("GetAccountForShare").Return(account, nil)
+		defer mockRepo.On("GetAccountForShare").Unset()
 
 		mockRepo.On("CreateCard").Return(card, nil)
 		defer mockRepo.On("CreateCard").Unset()
 
-		result, err := sut.Create(1, card.AccountID)
+		result, err := sut.Create(context.Background(), 1, card.AccountID)
 
 		assert.NoError(t, err)
 		assert.Equal(t, &card, result)
 		assert.Equal(t, card.Amount, result.Amount)
 	})
 
-	t.Run("Inactive account error", func(t *testing.T) {
-		account.Status = "inactive"
+	t.Run("Frozen account error", func(t *testing.T) {
+		account.Status = "frozen"
 
-		mockRepo.On("GetAccount").Return(account, nil)
-		defer mockRepo.On("GetAccount").Unset()
+		mockRepo.On("GetAccountForShare").Return(account, nil)
+		defer mockRepo.On("GetAccountForShare").Unset()
 
-		result, err := sut.Create(1, card.AccountID)
+		result, err := sut.Create(context.Background(), 1, card.AccountID)
 
 		assert.Nil(t, result)
-		assert.Equal(t, "Card cannot be created to an inactive account", err.Error())
+		assert.Equal(t, "card creation is not allowed for a frozen account", err.Error())
 	})
 }
@@ -9,11 +9,11 @@ import (
 )
 
 type FindAllCards struct {
-	repo               infra.Querier
+	repo               infra.QuerierTx
 	findAccountUsecase *usecases.FindOneAccountUsecase
 }
 
-func NewFindAllCards(repo infra.Querier,
+func NewFindAllCards(repo infra.QuerierTx,
 	findAccountUsecase *usecases.FindOneAccountUsecase) *FindAllCards {
 	return &FindAllCards{
 		repo:               repo,
@@ -21,8 +21,8 @@ func NewFindAllCards(repo infra.Querier,
 	}
 }
 
-func (uc *FindAllCards) FindAll(tenantId int32, accountId int32) ([]infra.Card, error) {
-	_, err := uc.findAccountUsecase.FindOne(tenantId, accountId)
+func (uc *FindAllCards) FindAll(ctx context.Context, tenantId int32, accountId int32) ([]infra.Card, error) {
+	_, err := uc.findAccountUsecase.FindOne(ctx, tenantId, accountId)
 
 	if err != nil {
 		return nil, err
@@ -30,10 +30,16 @@ func (uc *FindAllCards) FindAll(tenantId int32, accountId int32) ([]infra.Card,
 
 	cards := make([]infra.Card, 0)
 
-	result, err := uc.repo.GetCards(context.Background(), accountId)
+	var result []infra.Card
+
+	err = uc.repo.WithTenant(ctx, tenantId, func(q infra.Querier) (err error) {
+		result, err = q.GetCards(ctx, accountId)
+		return err
+	})
 
 	if err != nil {
-		slog.Error(
+		slog.ErrorContext(
+			ctx,
 			"error to find all cards",
 			slog.String("err", err.Error()),
 		)
@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -44,7 +45,7 @@ func TestFindAllCards(t *testing.T) {
 		mockRepo.On("GetAccount").Return(nil, sql.ErrNoRows)
 		defer mockRepo.On("GetAccount").Unset()
 
-		result, err := sut.FindAll(1, account.ID)
+		result, err := sut.FindAll(context.Background(), 1, account.ID)
 
 		assert.Nil(t, result)
 		assert.Equal(t, fmt.Sprintf("account not found with id %d", account.ID),
@@ -58,7 +59,7 @@ func TestFindAllCards(t *testing.T) {
 		mockRepo.On("GetCards").Return(nil, errors.New("internal error"))
 		defer mockRepo.On("GetCards").Unset()
 
-		result, err := sut.FindAll(1, account.ID)
+		result, err := sut.FindAll(context.Background(), 1, account.ID)
 
 		assert.Nil(t, result)
 		assert.EqualError(t, errors.New("internal error"), err.Error())
@@ -71,7 +72,7 @@ func TestFindAllCards(t *testing.T) {
 		mockRepo.On("GetCards").Return(cards, nil)
 		defer mockRepo.On("GetCards").Unset()
 
-		result, err := sut.FindAll(1, account.ID)
+		result, err := sut.FindAll(context.Background(), 1, account.ID)
 
 		assert.NoError(t, err)
 		assert.Len(t, result, 2)
@@ -11,11 +11,11 @@ import (
 )
 
 type FindCardUsecase struct {
-	repo               infra.Querier
+	repo               infra.QuerierTx
 	findAccountUsecase *usecases.FindOneAccountUsecase
 }
 
-func NewFindCardUsecase(repo infra.Querier,
+func NewFindCardUsecase(repo infra.QuerierTx,
 	findAccountUsecase *usecases.FindOneAccountUsecase) *FindCardUsecase {
 	return &FindCardUsecase{
 		repo:               repo,
@@ -23,16 +23,21 @@ func NewFindCardUsecase(repo infra.Querier,
 	}
 }
 
-func (uc *FindCardUsecase) FindOne(tenantId int32, accountId int32, cardId int32) (*infra.Card, error) {
-	_, err := uc.findAccountUsecase.FindOne(tenantId, accountId)
+func (uc *FindCardUsecase) FindOne(ctx context.Context, tenantId int32, accountId int32, cardId int32) (*infra.Card, error) {
+	_, err := uc.findAccountUsecase.FindOne(ctx, tenantId, accountId)
 
 	if err != nil {
 		return nil, err
 	}
 
-	card, err := uc.repo.GetCard(context.Background(), infra.GetCardParams{
-		AccountID: accountId,
-		ID:        cardId,
+	var card infra.Card
+
+	err = uc.repo.WithTenant(ctx, tenantId, func(q infra.Querier) (err error) {
+		card, err = q.GetCard(ctx, infra.GetCardParams{
+			AccountID: accountId,
+			ID:        cardId,
+		})
+		return err
 	})
 
 	if err != nil {
@@ -42,7 +47,8 @@ func (uc *FindCardUsecase) FindOne(tenantId int32, accountId int32, cardId int32
 				Id:     cardId,
 			}
 		}
-		slog.Error(
+		slog.ErrorContext(
+			ctx,
 			"error creating card",
 			slog.String("err", err.Error()),
 		)
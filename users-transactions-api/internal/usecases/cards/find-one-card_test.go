@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -37,7 +38,7 @@ func TestFindCardUsecase(t *testing.T) {
 		mockRepo.On("GetAccount").Return(nil, sql.ErrNoRows)
 		defer mockRepo.On("GetAccount").Unset()
 
-		result, err := sut.FindOne(1, 1, card.AccountID)
+		result, err := sut.FindOne(context.Background(), 1, 1, card.AccountID)
 
 		assert.Nil(t, result)
 		assert.Equal(t, fmt.Sprintf("account not found with id %d", card.AccountID),
@@ -51,7 +52,7 @@ func TestFindCardUsecase(t *testing.T) {
 		mockRepo.On("GetCard").Return(nil, errors.New("Internal error"))
 		defer mockRepo.On("GetCard").Unset()
 
-		result, err := sut.FindOne(1, 1, card.AccountID)
+		result, err := sut.FindOne(context.Background(), 1, 1, card.AccountID)
 
 		assert.Nil(t, result)
 		assert.Equal(t, "Internal error", err.Error())
@@ -64,7 +65,7 @@ func TestFindCardUsecase(t *testing.T) {
 		mockRepo.On("GetCard").Return(nil, sql.ErrNoRows)
 		defer mockRepo.On("GetCard").Unset()
 
-		result, err := sut.FindOne(1, 1, card.AccountID)
+		result, err := sut.FindOne(context.Background(), 1, 1, card.AccountID)
 
 		assert.Nil(t, result)
 		assert.Equal(t, fmt.Sprintf("card not found with id %d", card.ID),
@@ -78,7 +79,7 @@ func TestFindCardUsecase(t *testing.T) {
 		mockRepo.On("GetCard").Return(card, nil)
 		defer mockRepo.On("GetCard").Unset()
 
-		result, err := sut.FindOne(1, 1, card.AccountID)
+		result, err := sut.FindOne(context.Background(), 1, 1, card.AccountID)
 
 		assert.NoError(t, err)
 		assert.Equal(t, &card, result)
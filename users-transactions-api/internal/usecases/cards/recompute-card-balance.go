@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+// RecomputeCardBalanceUsecase sets the amount of a card back to the sum of
+// its transactions, repairing balances that drifted from the ledger.
+type RecomputeCardBalanceUsecase struct {
+	repo            infra.QuerierTx
+	findCardUsecase *FindCardUsecase
+}
+
+func NewRecomputeCardBalanceUsecase(repo infra.QuerierTx,
+	findCardUsecase *FindCardUsecase) *RecomputeCardBalanceUsecase {
+	return &RecomputeCardBalanceUsecase{
+		repo:            repo,
+		findCardUsecase: findCardUsecase,
+	}
+}
+
+// Recompute returns the card with its recomputed amount and the amount it
+// had before.
+func (uc *RecomputeCardBalanceUsecase) Recompute(ctx context.Context, tenantId int32, accountId int32,
+	cardId int32) (*infra.Card, int64, error) {
+	previous, err := uc.findCardUsecase.FindOne(ctx, tenantId, accountId, cardId)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var card infra.Card
+
+	err = uc.repo.WithTenant(ctx, tenantId, func(q infra.Querier) (err error) {
+		card, err = q.RecomputeCardAmount(ctx, infra.RecomputeCardAmountParams{
+			ID:        cardId,
+			UpdatedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		})
+		return err
+	})
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error recomputing card balance",
+			slog.String("err", err.Error()),
+		)
+		return nil, 0, err
+	}
+
+	if card.Amount != previous.Amount {
+		slog.WarnContext(
+			ctx,
+			"card balance drifted from its transactions",
+			slog.Int("card_id", int(cardId)),
+			slog.Int64("previous_amount", previous.Amount),
+			slog.Int64("amount", card.Amount),
+		)
+	}
+
+	return &card, previous.Amount, nil
+}
@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/account"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecomputeCardBalanceUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+
+	findAccountUsecase := usecases.NewFindOneAccountUsecase(mockRepo)
+	findCardUsecase := NewFindCardUsecase(mockRepo, findAccountUsecase)
+
+	sut := NewRecomputeCardBalanceUsecase(mockRepo, findCardUsecase)
+
+	account := infra.Account{
+		ID:       1,
+		TenantID: 1,
+		Status:   "active",
+	}
+
+	card := infra.Card{
+		ID:        1,
+		Amount:    200,
+		AccountID: 1,
+	}
+
+	recomputed := infra.Card{
+		ID:        1,
+		Amount:    150,
+		AccountID: 1,
+	}
+
+	t.Run("Error card not found", func(t *testing.T) {
+		mockRepo.On("GetAccount").Return(account, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetCard").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetCard").Unset()
+
+		result, previous, err := sut.Recompute(context.Background(), 1, 1, card.ID)
+
+		assert.Nil(t, result)
+		assert.Zero(t, previous)
+		assert.Equal(t, fmt.Sprintf("card not found with id %d", card.ID), err.Error())
+	})
+
+	t.Run("Error to recompute amount", func(t *testing.T) {
+		mockRepo.On("GetAccount").Return(account, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetCard").Return(card, nil)
+		defer mockRepo.On("GetCard").Unset()
+
+		mockRepo.On("RecomputeCardAmount").Return(nil, errors.New("Internal error"))
+		defer mockRepo.On("RecomputeCardAmount").Unset()
+
+		result, _, err := sut.Recompute(context.Background(), 1, 1, card.ID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, "Internal error", err.Error())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.On("GetAccount").Return(account, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetCard").Return(card, nil)
+		defer mockRepo.On("GetCard").Unset()
+
+		mockRepo.On("RecomputeCardAmount").Return(recomputed, nil)
+		defer mockRepo.On("RecomputeCardAmount").Unset()
+
+		result, previous, err := sut.Recompute(context.Background(), 1, 1, card.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &recomputed, result)
+		assert.Equal(t, card.Amount, previous)
+	})
+}
@@ -0,0 +1,100 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	authUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// contextStream replaces the context of a stream with the one built by the
+// interceptors.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// publicMethodPrefix is the health service, which probes call without
+// credentials.
+const publicMethodPrefix = "/grpc.health.v1.Health/"
+
+// AuthStreamInterceptor authenticates the api key or session token sent in
+// the "authorization" metadata and requires the reports:read scope.
+func AuthStreamInterceptor(authenticateUsecase *authUsecases.AuthenticateUsecase) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, publicMethodPrefix) {
+			return handler(srv, ss)
+		}
+
+		ctx, err := authenticate(ss.Context(), authenticateUsecase)
+
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &contextStream{
+			ServerStream: ss,
+			ctx:          ctx,
+		})
+	}
+}
+
+// AuthUnaryInterceptor applies the same rules of AuthStreamInterceptor to
+// unary calls.
+func AuthUnaryInterceptor(authenticateUsecase *authUsecases.AuthenticateUsecase) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, publicMethodPrefix) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := authenticate(ctx, authenticateUsecase)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func authenticate(ctx context.Context, authenticateUsecase *authUsecases.AuthenticateUsecase) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing credentials")
+	}
+
+	credential, _ := strings.CutPrefix(values[0], "Bearer ")
+
+	principal, err := authenticateUsecase.Authenticate(ctx, credential)
+
+	if err != nil {
+		if ue, ok := err.(*shared.UnauthorizedError); ok {
+			return nil, status.Error(codes.Unauthenticated, ue.Error())
+		}
+
+		slog.ErrorContext(
+			ctx,
+			"error to authenticate credentials",
+			slog.String("err", err.Error()),
+		)
+		return nil, status.Errorf(codes.Internal, "Internal repository error: %v", err)
+	}
+
+	if !principal.HasScope(shared.ScopeReportsRead) {
+		return nil, status.Error(codes.PermissionDenied, "missing scope "+shared.ScopeReportsRead)
+	}
+
+	return shared.ContextWithPrincipal(ctx, principal), nil
+}
@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/genproto"
+	healthUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/health"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthServices are the names reported by the grpc.health.v1 service, the
+// empty one being the server as a whole.
+var healthServices = []string{"", genproto.TransactionInfoService_ServiceDesc.ServiceName}
+
+// WatchReadiness keeps the grpc.health.v1 status in line with the readiness
+// checks, so clients and load balancers skip a replica that lost its
+// database. It runs until the returned function is called.
+func WatchReadiness(readinessUsecase *healthUsecases.ReadinessUsecase, server *health.Server,
+	interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	update := func() {
+		report := readinessUsecase.Check(context.Background())
+		status := healthpb.HealthCheckResponse_SERVING
+
+		if !report.Ready() {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+
+			slog.Warn(
+				"grpc server is not ready",
+				slog.Any("checks", report.Checks),
+			)
+		}
+
+		for _, service := range healthServices {
+			server.SetServingStatus(service, status)
+		}
+	}
+
+	update()
+
+	go func() {
+		defer close(stopped)
+
+		for {
+			select {
+			case <-ticker.C:
+				update()
+			case <-done:
+				ticker.Stop()
+				server.Shutdown()
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/metrics"
+	"google.golang.org/grpc"
+)
+
+// MetricsStreamInterceptor counts the calls and their latency. It runs
+// before the auth interceptors so the rejected calls are counted too.
+func MetricsStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+
+		metrics.ObserveGrpcServer(info.FullMethod, err, time.Since(start))
+		return err
+	}
+}
+
+func MetricsUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		metrics.ObserveGrpcServer(info.FullMethod, err, time.Since(start))
+		return resp, err
+	}
+}
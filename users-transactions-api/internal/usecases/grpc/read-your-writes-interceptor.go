@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ReadYourWritesStreamInterceptor keeps the reads of the call on the
+// primary database when the caller sends "x-read-your-writes: true".
+func ReadYourWritesStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, ok := withReadYourWrites(ss.Context())
+
+		if !ok {
+			return handler(srv, ss)
+		}
+
+		return handler(srv, &contextStream{
+			ServerStream: ss,
+			ctx:          ctx,
+		})
+	}
+}
+
+// ReadYourWritesUnaryInterceptor applies the same rule of
+// ReadYourWritesStreamInterceptor to unary calls.
+func ReadYourWritesUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, _ = withReadYourWrites(ctx)
+
+		return handler(ctx, req)
+	}
+}
+
+func withReadYourWrites(ctx context.Context) (context.Context, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(shared.ReadYourWritesMetadata)
+
+	if len(values) == 0 || !shared.IsReadYourWrites(values[0]) {
+		return ctx, false
+	}
+
+	return shared.ContextWithReadYourWrites(ctx), true
+}
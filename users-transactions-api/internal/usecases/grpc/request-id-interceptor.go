@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIdStreamInterceptor keeps the request id sent by the caller in the
+// "x-request-id" metadata, or creates one, so the logs of the call can be
+// matched with the caller ones. It must run before the other interceptors.
+func RequestIdStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := withRequestId(ss.Context())
+		requestId, _ := shared.RequestIdFromContext(ctx)
+
+		_ = ss.SetHeader(metadata.Pairs(shared.RequestIdMetadata, requestId))
+
+		return handler(srv, &contextStream{
+			ServerStream: ss,
+			ctx:          ctx,
+		})
+	}
+}
+
+// RequestIdUnaryInterceptor applies the same rules of
+// RequestIdStreamInterceptor to unary calls.
+func RequestIdUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = withRequestId(ctx)
+		requestId, _ := shared.RequestIdFromContext(ctx)
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(shared.RequestIdMetadata, requestId))
+
+		return handler(ctx, req)
+	}
+}
+
+func withRequestId(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(shared.RequestIdMetadata)
+
+	requestId := ""
+	if len(values) > 0 {
+		requestId = values[0]
+	}
+
+	if !shared.IsValidRequestId(requestId) {
+		requestId = shared.NewRequestId()
+	}
+
+	return shared.ContextWithRequestId(ctx, requestId)
+}
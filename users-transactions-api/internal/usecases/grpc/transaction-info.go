@@ -1,23 +1,39 @@
 package usecases
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log/slog"
 
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/genproto"
 	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	tenantUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/tenant"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/utils"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// TransactionInfo runs the queries of each call in a transaction scoped to
+// the tenant of the caller, so row level security applies to the gRPC
+// surface as it does to the REST one. Only the search goes to readRepo, the
+// replica when there is one. The tenant and its settings are read like in
+// the REST api, from the primary and the tenant cache. The values are sent
+// already formatted with the tenant settings, the reports only render them.
 type TransactionInfo struct {
-	repo infra.Querier
+	repo                 infra.QuerierTx
+	readRepo             infra.QuerierTx
+	findOneTenantUsecase *tenantUsecases.FindOneTenantUseCase
 }
 
-func NewTransactionInfo(repo infra.Querier) *TransactionInfo {
+func NewTransactionInfo(repo infra.QuerierTx, readRepo infra.QuerierTx,
+	findOneTenantUsecase *tenantUsecases.FindOneTenantUseCase) *TransactionInfo {
 	return &TransactionInfo{
-		repo: repo,
+		repo:                 repo,
+		readRepo:             readRepo,
+		findOneTenantUsecase: findOneTenantUsecase,
 	}
 }
 
@@ -26,61 +42,83 @@ func (ti *TransactionInfo) SearchTransactionInfo(req *genproto.SearchTransaction
 
 	filter := req.GetFilter()
 
-	_, err := ti.repo.GetTenant(stream.Context(), int32(filter.GetTenantId()))
+	tenantId, err := ti.checkTenant(stream.Context(), filter.GetTenantId())
 
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return status.Errorf(codes.NotFound, fmt.Sprintf("tenant with id %d not found", filter.GetTenantId()), err)
+		return err
+	}
+
+	var result []infra.SearchTransactionsRow
+
+	// the rows are streamed after the transaction ends, a slow client does
+	// not keep it open
+	err = ti.readRepo.WithTenant(stream.Context(), tenantId, func(q infra.Querier) error {
+		account, err := q.GetAccount(stream.Context(), infra.GetAccountParams{
+			TenantID: int32(filter.GetTenantId()),
+			ID:       int32(filter.GetAccountId()),
+		})
+
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return status.Errorf(codes.NotFound, fmt.Sprintf("account with id %d not found", filter.GetAccountId()), err)
+			}
+
+			slog.ErrorContext(
+				stream.Context(),
+				"error to find account by id",
+				slog.String("err", err.Error()),
+			)
+			return status.Errorf(codes.Internal, "Internal repository error: %v", err)
 		}
 
-		slog.Error(
-			"error to find tenant by id",
-			slog.String("err", err.Error()),
-		)
-		return status.Errorf(codes.Internal, "Internal repository error: %v", err)
-	}
+		err = shared.CheckAccountOperation(account.Status, shared.AccountOperationReport)
 
-	_, err = ti.repo.GetAccount(stream.Context(), infra.GetAccountParams{
-		TenantID: int32(filter.GetTenantId()),
-		ID:       int32(filter.GetAccountId()),
-	})
+		if err != nil {
+			return status.Errorf(codes.PermissionDenied, "account with id %d: %v", filter.GetAccountId(), err)
+		}
 
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return status.Errorf(codes.NotFound, fmt.Sprintf("account with id %d not found", filter.GetAccountId()), err)
+		result, err = q.SearchTransactions(stream.Context(), infra.SearchTransactionsParams{
+			TenantID:  int32(filter.TenantId),
+			Accountid: int32(filter.AccountId),
+		})
+
+		if err != nil {
+			slog.ErrorContext(
+				stream.Context(),
+				"error search transactions information",
+				slog.String("err", err.Error()),
+			)
+			return status.Errorf(codes.Internal, "Internal repository error: %v", err)
 		}
 
-		slog.Error(
-			"error to find account by id",
-			slog.String("err", err.Error()),
-		)
-		return status.Errorf(codes.Internal, "Internal repository error: %v", err)
+		return nil
+	})
+
+	if err != nil {
+		return err
 	}
 
-	result, err := ti.repo.SearchTransactions(stream.Context(), infra.SearchTransactionsParams{
-		TenantID:  int32(filter.TenantId),
-		Accountid: int32(filter.AccountId),
-	})
+	formatter, err := ti.localeFormatter(stream.Context(), tenantId)
 
 	if err != nil {
-		slog.Error(
-			"error search transactions information",
-			slog.String("err", err.Error()),
-		)
-		return status.Errorf(codes.Internal, "Internal repository error: %v", err)
+		return err
 	}
 
 	for _, t := range result {
 		response := &genproto.TransactionInfo{
-			AccountId: filter.GetAccountId(),
-			Kind:      t.Kind,
-			Value:     float64(t.Value),
+			AccountId:          filter.GetAccountId(),
+			Kind:               t.Kind,
+			Value:              float64(t.Value),
+			CreatedAt:          timestamppb.New(t.CreatedAt),
+			FormattedValue:     formatter.Money(t.Value),
+			FormattedCreatedAt: formatter.Date(t.CreatedAt),
 		}
 
 		err := stream.Send(&genproto.SearchTransactionInfoResponse{TransactionInfo: response})
 
 		if err != nil {
-			slog.Error(
+			slog.ErrorContext(
+				stream.Context(),
 				"error to send response stream",
 				slog.String("err", err.Error()),
 			)
@@ -89,3 +127,123 @@ func (ti *TransactionInfo) SearchTransactionInfo(req *genproto.SearchTransaction
 	}
 	return nil
 }
+
+func (ti *TransactionInfo) GetTenantSettings(ctx context.Context,
+	req *genproto.GetTenantSettingsRequest) (*genproto.TenantSettings, error) {
+
+	tenantId, err := ti.checkTenant(ctx, req.GetTenantId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	var response *genproto.TenantSettings
+
+	err = ti.repo.WithTenant(ctx, tenantId, func(q infra.Querier) error {
+		settings, err := q.GetTenantSettings(ctx, int32(req.GetTenantId()))
+
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return status.Errorf(codes.NotFound, "tenant settings with id %d not found", req.GetTenantId())
+			}
+
+			slog.ErrorContext(
+				ctx,
+				"error to find tenant settings",
+				slog.String("err", err.Error()),
+			)
+			return status.Errorf(codes.Internal, "Internal repository error: %v", err)
+		}
+
+		response = &genproto.TenantSettings{
+			TenantId:       uint32(settings.TenantID),
+			Currency:       settings.Currency,
+			Timezone:       settings.Timezone,
+			Locale:         settings.Locale,
+			ClosingDay:     uint32(settings.ClosingDay),
+			DisplayName:    settings.DisplayName.String,
+			PrimaryColor:   settings.PrimaryColor,
+			SecondaryColor: settings.SecondaryColor,
+		}
+
+		logo, err := q.GetTenantLogo(ctx, settings.TenantID)
+
+		if err != nil && err != sql.ErrNoRows {
+			slog.ErrorContext(
+				ctx,
+				"error to find tenant logo",
+				slog.String("err", err.Error()),
+			)
+			return status.Errorf(codes.Internal, "Internal repository error: %v", err)
+		}
+
+		if err == nil {
+			response.Logo = logo.Data
+			response.LogoContentType = logo.ContentType
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// localeFormatter reads the tenant settings from the primary, like
+// GetTenantSettings. A tenant without settings gets the defaults, like in
+// the REST api.
+func (ti *TransactionInfo) localeFormatter(ctx context.Context, tenantId int32) (*utils.LocaleFormatter, error) {
+	formatter := utils.DefaultLocaleFormatter()
+
+	err := ti.repo.WithTenant(ctx, tenantId, func(q infra.Querier) error {
+		settings, err := q.GetTenantSettings(ctx, tenantId)
+
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+
+			slog.ErrorContext(
+				ctx,
+				"error to find tenant settings",
+				slog.String("err", err.Error()),
+			)
+			return status.Errorf(codes.Internal, "Internal repository error: %v", err)
+		}
+
+		formatter = utils.NewLocaleFormatter(settings.Timezone, settings.Locale, settings.Currency)
+
+		return nil
+	})
+
+	return formatter, err
+}
+
+// checkTenant verifies that the caller credentials belong to the tenant and
+// that the tenant exists and is not suspended. It returns the tenant of the
+// caller, the one the queries are scoped to.
+func (ti *TransactionInfo) checkTenant(ctx context.Context, tenantId uint32) (int32, error) {
+	principal, ok := shared.PrincipalFromContext(ctx)
+
+	if !ok || principal.TenantId != int32(tenantId) {
+		return 0, status.Errorf(codes.PermissionDenied, "credentials do not belong to tenant %d", tenantId)
+	}
+
+	_, err := ti.findOneTenantUsecase.FindOne(ctx, principal.TenantId)
+
+	if err != nil {
+		switch err.(type) {
+		case *shared.EntityNotFoundError:
+			return 0, status.Error(codes.NotFound, err.Error())
+		case *shared.ForbiddenError:
+			return 0, status.Error(codes.PermissionDenied, err.Error())
+		}
+
+		return 0, status.Errorf(codes.Internal, "Internal repository error: %v", err)
+	}
+
+	return principal.TenantId, nil
+}
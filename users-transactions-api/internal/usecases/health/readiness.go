@@ -0,0 +1,149 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Database is the part of the repository the readiness checks use.
+type Database interface {
+	Ping(ctx context.Context) error
+	AppliedMigrations(ctx context.Context) (map[int64]bool, error)
+}
+
+type CheckResult struct {
+	Status string
+	Error  string
+}
+
+// Report is up only when every check is up.
+type Report struct {
+	Status string
+	Checks map[string]CheckResult
+}
+
+func (r *Report) Ready() bool {
+	return r.Status == StatusUp
+}
+
+func (r *Report) add(name string, err error) {
+	if err == nil {
+		r.Checks[name] = CheckResult{Status: StatusUp}
+		return
+	}
+
+	r.Status = StatusDown
+	r.Checks[name] = CheckResult{Status: StatusDown, Error: err.Error()}
+}
+
+// ReadinessUsecase tells whether the service can take traffic: it is not
+// shutting down and the database answers and has every migration the
+// binary was built with.
+type ReadinessUsecase struct {
+	db         Database
+	migrations fs.FS
+	timeout    time.Duration
+	draining   atomic.Bool
+}
+
+func NewReadinessUsecase(db Database, migrations fs.FS) *ReadinessUsecase {
+	return &ReadinessUsecase{
+		db:         db,
+		migrations: migrations,
+		timeout:    2 * time.Second,
+	}
+}
+
+// Drain fails the next checks, so load balancers stop sending traffic
+// before the servers shut down.
+func (uc *ReadinessUsecase) Drain() {
+	uc.draining.Store(true)
+}
+
+func (uc *ReadinessUsecase) Check(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]CheckResult),
+	}
+
+	if uc.draining.Load() {
+		report.add("shutdown", errors.New("shutting down"))
+	}
+
+	report.add("database", uc.db.Ping(ctx))
+	report.add("migrations", uc.checkMigrations(ctx))
+
+	return report
+}
+
+func (uc *ReadinessUsecase) checkMigrations(ctx context.Context) error {
+	versions, err := migrationVersions(uc.migrations)
+
+	if err != nil {
+		return err
+	}
+
+	applied, err := uc.db.AppliedMigrations(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	pending := make([]int64, 0)
+
+	for _, version := range versions {
+		if !applied[version] {
+			pending = append(pending, version)
+		}
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("%d pending migrations, starting at %d", len(pending), pending[0])
+	}
+
+	return nil
+}
+
+// migrationVersions reads the version prefix of the goose file names, like
+// 20240817181058 in 20240817181058_create_tenants_table.sql.
+func migrationVersions(migrations fs.FS) ([]int64, error) {
+	files, err := fs.Glob(migrations, "*.sql")
+
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]int64, 0, len(files))
+
+	for _, file := range files {
+		prefix, _, _ := strings.Cut(file, "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has no version", file)
+		}
+
+		versions = append(versions, version)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i] < versions[j]
+	})
+
+	return versions, nil
+}
@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadinessUsecase(t *testing.T) {
+	t.Parallel()
+
+	migrations := fstest.MapFS{
+		"20240817181058_create_tenants_table.sql":  &fstest.MapFile{},
+		"20240817181211_create_accounts_table.sql": &fstest.MapFile{},
+	}
+
+	t.Run("Ready", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		sut := NewReadinessUsecase(mockRepo, migrations)
+
+		mockRepo.On("Ping").Return(nil)
+		mockRepo.On("AppliedMigrations").Return(map[int64]bool{
+			20240817181058: true,
+			20240817181211: true,
+		}, nil)
+
+		report := sut.Check(context.Background())
+
+		assert.True(t, report.Ready())
+		assert.Equal(t, CheckResult{Status: StatusUp}, report.Checks["database"])
+		assert.Equal(t, CheckResult{Status: StatusUp}, report.Checks["migrations"])
+	})
+
+	t.Run("Database down", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		sut := NewReadinessUsecase(mockRepo, migrations)
+
+		mockRepo.On("Ping").Return(errors.New("connection refused"))
+		mockRepo.On("AppliedMigrations").Return(nil, errors.New("connection refused"))
+
+		report := sut.Check(context.Background())
+
+		assert.False(t, report.Ready())
+		assert.Equal(t, CheckResult{Status: StatusDown, Error: "connection refused"}, report.Checks["database"])
+	})
+
+	t.Run("Pending migrations", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		sut := NewReadinessUsecase(mockRepo, migrations)
+
+		// the second one was rolled back
+		mockRepo.On("Ping").Return(nil)
+		mockRepo.On("AppliedMigrations").Return(map[int64]bool{
+			20240817181058: true,
+			20240817181211: false,
+		}, nil)
+
+		report := sut.Check(context.Background())
+
+		assert.False(t, report.Ready())
+		assert.Equal(t, CheckResult{Status: StatusUp}, report.Checks["database"])
+		assert.Equal(t, CheckResult{Status: StatusDown, Error: "1 pending migrations, starting at 20240817181211"},
+			report.Checks["migrations"])
+	})
+
+	t.Run("Draining", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		sut := NewReadinessUsecase(mockRepo, migrations)
+
+		mockRepo.On("Ping").Return(nil)
+		mockRepo.On("AppliedMigrations").Return(map[int64]bool{
+			20240817181058: true,
+			20240817181211: true,
+		}, nil)
+
+		sut.Drain()
+		report := sut.Check(context.Background())
+
+		assert.False(t, report.Ready())
+		assert.Equal(t, CheckResult{Status: StatusDown, Error: "shutting down"}, report.Checks["shutdown"])
+		assert.Equal(t, CheckResult{Status: StatusUp}, report.Checks["database"])
+	})
+
+	t.Run("Migration without version", func(t *testing.T) {
+		_, err := migrationVersions(fstest.MapFS{"create_tenants_table.sql": &fstest.MapFile{}})
+
+		assert.EqualError(t, err, "migration create_tenants_table.sql has no version")
+	})
+}
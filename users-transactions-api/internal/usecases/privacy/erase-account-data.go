@@ -0,0 +1,192 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+// ErasedValue replaces the free text fields that may carry personal data,
+// such as transaction descriptions and status change reasons.
+const ErasedValue = "[erased]"
+
+// EraseAccountDataUsecase pseudonymizes an account. Amounts, dates and ids
+// are kept so the bookkeeping still balances, retention is how long they
+// must be kept before they can be purged.
+type EraseAccountDataUsecase struct {
+	repo      infra.QuerierTx
+	retention time.Duration
+}
+
+func NewEraseAccountDataUsecase(repo infra.QuerierTx, retention time.Duration) *EraseAccountDataUsecase {
+	return &EraseAccountDataUsecase{
+		repo:      repo,
+		retention: retention,
+	}
+}
+
+// Erase pseudonymizes the account, a non zero version makes it conditional
+// on the account still being at that version.
+func (uc *EraseAccountDataUsecase) Erase(ctx context.Context, tenantId int32, accountId int32, reason string,
+	actor string, version int32) (*infra.DataSubjectRequest, error) {
+	err := erasureInputValidation(reason)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var request infra.DataSubjectRequest
+
+	err = uc.repo.WithTenant(ctx, tenantId, func(q infra.Querier) error {
+		account, err := q.GetAccountForUpdate(ctx, infra.GetAccountForUpdateParams{
+			TenantID: tenantId,
+			ID:       accountId,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if version != 0 && version != account.Version {
+			return &shared.PreconditionFailedError{
+				Object:  "account",
+				Id:      accountId,
+				Version: account.Version,
+			}
+		}
+
+		if account.ErasedAt.Valid {
+			return &shared.AlreadyExistsError{
+				Object: "erasure for account",
+				Id:     accountId,
+			}
+		}
+
+		now := time.Now().UTC()
+		currentTime := sql.NullTime{
+			Time:  now,
+			Valid: true,
+		}
+
+		_, err = q.PseudonymizeAccountTransactions(ctx, infra.PseudonymizeAccountTransactionsParams{
+			Pseudonym: ErasedValue,
+			UpdatedAt: currentTime,
+			AccountID: accountId,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		err = q.PseudonymizeAccountStatusHistory(ctx, infra.PseudonymizeAccountStatusHistoryParams{
+			Pseudonym: ErasedValue,
+			AccountID: accountId,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		// closed after the history is pseudonymized so the closing entry
+		// keeps its reason
+		if account.Status != shared.AccountStatusClosed {
+			_, err = q.UpdateAccount(ctx, infra.UpdateAccountParams{
+				ID:        account.ID,
+				Status:    shared.AccountStatusClosed,
+				UpdatedAt: currentTime,
+				DeletedAt: currentTime,
+			})
+
+			if err != nil {
+				return err
+			}
+
+			_, err = q.CreateAccountStatusHistory(ctx, infra.CreateAccountStatusHistoryParams{
+				AccountID: account.ID,
+				FromStatus: sql.NullString{
+					String: account.Status,
+					Valid:  true,
+				},
+				ToStatus: shared.AccountStatusClosed,
+				Reason:   "data erasure request",
+				Actor:    actor,
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = q.MarkAccountErased(ctx, infra.MarkAccountErasedParams{
+			ID:       account.ID,
+			ErasedAt: currentTime,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		request, err = q.CreateDataSubjectRequest(ctx, infra.CreateDataSubjectRequestParams{
+			TenantID:  tenantId,
+			AccountID: accountId,
+			Kind:      RequestKindErasure,
+			Reason: sql.NullString{
+				String: reason,
+				Valid:  true,
+			},
+			Actor: actor,
+			RetainUntil: sql.NullTime{
+				Time:  now.Add(uc.retention),
+				Valid: true,
+			},
+		})
+		return err
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &shared.EntityNotFoundError{
+				Object: "account",
+				Id:     accountId,
+			}
+		}
+
+		switch err.(type) {
+		case *shared.AlreadyExistsError, *shared.PreconditionFailedError:
+			return nil, err
+		}
+
+		slog.ErrorContext(
+			ctx,
+			"error to erase account data",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+func erasureInputValidation(reason string) error {
+	valErr := &shared.ValidationError{
+		Errors: make(map[string]string),
+	}
+
+	if len(reason) == 0 {
+		valErr.AddError("reason", "cannot be empty")
+	}
+
+	if len(reason) > 255 {
+		valErr.AddError("reason", "must have at most 255 characters")
+	}
+
+	if valErr.HasErrors() {
+		return valErr
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEraseAccountDataUsecase(t *testing.T) {
+	t.Parallel()
+
+	account := infra.Account{ID: 1, TenantID: 1, Status: shared.AccountStatusActive}
+	erasure := infra.DataSubjectRequest{ID: 2, TenantID: 1, AccountID: 1, Kind: RequestKindErasure}
+
+	t.Run("Error input validation", func(t *testing.T) {
+		sut := NewEraseAccountDataUsecase(new(mocks.MockRepository), time.Hour)
+
+		result, err := sut.Erase(context.Background(), 1, account.ID, "", "user:1", 0)
+
+		assert.Nil(t, result)
+		assert.Equal(t, &shared.ValidationError{
+			Errors: map[string]string{"reason": "cannot be empty"},
+		}, err)
+	})
+
+	t.Run("Error account not found by id", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("GetAccountForUpdate").Return(nil, sql.ErrNoRows)
+
+		sut := NewEraseAccountDataUsecase(mockRepo, time.Hour)
+
+		result, err := sut.Erase(context.Background(), 1, account.ID, "customer request", "user:1", 0)
+
+		assert.Nil(t, result)
+		assert.Equal(t, "account not found with id 1", err.Error())
+	})
+
+	t.Run("Error account already erased", func(t *testing.T) {
+		erasedAccount := account
+		erasedAccount.ErasedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("GetAccountForUpdate").Return(erasedAccount, nil)
+
+		sut := NewEraseAccountDataUsecase(mockRepo, time.Hour)
+
+		result, err := sut.Erase(context.Background(), 1, account.ID, "customer request", "user:1", 0)
+
+		assert.Nil(t, result)
+		assert.IsType(t, &shared.AlreadyExistsError{}, err)
+		mockRepo.AssertNotCalled(t, "PseudonymizeAccountTransactions")
+	})
+
+	t.Run("Error stale version", func(t *testing.T) {
+		versionedAccount := account
+		versionedAccount.Version = 3
+
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("GetAccountForUpdate").Return(versionedAccount, nil)
+
+		sut := NewEraseAccountDataUsecase(mockRepo, time.Hour)
+
+		result, err := sut.Erase(context.Background(), 1, account.ID, "customer request", "user:1", 2)
+
+		assert.Nil(t, result)
+		assert.IsType(t, &shared.PreconditionFailedError{}, err)
+		mockRepo.AssertNotCalled(t, "PseudonymizeAccountTransactions")
+	})
+
+	t.Run("Error to pseudonymize transactions", func(t *testing.T) {
+		expectedErr := errors.New("internal repo error")
+
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("GetAccountForUpdate").Return(account, nil)
+		mockRepo.On("PseudonymizeAccountTransactions").Return(nil, expectedErr)
+
+		sut := NewEraseAccountDataUsecase(mockRepo, time.Hour)
+
+		result, err := sut.Erase(context.Background(), 1, account.ID, "customer request", "user:1", 0)
+
+		assert.Nil(t, result)
+		assert.Equal(t, expectedErr.Error(), err.Error())
+	})
+
+	t.Run("Success closes and pseudonymizes an active account", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("GetAccountForUpdate").Return(account, nil)
+		mockRepo.On("PseudonymizeAccountTransactions").Return(int64(3), nil)
+		mockRepo.On("PseudonymizeAccountStatusHistory").Return(nil)
+		mockRepo.On("UpdateAccount").Return(account, nil)
+		mockRepo.On("CreateAccountStatusHistory").Return(infra.AccountStatusHistory{}, nil)
+		mockRepo.On("MarkAccountErased").Return(account, nil)
+		mockRepo.On("CreateDataSubjectRequest").Return(erasure, nil)
+
+		sut := NewEraseAccountDataUsecase(mockRepo, time.Hour)
+
+		result, err := sut.Erase(context.Background(), 1, account.ID, "customer request", "user:1", 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &erasure, result)
+		mockRepo.AssertCalled(t, "UpdateAccount")
+		mockRepo.AssertCalled(t, "CreateAccountStatusHistory")
+	})
+
+	t.Run("Success keeps a closed account closed", func(t *testing.T) {
+		closedAccount := account
+		closedAccount.Status = shared.AccountStatusClosed
+
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("GetAccountForUpdate").Return(closedAccount, nil)
+		mockRepo.On("PseudonymizeAccountTransactions").Return(int64(0), nil)
+		mockRepo.On("PseudonymizeAccountStatusHistory").Return(nil)
+		mockRepo.On("MarkAccountErased").Return(closedAccount, nil)
+		mockRepo.On("CreateDataSubjectRequest").Return(erasure, nil)
+
+		sut := NewEraseAccountDataUsecase(mockRepo, time.Hour)
+
+		result, err := sut.Erase(context.Background(), 1, account.ID, "customer request", "user:1", 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &erasure, result)
+		mockRepo.AssertNotCalled(t, "UpdateAccount")
+	})
+}
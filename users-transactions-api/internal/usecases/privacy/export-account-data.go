@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+const (
+	RequestKindExport  = "export"
+	RequestKindErasure = "erasure"
+
+	FormatJSON = "json"
+	FormatZIP  = "zip"
+)
+
+// AccountDataExport is everything held about an account, as delivered to
+// a data subject access request.
+type AccountDataExport struct {
+	GeneratedAt   time.Time
+	Account       infra.Account
+	Cards         []infra.Card
+	Transactions  []infra.Transaction
+	StatusHistory []infra.AccountStatusHistory
+	Requests      []infra.DataSubjectRequest
+}
+
+type ExportAccountDataUsecase struct {
+	repo infra.QuerierTx
+}
+
+func NewExportAccountDataUsecase(repo infra.QuerierTx) *ExportAccountDataUsecase {
+	return &ExportAccountDataUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *ExportAccountDataUsecase) Export(ctx context.Context, tenantId int32, accountId int32, format string,
+	actor string) (*AccountDataExport, error) {
+	if format != FormatJSON && format != FormatZIP {
+		valErr := &shared.ValidationError{
+			Errors: make(map[string]string),
+		}
+		valErr.AddError("format", "must be json or zip")
+		return nil, valErr
+	}
+
+	export := &AccountDataExport{
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	err := uc.repo.WithTenant(ctx, tenantId, func(q infra.Querier) (err error) {
+		export.Account, err = q.GetAccount(ctx, infra.GetAccountParams{
+			TenantID: tenantId,
+			ID:       accountId,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		export.Cards, err = q.GetCards(ctx, accountId)
+
+		if err != nil {
+			return err
+		}
+
+		export.Transactions, err = q.GetAccountTransactions(ctx, accountId)
+
+		if err != nil {
+			return err
+		}
+
+		export.StatusHistory, err = q.GetAccountStatusHistory(ctx, accountId)
+
+		if err != nil {
+			return err
+		}
+
+		// recorded in the same transaction, so the bundle lists itself and
+		// no export is delivered without a trace
+		_, err = q.CreateDataSubjectRequest(ctx, infra.CreateDataSubjectRequestParams{
+			TenantID:  tenantId,
+			AccountID: accountId,
+			Kind:      RequestKindExport,
+			Format: sql.NullString{
+				String: format,
+				Valid:  true,
+			},
+			Actor: actor,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		export.Requests, err = q.GetDataSubjectRequests(ctx, infra.GetDataSubjectRequestsParams{
+			TenantID:  tenantId,
+			AccountID: accountId,
+		})
+		return err
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &shared.EntityNotFoundError{
+				Object: "account",
+				Id:     accountId,
+			}
+		}
+		slog.ErrorContext(
+			ctx,
+			"error to export account data",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	return export, nil
+}
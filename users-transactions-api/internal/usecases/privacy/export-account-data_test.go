@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportAccountDataUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+	account := infra.Account{ID: 1, TenantID: 1, Status: shared.AccountStatusActive}
+	cards := []infra.Card{{ID: 1, AccountID: 1, Amount: 200}}
+	transactions := []infra.Transaction{{ID: 1, CardID: 1, Kind: "Streaming Z", Value: 200}}
+	requests := []infra.DataSubjectRequest{{ID: 1, TenantID: 1, AccountID: 1, Kind: RequestKindExport}}
+
+	sut := NewExportAccountDataUsecase(mockRepo)
+
+	t.Run("Error invalid format", func(t *testing.T) {
+		result, err := sut.Export(context.Background(), 1, account.ID, "xml", "user:1")
+
+		assert.Nil(t, result)
+		assert.Equal(t, &shared.ValidationError{
+			Errors: map[string]string{"format": "must be json or zip"},
+		}, err)
+	})
+
+	t.Run("Error account not found by id", func(t *testing.T) {
+		mockRepo.On("GetAccount").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetAccount").Unset()
+
+		result, err := sut.Export(context.Background(), 1, account.ID, FormatJSON, "user:1")
+
+		assert.Nil(t, result)
+		assert.Equal(t, "account not found with id 1", err.Error())
+	})
+
+	t.Run("Error to record the request", func(t *testing.T) {
+		expectedErr := errors.New("internal repo error")
+
+		mockRepo.On("GetAccount").Return(account, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetCards").Return(cards, nil)
+		defer mockRepo.On("GetCards").Unset()
+
+		mockRepo.On("GetAccountTransactions").Return(transactions, nil)
+		defer mockRepo.On("GetAccountTransactions").Unset()
+
+		mockRepo.On("GetAccountStatusHistory").Return(nil, nil)
+		defer mockRepo.On("GetAccountStatusHistory").Unset()
+
+		mockRepo.On("CreateDataSubjectRequest").Return(nil, expectedErr)
+		defer mockRepo.On("CreateDataSubjectRequest").Unset()
+
+		result, err := sut.Export(context.Background(), 1, account.ID, FormatJSON, "user:1")
+
+		assert.Nil(t, result)
+		assert.Equal(t, expectedErr.Error(), err.Error())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.On("GetAccount").Return(account, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetCards").Return(cards, nil)
+		defer mockRepo.On("GetCards").Unset()
+
+		mockRepo.On("GetAccountTransactions").Return(transactions, nil)
+		defer mockRepo.On("GetAccountTransactions").Unset()
+
+		mockRepo.On("GetAccountStatusHistory").Return(nil, nil)
+		defer mockRepo.On("GetAccountStatusHistory").Unset()
+
+		mockRepo.On("CreateDataSubjectRequest").Return(requests[0], nil)
+		defer mockRepo.On("CreateDataSubjectRequest").Unset()
+
+		mockRepo.On("GetDataSubjectRequests").Return(requests, nil)
+		defer mockRepo.On("GetDataSubjectRequests").Unset()
+
+		result, err := sut.Export(context.Background(), 1, account.ID, FormatZIP, "user:1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, account, result.Account)
+		assert.Equal(t, cards, result.Cards)
+		assert.Equal(t, transactions, result.Transactions)
+		assert.Equal(t, requests, result.Requests)
+		assert.False(t, result.GeneratedAt.IsZero())
+	})
+}
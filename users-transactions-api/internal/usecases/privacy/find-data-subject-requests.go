@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+type FindDataSubjectRequestsUsecase struct {
+	repo infra.QuerierTx
+}
+
+func NewFindDataSubjectRequestsUsecase(repo infra.QuerierTx) *FindDataSubjectRequestsUsecase {
+	return &FindDataSubjectRequestsUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *FindDataSubjectRequestsUsecase) FindAll(ctx context.Context, tenantId int32,
+	accountId int32) ([]infra.DataSubjectRequest, error) {
+	var requests []infra.DataSubjectRequest
+
+	err := uc.repo.WithTenant(ctx, tenantId, func(q infra.Querier) (err error) {
+		requests, err = q.GetDataSubjectRequests(ctx, infra.GetDataSubjectRequestsParams{
+			TenantID:  tenantId,
+			AccountID: accountId,
+		})
+		return err
+	})
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error to find data subject requests",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	return requests, nil
+}
@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDataSubjectRequestsUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+	requests := []infra.DataSubjectRequest{
+		{ID: 1, TenantID: 1, AccountID: 1, Kind: RequestKindExport},
+		{ID: 2, TenantID: 1, AccountID: 1, Kind: RequestKindErasure},
+	}
+
+	sut := NewFindDataSubjectRequestsUsecase(mockRepo)
+
+	t.Run("Error to find requests", func(t *testing.T) {
+		expectedErr := errors.New("internal repo error")
+
+		mockRepo.On("GetDataSubjectRequests").Return(nil, expectedErr)
+		defer mockRepo.On("GetDataSubjectRequests").Unset()
+
+		result, err := sut.FindAll(context.Background(), 1, 1)
+
+		assert.Nil(t, result)
+		assert.Equal(t, expectedErr.Error(), err.Error())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.On("GetDataSubjectRequests").Return(requests, nil)
+		defer mockRepo.On("GetDataSubjectRequests").Unset()
+
+		result, err := sut.FindAll(context.Background(), 1, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, requests, result)
+	})
+}
@@ -0,0 +1,162 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	settingsUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/settings"
+)
+
+// QuotaUsecase checks the hard limits configured in the tenant settings
+// before new resources are created. A zero limit means unlimited.
+//
+// The checks run with the querier of the transaction that creates the
+// resource. A limited tenant is locked until that transaction ends, so
+// concurrent creations are counted one after the other and cannot all pass
+// the same count.
+type QuotaUsecase struct {
+	findTenantSettingsUsecase *settingsUsecases.FindTenantSettingsUsecase
+	now                       func() time.Time
+}
+
+func NewQuotaUsecase(findTenantSettingsUsecase *settingsUsecases.FindTenantSettingsUsecase) *QuotaUsecase {
+	return &QuotaUsecase{
+		findTenantSettingsUsecase: findTenantSettingsUsecase,
+		now:                       time.Now,
+	}
+}
+
+func (uc *QuotaUsecase) CheckAccounts(ctx context.Context, q infra.Querier, tenantId int32) error {
+	settings, err := uc.findSettings(ctx, tenantId)
+
+	if err != nil || settings == nil || settings.MaxAccounts <= 0 {
+		return err
+	}
+
+	count, err := lockAndCount(ctx, q, tenantId, func() (int64, error) {
+		return q.CountActiveAccounts(ctx, tenantId)
+	})
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error to count accounts",
+			slog.String("err", err.Error()),
+		)
+		return err
+	}
+
+	if count >= int64(settings.MaxAccounts) {
+		return &shared.QuotaExceededError{
+			Quota: "accounts",
+			Limit: settings.MaxAccounts,
+		}
+	}
+
+	return nil
+}
+
+func (uc *QuotaUsecase) CheckCards(ctx context.Context, q infra.Querier, tenantId int32, accountId int32) error {
+	settings, err := uc.findSettings(ctx, tenantId)
+
+	if err != nil || settings == nil || settings.MaxCardsPerAccount <= 0 {
+		return err
+	}
+
+	count, err := lockAndCount(ctx, q, tenantId, func() (int64, error) {
+		return q.CountCards(ctx, accountId)
+	})
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error to count cards",
+			slog.String("err", err.Error()),
+		)
+		return err
+	}
+
+	if count >= int64(settings.MaxCardsPerAccount) {
+		return &shared.QuotaExceededError{
+			Quota: "cards per account",
+			Limit: settings.MaxCardsPerAccount,
+		}
+	}
+
+	return nil
+}
+
+// CheckTransactions counts the transactions created since midnight in the
+// tenant timezone, the quota is reset at the next midnight.
+func (uc *QuotaUsecase) CheckTransactions(ctx context.Context, q infra.Querier, tenantId int32) error {
+	settings, err := uc.findSettings(ctx, tenantId)
+
+	if err != nil || settings == nil || settings.MaxTransactionsPerDay <= 0 {
+		return err
+	}
+
+	location, err := time.LoadLocation(settings.Timezone)
+
+	if err != nil {
+		location = time.UTC
+	}
+
+	now := uc.now().In(location)
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+
+	count, err := lockAndCount(ctx, q, tenantId, func() (int64, error) {
+		return q.CountTransactionsSince(ctx, infra.CountTransactionsSinceParams{
+			TenantID: tenantId,
+			Since:    startOfDay,
+		})
+	})
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error to count transactions",
+			slog.String("err", err.Error()),
+		)
+		return err
+	}
+
+	if count >= int64(settings.MaxTransactionsPerDay) {
+		return &shared.QuotaExceededError{
+			Quota:      "transactions per day",
+			Limit:      settings.MaxTransactionsPerDay,
+			RetryAfter: startOfDay.AddDate(0, 0, 1).Sub(now),
+		}
+	}
+
+	return nil
+}
+
+// lockAndCount holds the tenant quota lock, released with the transaction of
+// q, before counting.
+func lockAndCount(ctx context.Context, q infra.Querier, tenantId int32, count func() (int64, error)) (int64, error) {
+	err := q.LockTenantQuota(ctx, tenantId)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count()
+}
+
+// findSettings returns nil settings for tenants without them, which are
+// not limited.
+func (uc *QuotaUsecase) findSettings(ctx context.Context, tenantId int32) (*infra.TenantSetting, error) {
+	settings, err := uc.findTenantSettingsUsecase.FindOne(ctx, tenantId)
+
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotFoundError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return settings, nil
+}
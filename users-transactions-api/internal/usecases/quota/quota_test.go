@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	settingsUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaUsecase(t *testing.T) {
+	t.Parallel()
+
+	settings := infra.TenantSetting{
+		TenantID:              1,
+		Timezone:              "America/Sao_Paulo",
+		MaxAccounts:           2,
+		MaxCardsPerAccount:    1,
+		MaxTransactionsPerDay: 10,
+	}
+
+	newSut := func(mockRepo *mocks.MockRepository) *QuotaUsecase {
+		findTenantSettingsUsecase := settingsUsecases.NewFindTenantSettingsUsecase(mockRepo,
+			settingsUsecases.NewSettingsCache(0))
+		return NewQuotaUsecase(findTenantSettingsUsecase)
+	}
+
+	t.Run("Tenant without settings is not limited", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		sut := newSut(mockRepo)
+
+		mockRepo.On("GetTenantSettings").Return(nil, sql.ErrNoRows)
+
+		assert.NoError(t, sut.CheckAccounts(context.Background(), mockRepo, 1))
+		assert.NoError(t, sut.CheckCards(context.Background(), mockRepo, 1, 1))
+		assert.NoError(t, sut.CheckTransactions(context.Background(), mockRepo, 1))
+	})
+
+	t.Run("Zero limits are not enforced", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		sut := newSut(mockRepo)
+
+		mockRepo.On("GetTenantSettings").Return(infra.TenantSetting{TenantID: 1}, nil)
+
+		assert.NoError(t, sut.CheckAccounts(context.Background(), mockRepo, 1))
+		mockRepo.AssertNotCalled(t, "LockTenantQuota")
+		mockRepo.AssertNotCalled(t, "CountActiveAccounts")
+	})
+
+	t.Run("Accounts quota exceeded", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		sut := newSut(mockRepo)
+
+		mockRepo.On("GetTenantSettings").Return(settings, nil)
+		mockRepo.On("LockTenantQuota").Return(nil)
+		mockRepo.On("CountActiveAccounts").Return(int64(2), nil)
+
+		err := sut.CheckAccounts(context.Background(), mockRepo, 1)
+
+		assert.Equal(t, &shared.QuotaExceededError{
+			Quota: "accounts",
+			Limit: 2,
+		}, err)
+		mockRepo.AssertCalled(t, "LockTenantQuota")
+	})
+
+	t.Run("Cards quota not reached", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		sut := newSut(mockRepo)
+
+		mockRepo.On("GetTenantSettings").Return(settings, nil)
+		mockRepo.On("LockTenantQuota").Return(nil)
+		mockRepo.On("CountCards").Return(int64(0), nil)
+
+		assert.NoError(t, sut.CheckCards(context.Background(), mockRepo, 1, 1))
+	})
+
+	t.Run("Transactions quota resets at midnight in tenant timezone", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		sut := newSut(mockRepo)
+
+		// 22:00 in Sao Paulo, two hours before the quota is reset
+		sut.now = func() time.Time {
+			return time.Date(2024, 10, 20, 1, 0, 0, 0, time.UTC)
+		}
+
+		mockRepo.On("GetTenantSettings").Return(settings, nil)
+		mockRepo.On("LockTenantQuota").Return(nil)
+		mockRepo.On("CountTransactionsSince").Return(int64(10), nil)
+
+		err := sut.CheckTransactions(context.Background(), mockRepo, 1)
+
+		assert.Equal(t, &shared.QuotaExceededError{
+			Quota:      "transactions per day",
+			Limit:      10,
+			RetryAfter: 2 * time.Hour,
+		}, err)
+	})
+}
@@ -0,0 +1,24 @@
+package usecases
+
+import (
+	"context"
+	"time"
+)
+
+// Limit configures a token bucket: Rate tokens are added per second up to
+// Burst tokens. A zero rate disables the limit.
+type Limit struct {
+	Rate  float64
+	Burst float64
+}
+
+// Limiter takes one token from the bucket of the tenant. When the bucket is
+// empty it returns false and how long the client should wait.
+type Limiter interface {
+	Take(ctx context.Context, tenantId int32, limit Limit) (bool, time.Duration, error)
+}
+
+// retryAfter returns the time needed for the bucket to hold one token again.
+func retryAfter(tokens float64, rate float64) time.Duration {
+	return time.Duration((1 - tokens) / rate * float64(time.Second))
+}
@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// MemoryLimiter keeps the buckets in the process, so each replica applies
+// the limits on its own.
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[int32]*bucket
+	now     func() time.Time
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets: make(map[int32]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (l *MemoryLimiter) Take(ctx context.Context, tenantId int32, limit Limit) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[tenantId]
+
+	if !ok {
+		b = &bucket{
+			tokens:    limit.Burst,
+			updatedAt: now,
+		}
+		l.buckets[tenantId] = b
+	}
+
+	elapsed := now.Sub(b.updatedAt).Seconds()
+	b.tokens = math.Min(limit.Burst, b.tokens+elapsed*limit.Rate)
+	b.updatedAt = now
+
+	if b.tokens < 1 {
+		return false, retryAfter(b.tokens, limit.Rate), nil
+	}
+
+	b.tokens--
+
+	return true, 0, nil
+}
@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryLimiter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 10, 20, 12, 0, 0, 0, time.UTC)
+
+	sut := NewMemoryLimiter()
+	sut.now = func() time.Time {
+		return now
+	}
+
+	limit := Limit{
+		Rate:  2,
+		Burst: 3,
+	}
+
+	t.Run("Allow burst then reject", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			allowed, _, err := sut.Take(context.Background(), 1, limit)
+
+			assert.NoError(t, err)
+			assert.True(t, allowed)
+		}
+
+		allowed, retryAfter, err := sut.Take(context.Background(), 1, limit)
+
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+		assert.Equal(t, 500*time.Millisecond, retryAfter)
+	})
+
+	t.Run("Buckets are per tenant", func(t *testing.T) {
+		allowed, _, err := sut.Take(context.Background(), 2, limit)
+
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("Refill over time", func(t *testing.T) {
+		now = now.Add(500 * time.Millisecond)
+
+		allowed, _, err := sut.Take(context.Background(), 1, limit)
+
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+	})
+}
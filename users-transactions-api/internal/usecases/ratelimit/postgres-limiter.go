@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+// PostgresLimiter keeps the buckets in the database so every replica shares
+// the same limits, at the cost of one query per request.
+type PostgresLimiter struct {
+	repo infra.Querier
+}
+
+func NewPostgresLimiter(repo infra.Querier) *PostgresLimiter {
+	return &PostgresLimiter{
+		repo: repo,
+	}
+}
+
+func (l *PostgresLimiter) Take(ctx context.Context, tenantId int32, limit Limit) (bool, time.Duration, error) {
+	tokens, err := l.repo.TakeRateLimitToken(ctx, infra.TakeRateLimitTokenParams{
+		TenantID: tenantId,
+		Burst:    limit.Burst,
+		Rate:     limit.Rate,
+	})
+
+	if err != nil {
+		return false, 0, err
+	}
+
+	// the rejected request also took its token, which is paid back before
+	// the next one is allowed
+	if tokens < 0 {
+		return false, retryAfter(tokens, limit.Rate), nil
+	}
+
+	return true, 0, nil
+}
@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	settingsUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/settings"
+)
+
+type RateLimitUsecase struct {
+	limiter                   Limiter
+	findTenantSettingsUsecase *settingsUsecases.FindTenantSettingsUsecase
+}
+
+func NewRateLimitUsecase(limiter Limiter,
+	findTenantSettingsUsecase *settingsUsecases.FindTenantSettingsUsecase) *RateLimitUsecase {
+	return &RateLimitUsecase{
+		limiter:                   limiter,
+		findTenantSettingsUsecase: findTenantSettingsUsecase,
+	}
+}
+
+// Allow takes one request from the tenant budget configured in its
+// settings. Tenants without settings are not limited.
+func (uc *RateLimitUsecase) Allow(ctx context.Context, tenantId int32) error {
+	settings, err := uc.findTenantSettingsUsecase.FindOne(ctx, tenantId)
+
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotFoundError); ok {
+			return nil
+		}
+		return err
+	}
+
+	if settings.RateLimitPerSecond <= 0 {
+		return nil
+	}
+
+	allowed, retryAfter, err := uc.limiter.Take(ctx, tenantId, Limit{
+		Rate:  float64(settings.RateLimitPerSecond),
+		Burst: float64(max(settings.RateLimitBurst, 1)),
+	})
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error to take rate limit token",
+			slog.String("err", err.Error()),
+		)
+		return err
+	}
+
+	if !allowed {
+		return &shared.RateLimitError{
+			RetryAfter: retryAfter,
+		}
+	}
+
+	return nil
+}
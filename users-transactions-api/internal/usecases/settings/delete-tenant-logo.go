@@ -0,0 +1,33 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+type DeleteTenantLogoUsecase struct {
+	repo infra.Querier
+}
+
+func NewDeleteTenantLogoUsecase(repo infra.Querier) *DeleteTenantLogoUsecase {
+	return &DeleteTenantLogoUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *DeleteTenantLogoUsecase) Delete(ctx context.Context, tenantId int32) error {
+	err := uc.repo.DeleteTenantLogo(ctx, tenantId)
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error when delete tenant logo",
+			slog.String("err", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
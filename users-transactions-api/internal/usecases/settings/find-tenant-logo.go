@@ -0,0 +1,41 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+type FindTenantLogoUsecase struct {
+	repo infra.Querier
+}
+
+func NewFindTenantLogoUsecase(repo infra.Querier) *FindTenantLogoUsecase {
+	return &FindTenantLogoUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *FindTenantLogoUsecase) FindOne(ctx context.Context, tenantId int32) (*infra.TenantLogo, error) {
+	logo, err := uc.repo.GetTenantLogo(ctx, tenantId)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &shared.EntityNotFoundError{
+				Object: "tenant logo",
+				Id:     tenantId,
+			}
+		}
+		slog.ErrorContext(
+			ctx,
+			"error to find tenant logo",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	return &logo, nil
+}
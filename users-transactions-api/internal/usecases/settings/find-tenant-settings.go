@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+type FindTenantSettingsUsecase struct {
+	repo  infra.Querier
+	cache *SettingsCache
+}
+
+func NewFindTenantSettingsUsecase(repo infra.Querier, cache *SettingsCache) *FindTenantSettingsUsecase {
+	return &FindTenantSettingsUsecase{
+		repo:  repo,
+		cache: cache,
+	}
+}
+
+func (uc *FindTenantSettingsUsecase) FindOne(ctx context.Context, tenantId int32) (*infra.TenantSetting, error) {
+	if settings, found := uc.cache.Get(tenantId); found {
+		return &settings, nil
+	}
+
+	settings, err := uc.repo.GetTenantSettings(ctx, tenantId)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &shared.EntityNotFoundError{
+				Object: "tenant settings",
+				Id:     tenantId,
+			}
+		}
+		slog.ErrorContext(
+			ctx,
+			"error to find tenant settings",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	uc.cache.Set(settings)
+
+	return &settings, nil
+}
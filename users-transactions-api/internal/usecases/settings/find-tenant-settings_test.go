@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindTenantSettingsUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+
+	settings := infra.TenantSetting{
+		TenantID:   1,
+		Currency:   "BRL",
+		Timezone:   "America/Sao_Paulo",
+		Locale:     "pt-BR",
+		ClosingDay: 1,
+	}
+
+	t.Run("Error tenant settings not found", func(t *testing.T) {
+		sut := NewFindTenantSettingsUsecase(mockRepo, NewSettingsCache(time.Minute))
+
+		mockRepo.On("GetTenantSettings").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetTenantSettings").Unset()
+
+		result, err := sut.FindOne(context.Background(), settings.TenantID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, &shared.EntityNotFoundError{
+			Object: "tenant settings",
+			Id:     settings.TenantID,
+		}, err)
+	})
+
+	t.Run("Find tenant settings and cache the result", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+
+		sut := NewFindTenantSettingsUsecase(mockRepo, NewSettingsCache(time.Minute))
+
+		mockRepo.On("GetTenantSettings").Return(settings, nil).Once()
+
+		result, err := sut.FindOne(context.Background(), settings.TenantID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &settings, result)
+
+		result, err = sut.FindOne(context.Background(), settings.TenantID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &settings, result)
+		mockRepo.AssertNumberOfCalls(t, "GetTenantSettings", 1)
+	})
+}
@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"sync"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+type cachedSettings struct {
+	settings  infra.TenantSetting
+	expiresAt time.Time
+}
+
+// SettingsCache keeps tenant settings in memory since they are read on
+// every request. Writes through this instance invalidate it right away,
+// other replicas see the change once the ttl expires.
+type SettingsCache struct {
+	mutex   sync.RWMutex
+	ttl     time.Duration
+	entries map[int32]cachedSettings
+}
+
+func NewSettingsCache(ttl time.Duration) *SettingsCache {
+	return &SettingsCache{
+		ttl:     ttl,
+		entries: make(map[int32]cachedSettings),
+	}
+}
+
+func (c *SettingsCache) Get(tenantId int32) (infra.TenantSetting, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	entry, found := c.entries[tenantId]
+
+	if !found || time.Now().After(entry.expiresAt) {
+		return infra.TenantSetting{}, false
+	}
+
+	return entry.settings, true
+}
+
+func (c *SettingsCache) Set(settings infra.TenantSetting) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[settings.TenantID] = cachedSettings{
+		settings:  settings,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
+
+func (c *SettingsCache) Invalidate(tenantId int32) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.entries, tenantId)
+}
+
+func (c *SettingsCache) TTL() time.Duration {
+	return c.ttl
+}
@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+// UpdateTenantLimitsUsecase changes the rate limit and quotas of a tenant.
+// It is reserved to the platform admin, tenants can only read their limits.
+type UpdateTenantLimitsUsecase struct {
+	repo  infra.Querier
+	cache *SettingsCache
+}
+
+func NewUpdateTenantLimitsUsecase(repo infra.Querier, cache *SettingsCache) *UpdateTenantLimitsUsecase {
+	return &UpdateTenantLimitsUsecase{
+		repo:  repo,
+		cache: cache,
+	}
+}
+
+func (uc *UpdateTenantLimitsUsecase) Update(ctx context.Context, tenantId int32, limits infra.TenantSetting) (*infra.TenantSetting, error) {
+	err := limitsInputValidation(limits)
+
+	if err != nil {
+		return nil, err
+	}
+
+	updatedSettings, err := uc.repo.UpdateTenantLimits(ctx, infra.UpdateTenantLimitsParams{
+		TenantID:              tenantId,
+		RateLimitPerSecond:    limits.RateLimitPerSecond,
+		RateLimitBurst:        limits.RateLimitBurst,
+		MaxAccounts:           limits.MaxAccounts,
+		MaxCardsPerAccount:    limits.MaxCardsPerAccount,
+		MaxTransactionsPerDay: limits.MaxTransactionsPerDay,
+		UpdatedAt: sql.NullTime{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		},
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &shared.EntityNotFoundError{
+				Object: "tenant settings",
+				Id:     tenantId,
+			}
+		}
+		slog.ErrorContext(
+			ctx,
+			"error when update tenant limits",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	uc.cache.Invalidate(tenantId)
+
+	return &updatedSettings, nil
+}
+
+func limitsInputValidation(s infra.TenantSetting) error {
+	valErr := &shared.ValidationError{
+		Errors: make(map[string]string),
+	}
+
+	limits := map[string]int32{
+		"rate_limit_per_second":    s.RateLimitPerSecond,
+		"rate_limit_burst":         s.RateLimitBurst,
+		"max_accounts":             s.MaxAccounts,
+		"max_cards_per_account":    s.MaxCardsPerAccount,
+		"max_transactions_per_day": s.MaxTransactionsPerDay,
+	}
+
+	for field, value := range limits {
+		if value < 0 {
+			valErr.AddError(field, "cannot be negative")
+		}
+	}
+
+	if s.RateLimitPerSecond > 0 && s.RateLimitBurst < 1 {
+		valErr.AddError("rate_limit_burst", "must be at least 1 when the rate limit is enabled")
+	}
+
+	if valErr.HasErrors() {
+		return valErr
+	}
+
+	return nil
+}
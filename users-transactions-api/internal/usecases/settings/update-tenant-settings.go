@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"regexp"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+)
+
+var colorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+type UpdateTenantSettingsUsecase struct {
+	repo  infra.Querier
+	cache *SettingsCache
+}
+
+func NewUpdateTenantSettingsUsecase(repo infra.Querier, cache *SettingsCache) *UpdateTenantSettingsUsecase {
+	return &UpdateTenantSettingsUsecase{
+		repo:  repo,
+		cache: cache,
+	}
+}
+
+func (uc *UpdateTenantSettingsUsecase) Update(ctx context.Context, tenantId int32, settings infra.TenantSetting) (*infra.TenantSetting, error) {
+	err := settingsInputValidation(settings)
+
+	if err != nil {
+		return nil, err
+	}
+
+	updatedSettings, err := uc.repo.UpdateTenantSettings(ctx, infra.UpdateTenantSettingsParams{
+		TenantID:       tenantId,
+		Currency:       settings.Currency,
+		Timezone:       settings.Timezone,
+		Locale:         settings.Locale,
+		ClosingDay:     settings.ClosingDay,
+		DisplayName:    settings.DisplayName,
+		PrimaryColor:   settings.PrimaryColor,
+		SecondaryColor: settings.SecondaryColor,
+		UpdatedAt: sql.NullTime{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		},
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &shared.EntityNotFoundError{
+				Object: "tenant settings",
+				Id:     tenantId,
+			}
+		}
+		slog.ErrorContext(
+			ctx,
+			"error when update tenant settings",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	uc.cache.Invalidate(tenantId)
+
+	return &updatedSettings, nil
+}
+
+func settingsInputValidation(s infra.TenantSetting) error {
+	valErr := &shared.ValidationError{
+		Errors: make(map[string]string),
+	}
+
+	if _, err := currency.ParseISO(s.Currency); err != nil || len(s.Currency) != 3 {
+		valErr.AddError("currency", "must be an ISO 4217 currency code")
+	}
+
+	if _, err := time.LoadLocation(s.Timezone); err != nil || len(s.Timezone) == 0 {
+		valErr.AddError("timezone", "must be an IANA time zone")
+	}
+
+	if _, err := language.Parse(s.Locale); err != nil {
+		valErr.AddError("locale", "must be a BCP 47 language tag")
+	}
+
+	// days after the 28th do not exist in every month
+	if s.ClosingDay < 1 || s.ClosingDay > 28 {
+		valErr.AddError("closing_day", "must be between 1 and 28")
+	}
+
+	if len(s.DisplayName.String) > 255 {
+		valErr.AddError("display_name", "must have at most 255 characters")
+	}
+
+	if !colorPattern.MatchString(s.PrimaryColor) {
+		valErr.AddError("primary_color", "must be a hex color like #1A2B3C")
+	}
+
+	if !colorPattern.MatchString(s.SecondaryColor) {
+		valErr.AddError("secondary_color", "must be a hex color like #1A2B3C")
+	}
+
+	if valErr.HasErrors() {
+		return valErr
+	}
+
+	return nil
+}
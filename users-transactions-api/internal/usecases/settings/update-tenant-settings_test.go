@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateTenantSettingsUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+
+	cache := NewSettingsCache(time.Minute)
+
+	sut := NewUpdateTenantSettingsUsecase(mockRepo, cache)
+
+	settings := infra.TenantSetting{
+		TenantID:       1,
+		Currency:       "USD",
+		Timezone:       "America/New_York",
+		Locale:         "en-US",
+		ClosingDay:     10,
+		PrimaryColor:   "#112233",
+		SecondaryColor: "#ffffff",
+	}
+
+	t.Run("Error invalid settings", func(t *testing.T) {
+		result, err := sut.Update(context.Background(), settings.TenantID, infra.TenantSetting{
+			Currency:       "XX",
+			Timezone:       "Mars/Olympus",
+			Locale:         "!!",
+			ClosingDay:     31,
+			PrimaryColor:   "red",
+			SecondaryColor: "#fff",
+		})
+
+		valErr, ok := err.(*shared.ValidationError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+		assert.Len(t, valErr.Errors, 6)
+	})
+
+	t.Run("Update settings and invalidate cache", func(t *testing.T) {
+		cache.Set(infra.TenantSetting{TenantID: settings.TenantID})
+
+		mockRepo.On("UpdateTenantSettings").Return(settings, nil)
+		defer mockRepo.On("UpdateTenantSettings").Unset()
+
+		result, err := sut.Update(context.Background(), settings.TenantID, settings)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &settings, result)
+
+		_, found := cache.Get(settings.TenantID)
+
+		assert.False(t, found)
+	})
+}
@@ -0,0 +1,77 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+const MaxLogoSize = 256 * 1024
+
+var allowedLogoTypes = map[string]struct{}{
+	"image/png":  {},
+	"image/jpeg": {},
+}
+
+type UploadTenantLogoUsecase struct {
+	repo infra.Querier
+}
+
+func NewUploadTenantLogoUsecase(repo infra.Querier) *UploadTenantLogoUsecase {
+	return &UploadTenantLogoUsecase{
+		repo: repo,
+	}
+}
+
+// Upload stores the logo printed on the tenant reports. The content type
+// is sniffed from the data, the one declared by the client is not trusted.
+func (uc *UploadTenantLogoUsecase) Upload(ctx context.Context, tenantId int32, data []byte) (*infra.TenantLogo, error) {
+	contentType := http.DetectContentType(data)
+
+	err := logoInputValidation(contentType, data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logo, err := uc.repo.UpsertTenantLogo(ctx, infra.UpsertTenantLogoParams{
+		TenantID:    tenantId,
+		ContentType: contentType,
+		Data:        data,
+		UpdatedAt:   time.Now().UTC(),
+	})
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error when save tenant logo",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	return &logo, nil
+}
+
+func logoInputValidation(contentType string, data []byte) error {
+	valErr := &shared.ValidationError{
+		Errors: make(map[string]string),
+	}
+
+	if len(data) == 0 || len(data) > MaxLogoSize {
+		valErr.AddError("logo", fmt.Sprintf("must have between 1 and %d bytes", MaxLogoSize))
+	} else if _, ok := allowedLogoTypes[contentType]; !ok {
+		valErr.AddError("logo", "must be a png or jpeg image")
+	}
+
+	if valErr.HasErrors() {
+		return valErr
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadTenantLogoUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+
+	sut := NewUploadTenantLogoUsecase(mockRepo)
+
+	png := append([]byte("\x89PNG\x0D\x0A\x1A\x0A"), make([]byte, 16)...)
+
+	t.Run("Error unsupported content type", func(t *testing.T) {
+		result, err := sut.Upload(context.Background(), 1, []byte("GIF89a"))
+
+		valErr, ok := err.(*shared.ValidationError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+		assert.Equal(t, "must be a png or jpeg image", valErr.Errors["logo"])
+	})
+
+	t.Run("Error logo too large", func(t *testing.T) {
+		data := append(png, bytes.Repeat([]byte{0}, MaxLogoSize)...)
+
+		result, err := sut.Upload(context.Background(), 1, data)
+
+		_, ok := err.(*shared.ValidationError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+
+	t.Run("Upload png logo successfully", func(t *testing.T) {
+		logo := infra.TenantLogo{
+			TenantID:    1,
+			ContentType: "image/png",
+			Data:        png,
+		}
+
+		mockRepo.On("UpsertTenantLogo").Return(logo, nil)
+		defer mockRepo.On("UpsertTenantLogo").Unset()
+
+		result, err := sut.Upload(context.Background(), 1, png)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &logo, result)
+	})
+}
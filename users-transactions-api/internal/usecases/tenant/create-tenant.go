@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/ports"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	userUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/user"
+)
+
+type CreateTenantUsecase struct {
+	repo   infra.QuerierTx
+	mailer ports.Mailer
+}
+
+func NewCreateTenantUsecase(repo infra.QuerierTx, mailer ports.Mailer) *CreateTenantUsecase {
+	return &CreateTenantUsecase{
+		repo:   repo,
+		mailer: mailer,
+	}
+}
+
+// Create saves the tenant together with its default settings and invites
+// adminEmail as the tenant owner.
+func (uc *CreateTenantUsecase) Create(ctx context.Context, name string, adminEmail string) (*infra.CreateTenantTxResult, error) {
+	name = strings.TrimSpace(name)
+
+	err := tenantInputValidation(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	admin, adminToken, token, err := userUsecases.NewOwnerInvitation(adminEmail)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := uc.repo.CreateTenantTx(ctx, infra.CreateTenantTxParams{
+		Name:       name,
+		Admin:      admin,
+		AdminToken: adminToken,
+	})
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error creating tenant",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	err = uc.mailer.Send(userUsecases.InvitationMail(result.Admin, token, adminToken.ExpiresAt))
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error sending invitation mail",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func tenantInputValidation(name string) error {
+	valErr := &shared.ValidationError{
+		Errors: make(map[string]string),
+	}
+
+	if len(name) == 0 || len(name) > 255 {
+		valErr.AddError("name", "must have between 1 and 255 characters")
+	}
+
+	if valErr.HasErrors() {
+		return valErr
+	}
+
+	return nil
+}
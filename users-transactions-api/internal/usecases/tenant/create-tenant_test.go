@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTenantUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+	mockMailer := new(mocks.MockMailer)
+
+	result := infra.CreateTenantTxResult{
+		Tenant: infra.Tenant{
+			ID:     6,
+			Name:   "Tenant F",
+			Status: shared.TenantStatusActive,
+		},
+		Settings: infra.TenantSetting{
+			TenantID: 6,
+			Currency: "BRL",
+		},
+		Admin: infra.User{
+			ID:       1,
+			TenantID: 6,
+			Email:    "owner@example.com",
+			Role:     shared.RoleOwner,
+		},
+	}
+
+	sut := NewCreateTenantUsecase(mockRepo, mockMailer)
+
+	t.Run("Error invalid input", func(t *testing.T) {
+		tenant, err := sut.Create(context.Background(), "  ", "owner@example.com")
+
+		valErr, ok := err.(*shared.ValidationError)
+
+		assert.Nil(t, tenant)
+		assert.True(t, ok)
+		assert.Equal(t, "must have between 1 and 255 characters", valErr.Errors["name"])
+	})
+
+	t.Run("Error invalid admin email", func(t *testing.T) {
+		tenant, err := sut.Create(context.Background(), "Tenant F", "not-an-email")
+
+		valErr, ok := err.(*shared.ValidationError)
+
+		assert.Nil(t, tenant)
+		assert.True(t, ok)
+		assert.Equal(t, "must be a valid email address", valErr.Errors["email"])
+	})
+
+	t.Run("Error to create tenant", func(t *testing.T) {
+		expectedErr := errors.New("internal repo error")
+
+		mockRepo.On("CreateTenantTx").Return(nil, expectedErr)
+		defer mockRepo.On("CreateTenantTx").Unset()
+
+		tenant, err := sut.Create(context.Background(), "Tenant F", "owner@example.com")
+
+		assert.Nil(t, tenant)
+		assert.Equal(t, expectedErr, err)
+		mockMailer.AssertNotCalled(t, "Send")
+	})
+
+	t.Run("Create tenant and invite owner successfully", func(t *testing.T) {
+		mockRepo.On("CreateTenantTx").Return(result, nil)
+		defer mockRepo.On("CreateTenantTx").Unset()
+
+		mockMailer.On("Send").Return(nil)
+		defer mockMailer.On("Send").Unset()
+
+		tenant, err := sut.Create(context.Background(), "Tenant F", "Owner@Example.com")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &result, tenant)
+		mockMailer.AssertNumberOfCalls(t, "Send", 1)
+	})
+}
@@ -0,0 +1,33 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+type FindAllTenantsUsecase struct {
+	repo infra.Querier
+}
+
+func NewFindAllTenantsUsecase(repo infra.Querier) *FindAllTenantsUsecase {
+	return &FindAllTenantsUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *FindAllTenantsUsecase) FindAll(ctx context.Context) ([]infra.Tenant, error) {
+	tenants, err := uc.repo.GetTenants(ctx)
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error to find all tenants",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	return tenants, nil
+}
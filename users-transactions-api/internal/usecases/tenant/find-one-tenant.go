@@ -3,6 +3,7 @@ package usecases
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log/slog"
 
 	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
@@ -11,16 +12,69 @@ import (
 
 type FindOneTenantUseCase struct {
 	repo infra.Querier
+	// cache is nil when disabled
+	cache *TenantCache
 }
 
-func NewFindOneTenantUseCase(repo infra.Querier) *FindOneTenantUseCase {
+func NewFindOneTenantUseCase(repo infra.Querier, cache *TenantCache) *FindOneTenantUseCase {
 	return &FindOneTenantUseCase{
-		repo: repo,
+		repo:  repo,
+		cache: cache,
 	}
 }
 
-func (uc *FindOneTenantUseCase) FindOne(tenantId int32) (*infra.Tenant, error) {
-	tenant, err := uc.repo.GetTenant(context.Background(), tenantId)
+// FindOne only returns tenants that are allowed to operate, a suspended
+// tenant is reported as forbidden.
+func (uc *FindOneTenantUseCase) FindOne(ctx context.Context, tenantId int32) (*infra.Tenant, error) {
+	tenant, err := uc.find(ctx, tenantId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if tenant.Status == shared.TenantStatusSuspended {
+		return nil, &shared.ForbiddenError{
+			Message: fmt.Sprintf("tenant with id %d is suspended", tenantId),
+		}
+	}
+
+	return tenant, nil
+}
+
+// find goes through the cache, suspended tenants are cached as well so
+// they are refused without a query.
+func (uc *FindOneTenantUseCase) find(ctx context.Context, tenantId int32) (*infra.Tenant, error) {
+	if uc.cache == nil {
+		return findTenant(ctx, uc.repo, tenantId)
+	}
+
+	if tenant, found := uc.cache.Get(tenantId); found {
+		if tenant == nil {
+			return nil, &shared.EntityNotFoundError{
+				Object: "tenant",
+				Id:     tenantId,
+			}
+		}
+		return tenant, nil
+	}
+
+	generation := uc.cache.Generation()
+	tenant, err := findTenant(ctx, uc.repo, tenantId)
+
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotFoundError); ok {
+			uc.cache.SetNotFound(tenantId, generation)
+		}
+		return nil, err
+	}
+
+	uc.cache.Set(*tenant, generation)
+
+	return tenant, nil
+}
+
+func findTenant(ctx context.Context, repo infra.Querier, tenantId int32) (*infra.Tenant, error) {
+	tenant, err := repo.GetTenant(ctx, tenantId)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -29,7 +83,8 @@ func (uc *FindOneTenantUseCase) FindOne(tenantId int32) (*infra.Tenant, error) {
 				Id:     tenantId,
 			}
 		}
-		slog.Error(
+		slog.ErrorContext(
+			ctx,
 			"error to find tenant by id",
 			slog.String("err", err.Error()),
 		)
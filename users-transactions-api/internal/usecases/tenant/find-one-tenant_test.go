@@ -1,14 +1,17 @@
 package usecases
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestFindOneTenantUseCase(t *testing.T) {
@@ -16,7 +19,7 @@ func TestFindOneTenantUseCase(t *testing.T) {
 
 	mockRepo := new(mocks.MockRepository)
 
-	sut := NewFindOneTenantUseCase(mockRepo)
+	sut := NewFindOneTenantUseCase(mockRepo, nil)
 
 	t.Run("Error to find tenant by id", func(t *testing.T) {
 		expectedErr := errors.New("internal repo error")
@@ -24,7 +27,7 @@ func TestFindOneTenantUseCase(t *testing.T) {
 		mockRepo.On("GetTenant").Return(nil, expectedErr)
 		defer mockRepo.On("GetTenant").Unset()
 
-		result, err := sut.FindOne(1)
+		result, err := sut.FindOne(context.Background(), 1)
 
 		assert.Empty(t, result)
 		assert.Equal(t, expectedErr.Error(), err.Error())
@@ -34,7 +37,7 @@ func TestFindOneTenantUseCase(t *testing.T) {
 		mockRepo.On("GetTenant").Return(nil, sql.ErrNoRows)
 		defer mockRepo.On("GetTenant").Unset()
 
-		result, err := sut.FindOne(1)
+		result, err := sut.FindOne(context.Background(), 1)
 
 		expectedErr := &shared.EntityNotFoundError{
 			Object: "tenant",
@@ -54,9 +57,132 @@ func TestFindOneTenantUseCase(t *testing.T) {
 		mockRepo.On("GetTenant").Return(tenant, nil)
 		defer mockRepo.On("GetTenant").Unset()
 
-		result, err := sut.FindOne(tenant.ID)
+		result, err := sut.FindOne(context.Background(), tenant.ID)
 
 		assert.NoError(t, err)
 		assert.Equal(t, *result, tenant)
 	})
+
+	t.Run("Error tenant suspended", func(t *testing.T) {
+		tenant := infra.Tenant{
+			ID:     int32(1),
+			Name:   "Tenant A",
+			Status: shared.TenantStatusSuspended,
+		}
+
+		mockRepo.On("GetTenant").Return(tenant, nil)
+		defer mockRepo.On("GetTenant").Unset()
+
+		result, err := sut.FindOne(context.Background(), tenant.ID)
+
+		_, ok := err.(*shared.ForbiddenError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+		assert.Equal(t, "tenant with id 1 is suspended", err.Error())
+	})
+
+	t.Run("Find tenant and cache the result", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		tenant := infra.Tenant{
+			ID:   int32(1),
+			Name: "Tenant A",
+		}
+
+		sut := NewFindOneTenantUseCase(mockRepo, NewTenantCache(10, time.Minute, time.Minute))
+
+		mockRepo.On("GetTenant").Return(tenant, nil).Once()
+
+		result, err := sut.FindOne(context.Background(), tenant.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tenant, *result)
+
+		result, err = sut.FindOne(context.Background(), tenant.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tenant, *result)
+		mockRepo.AssertNumberOfCalls(t, "GetTenant", 1)
+	})
+
+	t.Run("Cache tenant not found", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+
+		sut := NewFindOneTenantUseCase(mockRepo, NewTenantCache(10, time.Minute, time.Minute))
+
+		mockRepo.On("GetTenant").Return(nil, sql.ErrNoRows).Once()
+
+		for i := 0; i < 2; i++ {
+			_, err := sut.FindOne(context.Background(), 1)
+
+			assert.Equal(t, &shared.EntityNotFoundError{Object: "tenant", Id: int32(1)}, err)
+		}
+
+		mockRepo.AssertNumberOfCalls(t, "GetTenant", 1)
+	})
+
+	t.Run("Refuse cached tenant suspended after notification", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		cache := NewTenantCache(10, time.Minute, time.Minute)
+		tenant := infra.Tenant{
+			ID:     int32(1),
+			Name:   "Tenant A",
+			Status: shared.TenantStatusActive,
+		}
+
+		sut := NewFindOneTenantUseCase(mockRepo, cache)
+
+		mockRepo.On("GetTenant").Return(tenant, nil).Once()
+
+		_, err := sut.FindOne(context.Background(), tenant.ID)
+
+		assert.NoError(t, err)
+
+		tenant.Status = shared.TenantStatusSuspended
+		mockRepo.On("GetTenant").Return(tenant, nil).Once()
+		cache.OnTenantChanged("1")
+
+		_, err = sut.FindOne(context.Background(), tenant.ID)
+
+		_, ok := err.(*shared.ForbiddenError)
+
+		assert.True(t, ok)
+		mockRepo.AssertNumberOfCalls(t, "GetTenant", 2)
+	})
+
+	t.Run("Do not cache repository errors", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		cache := NewTenantCache(10, time.Minute, time.Minute)
+
+		sut := NewFindOneTenantUseCase(mockRepo, cache)
+
+		mockRepo.On("GetTenant").Return(nil, errors.New("internal repo error")).Once()
+
+		_, err := sut.FindOne(context.Background(), 1)
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, cache.Len())
+	})
+
+	t.Run("Do not cache tenant changed during the lookup", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		cache := NewTenantCache(10, time.Minute, time.Minute)
+		tenant := infra.Tenant{
+			ID:     int32(1),
+			Name:   "Tenant A",
+			Status: shared.TenantStatusActive,
+		}
+
+		sut := NewFindOneTenantUseCase(mockRepo, cache)
+
+		// the suspension is notified while the old row is being read
+		mockRepo.On("GetTenant").Run(func(mock.Arguments) {
+			cache.OnTenantChanged("1")
+		}).Return(tenant, nil).Once()
+
+		_, err := sut.FindOne(context.Background(), tenant.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, cache.Len())
+	})
 }
@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+type ReactivateTenantUsecase struct {
+	repo infra.Querier
+}
+
+func NewReactivateTenantUsecase(repo infra.Querier) *ReactivateTenantUsecase {
+	return &ReactivateTenantUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *ReactivateTenantUsecase) Reactivate(ctx context.Context, tenantId int32) (*infra.Tenant, error) {
+	tenant, err := findTenant(ctx, uc.repo, tenantId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if tenant.Status == shared.TenantStatusActive {
+		return tenant, nil
+	}
+
+	return updateTenantStatus(ctx, uc.repo, infra.UpdateTenantStatusParams{
+		ID:        tenant.ID,
+		Status:    shared.TenantStatusActive,
+		UpdatedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+	})
+}
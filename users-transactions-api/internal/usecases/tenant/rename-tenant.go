@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+type RenameTenantUsecase struct {
+	repo infra.Querier
+}
+
+func NewRenameTenantUsecase(repo infra.Querier) *RenameTenantUsecase {
+	return &RenameTenantUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *RenameTenantUsecase) Rename(ctx context.Context, tenantId int32, name string) (*infra.Tenant, error) {
+	name = strings.TrimSpace(name)
+
+	err := tenantInputValidation(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, err := findTenant(ctx, uc.repo, tenantId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	renamedTenant, err := uc.repo.UpdateTenantName(ctx, infra.UpdateTenantNameParams{
+		ID:   tenant.ID,
+		Name: name,
+		UpdatedAt: sql.NullTime{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		},
+	})
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error when rename tenant",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	return &renamedTenant, nil
+}
@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenameTenantUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+
+	tenant := infra.Tenant{
+		ID:     1,
+		Name:   "Tenant A",
+		Status: shared.TenantStatusSuspended,
+	}
+
+	sut := NewRenameTenantUsecase(mockRepo)
+
+	t.Run("Error invalid name", func(t *testing.T) {
+		result, err := sut.Rename(context.Background(), tenant.ID, "")
+
+		_, ok := err.(*shared.ValidationError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+
+	t.Run("Rename suspended tenant successfully", func(t *testing.T) {
+		renamedTenant := tenant
+		renamedTenant.Name = "Tenant Z"
+
+		mockRepo.On("GetTenant").Return(tenant, nil)
+		defer mockRepo.On("GetTenant").Unset()
+
+		mockRepo.On("UpdateTenantName").Return(renamedTenant, nil)
+		defer mockRepo.On("UpdateTenantName").Unset()
+
+		result, err := sut.Rename(context.Background(), tenant.ID, " Tenant Z ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Tenant Z", result.Name)
+	})
+}
@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+type SuspendTenantUsecase struct {
+	repo infra.Querier
+}
+
+func NewSuspendTenantUsecase(repo infra.Querier) *SuspendTenantUsecase {
+	return &SuspendTenantUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *SuspendTenantUsecase) Suspend(ctx context.Context, tenantId int32) (*infra.Tenant, error) {
+	tenant, err := findTenant(ctx, uc.repo, tenantId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if tenant.Status == shared.TenantStatusSuspended {
+		return tenant, nil
+	}
+
+	now := time.Now().UTC()
+
+	return updateTenantStatus(ctx, uc.repo, infra.UpdateTenantStatusParams{
+		ID:          tenant.ID,
+		Status:      shared.TenantStatusSuspended,
+		SuspendedAt: sql.NullTime{Time: now, Valid: true},
+		UpdatedAt:   sql.NullTime{Time: now, Valid: true},
+	})
+}
+
+func updateTenantStatus(ctx context.Context, repo infra.Querier, arg infra.UpdateTenantStatusParams) (*infra.Tenant, error) {
+	updatedTenant, err := repo.UpdateTenantStatus(ctx, arg)
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error when update tenant status",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	return &updatedTenant, nil
+}
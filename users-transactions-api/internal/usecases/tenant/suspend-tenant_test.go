@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuspendTenantUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+
+	tenant := infra.Tenant{
+		ID:     1,
+		Name:   "Tenant A",
+		Status: shared.TenantStatusActive,
+	}
+
+	suspendedTenant := tenant
+	suspendedTenant.Status = shared.TenantStatusSuspended
+	suspendedTenant.SuspendedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+
+	sut := NewSuspendTenantUsecase(mockRepo)
+
+	t.Run("Error tenant not found", func(t *testing.T) {
+		mockRepo.On("GetTenant").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetTenant").Unset()
+
+		result, err := sut.Suspend(context.Background(), tenant.ID)
+
+		_, ok := err.(*shared.EntityNotFoundError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+
+	t.Run("Tenant already suspended", func(t *testing.T) {
+		mockRepo.On("GetTenant").Return(suspendedTenant, nil)
+		defer mockRepo.On("GetTenant").Unset()
+
+		result, err := sut.Suspend(context.Background(), tenant.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &suspendedTenant, result)
+		mockRepo.AssertNotCalled(t, "UpdateTenantStatus")
+	})
+
+	t.Run("Suspend tenant successfully", func(t *testing.T) {
+		mockRepo.On("GetTenant").Return(tenant, nil)
+		defer mockRepo.On("GetTenant").Unset()
+
+		mockRepo.On("UpdateTenantStatus").Return(suspendedTenant, nil)
+		defer mockRepo.On("UpdateTenantStatus").Unset()
+
+		result, err := sut.Suspend(context.Background(), tenant.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, shared.TenantStatusSuspended, result.Status)
+	})
+}
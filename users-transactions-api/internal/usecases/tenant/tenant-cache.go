@@ -0,0 +1,188 @@
+package usecases
+
+import (
+	"container/list"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/metrics"
+)
+
+// TenantChangedChannel is notified with the id of every tenant inserted,
+// updated or deleted, by a trigger on the tenants table.
+const TenantChangedChannel = "tenant_changed"
+
+type cachedTenant struct {
+	id int32
+	// tenant is nil when the tenant was not found
+	tenant    *infra.Tenant
+	expiresAt time.Time
+}
+
+// TenantCache keeps the tenants looked up on every request in memory, the
+// least recently used ones are evicted past the size. Tenants not found are
+// cached too, for a shorter ttl, so unknown ids do not reach the database
+// on every request. Changes are invalidated through the tenant_changed
+// notification, the ttl bounds how stale an entry gets if one is missed.
+type TenantCache struct {
+	mutex       sync.Mutex
+	size        int
+	ttl         time.Duration
+	negativeTtl time.Duration
+	entries     map[int32]*list.Element
+	// order has the most recently used entry at the front
+	order *list.List
+	// generation counts the invalidations, a lookup started before the
+	// last invalidation of its tenant is not cached
+	generation    uint64
+	invalidatedAt map[int32]uint64
+	purgedAt      uint64
+}
+
+func NewTenantCache(size int, ttl time.Duration, negativeTtl time.Duration) *TenantCache {
+	return &TenantCache{
+		size:          size,
+		ttl:           ttl,
+		negativeTtl:   negativeTtl,
+		entries:       make(map[int32]*list.Element),
+		order:         list.New(),
+		invalidatedAt: make(map[int32]uint64),
+	}
+}
+
+// Generation is read before looking a tenant up in the database and passed
+// to Set, which then drops the result if the tenant changed meanwhile.
+func (c *TenantCache) Generation() uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.generation
+}
+
+// Get reports whether the tenant is cached, the tenant is nil when it is
+// cached as not found.
+func (c *TenantCache) Get(tenantId int32) (*infra.Tenant, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, found := c.entries[tenantId]
+
+	if !found {
+		metrics.TenantCacheMiss()
+		return nil, false
+	}
+
+	entry := element.Value.(*cachedTenant)
+
+	if time.Now().After(entry.expiresAt) {
+		c.remove(element)
+		metrics.TenantCacheMiss()
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	metrics.TenantCacheHit(entry.tenant == nil)
+
+	if entry.tenant == nil {
+		return nil, true
+	}
+
+	tenant := *entry.tenant
+
+	return &tenant, true
+}
+
+func (c *TenantCache) Set(tenant infra.Tenant, generation uint64) {
+	c.set(tenant.ID, &tenant, c.ttl, generation)
+}
+
+// SetNotFound caches that the tenant does not exist.
+func (c *TenantCache) SetNotFound(tenantId int32, generation uint64) {
+	c.set(tenantId, nil, c.negativeTtl, generation)
+}
+
+func (c *TenantCache) set(tenantId int32, tenant *infra.Tenant, ttl time.Duration, generation uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// the lookup may have read the tenant before an invalidation that was
+	// already applied, caching it would bring the old value back
+	if c.invalidatedAt[tenantId] > generation || c.purgedAt > generation {
+		return
+	}
+
+	entry := &cachedTenant{
+		id:        tenantId,
+		tenant:    tenant,
+		expiresAt: time.Now().Add(ttl),
+	}
+
+	if element, found := c.entries[tenantId]; found {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[tenantId] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+		metrics.TenantCacheEvicted()
+	}
+}
+
+func (c *TenantCache) Invalidate(tenantId int32) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+	c.invalidatedAt[tenantId] = c.generation
+
+	if element, found := c.entries[tenantId]; found {
+		c.remove(element)
+	}
+}
+
+// OnTenantChanged invalidates the tenant notified on TenantChangedChannel.
+func (c *TenantCache) OnTenantChanged(payload string) {
+	tenantId, err := strconv.ParseInt(payload, 10, 32)
+
+	if err != nil {
+		slog.Warn("invalid tenant changed notification",
+			slog.String("payload", payload),
+		)
+		return
+	}
+
+	c.Invalidate(int32(tenantId))
+	metrics.TenantCacheInvalidated("notification")
+}
+
+// Purge empties the cache, for when notifications may have been missed.
+func (c *TenantCache) Purge() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = make(map[int32]*list.Element)
+	c.order.Init()
+	c.generation++
+	c.purgedAt = c.generation
+	// older invalidations are covered by purgedAt
+	c.invalidatedAt = make(map[int32]uint64)
+	metrics.TenantCacheInvalidated("purge")
+}
+
+func (c *TenantCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *TenantCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*cachedTenant).id)
+}
@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantCache(t *testing.T) {
+	t.Parallel()
+
+	tenantA := infra.Tenant{ID: 1, Name: "Tenant A"}
+	tenantB := infra.Tenant{ID: 2, Name: "Tenant B"}
+	tenantC := infra.Tenant{ID: 3, Name: "Tenant C"}
+
+	t.Run("Evict the least recently used tenant", func(t *testing.T) {
+		cache := NewTenantCache(2, time.Minute, time.Minute)
+
+		cache.Set(tenantA, cache.Generation())
+		cache.Set(tenantB, cache.Generation())
+		cache.Get(tenantA.ID)
+		cache.Set(tenantC, cache.Generation())
+
+		_, found := cache.Get(tenantB.ID)
+		assert.False(t, found)
+
+		result, found := cache.Get(tenantA.ID)
+		assert.True(t, found)
+		assert.Equal(t, tenantA, *result)
+
+		_, found = cache.Get(tenantC.ID)
+		assert.True(t, found)
+		assert.Equal(t, 2, cache.Len())
+	})
+
+	t.Run("Expire tenants not found sooner", func(t *testing.T) {
+		cache := NewTenantCache(10, time.Minute, time.Millisecond)
+
+		cache.Set(tenantA, cache.Generation())
+		cache.SetNotFound(tenantB.ID, cache.Generation())
+
+		result, found := cache.Get(tenantB.ID)
+		assert.True(t, found)
+		assert.Nil(t, result)
+
+		time.Sleep(5 * time.Millisecond)
+
+		_, found = cache.Get(tenantB.ID)
+		assert.False(t, found)
+
+		_, found = cache.Get(tenantA.ID)
+		assert.True(t, found)
+	})
+
+	t.Run("Invalidate notified tenant", func(t *testing.T) {
+		cache := NewTenantCache(10, time.Minute, time.Minute)
+
+		cache.Set(tenantA, cache.Generation())
+		cache.Set(tenantB, cache.Generation())
+		cache.OnTenantChanged("1")
+		cache.OnTenantChanged("not a tenant id")
+
+		_, found := cache.Get(tenantA.ID)
+		assert.False(t, found)
+
+		_, found = cache.Get(tenantB.ID)
+		assert.True(t, found)
+	})
+
+	t.Run("Purge every tenant", func(t *testing.T) {
+		cache := NewTenantCache(10, time.Minute, time.Minute)
+
+		cache.Set(tenantA, cache.Generation())
+		cache.SetNotFound(tenantB.ID, cache.Generation())
+		cache.Purge()
+
+		assert.Equal(t, 0, cache.Len())
+
+		cache.Set(tenantC, cache.Generation())
+
+		_, found := cache.Get(tenantC.ID)
+		assert.True(t, found)
+	})
+
+	t.Run("Return a copy of the cached tenant", func(t *testing.T) {
+		cache := NewTenantCache(10, time.Minute, time.Minute)
+
+		cache.Set(tenantA, cache.Generation())
+
+		result, _ := cache.Get(tenantA.ID)
+		result.Name = "Changed"
+
+		result, _ = cache.Get(tenantA.ID)
+		assert.Equal(t, "Tenant A", result.Name)
+	})
+
+	t.Run("Drop lookup started before an invalidation", func(t *testing.T) {
+		cache := NewTenantCache(10, time.Minute, time.Minute)
+
+		generation := cache.Generation()
+		cache.OnTenantChanged("1")
+		cache.Set(tenantA, generation)
+		cache.SetNotFound(tenantB.ID, generation)
+
+		_, found := cache.Get(tenantA.ID)
+		assert.False(t, found)
+
+		// other tenants are not affected by the invalidation
+		_, found = cache.Get(tenantB.ID)
+		assert.True(t, found)
+
+		cache.Set(tenantA, cache.Generation())
+
+		_, found = cache.Get(tenantA.ID)
+		assert.True(t, found)
+	})
+
+	t.Run("Drop lookup started before a purge", func(t *testing.T) {
+		cache := NewTenantCache(10, time.Minute, time.Minute)
+
+		generation := cache.Generation()
+		cache.Purge()
+		cache.Set(tenantA, generation)
+
+		assert.Equal(t, 0, cache.Len())
+	})
+}
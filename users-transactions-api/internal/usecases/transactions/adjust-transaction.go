@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/cards"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/validation"
+)
+
+const maxAdjustmentReasonLength = 255
+
+// AdjustTransactionUsecase posts the corrections of card balances made by
+// operators. Unlike CreateTransactionUsecase the value is signed, negative
+// to give money back, and the account status is not checked, a frozen or
+// closed account may be the one needing the correction. Adjustments are not
+// counted in the tenant quota.
+type AdjustTransactionUsecase struct {
+	repo            infra.QuerierTx
+	findCardUsecase *usecases.FindCardUsecase
+}
+
+func NewAdjustTransactionUsecase(repo infra.QuerierTx, findCardUsecase *usecases.FindCardUsecase) *AdjustTransactionUsecase {
+	return &AdjustTransactionUsecase{
+		repo:            repo,
+		findCardUsecase: findCardUsecase,
+	}
+}
+
+// Adjust saves the adjustment with the actor posting it and the reason.
+func (uc *AdjustTransactionUsecase) Adjust(ctx context.Context, tenantId int32, accountId int32,
+	transaction infra.Transaction, actor string, reason string) (*infra.Transaction, error) {
+	reason = strings.TrimSpace(reason)
+
+	err := adjustmentInputValidation(transaction, reason)
+
+	if err != nil {
+		return nil, err
+	}
+
+	card, err := uc.findCardUsecase.FindOne(ctx, tenantId, accountId, transaction.CardID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = infra.ContextWithTenant(ctx, tenantId)
+
+	savedTransaction, err := uc.repo.AdjustTransactionTx(ctx, infra.AdjustTransactionTxParams{
+		Transaction: infra.CreateTransactionParams{
+			CardID: card.ID,
+			Kind:   transaction.Kind,
+			Value:  transaction.Value,
+		},
+		Actor:  actor,
+		Reason: reason,
+	})
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error adjusting transaction",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	slog.InfoContext(
+		ctx,
+		"transaction adjusted",
+		slog.Int("transaction_id", int(savedTransaction.ID)),
+		slog.Int64("value", savedTransaction.Value),
+		slog.String("actor", actor),
+		slog.String("reason", reason),
+	)
+
+	return &savedTransaction, nil
+}
+
+func adjustmentInputValidation(t infra.Transaction, reason string) error {
+	valErr := &shared.ValidationError{
+		Errors: make(map[string]string),
+	}
+
+	if !validation.IsTransactionKind(t.Kind) {
+		valErr.AddError("kind", fmt.Sprintf("must be a description of up to %d printable characters",
+			validation.MaxTransactionKindLength))
+	}
+
+	if t.Value == 0 {
+		valErr.AddError("value", "cannot be zero (0)")
+	}
+
+	if len(reason) == 0 || len(reason) > maxAdjustmentReasonLength {
+		valErr.AddError("reason", fmt.Sprintf("must have between 1 and %d characters", maxAdjustmentReasonLength))
+	}
+
+	if valErr.HasErrors() {
+		return valErr
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	accountUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/account"
+	cardUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/cards"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdjustTransactionUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+
+	findAccountUsecase := accountUsecases.NewFindOneAccountUsecase(mockRepo)
+	findCardUsecase := cardUsecases.NewFindCardUsecase(mockRepo, findAccountUsecase)
+
+	sut := NewAdjustTransactionUsecase(mockRepo, findCardUsecase)
+
+	card := infra.Card{
+		ID:        1,
+		Amount:    200,
+		AccountID: 1,
+	}
+
+	closedAccount := infra.Account{
+		ID:       1,
+		TenantID: 1,
+		Status:   shared.AccountStatusClosed,
+	}
+
+	refund := infra.Transaction{
+		ID:     1,
+		CardID: 1,
+		Kind:   "adjustment",
+		Value:  -150,
+	}
+
+	t.Run("Adjust the balance of a closed account", func(t *testing.T) {
+		mockRepo.On("GetAccount").Return(closedAccount, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetCard").Return(card, nil)
+		defer mockRepo.On("GetCard").Unset()
+
+		mockRepo.On("AdjustTransactionTx").Return(refund, nil)
+		defer mockRepo.On("AdjustTransactionTx").Unset()
+
+		savedTransaction, err := sut.Adjust(context.Background(), 1, closedAccount.ID, refund,
+			"admin_cli:ops", "duplicated charge")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &refund, savedTransaction)
+		mockRepo.AssertNotCalled(t, "CreateTransactionTx")
+	})
+
+	t.Run("Error input validation", func(t *testing.T) {
+		invalidTransaction := infra.Transaction{
+			CardID: 1,
+			Kind:   "",
+			Value:  0,
+		}
+
+		savedTransaction, err := sut.Adjust(context.Background(), 1, closedAccount.ID, invalidTransaction,
+			"admin_cli:ops", " ")
+
+		assert.Nil(t, savedTransaction)
+		assert.Equal(t, &shared.ValidationError{
+			Errors: map[string]string{
+				"kind": fmt.Sprintf("must be a description of up to %d printable characters",
+					validation.MaxTransactionKindLength),
+				"value":  "cannot be zero (0)",
+				"reason": "must have between 1 and 255 characters",
+			},
+		}, err)
+	})
+
+	t.Run("Error card not found", func(t *testing.T) {
+		mockRepo.On("GetAccount").Return(closedAccount, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetCard").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetCard").Unset()
+
+		savedTransaction, err := sut.Adjust(context.Background(), 1, closedAccount.ID, refund,
+			"admin_cli:ops", "duplicated charge")
+
+		assert.Nil(t, savedTransaction)
+		assert.IsType(t, &shared.EntityNotFoundError{}, err)
+	})
+}
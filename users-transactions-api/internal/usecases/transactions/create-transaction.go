@@ -2,28 +2,47 @@ package usecases
 
 import (
 	"context"
+	"fmt"
 
 	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/metrics"
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	accountUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/account"
 	usecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/cards"
+	quotaUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/quota"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/validation"
 )
 
 type CreateTransactionUsecase struct {
 	repo            infra.QuerierTx
 	findCardUsecase *usecases.FindCardUsecase
+	quotaUsecase    *quotaUsecases.QuotaUsecase
 }
 
-func NewCreateTransactionUsecase(repo infra.QuerierTx,
-	findCardUsecase *usecases.FindCardUsecase) *CreateTransactionUsecase {
+func NewCreateTransactionUsecase(repo infra.QuerierTx, findCardUsecase *usecases.FindCardUsecase,
+	quotaUsecase *quotaUsecases.QuotaUsecase) *CreateTransactionUsecase {
 	return &CreateTransactionUsecase{
 		repo:            repo,
 		findCardUsecase: findCardUsecase,
+		quotaUsecase:    quotaUsecase,
 	}
 }
 
-func (uc *CreateTransactionUsecase) Create(tenantId int32, accountId int32,
-	transaction infra.Transaction) (*infra.Transaction, error) {
-	card, err := uc.findCardUsecase.FindOne(tenantId, accountId, transaction.CardID)
+// Create charges the card for a debit or gives the value back to it for a
+// credit, each checked against the account status in the transaction that
+// saves it. The transaction value is the positive amount, credits are saved
+// with it negated.
+func (uc *CreateTransactionUsecase) Create(ctx context.Context, tenantId int32, accountId int32,
+	direction shared.TransactionDirection, transaction infra.Transaction) (*infra.Transaction, error) {
+	if !shared.IsTransactionDirection(direction) {
+		return nil, &shared.ValidationError{
+			Errors: map[string]string{
+				"direction": "must be one of debit or credit",
+			},
+		}
+	}
+
+	card, err := uc.findCardUsecase.FindOne(ctx, tenantId, accountId, transaction.CardID)
 
 	if err != nil {
 		return nil, err
@@ -35,16 +54,28 @@ func (uc *CreateTransactionUsecase) Create(tenantId int32, accountId int32,
 		return nil, err
 	}
 
-	savedTransaction, err := uc.repo.CreateTransactionTx(context.Background(), infra.CreateTransactionParams{
+	ctx = infra.ContextWithTenant(ctx, tenantId)
+
+	savedTransaction, err := uc.repo.CreateTransactionTx(ctx, infra.CreateTransactionParams{
 		CardID: card.ID,
 		Kind:   transaction.Kind,
-		Value:  transaction.Value,
+		Value:  direction.SignedValue(transaction.Value),
+	}, func(q infra.Querier) error {
+		err := accountUsecases.CheckAccountOperation(ctx, q, tenantId, accountId, direction.Operation())
+
+		if err != nil {
+			return err
+		}
+
+		return uc.quotaUsecase.CheckTransactions(ctx, q, tenantId)
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
+	metrics.TransactionCreated(savedTransaction.Kind, savedTransaction.Value)
+
 	return &savedTransaction, nil
 }
 
@@ -53,11 +84,12 @@ func transactionInputValidation(t infra.Transaction) error {
 		Errors: make(map[string]string),
 	}
 
-	if len(t.Kind) == 0 {
-		valErr.AddError("kind", "cannot be empty")
+	if !validation.IsTransactionKind(t.Kind) {
+		valErr.AddError("kind", fmt.Sprintf("must be a description of up to %d printable characters",
+			validation.MaxTransactionKindLength))
 	}
 
-	if t.Value < 0 {
+	if t.Value <= 0 {
 		valErr.AddError("value", "must be greater than zero (0)")
 	}
 
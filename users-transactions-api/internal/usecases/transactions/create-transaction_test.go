@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -11,6 +12,8 @@ import (
 	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
 	accountUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/account"
 	cardUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/cards"
+	quotaUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/quota"
+	settingsUsecases "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/usecases/settings"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,10 +21,15 @@ func TestCreateTransactionUsecase(t *testing.T) {
 	t.Parallel()
 
 	mockRepo := new(mocks.MockRepository)
-	fincAccountUsecase := accountUsecases.NewFindOneAccountUsecase(mockRepo)
-	findCardUsecase := cardUsecases.NewFindCardUsecase(mockRepo, fincAccountUsecase)
 
-	sut := NewCreateTransactionUsecase(mockRepo, findCardUsecase)
+	// tenants without settings are not limited by quotas
+	mockRepo.On("GetTenantSettings").Return(nil, sql.ErrNoRows)
+	findTenantSettingsUsecase := settingsUsecases.NewFindTenantSettingsUsecase(mockRepo, settingsUsecases.NewSettingsCache(0))
+	quotaUsecase := quotaUsecases.NewQuotaUsecase(findTenantSettingsUsecase)
+	findAccountUsecase := accountUsecases.NewFindOneAccountUsecase(mockRepo)
+	findCardUsecase := cardUsecases.NewFindCardUsecase(mockRepo, findAccountUsecase)
+
+	sut := NewCreateTransactionUsecase(mockRepo, findCardUsecase, quotaUsecase)
 
 	card := infra.Card{
 		ID:        1,
@@ -49,25 +57,120 @@ func TestCreateTransactionUsecase(t *testing.T) {
 		mockRepo.On("GetCard").Return(card, nil)
 		defer mockRepo.On("GetCard").Unset()
 
+		mockRepo.On("GetAccountForShare").Return(account, nil)
+		defer mockRepo.On("GetAccountForShare").Unset()
+
 		mockRepo.On("CreateTransactionTx").Return(transaction, nil)
 		defer mockRepo.On("CreateTransactionTx").Unset()
 
-		savedTransaction, err := sut.Create(1, account.ID, transaction)
+		savedTransaction, err := sut.Create(context.Background(), 1, account.ID, shared.TransactionDirectionDebit, transaction)
 
 		assert.NoError(t, err)
 		assert.Equal(t, &transaction, savedTransaction)
 	})
 
+	t.Run("Success to create credit on a frozen account", func(t *testing.T) {
+		frozenAccount := account
+		frozenAccount.Status = shared.AccountStatusFrozen
+
+		mockRepo.On("GetAccount").Return(frozenAccount, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetCard").Return(card, nil)
+		defer mockRepo.On("GetCard").Unset()
+
+		mockRepo.On("GetAccountForShare").Return(frozenAccount, nil)
+		defer mockRepo.On("GetAccountForShare").Unset()
+
+		credit := transaction
+		credit.Value = -transaction.Value
+
+		mockRepo.On("CreateTransactionTx").Return(credit, nil)
+		defer mockRepo.On("CreateTransactionTx").Unset()
+
+		savedTransaction, err := sut.Create(context.Background(), 1, account.ID, shared.TransactionDirectionCredit, transaction)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &credit, savedTransaction)
+	})
+
+	t.Run("Error account status blocks credits", func(t *testing.T) {
+		suspendedAccount := account
+		suspendedAccount.Status = shared.AccountStatusSuspended
+
+		mockRepo.On("GetAccount").Return(suspendedAccount, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetCard").Return(card, nil)
+		defer mockRepo.On("GetCard").Unset()
+
+		mockRepo.On("GetAccountForShare").Return(suspendedAccount, nil)
+		defer mockRepo.On("GetAccountForShare").Unset()
+
+		savedTransaction, err := sut.Create(context.Background(), 1, account.ID, shared.TransactionDirectionCredit, transaction)
+
+		assert.Nil(t, savedTransaction)
+		assert.Equal(t, "credit is not allowed for a suspended account", err.Error())
+	})
+
+	t.Run("Error invalid direction", func(t *testing.T) {
+		savedTransaction, err := sut.Create(context.Background(), 1, account.ID, "refund", transaction)
+
+		assert.Nil(t, savedTransaction)
+		assert.IsType(t, &shared.ValidationError{}, err)
+	})
+
 	t.Run("Erro account not found", func(t *testing.T) {
 		mockRepo.On("GetAccount").Return(nil, sql.ErrNoRows)
 		defer mockRepo.On("GetAccount").Unset()
 
-		savedTransaction, err := sut.Create(1, account.ID, transaction)
+		savedTransaction, err := sut.Create(context.Background(), 1, account.ID, shared.TransactionDirectionDebit, transaction)
 
 		assert.Nil(t, savedTransaction)
 		assert.Equal(t, fmt.Sprintf("account not found with id %d", transaction.CardID), err.Error())
 	})
 
+	t.Run("Error account status blocks debits", func(t *testing.T) {
+		frozenAccount := account
+		frozenAccount.Status = shared.AccountStatusFrozen
+
+		mockRepo.On("GetAccount").Return(frozenAccount, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetCard").Return(card, nil)
+		defer mockRepo.On("GetCard").Unset()
+
+		mockRepo.On("GetAccountForShare").Return(frozenAccount, nil)
+		defer mockRepo.On("GetAccountForShare").Unset()
+
+		savedTransaction, err := sut.Create(context.Background(), 1, account.ID, shared.TransactionDirectionDebit, transaction)
+
+		assert.Nil(t, savedTransaction)
+		assert.IsType(t, &shared.AccountStatusError{}, err)
+		assert.Equal(t, "debit is not allowed for a frozen account", err.Error())
+	})
+
+	t.Run("Error account frozen after it was read", func(t *testing.T) {
+		frozenAccount := account
+		frozenAccount.Status = shared.AccountStatusFrozen
+
+		// the account was active when the card was found, the locked read
+		// in the transaction sees the freeze
+		mockRepo.On("GetAccount").Return(account, nil)
+		defer mockRepo.On("GetAccount").Unset()
+
+		mockRepo.On("GetCard").Return(card, nil)
+		defer mockRepo.On("GetCard").Unset()
+
+		mockRepo.On("GetAccountForShare").Return(frozenAccount, nil)
+		defer mockRepo.On("GetAccountForShare").Unset()
+
+		savedTransaction, err := sut.Create(context.Background(), 1, account.ID, shared.TransactionDirectionDebit, transaction)
+
+		assert.Nil(t, savedTransaction)
+		assert.IsType(t, &shared.AccountStatusError{}, err)
+	})
+
 	t.Run("Erro card not found", func(t *testing.T) {
 		mockRepo.On("GetAccount").Return(account, nil)
 		defer mockRepo.On("GetAccount").Unset()
@@ -75,7 +178,7 @@ func TestCreateTransactionUsecase(t *testing.T) {
 		mockRepo.On("GetCard").Return(nil, sql.ErrNoRows)
 		defer mockRepo.On("GetCard").Unset()
 
-		savedTransaction, err := sut.Create(1, account.ID, transaction)
+		savedTransaction, err := sut.Create(context.Background(), 1, account.ID, shared.TransactionDirectionDebit, transaction)
 
 		assert.Nil(t, savedTransaction)
 		assert.Equal(t, fmt.Sprintf("card not found with id %d", transaction.CardID), err.Error())
@@ -102,7 +205,7 @@ func TestCreateTransactionUsecase(t *testing.T) {
 			},
 		}
 
-		savedTransaction, err := sut.Create(1, account.ID, invalidTransaction)
+		savedTransaction, err := sut.Create(context.Background(), 1, account.ID, shared.TransactionDirectionDebit, invalidTransaction)
 
 		assert.Nil(t, savedTransaction)
 		assert.EqualError(t, err, expectedError.Error())
@@ -115,10 +218,13 @@ func TestCreateTransactionUsecase(t *testing.T) {
 		mockRepo.On("GetCard").Return(card, nil)
 		defer mockRepo.On("GetCard").Unset()
 
+		mockRepo.On("GetAccountForShare").Return(account, nil)
+		defer mockRepo.On("GetAccountForShare").Unset()
+
 		mockRepo.On("CreateTransactionTx").Return(nil, errors.New("internal error"))
 		defer mockRepo.On("CreateTransactionTx").Unset()
 
-		savedTransaction, err := sut.Create(1, account.ID, transaction)
+		savedTransaction, err := sut.Create(context.Background(), 1, account.ID, shared.TransactionDirectionDebit, transaction)
 
 		assert.Nil(t, savedTransaction)
 		assert.EqualError(t, errors.New("internal error"), err.Error())
@@ -9,11 +9,11 @@ import (
 )
 
 type FindAllTransactionsUsecase struct {
-	repo            infra.Querier
+	repo            infra.QuerierTx
 	findCardUsecase *usecases.FindCardUsecase
 }
 
-func NewFindAllTransactionsUsecase(repo infra.Querier,
+func NewFindAllTransactionsUsecase(repo infra.QuerierTx,
 	findCardUsecase *usecases.FindCardUsecase) *FindAllTransactionsUsecase {
 	return &FindAllTransactionsUsecase{
 		repo:            repo,
@@ -21,9 +21,9 @@ func NewFindAllTransactionsUsecase(repo infra.Querier,
 	}
 }
 
-func (uc *FindAllTransactionsUsecase) FindAll(tenantId int32, accountId int32,
+func (uc *FindAllTransactionsUsecase) FindAll(ctx context.Context, tenantId int32, accountId int32,
 	cardId int32) ([]infra.Transaction, error) {
-	_, err := uc.findCardUsecase.FindOne(tenantId, accountId, cardId)
+	_, err := uc.findCardUsecase.FindOne(ctx, tenantId, accountId, cardId)
 
 	if err != nil {
 		return nil, err
@@ -31,10 +31,16 @@ func (uc *FindAllTransactionsUsecase) FindAll(tenantId int32, accountId int32,
 
 	transactions := make([]infra.Transaction, 0)
 
-	result, err := uc.repo.GetTransactions(context.Background(), cardId)
+	var result []infra.Transaction
+
+	err = uc.repo.WithTenant(ctx, tenantId, func(q infra.Querier) (err error) {
+		result, err = q.GetTransactions(ctx, cardId)
+		return err
+	})
 
 	if err != nil {
-		slog.Error(
+		slog.ErrorContext(
+			ctx,
 			"error to find all transactions",
 			slog.String("err", err.Error()),
 		)
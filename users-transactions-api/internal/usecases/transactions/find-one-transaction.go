@@ -11,28 +11,33 @@ import (
 )
 
 type FindTransactionUsecase struct {
-	repo            infra.Querier
+	repo            infra.QuerierTx
 	findCardUsecase *usecases.FindCardUsecase
 }
 
-func NewFindTransactionUsecase(repo infra.Querier, findCardUsecase *usecases.FindCardUsecase) *FindTransactionUsecase {
+func NewFindTransactionUsecase(repo infra.QuerierTx, findCardUsecase *usecases.FindCardUsecase) *FindTransactionUsecase {
 	return &FindTransactionUsecase{
 		repo:            repo,
 		findCardUsecase: findCardUsecase,
 	}
 }
 
-func (uc *FindTransactionUsecase) FindOne(tenantId int32, accountId int32,
+func (uc *FindTransactionUsecase) FindOne(ctx context.Context, tenantId int32, accountId int32,
 	cardId int32, transactionId int32) (*infra.Transaction, error) {
-	_, err := uc.findCardUsecase.FindOne(tenantId, accountId, cardId)
+	_, err := uc.findCardUsecase.FindOne(ctx, tenantId, accountId, cardId)
 
 	if err != nil {
 		return nil, err
 	}
 
-	transaction, err := uc.repo.GetTransaction(context.Background(), infra.GetTransactionParams{
-		CardID: cardId,
-		ID:     transactionId,
+	var transaction infra.Transaction
+
+	err = uc.repo.WithTenant(ctx, tenantId, func(q infra.Querier) (err error) {
+		transaction, err = q.GetTransaction(ctx, infra.GetTransactionParams{
+			CardID: cardId,
+			ID:     transactionId,
+		})
+		return err
 	})
 
 	if err != nil {
@@ -42,7 +47,8 @@ func (uc *FindTransactionUsecase) FindOne(tenantId int32, accountId int32,
 				Id:     transactionId,
 			}
 		}
-		slog.Error(
+		slog.ErrorContext(
+			ctx,
 			"error to find transaction",
 			slog.String("err", err.Error()),
 		)
@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -51,7 +52,7 @@ func TestFindTransactionUsecase(t *testing.T) {
 		mockRepo.On("GetTransaction").Return(transaction, nil)
 		defer mockRepo.On("GetTransaction").Unset()
 
-		result, err := sut.FindOne(1, 1, 1, 1)
+		result, err := sut.FindOne(context.Background(), 1, 1, 1, 1)
 
 		assert.NoError(t, err)
 		assert.Equal(t, transaction, *result)
@@ -61,7 +62,7 @@ func TestFindTransactionUsecase(t *testing.T) {
 		mockRepo.On("GetAccount").Return(nil, sql.ErrNoRows)
 		defer mockRepo.On("GetAccount").Unset()
 
-		result, err := sut.FindOne(1, 1, 1, 1)
+		result, err := sut.FindOne(context.Background(), 1, 1, 1, 1)
 
 		assert.Nil(t, result)
 		assert.Equal(t, fmt.Sprintf("account not found with id %d", transaction.CardID), err.Error())
@@ -74,7 +75,7 @@ func TestFindTransactionUsecase(t *testing.T) {
 		mockRepo.On("GetCard").Return(nil, sql.ErrNoRows)
 		defer mockRepo.On("GetCard").Unset()
 
-		result, err := sut.FindOne(1, 1, 1, 1)
+		result, err := sut.FindOne(context.Background(), 1, 1, 1, 1)
 
 		assert.Nil(t, result)
 		assert.Equal(t, fmt.Sprintf("card not found with id %d", transaction.CardID), err.Error())
@@ -90,7 +91,7 @@ func TestFindTransactionUsecase(t *testing.T) {
 		mockRepo.On("GetTransaction").Return(nil, sql.ErrNoRows)
 		defer mockRepo.On("GetTransaction").Unset()
 
-		result, err := sut.FindOne(1, 1, 1, 1)
+		result, err := sut.FindOne(context.Background(), 1, 1, 1, 1)
 
 		assert.Nil(t, result)
 		assert.Equal(t, fmt.Sprintf("transaction not found with id %d", transaction.CardID), err.Error())
@@ -106,7 +107,7 @@ func TestFindTransactionUsecase(t *testing.T) {
 		mockRepo.On("GetTransaction").Return(nil, errors.New("internal error"))
 		defer mockRepo.On("GetTransaction").Unset()
 
-		result, err := sut.FindOne(1, 1, 1, 1)
+		result, err := sut.FindOne(context.Background(), 1, 1, 1, 1)
 
 		assert.Nil(t, result)
 		assert.EqualError(t, errors.New("internal error"), err.Error())
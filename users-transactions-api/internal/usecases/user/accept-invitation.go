@@ -0,0 +1,29 @@
+package usecases
+
+import (
+	"context"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/ports"
+)
+
+type AcceptInvitationUsecase struct {
+	repo    infra.Querier
+	txRepo  infra.QuerierTx
+	tenants ports.TenantFinder
+}
+
+func NewAcceptInvitationUsecase(repo infra.Querier, txRepo infra.QuerierTx,
+	tenants ports.TenantFinder) *AcceptInvitationUsecase {
+	return &AcceptInvitationUsecase{
+		repo:    repo,
+		txRepo:  txRepo,
+		tenants: tenants,
+	}
+}
+
+// Accept activates the invited user, the invitation cannot be accepted
+// while their tenant is suspended.
+func (uc *AcceptInvitationUsecase) Accept(ctx context.Context, token string, password string) error {
+	return setPassword(ctx, uc.repo, uc.txRepo, uc.tenants, token, tokenKindInvitation, password)
+}
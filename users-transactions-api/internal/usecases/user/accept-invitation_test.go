@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcceptInvitationUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+	mockTenants := new(mocks.MockTenantFinder)
+
+	user := infra.User{
+		ID:       1,
+		TenantID: 1,
+		Email:    "jane@example.com",
+		Role:     shared.RoleOperator,
+		Status:   userStatusInvited,
+	}
+
+	userToken := infra.UserToken{
+		ID:        1,
+		UserID:    user.ID,
+		Kind:      tokenKindInvitation,
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+	}
+
+	sut := NewAcceptInvitationUsecase(mockRepo, mockRepo, mockTenants)
+
+	t.Run("Error tenant suspended", func(t *testing.T) {
+		mockRepo.On("GetUserToken").Return(userToken, nil)
+		defer mockRepo.On("GetUserToken").Unset()
+
+		mockRepo.On("GetUserById").Return(user, nil)
+		defer mockRepo.On("GetUserById").Unset()
+
+		mockTenants.On("FindOne").Return(nil, &shared.ForbiddenError{Message: "tenant with id 1 is suspended"})
+		defer mockTenants.On("FindOne").Unset()
+
+		err := sut.Accept(context.Background(), "uti_token", "new-password")
+
+		_, ok := err.(*shared.ForbiddenError)
+
+		assert.True(t, ok)
+		mockRepo.AssertNotCalled(t, "SetPasswordTx")
+	})
+
+	t.Run("Accept invitation successfully", func(t *testing.T) {
+		mockRepo.On("GetUserToken").Return(userToken, nil)
+		defer mockRepo.On("GetUserToken").Unset()
+
+		mockRepo.On("GetUserById").Return(user, nil)
+		defer mockRepo.On("GetUserById").Unset()
+
+		mockTenants.On("FindOne").Return(&infra.Tenant{ID: 1, Status: shared.TenantStatusActive}, nil)
+		defer mockTenants.On("FindOne").Unset()
+
+		mockRepo.On("SetPasswordTx").Return(user, nil)
+		defer mockRepo.On("SetPasswordTx").Unset()
+
+		err := sut.Accept(context.Background(), "uti_token", "new-password")
+
+		assert.NoError(t, err)
+		mockRepo.AssertCalled(t, "SetPasswordTx")
+	})
+}
@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+type AuthenticateUserUsecase struct {
+	repo infra.Querier
+}
+
+func NewAuthenticateUserUsecase(repo infra.Querier) *AuthenticateUserUsecase {
+	return &AuthenticateUserUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *AuthenticateUserUsecase) Authenticate(ctx context.Context, token string) (*shared.Principal, error) {
+	invalidTokenErr := &shared.UnauthorizedError{Message: "invalid or expired token"}
+
+	userToken, err := findActiveToken(ctx, uc.repo, token, tokenKindSession)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if userToken == nil {
+		return nil, invalidTokenErr
+	}
+
+	user, err := uc.repo.GetUserById(ctx, userToken.UserID)
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error to find user by id",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	if user.Status != userStatusActive {
+		return nil, invalidTokenErr
+	}
+
+	return &shared.Principal{
+		TenantId: user.TenantID,
+		UserId:   user.ID,
+		Role:     user.Role,
+		Scopes:   shared.RoleScopes(user.Role),
+	}, nil
+}
@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticateUserUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+
+	user := infra.User{
+		ID:       1,
+		TenantID: 1,
+		Email:    "jane@example.com",
+		Role:     shared.RoleViewer,
+		Status:   userStatusActive,
+	}
+
+	userToken := infra.UserToken{
+		ID:        1,
+		UserID:    user.ID,
+		Kind:      tokenKindSession,
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+	}
+
+	sut := NewAuthenticateUserUsecase(mockRepo)
+
+	t.Run("Error unknown token", func(t *testing.T) {
+		mockRepo.On("GetUserToken").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetUserToken").Unset()
+
+		result, err := sut.Authenticate(context.Background(), "uts_unknown")
+
+		_, ok := err.(*shared.UnauthorizedError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+
+	t.Run("Error expired token", func(t *testing.T) {
+		expiredToken := userToken
+		expiredToken.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+
+		mockRepo.On("GetUserToken").Return(expiredToken, nil)
+		defer mockRepo.On("GetUserToken").Unset()
+
+		result, err := sut.Authenticate(context.Background(), "uts_expired")
+
+		_, ok := err.(*shared.UnauthorizedError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+
+	t.Run("Error disabled user", func(t *testing.T) {
+		disabledUser := user
+		disabledUser.Status = userStatusDisabled
+
+		mockRepo.On("GetUserToken").Return(userToken, nil)
+		defer mockRepo.On("GetUserToken").Unset()
+
+		mockRepo.On("GetUserById").Return(disabledUser, nil)
+		defer mockRepo.On("GetUserById").Unset()
+
+		result, err := sut.Authenticate(context.Background(), "uts_valid")
+
+		_, ok := err.(*shared.UnauthorizedError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+
+	t.Run("Authenticate viewer with read scopes only", func(t *testing.T) {
+		mockRepo.On("GetUserToken").Return(userToken, nil)
+		defer mockRepo.On("GetUserToken").Unset()
+
+		mockRepo.On("GetUserById").Return(user, nil)
+		defer mockRepo.On("GetUserById").Unset()
+
+		result, err := sut.Authenticate(context.Background(), "uts_valid")
+
+		assert.NoError(t, err)
+		assert.Equal(t, user.TenantID, result.TenantId)
+		assert.Equal(t, user.ID, result.UserId)
+		assert.Equal(t, shared.RoleViewer, result.Role)
+		assert.True(t, result.HasScope(shared.ScopeAccountsRead))
+		assert.False(t, result.HasScope(shared.ScopeAccountsWrite))
+		assert.False(t, result.HasScope(shared.ScopeTransactionsWrite))
+	})
+}
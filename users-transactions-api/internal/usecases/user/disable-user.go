@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+type DisableUserUsecase struct {
+	repo infra.Querier
+}
+
+func NewDisableUserUsecase(repo infra.Querier) *DisableUserUsecase {
+	return &DisableUserUsecase{
+		repo: repo,
+	}
+}
+
+// Disable blocks the user from logging in; sessions already issued stop
+// working on the next request.
+func (uc *DisableUserUsecase) Disable(ctx context.Context, actor *shared.Principal, userId int32) error {
+	user, err := findUser(ctx, uc.repo, actor.TenantId, userId)
+
+	if err != nil {
+		return err
+	}
+
+	err = canManage(actor, user)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.repo.UpdateUserStatus(ctx, infra.UpdateUserStatusParams{
+		ID:     user.ID,
+		Status: userStatusDisabled,
+		UpdatedAt: sql.NullTime{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		},
+	})
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error when disable user",
+			slog.String("err", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
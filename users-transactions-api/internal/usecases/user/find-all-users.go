@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+type FindAllUsersUsecase struct {
+	repo infra.Querier
+}
+
+func NewFindAllUsersUsecase(repo infra.Querier) *FindAllUsersUsecase {
+	return &FindAllUsersUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *FindAllUsersUsecase) FindAll(ctx context.Context, tenantId int32) ([]infra.User, error) {
+	users := make([]infra.User, 0)
+
+	result, err := uc.repo.GetUsers(ctx, tenantId)
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error to find all users",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	users = append(users, result...)
+
+	return users, nil
+}
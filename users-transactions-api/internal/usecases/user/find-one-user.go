@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+func findUser(ctx context.Context, repo infra.Querier, tenantId int32, userId int32) (*infra.User, error) {
+	user, err := repo.GetUser(ctx, infra.GetUserParams{
+		TenantID: tenantId,
+		ID:       userId,
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &shared.EntityNotFoundError{
+				Object: "user",
+				Id:     userId,
+			}
+		}
+		slog.ErrorContext(
+			ctx,
+			"error to find user by id",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// canManage tells whether actor may change target: only owners can touch
+// other owners and nobody changes their own access.
+func canManage(actor *shared.Principal, target *infra.User) error {
+	if actor.UserId == target.ID {
+		return &shared.ForbiddenError{Message: "users cannot change their own access"}
+	}
+
+	if target.Role == shared.RoleOwner && actor.Role != shared.RoleOwner {
+		return &shared.ForbiddenError{Message: "only owners can manage owners"}
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"strings"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/ports"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+type InviteUserUsecase struct {
+	repo   infra.Querier
+	txRepo infra.QuerierTx
+	mailer ports.Mailer
+}
+
+func NewInviteUserUsecase(repo infra.Querier, txRepo infra.QuerierTx, mailer ports.Mailer) *InviteUserUsecase {
+	return &InviteUserUsecase{
+		repo:   repo,
+		txRepo: txRepo,
+		mailer: mailer,
+	}
+}
+
+// Invite saves the user with its invitation token and mails the token. A
+// user still invited, whose mail may have been lost, gets a new token that
+// replaces the previous one, keeping the role of the first invitation.
+func (uc *InviteUserUsecase) Invite(ctx context.Context, actor *shared.Principal, email string, role string) (*infra.User, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	err := userInputValidation(email, role)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if role == shared.RoleOwner && actor.Role != shared.RoleOwner {
+		return nil, &shared.ForbiddenError{Message: "only owners can invite owners"}
+	}
+
+	alreadyExistsErr := &shared.AlreadyExistsError{
+		Object: "user",
+		Id:     email,
+	}
+
+	user, err := uc.repo.GetUserByEmail(ctx, infra.GetUserByEmailParams{
+		TenantID: actor.TenantId,
+		Email:    email,
+	})
+
+	if err != nil && err != sql.ErrNoRows {
+		slog.ErrorContext(
+			ctx,
+			"error to find user by email",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	found := err == nil
+
+	token, tokenArg, err := newToken(tokenKindInvitation, invitationTtl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		if user.Status != userStatusInvited {
+			return nil, alreadyExistsErr
+		}
+
+		if user.Role == shared.RoleOwner && actor.Role != shared.RoleOwner {
+			return nil, &shared.ForbiddenError{Message: "only owners can invite owners"}
+		}
+
+		tokenArg.UserID = user.ID
+		err = uc.txRepo.ReinviteUserTx(ctx, infra.ReinviteUserTxParams{
+			Token: tokenArg,
+			RevokedAt: sql.NullTime{
+				Time:  time.Now().UTC(),
+				Valid: true,
+			},
+		})
+	} else {
+		user, err = uc.txRepo.InviteUserTx(ctx, infra.InviteUserTxParams{
+			User: infra.CreateUserParams{
+				TenantID: actor.TenantId,
+				Email:    email,
+				Role:     role,
+				Status:   userStatusInvited,
+			},
+			Token: tokenArg,
+		})
+
+		// invited by a concurrent request since the lookup
+		if infra.IsUniqueViolation(err) {
+			return nil, alreadyExistsErr
+		}
+	}
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error inviting user",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	// the mail goes out after the commit, if it fails the invitation can be
+	// sent again
+	err = uc.mailer.Send(InvitationMail(user, token, tokenArg.ExpiresAt))
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error sending invitation mail",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// NewOwnerInvitation prepares the first user of a tenant that is still being
+// created, so both can be saved in the same transaction. The user TenantID
+// and the token UserID are left for the caller to fill.
+func NewOwnerInvitation(email string) (infra.CreateUserParams, infra.CreateUserTokenParams, string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	err := userInputValidation(email, shared.RoleOwner)
+
+	if err != nil {
+		return infra.CreateUserParams{}, infra.CreateUserTokenParams{}, "", err
+	}
+
+	token, tokenArg, err := newToken(tokenKindInvitation, invitationTtl)
+
+	if err != nil {
+		return infra.CreateUserParams{}, infra.CreateUserTokenParams{}, "", err
+	}
+
+	return infra.CreateUserParams{
+		Email:  email,
+		Role:   shared.RoleOwner,
+		Status: userStatusInvited,
+	}, tokenArg, token, nil
+}
+
+func InvitationMail(user infra.User, token string, expiresAt time.Time) ports.Mail {
+	return ports.Mail{
+		To:      user.Email,
+		Subject: "You were invited to customers-users-transactions",
+		Body: fmt.Sprintf("You were invited as %s. Accept the invitation before %s with the token:\n\n%s",
+			user.Role, expiresAt.Format("2006-01-02 15:04 MST"), token),
+	}
+}
+
+func userInputValidation(email string, role string) error {
+	valErr := &shared.ValidationError{
+		Errors: make(map[string]string),
+	}
+
+	if _, err := mail.ParseAddress(email); err != nil {
+		valErr.AddError("email", "must be a valid email address")
+	}
+
+	if !shared.IsValidRole(role) {
+		valErr.AddError("role", "must be one of owner, admin, operator or viewer")
+	}
+
+	if valErr.HasErrors() {
+		return valErr
+	}
+
+	return nil
+}
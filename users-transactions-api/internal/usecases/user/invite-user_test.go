@@ -0,0 +1,132 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInviteUserUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+	mockMailer := new(mocks.MockMailer)
+
+	admin := &shared.Principal{TenantId: 1, UserId: 1, Role: shared.RoleAdmin}
+
+	user := infra.User{
+		ID:       2,
+		TenantID: 1,
+		Email:    "john@example.com",
+		Role:     shared.RoleOperator,
+		Status:   userStatusInvited,
+	}
+
+	sut := NewInviteUserUsecase(mockRepo, mockRepo, mockMailer)
+
+	t.Run("Error invalid input", func(t *testing.T) {
+		result, err := sut.Invite(context.Background(), admin, "not-an-email", "superuser")
+
+		valErr, ok := err.(*shared.ValidationError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+		assert.Equal(t, "must be a valid email address", valErr.Errors["email"])
+		assert.Equal(t, "must be one of owner, admin, operator or viewer", valErr.Errors["role"])
+	})
+
+	t.Run("Error admin inviting owner", func(t *testing.T) {
+		result, err := sut.Invite(context.Background(), admin, user.Email, shared.RoleOwner)
+
+		_, ok := err.(*shared.ForbiddenError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+
+	t.Run("Error user already exists", func(t *testing.T) {
+		activeUser := user
+		activeUser.Status = userStatusActive
+
+		mockRepo.On("GetUserByEmail").Return(activeUser, nil)
+		defer mockRepo.On("GetUserByEmail").Unset()
+
+		result, err := sut.Invite(context.Background(), admin, user.Email, user.Role)
+
+		_, ok := err.(*shared.AlreadyExistsError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+
+	t.Run("Error user invited by a concurrent request", func(t *testing.T) {
+		mockRepo.On("GetUserByEmail").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetUserByEmail").Unset()
+
+		mockRepo.On("InviteUserTx").Return(nil, &pq.Error{Code: "23505"})
+		defer mockRepo.On("InviteUserTx").Unset()
+
+		result, err := sut.Invite(context.Background(), admin, user.Email, user.Role)
+
+		_, ok := err.(*shared.AlreadyExistsError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+
+	t.Run("Invite user successfully", func(t *testing.T) {
+		mockRepo.On("GetUserByEmail").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetUserByEmail").Unset()
+
+		mockRepo.On("InviteUserTx").Return(user, nil)
+		defer mockRepo.On("InviteUserTx").Unset()
+
+		mockMailer.On("Send").Return(nil)
+		defer mockMailer.On("Send").Unset()
+
+		result, err := sut.Invite(context.Background(), admin, user.Email, user.Role)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &user, result)
+		mockMailer.AssertNumberOfCalls(t, "Send", 1)
+	})
+
+	t.Run("Invite again a user still invited", func(t *testing.T) {
+		mockRepo.On("GetUserByEmail").Return(user, nil)
+		defer mockRepo.On("GetUserByEmail").Unset()
+
+		mockRepo.On("ReinviteUserTx").Return(nil)
+		defer mockRepo.On("ReinviteUserTx").Unset()
+
+		mockMailer.On("Send").Return(nil)
+		defer mockMailer.On("Send").Unset()
+
+		result, err := sut.Invite(context.Background(), admin, user.Email, shared.RoleViewer)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &user, result)
+		mockRepo.AssertCalled(t, "ReinviteUserTx")
+		mockMailer.AssertNumberOfCalls(t, "Send", 2)
+	})
+
+	t.Run("Error admin inviting again an invited owner", func(t *testing.T) {
+		invitedOwner := user
+		invitedOwner.Role = shared.RoleOwner
+
+		mockRepo.On("GetUserByEmail").Return(invitedOwner, nil)
+		defer mockRepo.On("GetUserByEmail").Unset()
+
+		result, err := sut.Invite(context.Background(), admin, user.Email, shared.RoleViewer)
+
+		_, ok := err.(*shared.ForbiddenError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+}
@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/ports"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type LoginUsecase struct {
+	repo    infra.Querier
+	tenants ports.TenantFinder
+}
+
+func NewLoginUsecase(repo infra.Querier, tenants ports.TenantFinder) *LoginUsecase {
+	return &LoginUsecase{
+		repo:    repo,
+		tenants: tenants,
+	}
+}
+
+// Login opens a session for the user. The tenant is checked only once the
+// credentials are valid, so its status is not told to anyone else.
+func (uc *LoginUsecase) Login(ctx context.Context, tenantId int32, email string, password string) (string, time.Time, error) {
+	invalidCredentialsErr := &shared.UnauthorizedError{Message: "invalid credentials"}
+
+	user, err := uc.repo.GetUserByEmail(ctx, infra.GetUserByEmailParams{
+		TenantID: tenantId,
+		Email:    strings.ToLower(strings.TrimSpace(email)),
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", time.Time{}, invalidCredentialsErr
+		}
+		slog.ErrorContext(
+			ctx,
+			"error to find user by email",
+			slog.String("err", err.Error()),
+		)
+		return "", time.Time{}, err
+	}
+
+	if user.Status != userStatusActive || !user.PasswordHash.Valid {
+		return "", time.Time{}, invalidCredentialsErr
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash.String), []byte(password))
+
+	if err != nil {
+		return "", time.Time{}, invalidCredentialsErr
+	}
+
+	_, err = uc.tenants.FindOne(ctx, user.TenantID)
+
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return issueToken(ctx, uc.repo, user.ID, tokenKindSession, sessionTtl)
+}
+
+func (uc *LoginUsecase) Logout(ctx context.Context, token string) error {
+
+	userToken, err := findActiveToken(ctx, uc.repo, token, tokenKindSession)
+
+	if err != nil || userToken == nil {
+		return err
+	}
+
+	return useToken(ctx, uc.repo, userToken)
+}
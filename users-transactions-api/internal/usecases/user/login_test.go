@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestLoginUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+	mockTenants := new(mocks.MockTenantFinder)
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte("s3cret-password"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	user := infra.User{
+		ID:           1,
+		TenantID:     1,
+		Email:        "jane@example.com",
+		PasswordHash: sql.NullString{String: string(passwordHash), Valid: true},
+		Role:         shared.RoleOperator,
+		Status:       userStatusActive,
+	}
+
+	sut := NewLoginUsecase(mockRepo, mockTenants)
+
+	t.Run("Error unknown email", func(t *testing.T) {
+		mockRepo.On("GetUserByEmail").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetUserByEmail").Unset()
+
+		token, _, err := sut.Login(context.Background(), 1, "unknown@example.com", "s3cret-password")
+
+		_, ok := err.(*shared.UnauthorizedError)
+
+		assert.Empty(t, token)
+		assert.True(t, ok)
+	})
+
+	t.Run("Error wrong password", func(t *testing.T) {
+		mockRepo.On("GetUserByEmail").Return(user, nil)
+		defer mockRepo.On("GetUserByEmail").Unset()
+
+		token, _, err := sut.Login(context.Background(), 1, user.Email, "wrong-password")
+
+		_, ok := err.(*shared.UnauthorizedError)
+
+		assert.Empty(t, token)
+		assert.True(t, ok)
+	})
+
+	t.Run("Error user not active", func(t *testing.T) {
+		invitedUser := user
+		invitedUser.Status = userStatusInvited
+
+		mockRepo.On("GetUserByEmail").Return(invitedUser, nil)
+		defer mockRepo.On("GetUserByEmail").Unset()
+
+		token, _, err := sut.Login(context.Background(), 1, user.Email, "s3cret-password")
+
+		_, ok := err.(*shared.UnauthorizedError)
+
+		assert.Empty(t, token)
+		assert.True(t, ok)
+	})
+
+	t.Run("Error tenant suspended", func(t *testing.T) {
+		mockRepo.On("GetUserByEmail").Return(user, nil)
+		defer mockRepo.On("GetUserByEmail").Unset()
+
+		mockTenants.On("FindOne").Return(nil, &shared.ForbiddenError{Message: "tenant with id 1 is suspended"})
+		defer mockTenants.On("FindOne").Unset()
+
+		token, _, err := sut.Login(context.Background(), 1, user.Email, "s3cret-password")
+
+		_, ok := err.(*shared.ForbiddenError)
+
+		assert.Empty(t, token)
+		assert.True(t, ok)
+	})
+
+	t.Run("Login successfully", func(t *testing.T) {
+		mockRepo.On("GetUserByEmail").Return(user, nil)
+		defer mockRepo.On("GetUserByEmail").Unset()
+
+		mockTenants.On("FindOne").Return(&infra.Tenant{ID: 1, Status: shared.TenantStatusActive}, nil)
+		defer mockTenants.On("FindOne").Unset()
+
+		mockRepo.On("CreateUserToken").Return(infra.UserToken{ID: 1}, nil)
+		defer mockRepo.On("CreateUserToken").Unset()
+
+		token, expiresAt, err := sut.Login(context.Background(), 1, " Jane@Example.com ", "s3cret-password")
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(token, "uts_"))
+		assert.True(t, expiresAt.After(time.Now()))
+	})
+}
@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/ports"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	userStatusInvited  = "invited"
+	userStatusActive   = "active"
+	userStatusDisabled = "disabled"
+
+	minPasswordLength = 8
+)
+
+func passwordInputValidation(password string) error {
+	// bcrypt ignores everything after the 72nd byte
+	if len(password) < minPasswordLength || len(password) > 72 {
+		return &shared.ValidationError{
+			Errors: map[string]string{"password": "must have between 8 and 72 characters"},
+		}
+	}
+
+	return nil
+}
+
+// setPassword consumes a single-use token and activates its user with the
+// new password, both in one transaction that fails if the token was used
+// meanwhile. The tenant of the user is checked when tenants is not nil.
+func setPassword(ctx context.Context, repo infra.Querier, txRepo infra.QuerierTx, tenants ports.TenantFinder,
+	token string, kind string, password string) error {
+	err := passwordInputValidation(password)
+
+	if err != nil {
+		return err
+	}
+
+	userToken, err := findActiveToken(ctx, repo, token, kind)
+
+	if err != nil {
+		return err
+	}
+
+	if userToken == nil {
+		return &shared.ValidationError{
+			Errors: map[string]string{"token": "invalid or expired"},
+		}
+	}
+
+	user, err := repo.GetUserById(ctx, userToken.UserID)
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error to find user by id",
+			slog.String("err", err.Error()),
+		)
+		return err
+	}
+
+	if user.Status == userStatusDisabled {
+		return &shared.ForbiddenError{Message: "user is disabled"}
+	}
+
+	if tenants != nil {
+		if _, err = tenants.FindOne(ctx, user.TenantID); err != nil {
+			return err
+		}
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+
+	if err != nil {
+		return err
+	}
+
+	now := sql.NullTime{
+		Time:  time.Now().UTC(),
+		Valid: true,
+	}
+
+	_, err = txRepo.SetPasswordTx(ctx, infra.SetPasswordTxParams{
+		Token: infra.UseUserTokenParams{
+			ID:     userToken.ID,
+			UsedAt: now,
+		},
+		Password: infra.UpdateUserPasswordParams{
+			ID:           user.ID,
+			PasswordHash: sql.NullString{String: string(passwordHash), Valid: true},
+			Status:       userStatusActive,
+			UpdatedAt:    now,
+		},
+	})
+
+	if errors.Is(err, infra.ErrUserTokenUsed) {
+		return &shared.ValidationError{
+			Errors: map[string]string{"token": "invalid or expired"},
+		}
+	}
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error when update user password",
+			slog.String("err", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/ports"
+)
+
+type RequestPasswordResetUsecase struct {
+	repo   infra.Querier
+	mailer ports.Mailer
+}
+
+func NewRequestPasswordResetUsecase(repo infra.Querier, mailer ports.Mailer) *RequestPasswordResetUsecase {
+	return &RequestPasswordResetUsecase{
+		repo:   repo,
+		mailer: mailer,
+	}
+}
+
+// Request succeeds for unknown emails too, so the endpoint cannot be used
+// to discover which users exist.
+func (uc *RequestPasswordResetUsecase) Request(ctx context.Context, tenantId int32, email string) error {
+
+	user, err := uc.repo.GetUserByEmail(ctx, infra.GetUserByEmailParams{
+		TenantID: tenantId,
+		Email:    strings.ToLower(strings.TrimSpace(email)),
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		slog.ErrorContext(
+			ctx,
+			"error to find user by email",
+			slog.String("err", err.Error()),
+		)
+		return err
+	}
+
+	if user.Status != userStatusActive {
+		return nil
+	}
+
+	token, expiresAt, err := issueToken(ctx, uc.repo, user.ID, tokenKindPasswordReset, passwordResetTtl)
+
+	if err != nil {
+		return err
+	}
+
+	err = uc.mailer.Send(ports.Mail{
+		To:      user.Email,
+		Subject: "Password reset",
+		Body: fmt.Sprintf("Reset your password before %s with the token:\n\n%s",
+			expiresAt.Format("2006-01-02 15:04 MST"), token),
+	})
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error sending password reset mail",
+			slog.String("err", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package usecases
+
+import (
+	"context"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+type ResetPasswordUsecase struct {
+	repo   infra.Querier
+	txRepo infra.QuerierTx
+}
+
+func NewResetPasswordUsecase(repo infra.Querier, txRepo infra.QuerierTx) *ResetPasswordUsecase {
+	return &ResetPasswordUsecase{
+		repo:   repo,
+		txRepo: txRepo,
+	}
+}
+
+// Reset does not check the tenant, the password is kept for when a
+// suspended tenant is reactivated and login refuses it meanwhile.
+func (uc *ResetPasswordUsecase) Reset(ctx context.Context, token string, password string) error {
+	return setPassword(ctx, uc.repo, uc.txRepo, nil, token, tokenKindPasswordReset, password)
+}
@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResetPasswordUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+
+	user := infra.User{
+		ID:       1,
+		TenantID: 1,
+		Email:    "jane@example.com",
+		Role:     shared.RoleAdmin,
+		Status:   userStatusActive,
+	}
+
+	userToken := infra.UserToken{
+		ID:        1,
+		UserID:    user.ID,
+		Kind:      tokenKindPasswordReset,
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+	}
+
+	sut := NewResetPasswordUsecase(mockRepo, mockRepo)
+
+	t.Run("Error short password", func(t *testing.T) {
+		err := sut.Reset(context.Background(), "utr_token", "short")
+
+		valErr, ok := err.(*shared.ValidationError)
+
+		assert.True(t, ok)
+		assert.Equal(t, "must have between 8 and 72 characters", valErr.Errors["password"])
+	})
+
+	t.Run("Error used token", func(t *testing.T) {
+		usedToken := userToken
+		usedToken.UsedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+
+		mockRepo.On("GetUserToken").Return(usedToken, nil)
+		defer mockRepo.On("GetUserToken").Unset()
+
+		err := sut.Reset(context.Background(), "utr_token", "new-password")
+
+		valErr, ok := err.(*shared.ValidationError)
+
+		assert.True(t, ok)
+		assert.Equal(t, "invalid or expired", valErr.Errors["token"])
+	})
+
+	t.Run("Reset password successfully", func(t *testing.T) {
+		mockRepo.On("GetUserToken").Return(userToken, nil)
+		defer mockRepo.On("GetUserToken").Unset()
+
+		mockRepo.On("GetUserById").Return(user, nil)
+		defer mockRepo.On("GetUserById").Unset()
+
+		mockRepo.On("SetPasswordTx").Return(user, nil)
+		defer mockRepo.On("SetPasswordTx").Unset()
+
+		err := sut.Reset(context.Background(), "utr_token", "new-password")
+
+		assert.NoError(t, err)
+		mockRepo.AssertCalled(t, "SetPasswordTx")
+	})
+
+	t.Run("Error token used by a concurrent request", func(t *testing.T) {
+		mockRepo.On("GetUserToken").Return(userToken, nil)
+		defer mockRepo.On("GetUserToken").Unset()
+
+		mockRepo.On("GetUserById").Return(user, nil)
+		defer mockRepo.On("GetUserById").Unset()
+
+		mockRepo.On("SetPasswordTx").Return(nil, infra.ErrUserTokenUsed)
+		defer mockRepo.On("SetPasswordTx").Unset()
+
+		err := sut.Reset(context.Background(), "utr_token", "new-password")
+
+		valErr, ok := err.(*shared.ValidationError)
+
+		assert.True(t, ok)
+		assert.Equal(t, "invalid or expired", valErr.Errors["token"])
+	})
+}
@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+)
+
+type UpdateUserRoleUsecase struct {
+	repo infra.Querier
+}
+
+func NewUpdateUserRoleUsecase(repo infra.Querier) *UpdateUserRoleUsecase {
+	return &UpdateUserRoleUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *UpdateUserRoleUsecase) UpdateRole(ctx context.Context, actor *shared.Principal, userId int32, role string) (*infra.User, error) {
+	if !shared.IsValidRole(role) {
+		return nil, &shared.ValidationError{
+			Errors: map[string]string{"role": "must be one of owner, admin, operator or viewer"},
+		}
+	}
+
+	user, err := findUser(ctx, uc.repo, actor.TenantId, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = canManage(actor, user)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if role == shared.RoleOwner && actor.Role != shared.RoleOwner {
+		return nil, &shared.ForbiddenError{Message: "only owners can grant the owner role"}
+	}
+
+	updatedUser, err := uc.repo.UpdateUserRole(ctx, infra.UpdateUserRoleParams{
+		ID:   user.ID,
+		Role: role,
+		UpdatedAt: sql.NullTime{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		},
+	})
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error when update user role",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	return &updatedUser, nil
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/mocks"
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateUserRoleUsecase(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(mocks.MockRepository)
+
+	admin := &shared.Principal{TenantId: 1, UserId: 1, Role: shared.RoleAdmin}
+
+	user := infra.User{
+		ID:       2,
+		TenantID: 1,
+		Email:    "john@example.com",
+		Role:     shared.RoleViewer,
+		Status:   userStatusActive,
+	}
+
+	sut := NewUpdateUserRoleUsecase(mockRepo)
+
+	t.Run("Error user not found", func(t *testing.T) {
+		mockRepo.On("GetUser").Return(nil, sql.ErrNoRows)
+		defer mockRepo.On("GetUser").Unset()
+
+		result, err := sut.UpdateRole(context.Background(), admin, user.ID, shared.RoleOperator)
+
+		_, ok := err.(*shared.EntityNotFoundError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+
+	t.Run("Error changing own role", func(t *testing.T) {
+		self := user
+		self.ID = admin.UserId
+
+		mockRepo.On("GetUser").Return(self, nil)
+		defer mockRepo.On("GetUser").Unset()
+
+		result, err := sut.UpdateRole(context.Background(), admin, self.ID, shared.RoleOwner)
+
+		_, ok := err.(*shared.ForbiddenError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+
+	t.Run("Error admin managing owner", func(t *testing.T) {
+		owner := user
+		owner.Role = shared.RoleOwner
+
+		mockRepo.On("GetUser").Return(owner, nil)
+		defer mockRepo.On("GetUser").Unset()
+
+		result, err := sut.UpdateRole(context.Background(), admin, owner.ID, shared.RoleViewer)
+
+		_, ok := err.(*shared.ForbiddenError)
+
+		assert.Nil(t, result)
+		assert.True(t, ok)
+	})
+
+	t.Run("Update role successfully", func(t *testing.T) {
+		updatedUser := user
+		updatedUser.Role = shared.RoleOperator
+
+		mockRepo.On("GetUser").Return(user, nil)
+		defer mockRepo.On("GetUser").Unset()
+
+		mockRepo.On("UpdateUserRole").Return(updatedUser, nil)
+		defer mockRepo.On("UpdateUserRole").Unset()
+
+		result, err := sut.UpdateRole(context.Background(), admin, user.ID, shared.RoleOperator)
+
+		assert.NoError(t, err)
+		assert.Equal(t, shared.RoleOperator, result.Role)
+	})
+}
@@ -0,0 +1,123 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	infra "github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/infra/repository/sqlc"
+)
+
+const (
+	tokenKindSession       = "session"
+	tokenKindInvitation    = "invitation"
+	tokenKindPasswordReset = "password_reset"
+
+	sessionTtl       = 12 * time.Hour
+	invitationTtl    = 72 * time.Hour
+	passwordResetTtl = time.Hour
+)
+
+var tokenPrefixes = map[string]string{
+	tokenKindSession:       "uts_",
+	tokenKindInvitation:    "uti_",
+	tokenKindPasswordReset: "utr_",
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func newToken(kind string, ttl time.Duration) (string, infra.CreateUserTokenParams, error) {
+	random := make([]byte, 32)
+
+	if _, err := rand.Read(random); err != nil {
+		return "", infra.CreateUserTokenParams{}, err
+	}
+
+	token := tokenPrefixes[kind] + hex.EncodeToString(random)
+
+	return token, infra.CreateUserTokenParams{
+		Kind:        kind,
+		HashedToken: hashToken(token),
+		ExpiresAt:   time.Now().UTC().Add(ttl),
+	}, nil
+}
+
+func issueToken(ctx context.Context, repo infra.Querier, userId int32, kind string,
+	ttl time.Duration) (string, time.Time, error) {
+	token, arg, err := newToken(kind, ttl)
+
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	arg.UserID = userId
+	_, err = repo.CreateUserToken(ctx, arg)
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error creating user token",
+			slog.String("kind", kind),
+			slog.String("err", err.Error()),
+		)
+		return "", time.Time{}, err
+	}
+
+	return token, arg.ExpiresAt, nil
+}
+
+// findActiveToken returns nil without error when the token does not exist,
+// was already used or is expired.
+func findActiveToken(ctx context.Context, repo infra.Querier, token string, kind string) (*infra.UserToken, error) {
+	userToken, err := repo.GetUserToken(ctx, infra.GetUserTokenParams{
+		HashedToken: hashToken(token),
+		Kind:        kind,
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		slog.ErrorContext(
+			ctx,
+			"error to find user token",
+			slog.String("err", err.Error()),
+		)
+		return nil, err
+	}
+
+	if userToken.UsedAt.Valid || time.Now().UTC().After(userToken.ExpiresAt) {
+		return nil, nil
+	}
+
+	return &userToken, nil
+}
+
+// useToken marks the token as used, a token used meanwhile is left as is.
+func useToken(ctx context.Context, repo infra.Querier, userToken *infra.UserToken) error {
+	_, err := repo.UseUserToken(ctx, infra.UseUserTokenParams{
+		ID: userToken.ID,
+		UsedAt: sql.NullTime{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		},
+	})
+
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"error when use user token",
+			slog.String("err", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
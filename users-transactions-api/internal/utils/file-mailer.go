@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/ports"
+)
+
+// FileMailer stands in for a mail provider by writing each message as an
+// .eml file into a local directory.
+type FileMailer struct {
+	dir string
+}
+
+func NewFileMailer(dir string) *FileMailer {
+	return &FileMailer{dir: dir}
+}
+
+func (m *FileMailer) Send(mail ports.Mail) error {
+	err := os.MkdirAll(m.dir, 0o755)
+
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	recipient := strings.NewReplacer("@", "_at_", "/", "_").Replace(mail.To)
+	fileName := fmt.Sprintf("%d-%s.eml", now.UnixNano(), recipient)
+
+	content := fmt.Sprintf("Date: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n",
+		now.Format(time.RFC1123Z), mail.To, mail.Subject, mail.Body)
+
+	return os.WriteFile(filepath.Join(m.dir, fileName), []byte(content), 0o600)
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"math"
+	"time"
+	_ "time/tzdata"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+const (
+	DefaultCurrency = "BRL"
+	DefaultTimezone = "America/Sao_Paulo"
+	DefaultLocale   = "pt-BR"
+)
+
+// dateLayouts keeps the date order used by each language, languages not
+// listed here use the ISO order.
+var dateLayouts = map[string]string{
+	"pt": "02/01/2006 15:04",
+	"es": "02/01/2006 15:04",
+	"fr": "02/01/2006 15:04",
+	"de": "02.01.2006 15:04",
+	"en": "01/02/2006 15:04",
+}
+
+// LocaleFormatter renders times and money the way a tenant configured them.
+// Invalid settings fall back to the defaults instead of failing the request.
+// It is the only formatter of the project, the pdf-generator-api renders
+// the values already formatted by the gRPC service.
+type LocaleFormatter struct {
+	location   *time.Location
+	printer    *message.Printer
+	currency   currency.Unit
+	tag        language.Tag
+	dateLayout string
+}
+
+func NewLocaleFormatter(timezone string, locale string, currencyCode string) *LocaleFormatter {
+	location, err := time.LoadLocation(timezone)
+
+	if err != nil {
+		location, _ = time.LoadLocation(DefaultTimezone)
+	}
+
+	tag, err := language.Parse(locale)
+
+	if err != nil {
+		tag = language.MustParse(DefaultLocale)
+	}
+
+	unit, err := currency.ParseISO(currencyCode)
+
+	if err != nil {
+		unit = currency.MustParseISO(DefaultCurrency)
+	}
+
+	base, _ := tag.Base()
+	dateLayout, ok := dateLayouts[base.String()]
+
+	if !ok {
+		dateLayout = "2006-01-02 15:04"
+	}
+
+	return &LocaleFormatter{
+		location:   location,
+		printer:    message.NewPrinter(tag),
+		currency:   unit,
+		tag:        tag,
+		dateLayout: dateLayout,
+	}
+}
+
+func DefaultLocaleFormatter() *LocaleFormatter {
+	return NewLocaleFormatter(DefaultTimezone, DefaultLocale, DefaultCurrency)
+}
+
+func (f *LocaleFormatter) Time(t time.Time) time.Time {
+	return t.In(f.location)
+}
+
+// Date renders the time for people, like in the pdf reports.
+func (f *LocaleFormatter) Date(t time.Time) string {
+	return t.In(f.location).Format(f.dateLayout)
+}
+
+// Money renders an amount stored in the minor unit of the currency, cents
+// for most of them.
+func (f *LocaleFormatter) Money(value int64) string {
+	scale, _ := currency.Standard.Rounding(f.currency)
+	amount := float64(value) / math.Pow10(scale)
+
+	return f.printer.Sprintf("%v", currency.Symbol(f.currency.Amount(amount)))
+}
+
+func (f *LocaleFormatter) Locale() string {
+	return f.tag.String()
+}
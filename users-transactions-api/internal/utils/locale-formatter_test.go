@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocaleFormatter(t *testing.T) {
+	t.Parallel()
+
+	instant := time.Date(2024, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Format with tenant settings", func(t *testing.T) {
+		sut := NewLocaleFormatter("America/New_York", "en-US", "USD")
+
+		assert.Equal(t, "$ 1,234.56", sut.Money(123456))
+		assert.Equal(t, "2024-10-19T08:00:00-04:00", sut.Time(instant).Format(time.RFC3339))
+		assert.Equal(t, "10/19/2024 08:00", sut.Date(instant))
+		assert.Equal(t, "en-US", sut.Locale())
+	})
+
+	t.Run("Fallback to defaults on invalid settings", func(t *testing.T) {
+		sut := NewLocaleFormatter("Mars/Olympus", "not a locale", "XX")
+
+		assert.Equal(t, "R$ 12,34", sut.Money(1234))
+		assert.Equal(t, "2024-10-19T09:00:00-03:00", sut.Time(instant).Format(time.RFC3339))
+		assert.Equal(t, "19/10/2024 09:00", sut.Date(instant))
+		assert.Equal(t, "pt-BR", sut.Locale())
+	})
+
+	t.Run("Money in the minor unit of the currency", func(t *testing.T) {
+		assert.Equal(t, "R$ 39,90", NewLocaleFormatter("America/Sao_Paulo", "pt-BR", "BRL").Money(3990))
+		assert.Equal(t, "R$ -0,05", NewLocaleFormatter("America/Sao_Paulo", "pt-BR", "BRL").Money(-5))
+		assert.Equal(t, "¥ 1,234", NewLocaleFormatter("Asia/Tokyo", "en-US", "JPY").Money(1234))
+	})
+
+	t.Run("Date in the order of the language", func(t *testing.T) {
+		assert.Equal(t, "19.10.2024 14:00", NewLocaleFormatter("Europe/Berlin", "de-DE", "EUR").Date(instant))
+		assert.Equal(t, "2024-10-19 21:00", NewLocaleFormatter("Asia/Tokyo", "ja-JP", "JPY").Date(instant))
+	})
+}
@@ -0,0 +1,270 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/go-playground/validator/v10"
+)
+
+// Rules are declared on the request structs with the `validate` tag. Besides
+// the validator built-ins there are:
+//
+//   - id: a path parameter holding a positive int32
+//   - account_status: one of the account statuses
+//   - role: one of the user roles
+//   - scope: one of the api key scopes
+//   - hex_color: a color like #1A2B3C
+//   - transaction_kind: the merchant description of a transaction
+//   - positive_money: an amount in cents greater than zero
+//   - rate_limit_burst: at least 1 when the RateLimitPerSecond sibling is set
+//   - existing_card: a card of the account set with WithCardLookup
+var (
+	validate     *validator.Validate
+	validateOnce sync.Once
+
+	colorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+)
+
+// MaxTransactionKindLength matches the transactions.kind column.
+const MaxTransactionKindLength = 145
+
+// CardLookup tells whether the card belongs to the account being handled.
+type CardLookup func(ctx context.Context, cardId int32) (bool, error)
+
+type cardLookupKey struct{}
+
+// lookupState keeps the first lookup error, validators cannot return one.
+type lookupState struct {
+	lookup CardLookup
+	err    error
+}
+
+func WithCardLookup(ctx context.Context, lookup CardLookup) context.Context {
+	return context.WithValue(ctx, cardLookupKey{}, &lookupState{lookup: lookup})
+}
+
+func engine() *validator.Validate {
+	validateOnce.Do(func() {
+		validate = validator.New(validator.WithRequiredStructEnabled())
+
+		// field errors are keyed by the json name the client sent
+		validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+			if name == "-" {
+				return ""
+			}
+
+			if len(name) == 0 {
+				return field.Name
+			}
+
+			return name
+		})
+
+		validate.RegisterValidation("id", func(fl validator.FieldLevel) bool {
+			id, err := strconv.ParseInt(fl.Field().String(), 0, 32)
+			return err == nil && id > 0
+		})
+		validate.RegisterValidation("account_status", func(fl validator.FieldLevel) bool {
+			return shared.IsAccountStatus(fl.Field().String())
+		})
+		validate.RegisterValidation("role", func(fl validator.FieldLevel) bool {
+			return shared.IsValidRole(fl.Field().String())
+		})
+		validate.RegisterValidation("scope", func(fl validator.FieldLevel) bool {
+			return shared.IsValidScope(fl.Field().String())
+		})
+		validate.RegisterValidation("hex_color", func(fl validator.FieldLevel) bool {
+			return colorPattern.MatchString(fl.Field().String())
+		})
+		validate.RegisterValidation("transaction_kind", func(fl validator.FieldLevel) bool {
+			return IsTransactionKind(fl.Field().String())
+		})
+		validate.RegisterValidation("positive_money", func(fl validator.FieldLevel) bool {
+			return fl.Field().Int() > 0
+		})
+		validate.RegisterValidation("rate_limit_burst", func(fl validator.FieldLevel) bool {
+			rate := fl.Parent().FieldByName("RateLimitPerSecond")
+			return !rate.IsValid() || rate.Int() <= 0 || fl.Field().Int() >= 1
+		})
+		validate.RegisterValidationCtx("existing_card", existingCard)
+	})
+
+	return validate
+}
+
+// IsTransactionKind accepts the descriptions printed on statements, like
+// "Streaming Z": printable text that does not start or end with spaces.
+func IsTransactionKind(kind string) bool {
+	if len(kind) == 0 || len(kind) > MaxTransactionKindLength || strings.TrimSpace(kind) != kind {
+		return false
+	}
+
+	for _, r := range kind {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func existingCard(ctx context.Context, fl validator.FieldLevel) bool {
+	state, ok := ctx.Value(cardLookupKey{}).(*lookupState)
+
+	if !ok {
+		return false
+	}
+
+	exists, err := state.lookup(ctx, int32(fl.Field().Int()))
+
+	if err != nil {
+		if state.err == nil {
+			state.err = err
+		}
+		return true
+	}
+
+	return exists
+}
+
+// Struct checks every rule of s and returns all failures at once in a
+// shared.ValidationError. Errors of lookups made by the rules are returned
+// as they are, so a missing account is still a not found.
+func Struct(ctx context.Context, s any) error {
+	err := engine().StructCtx(ctx, s)
+
+	if state, ok := ctx.Value(cardLookupKey{}).(*lookupState); ok && state.err != nil {
+		return state.err
+	}
+
+	return toValidationError(err)
+}
+
+// Var checks a single value, like a path or query parameter, reporting the
+// failures under name.
+func Var(name string, value any, tag string) error {
+	err := engine().Var(value, tag)
+
+	var fieldErrors validator.ValidationErrors
+
+	if !errors.As(err, &fieldErrors) {
+		return err
+	}
+
+	valErr := &shared.ValidationError{
+		Errors: make(map[string]string),
+	}
+
+	valErr.AddError(name, message(fieldErrors[0]))
+
+	return valErr
+}
+
+func toValidationError(err error) error {
+	var fieldErrors validator.ValidationErrors
+
+	if !errors.As(err, &fieldErrors) {
+		return err
+	}
+
+	valErr := &shared.ValidationError{
+		Errors: make(map[string]string),
+	}
+
+	for _, fe := range fieldErrors {
+		valErr.AddError(fieldName(fe), message(fe))
+	}
+
+	return valErr
+}
+
+// fieldName drops the struct name and the slice indexes, so the errors of
+// an element are reported under its list, e.g. "scopes".
+func fieldName(fe validator.FieldError) string {
+	_, name, found := strings.Cut(fe.Namespace(), ".")
+
+	if !found {
+		name = fe.Field()
+	}
+
+	name, _, _ = strings.Cut(name, "[")
+
+	return name
+}
+
+func message(fe validator.FieldError) string {
+	isText := fe.Kind() == reflect.String || fe.Kind() == reflect.Slice
+
+	switch fe.Tag() {
+	case "required":
+		return "cannot be empty"
+	case "id":
+		return "must be a valid id"
+	case "max":
+		if isText {
+			return fmt.Sprintf("must have at most %s characters", fe.Param())
+		}
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "min":
+		if isText {
+			return fmt.Sprintf("must have at least %s characters", fe.Param())
+		}
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "gte":
+		if fe.Param() == "0" {
+			return "cannot be negative"
+		}
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "lte":
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fe.Param())
+	case "oneof":
+		return "must be one of " + joinOptions(strings.Fields(fe.Param()))
+	case "email":
+		return "must be a valid email address"
+	case "iso4217":
+		return "must be an ISO 4217 currency code"
+	case "timezone":
+		return "must be an IANA time zone"
+	case "bcp47_language_tag":
+		return "must be a BCP 47 language tag"
+	case "hex_color":
+		return "must be a hex color like #1A2B3C"
+	case "account_status":
+		return "must be one of pending, active, frozen, suspended or closed"
+	case "role":
+		return "must be one of owner, admin, operator or viewer"
+	case "scope":
+		return fmt.Sprintf("unknown scope %v", fe.Value())
+	case "transaction_kind":
+		return fmt.Sprintf("must be a description of up to %d printable characters", MaxTransactionKindLength)
+	case "positive_money":
+		return "must be greater than zero (0)"
+	case "rate_limit_burst":
+		return "must be at least 1 when the rate limit is enabled"
+	case "existing_card":
+		return "card not found in this account"
+	}
+
+	return "is invalid"
+}
+
+func joinOptions(options []string) string {
+	if len(options) < 2 {
+		return strings.Join(options, "")
+	}
+
+	return strings.Join(options[:len(options)-1], ", ") + " or " + options[len(options)-1]
+}
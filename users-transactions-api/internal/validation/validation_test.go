@@ -0,0 +1,106 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Lukasveiga/customers-users-transaction/users-transactions-api/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRequest struct {
+	CardId int32    `json:"card_id" validate:"required,existing_card"`
+	Kind   string   `json:"kind" validate:"required,transaction_kind"`
+	Value  int64    `json:"value" validate:"positive_money"`
+	Scopes []string `json:"scopes" validate:"required,dive,scope"`
+	Color  string   `json:"color" validate:"omitempty,hex_color"`
+}
+
+func TestStruct(t *testing.T) {
+	t.Parallel()
+
+	cards := func(ctx context.Context, cardId int32) (bool, error) {
+		return cardId == 1, nil
+	}
+
+	t.Run("Valid request", func(t *testing.T) {
+		ctx := WithCardLookup(context.Background(), cards)
+
+		err := Struct(ctx, &testRequest{
+			CardId: 1,
+			Kind:   "Streaming Z",
+			Value:  50,
+			Scopes: []string{shared.ScopeAccountsRead},
+			Color:  "#1A2B3C",
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Every field error at once", func(t *testing.T) {
+		ctx := WithCardLookup(context.Background(), cards)
+
+		err := Struct(ctx, &testRequest{
+			CardId: 2,
+			Kind:   " Streaming Z",
+			Value:  0,
+			Scopes: []string{shared.ScopeAccountsRead, "accounts:delete"},
+			Color:  "blue",
+		})
+
+		assert.Equal(t, &shared.ValidationError{
+			Errors: map[string]string{
+				"card_id": "card not found in this account",
+				"kind":    "must be a description of up to 145 printable characters",
+				"value":   "must be greater than zero (0)",
+				"scopes":  "unknown scope accounts:delete",
+				"color":   "must be a hex color like #1A2B3C",
+			},
+		}, err)
+	})
+
+	t.Run("Lookup error is returned as is", func(t *testing.T) {
+		lookupErr := &shared.EntityNotFoundError{Object: "account", Id: 1}
+
+		ctx := WithCardLookup(context.Background(), func(ctx context.Context, cardId int32) (bool, error) {
+			return false, lookupErr
+		})
+
+		err := Struct(ctx, &testRequest{
+			CardId: 1,
+			Kind:   "Streaming Z",
+			Value:  50,
+			Scopes: []string{shared.ScopeAccountsRead},
+		})
+
+		assert.True(t, errors.Is(err, lookupErr))
+	})
+
+	t.Run("Card rule without lookup fails", func(t *testing.T) {
+		err := Struct(context.Background(), &testRequest{
+			CardId: 1,
+			Kind:   "Streaming Z",
+			Value:  50,
+			Scopes: []string{shared.ScopeAccountsRead},
+		})
+
+		assert.IsType(t, &shared.ValidationError{}, err)
+	})
+}
+
+func TestVar(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, Var("accountId", "12", "id"))
+
+	for _, value := range []string{"", "0", "-1", "abc", "2147483648"} {
+		assert.Equal(t, &shared.ValidationError{
+			Errors: map[string]string{"accountId": "must be a valid id"},
+		}, Var("accountId", value, "id"), value)
+	}
+
+	assert.Equal(t, &shared.ValidationError{
+		Errors: map[string]string{"format": "must be one of json or zip"},
+	}, Var("format", "pdf", "oneof=json zip"))
+}
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tenants
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active',
+    ADD COLUMN created_at timestamptz NOT NULL DEFAULT 'now()',
+    ADD COLUMN updated_at timestamptz,
+    ADD COLUMN suspended_at timestamptz;
+
+CREATE TABLE tenant_settings (
+    tenant_id INT PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    currency VARCHAR(3) NOT NULL DEFAULT 'BRL',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'America/Sao_Paulo',
+    locale VARCHAR(16) NOT NULL DEFAULT 'pt-BR',
+    closing_day INT NOT NULL DEFAULT 1,
+    created_at timestamptz NOT NULL DEFAULT 'now()',
+    updated_at timestamptz
+);
+
+INSERT INTO tenant_settings (tenant_id) SELECT id FROM tenants;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tenant_settings;
+
+ALTER TABLE tenants
+    DROP COLUMN IF EXISTS suspended_at,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tenant_settings
+    ADD COLUMN display_name VARCHAR(255),
+    ADD COLUMN primary_color VARCHAR(7) NOT NULL DEFAULT '#000000',
+    ADD COLUMN secondary_color VARCHAR(7) NOT NULL DEFAULT '#FFFFFF';
+
+CREATE TABLE tenant_logos (
+    tenant_id INT PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    content_type VARCHAR(50) NOT NULL,
+    data BYTEA NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT 'now()'
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tenant_logos;
+
+ALTER TABLE tenant_settings
+    DROP COLUMN IF EXISTS secondary_color,
+    DROP COLUMN IF EXISTS primary_color,
+    DROP COLUMN IF EXISTS display_name;
+-- +goose StatementEnd
@@ -0,0 +1,75 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Row level security is defense in depth for the tenant scoped tables. The
+-- application connects as the table owner, which bypasses the policies, so
+-- tenant scoped transactions switch to the tenant_user role and set the
+-- app.tenant_id setting before running their queries.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'tenant_user') THEN
+        CREATE ROLE tenant_user NOLOGIN;
+    END IF;
+
+    EXECUTE format('GRANT tenant_user TO %I', current_user);
+END
+$$;
+
+GRANT USAGE ON SCHEMA public TO tenant_user;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO tenant_user;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO tenant_user;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO tenant_user;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT USAGE, SELECT ON SEQUENCES TO tenant_user;
+
+CREATE FUNCTION current_tenant_id() RETURNS INT AS $$
+    SELECT NULLIF(current_setting('app.tenant_id', true), '')::INT
+$$ LANGUAGE SQL STABLE;
+
+ALTER TABLE accounts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE cards ENABLE ROW LEVEL SECURITY;
+ALTER TABLE transactions ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation ON accounts
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+CREATE POLICY tenant_isolation ON cards
+    USING (EXISTS (
+        SELECT 1 FROM accounts a
+        WHERE a.id = cards.account_id AND a.tenant_id = current_tenant_id()
+    ))
+    WITH CHECK (EXISTS (
+        SELECT 1 FROM accounts a
+        WHERE a.id = cards.account_id AND a.tenant_id = current_tenant_id()
+    ));
+
+CREATE POLICY tenant_isolation ON transactions
+    USING (EXISTS (
+        SELECT 1 FROM cards c
+        JOIN accounts a ON a.id = c.account_id
+        WHERE c.id = transactions.card_id AND a.tenant_id = current_tenant_id()
+    ))
+    WITH CHECK (EXISTS (
+        SELECT 1 FROM cards c
+        JOIN accounts a ON a.id = c.account_id
+        WHERE c.id = transactions.card_id AND a.tenant_id = current_tenant_id()
+    ));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP POLICY IF EXISTS tenant_isolation ON transactions;
+DROP POLICY IF EXISTS tenant_isolation ON cards;
+DROP POLICY IF EXISTS tenant_isolation ON accounts;
+
+ALTER TABLE transactions DISABLE ROW LEVEL SECURITY;
+ALTER TABLE cards DISABLE ROW LEVEL SECURITY;
+ALTER TABLE accounts DISABLE ROW LEVEL SECURITY;
+
+DROP FUNCTION IF EXISTS current_tenant_id();
+
+ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE USAGE, SELECT ON SEQUENCES FROM tenant_user;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE SELECT, INSERT, UPDATE, DELETE ON TABLES FROM tenant_user;
+REVOKE ALL ON ALL SEQUENCES IN SCHEMA public FROM tenant_user;
+REVOKE ALL ON ALL TABLES IN SCHEMA public FROM tenant_user;
+REVOKE USAGE ON SCHEMA public FROM tenant_user;
+-- +goose StatementEnd
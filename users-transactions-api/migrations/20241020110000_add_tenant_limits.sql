@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A zero value disables the limit.
+ALTER TABLE tenant_settings
+    ADD COLUMN rate_limit_per_second INT NOT NULL DEFAULT 20,
+    ADD COLUMN rate_limit_burst INT NOT NULL DEFAULT 40,
+    ADD COLUMN max_accounts INT NOT NULL DEFAULT 0,
+    ADD COLUMN max_cards_per_account INT NOT NULL DEFAULT 0,
+    ADD COLUMN max_transactions_per_day INT NOT NULL DEFAULT 0;
+
+CREATE TABLE rate_limit_buckets (
+    tenant_id INT PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT 'now()'
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS rate_limit_buckets;
+
+ALTER TABLE tenant_settings
+    DROP COLUMN IF EXISTS max_transactions_per_day,
+    DROP COLUMN IF EXISTS max_cards_per_account,
+    DROP COLUMN IF EXISTS max_accounts,
+    DROP COLUMN IF EXISTS rate_limit_burst,
+    DROP COLUMN IF EXISTS rate_limit_per_second;
+-- +goose StatementEnd
@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE account_status_history (
+    id SERIAL PRIMARY KEY,
+    account_id INT REFERENCES accounts(id) ON DELETE CASCADE NOT NULL,
+    from_status VARCHAR(50),
+    to_status VARCHAR(50) NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT 'now()'
+);
+
+CREATE INDEX account_status_history_account_id_idx ON account_status_history (account_id);
+
+-- Accounts deleted before the state machine existed are frozen, not closed,
+-- so they can still be reactivated. Frozen keeps their reports and refunds.
+WITH frozen AS (
+    UPDATE accounts SET status = 'frozen' WHERE status = 'inactive'
+    RETURNING id
+)
+INSERT INTO account_status_history (account_id, from_status, to_status, reason, actor)
+SELECT id, 'inactive', 'frozen', 'migrated from the inactive status', 'migration'
+FROM frozen;
+
+ALTER TABLE accounts
+    ADD CONSTRAINT accounts_status_check
+    CHECK (status IN ('pending', 'active', 'frozen', 'suspended', 'closed'));
+
+ALTER TABLE account_status_history ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation ON account_status_history
+    USING (EXISTS (
+        SELECT 1 FROM accounts a
+        WHERE a.id = account_status_history.account_id AND a.tenant_id = current_tenant_id()
+    ))
+    WITH CHECK (EXISTS (
+        SELECT 1 FROM accounts a
+        WHERE a.id = account_status_history.account_id AND a.tenant_id = current_tenant_id()
+    ));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS account_status_history;
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_status_check;
+
+-- Only active and inactive existed before, every status that blocks debits
+-- goes back to inactive.
+UPDATE accounts SET status = 'inactive'
+WHERE status IN ('pending', 'frozen', 'suspended', 'closed');
+-- +goose StatementEnd
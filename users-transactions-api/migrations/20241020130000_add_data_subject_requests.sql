@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE accounts ADD COLUMN erased_at timestamptz;
+
+CREATE TABLE data_subject_requests (
+    id SERIAL PRIMARY KEY,
+    tenant_id INT REFERENCES tenants(id) ON DELETE CASCADE NOT NULL,
+    account_id INT REFERENCES accounts(id) ON DELETE CASCADE NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    format VARCHAR(10),
+    reason VARCHAR(255),
+    actor VARCHAR(100) NOT NULL,
+    retain_until timestamptz,
+    created_at timestamptz NOT NULL DEFAULT 'now()'
+);
+
+CREATE INDEX data_subject_requests_account_id_idx ON data_subject_requests (account_id);
+
+ALTER TABLE data_subject_requests ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation ON data_subject_requests
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS data_subject_requests;
+
+ALTER TABLE accounts DROP COLUMN IF EXISTS erased_at;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE accounts ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE cards ADD COLUMN version INT NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE cards DROP COLUMN IF EXISTS version;
+ALTER TABLE accounts DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE FUNCTION notify_tenant_changed() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('tenant_changed', COALESCE(NEW.id, OLD.id)::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- inserts too, an id cached as not found may be created afterwards
+CREATE TRIGGER tenant_changed
+    AFTER INSERT OR UPDATE OR DELETE ON tenants
+    FOR EACH ROW EXECUTE FUNCTION notify_tenant_changed();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS tenant_changed ON tenants;
+DROP FUNCTION IF EXISTS notify_tenant_changed();
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Adjustments are transactions posted by an operator to correct a balance,
+-- this records who posted each one and why.
+CREATE TABLE transaction_adjustments (
+    id SERIAL PRIMARY KEY,
+    transaction_id INT REFERENCES transactions(id) ON DELETE CASCADE NOT NULL UNIQUE,
+    actor VARCHAR(100) NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+ALTER TABLE transaction_adjustments ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation ON transaction_adjustments
+    USING (EXISTS (
+        SELECT 1 FROM transactions t
+        JOIN cards c ON c.id = t.card_id
+        JOIN accounts a ON a.id = c.account_id
+        WHERE t.id = transaction_adjustments.transaction_id AND a.tenant_id = current_tenant_id()
+    ))
+    WITH CHECK (EXISTS (
+        SELECT 1 FROM transactions t
+        JOIN cards c ON c.id = t.card_id
+        JOIN accounts a ON a.id = c.account_id
+        WHERE t.id = transaction_adjustments.transaction_id AND a.tenant_id = current_tenant_id()
+    ));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS transaction_adjustments;
+-- +goose StatementEnd
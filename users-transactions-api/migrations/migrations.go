@@ -0,0 +1,9 @@
+// Package migrations embeds the goose migrations, so the binary can apply
+// them and tell which of them are missing from the database. They are also
+// the schema read by sqlc.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS